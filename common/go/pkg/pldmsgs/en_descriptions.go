@@ -52,6 +52,20 @@ var (
 	EventWithDataData                  = pdm("EventWithData.data", "JSON formatted data from the event")
 )
 
+// pldapi/blockchain_status.go
+var (
+	BlockchainStatusChainID             = pdm("BlockchainStatus.chainId", "The chain ID of the base ledger, which all endpoints must match")
+	BlockchainStatusActiveEndpoint      = pdm("BlockchainStatus.activeEndpoint", "The index in the list of endpoints of the node currently in use")
+	BlockchainStatusEndpoints           = pdm("BlockchainStatus.endpoints", "The configured endpoints, in order of preference")
+	BlockchainEndpointStatusHTTP        = pdm("BlockchainEndpointStatus.http", "The HTTP JSON/RPC URL of the endpoint")
+	BlockchainEndpointStatusWS          = pdm("BlockchainEndpointStatus.ws", "The WebSocket JSON/RPC URL of the endpoint")
+	BlockchainEndpointStatusActive      = pdm("BlockchainEndpointStatus.active", "True for the endpoint currently used for all requests, including transaction submission")
+	BlockchainEndpointStatusHealthy     = pdm("BlockchainEndpointStatus.healthy", "Whether the endpoint passed its most recent health check")
+	BlockchainEndpointStatusBlockNumber = pdm("BlockchainEndpointStatus.blockNumber", "The head block number reported by the endpoint in its most recent health check")
+	BlockchainEndpointStatusLastChecked = pdm("BlockchainEndpointStatus.lastChecked", "The time of the most recent health check")
+	BlockchainEndpointStatusError       = pdm("BlockchainEndpointStatus.error", "The reason the endpoint is unhealthy")
+)
+
 // pldapi/keymgr.go
 var (
 	WalletInfoName                     = pdm("WalletInfo.name", "The name of the wallet")
@@ -77,6 +91,8 @@ var (
 	PublicTxGasPricingMaxPriorityFeePerGas = pdm("PublicTxGasPricing.maxPriorityFeePerGas", "The maximum priority fee per gas (optional)")
 	PublicTxGasPricingMaxFeePerGas         = pdm("PublicTxGasPricing.maxFeePerGas", "The maximum fee per gas (optional)")
 	PublicTxGasPricingGasPrice             = pdm("PublicTxGasPricing.gasPrice", "The gas price (optional)")
+	PublicTxGasPriceCapsMaxFee             = pdm("PublicTxGasPriceCaps.maxFeePerGasCap", "The upper limit for the maximum fee per gas set by the gas pricing engine for this transaction, including on resubmission (optional)")
+	PublicTxGasPriceCapsMaxPriorityFee     = pdm("PublicTxGasPriceCaps.maxPriorityFeePerGasCap", "The upper limit for the maximum priority fee per gas set by the gas pricing engine for this transaction, including on resubmission (optional)")
 	PublicTxInputFrom                      = pdm("PublicTxInput.from", "The resolved signing account")
 	PublicTxInputTo                        = pdm("PublicTxInput.to", "The target contract address (optional)")
 	PublicTxInputData                      = pdm("PublicTxInput.data", "The pre-encoded calldata (optional)")
@@ -96,8 +112,12 @@ var (
 	PublicTxRevertData                     = pdm("PublicTx.revertData", "The revert data (optional)")
 	PublicTxSubmissions                    = pdm("PublicTx.submissions", "The submission data (optional)")
 	PublicTxActivity                       = pdm("PublicTx.activity", "The transaction activity records (optional)")
+	PublicTxStuck                          = pdm("PublicTx.stuck", "True if the transaction is still pending after the configured number of submissions with escalating fees")
 	PublicTxBindingTransaction             = pdm("PublicTxBinding.transaction", "The transaction ID")
 	PublicTxBindingTransactionType         = pdm("PublicTxBinding.transactionType", "The transaction type")
+	PublicTxNonceGapFrom                   = pdm("PublicTxNonceGap.from", "The signing address")
+	PublicTxNonceGapFirstNonce             = pdm("PublicTxNonceGap.firstNonce", "The first nonce in the gap, which is the next nonce the chain expects when the gap is at the start of the pending transactions")
+	PublicTxNonceGapLastNonce              = pdm("PublicTxNonceGap.lastNonce", "The last nonce in the gap")
 )
 
 // pldapi/stored_abi.go
@@ -167,6 +187,7 @@ var (
 	TransactionReceiptFiltersDomain                         = pdm("TransactionReceiptFilters.domain", "Only deliver receipts for an individual domain (only valid with type=private)")
 	TransactionReceiptOptionsDomainReceipts                 = pdm("TransactionReceiptOptions.domainReceipts", "When true, a full domain receipt will be generated for each event with complete state data")
 	TransactionReceiptOptionsIncompleteStateReceiptBehavior = pdm("TransactionReceiptOptions.incompleteStateReceiptBehavior", "When set to 'block_contract', if a transaction with incomplete state data is detected then delivery of all receipts on that individual smart contract address will pause until the missing state arrives. Receipts for other contract addresses continue to be delivered")
+	TransactionReceiptOptionsWebhook                        = pdm("TransactionReceiptOptions.webhook", "When set, batches of receipts are POSTed to an HTTP endpoint instead of being delivered to WebSocket subscribers")
 	BlockchainEventListenerName                             = pdm("BlockchainEventListener.name", "Unique name for the blockchain event listener")
 	BlockchainEventListenerCreated                          = pdm("BlockchainEventListener.created", "Time the listener was created")
 	BlockchainEventListenerStarted                          = pdm("BlockchainEventListener.started", "If the listener is started - can be set to false to disable delivery server-side")
@@ -174,8 +195,45 @@ var (
 	BlockchainEventListenerOptions                          = pdm("BlockchainEventListener.options", "Options for the event listener")
 	BlockchainEventListenerOptionsBatchSize                 = pdm("BlockchainEventListenerOptions.batchSize", "The maximum number of events to deliver in each batch")
 	BlockchainEventListenerOptionsBatchTimeout              = pdm("BlockchainEventListenerOptions.batchTimeout", "The maximum time to wait for a batch to fill before delivering")
+	BlockchainEventListenerOptionsWebhook                   = pdm("BlockchainEventListenerOptions.webhook", "When set, batches of events are POSTed to an HTTP endpoint instead of being delivered to WebSocket subscribers")
 	BlockchainEventListenerSourceABI                        = pdm("BlockchainEventListenerSource.abi", "The ABI containing events to listen for")
 	BlockchainEventListenerSourceAddress                    = pdm("BlockchainEventListenerSource.address", "The address to listen for events from")
+	ListenerWebhookHTTP                                     = pdm("ListenerWebhook.http", "HTTP client configuration for the webhook endpoint. Each batch is POSTed to the URL, and any 2xx response acknowledges the batch")
+	ListenerWebhookRetry                                    = pdm("ListenerWebhook.retry", "Retry configuration for failed deliveries. Delivery is retried indefinitely, unless maxAttempts is set - in which case once it is reached the batch is dead-lettered, and the listener moves on")
+	ListenerDeadLetterID                                    = pdm("ListenerDeadLetter.id", "Unique ID of the dead letter entry")
+	ListenerDeadLetterListenerType                          = pdm("ListenerDeadLetter.listenerType", "The type of the listener that failed to deliver the batch - receipt or blockchain_event")
+	ListenerDeadLetterListener                              = pdm("ListenerDeadLetter.listener", "Name of the listener that failed to deliver the batch")
+	ListenerDeadLetterCreated                               = pdm("ListenerDeadLetter.created", "Time the batch was dead-lettered")
+	ListenerDeadLetterBatchID                               = pdm("ListenerDeadLetter.batchId", "ID of the batch that failed delivery")
+	ListenerDeadLetterAttempts                              = pdm("ListenerDeadLetter.attempts", "Number of delivery attempts made before the batch was dead-lettered")
+	ListenerDeadLetterError                                 = pdm("ListenerDeadLetter.error", "The error from the last delivery attempt")
+	ListenerDeadLetterBatch                                 = pdm("ListenerDeadLetter.batch", "The JSON payload of the batch, exactly as it would have been POSTed to the webhook")
+)
+
+// config/pkg/pldconf - configuration types that are embedded in API objects
+var (
+	HTTPClientConfigURL               = pdm("HTTPClientConfig.url", "The URL of the HTTP endpoint")
+	HTTPClientConfigHTTPHeaders       = pdm("HTTPClientConfig.httpHeaders", "Additional HTTP headers to set on each request")
+	HTTPClientConfigAuth              = pdm("HTTPClientConfig.auth", "Basic authentication credentials")
+	HTTPClientConfigTLS               = pdm("HTTPClientConfig.tls", "TLS configuration")
+	HTTPClientConfigRequestTimeout    = pdm("HTTPClientConfig.requestTimeout", "Timeout for each request")
+	HTTPClientConfigConnectionTimeout = pdm("HTTPClientConfig.connectionTimeout", "Timeout for establishing a connection")
+	HTTPBasicAuthConfigUsername       = pdm("HTTPBasicAuthConfig.username", "Username for basic authentication")
+	HTTPBasicAuthConfigPassword       = pdm("HTTPBasicAuthConfig.password", "Password for basic authentication")
+	TLSConfigEnabled                  = pdm("TLSConfig.enabled", "Enables TLS")
+	TLSConfigClientAuth               = pdm("TLSConfig.clientAuth", "Enables mutual TLS client authentication")
+	TLSConfigCAFile                   = pdm("TLSConfig.caFile", "Path to a PEM file containing CA certificates to trust")
+	TLSConfigCA                       = pdm("TLSConfig.ca", "PEM encoded CA certificates to trust")
+	TLSConfigCertFile                 = pdm("TLSConfig.certFile", "Path to a PEM file containing the certificate to present")
+	TLSConfigCert                     = pdm("TLSConfig.cert", "PEM encoded certificate to present")
+	TLSConfigKeyFile                  = pdm("TLSConfig.keyFile", "Path to a PEM file containing the private key for the certificate")
+	TLSConfigKey                      = pdm("TLSConfig.key", "PEM encoded private key for the certificate")
+	TLSConfigInsecureSkipHostVerify   = pdm("TLSConfig.insecureSkipHostVerify", "Disables verification of the server hostname. Not for production use")
+	TLSConfigRequiredDNAttributes     = pdm("TLSConfig.requiredDNAttributes", "Regular expressions that the subject DN attributes of the peer certificate must match")
+	RetryConfigInitialDelay           = pdm("RetryConfig.initialDelay", "The delay before the first retry")
+	RetryConfigMaxDelay               = pdm("RetryConfig.maxDelay", "The maximum delay between retries")
+	RetryConfigFactor                 = pdm("RetryConfig.factor", "The factor the delay is multiplied by after each failure")
+	RetryConfigWithMaxMaxAttempts     = pdm("RetryConfigWithMax.maxAttempts", "The maximum number of attempts before giving up")
 )
 
 // query/query_json.go
@@ -241,6 +299,7 @@ var (
 	TransactionStatesRead        = pdm("TransactionStates.read", "Private state data for states that were unspent and used during execution of this transaction, but were not spent by it")
 	TransactionStatesConfirmed   = pdm("TransactionStates.confirmed", "Private state data for new states that were confirmed as new unspent states during this transaction")
 	TransactionStatesInfo        = pdm("TransactionStates.info", "Private state data for states that were recorded as part of this transaction, and existed only as reference data during its execution. They were not validated as unspent during execution, or recorded as new unspent states")
+	TransactionStatesNullifiers  = pdm("TransactionStates.nullifiers", "For domains that use nullifiers, the nullifiers recorded as spent by this transaction that were resolved to the spent states on this node")
 	TransactionStatesUnavailable = pdm("TransactionStates.unavailable", "If present, this contains information about states recorded as used by this transactions when indexing, but for which the private data is unavailable on this node")
 	UnavailableStatesSpent       = pdm("UnavailableStates.spent", "The IDs of spent states consumed by this transaction, for which the private data is unavailable")
 	UnavailableStatesRead        = pdm("UnavailableStates.read", "The IDs of read states used by this transaction, for which the private data is unavailable")
@@ -296,6 +355,20 @@ var (
 	ReliableMessageAckMessageError = pdm("ReliableMessageAck.error", "A permanent failure (a 'nack') that will stop any further attempts to deliver this message")
 )
 
+// pldclient/plugin.go
+var (
+	PluginStatusName          = pdm("PluginStatus.name", "The name of the plugin in the configuration")
+	PluginStatusID            = pdm("PluginStatus.id", "The runtime identifier of the plugin, which changes each time Paladin starts")
+	PluginStatusPluginType    = pdm("PluginStatus.pluginType", "The type of the plugin - DOMAIN, TRANSPORT, REGISTRY or SIGNING_MODULE")
+	PluginStatusLibraryType   = pdm("PluginStatus.libraryType", "How the plugin is loaded - c-shared, jar, process or remote")
+	PluginStatusLibrary       = pdm("PluginStatus.library", "The library, executable or gRPC address of the plugin")
+	PluginStatusInitialized   = pdm("PluginStatus.initialized", "True when the plugin is connected and has completed initialization")
+	PluginStatusPID           = pdm("PluginStatus.pid", "For process plugins, the operating system process ID of the running plugin")
+	PluginStatusRestarts      = pdm("PluginStatus.restarts", "For process and remote plugins, the number of times the plugin has been restarted after a crash or disconnect")
+	PluginStatusLastError     = pdm("PluginStatus.lastError", "The most recent crash, disconnect or start failure of the plugin")
+	PluginStatusLastErrorTime = pdm("PluginStatus.lastErrorTime", "The time of the most recent crash, disconnect or start failure of the plugin")
+)
+
 // pldclient/privacygroups.go
 var (
 	PrivacyGroupEVMCallDomain        = pdm("PrivacyGroupEVMTX.domain", "The domain that manages the privacy group")
@@ -80,6 +80,11 @@ var (
 	MsgPluginUnexpectedResponse   = pde("PD020301", "Unexpected response %T (expected %T)")
 	MsgPluginUnimplementedRequest = pde("PD020302", "Unimplemented plugin request %T")
 	MsgPluginErrorFromServerNoMsg = pde("PD020303", "Error from server (no detailed message in response)")
+	MsgPluginRunnerAuthRequired   = pde("PD020304", "Listening for remote plugin requests requires mutual TLS (-tls-cert, -tls-key and -tls-ca) or a shared token (-auth-token-file)")
+	MsgPluginRunnerTLSKeyPair     = pde("PD020305", "Both -tls-cert and -tls-key must be set to listen with TLS")
+	MsgPluginAuthTokenFile        = pde("PD020306", "Failed to read auth token file '%s'")
+	MsgPluginAuthTokenEmpty       = pde("PD020307", "Auth token file '%s' is empty")
+	MsgPluginRunnerUnauthorized   = pde("PD020308", "Missing or invalid auth token", 401)
 
 	// TLS PD0204XX
 	MsgTLSInvalidCAFile             = pde("PD020400", "Invalid CA certificates file")
@@ -113,6 +118,16 @@ var (
 	MsgJSONRPCInvalidParam        = pde("PD020704", "method %s parameter %d invalid: %s")
 	MsgJSONRPCResultSerialization = pde("PD020705", "method %s result serialization failed: %s")
 	MsgJSONRPCAysncNonWSConn      = pde("PD020706", "method %s only available on WebSocket connections")
+	MsgJSONRPCUnauthenticated     = pde("PD020707", "Authentication required")
+	MsgJSONRPCMethodNotAuthorized = pde("PD020708", "Principal '%s' is not authorized to call method %s")
+	MsgJSONRPCKeyNotAuthorized    = pde("PD020709", "Principal '%s' is not authorized to use key '%s' with method %s")
+	MsgJSONRPCAuthJWKSLoadFailed  = pde("PD020710", "Failed to load JWKS file '%s'")
+	MsgJSONRPCAuthInvalidJWT      = pde("PD020711", "Invalid JWT")
+	MsgJSONRPCAuthJWTNoPrincipal  = pde("PD020712", "JWT does not contain a string value for claim '%s'")
+	MsgJSONRPCAuthInvalidToken    = pde("PD020713", "Static token %d must have a principal, and exactly one of a token or a token file")
+	MsgJSONRPCAuthTokenFileFailed = pde("PD020714", "Failed to read static token file '%s'")
+	MsgJSONRPCAuthNoMethods       = pde("PD020715", "JSON/RPC authentication is enabled, but no authentication methods are configured")
+	MsgJSONRPCAuthInvalidPattern  = pde("PD020716", "Invalid pattern '%s' in authorization policy %d")
 
 	// Signing module PD0208XX
 	MsgSigningModuleBadPathError                = pde("PD020800", "Path '%s' does not exist, or it is not a directory")
@@ -143,6 +158,12 @@ var (
 	MsgSigningEmptyPayload                      = pde("PD020825", "No payload supplied for signing")
 	MsgSigningInvalidDomainAlgorithmNoPrefix    = pde("PD020826", "Invalid domain algorithm (no 'domain:' prefix): %s")
 	MsgSigningNoDomainRegisteredWithModule      = pde("PD020827", "Domain '%s' has not been registered in this signing module")
+	MsgSigningRemoteNoEndpoint                  = pde("PD020828", "Remote signer must be configured with exactly one of an HTTP or WebSocket URL")
+	MsgSigningPluginInvalidConfig               = pde("PD020829", "Invalid configuration supplied to signing module plugin '%s'")
+	MsgSigningPluginNotConfigured               = pde("PD020830", "Signing module plugin has not been configured")
+	MsgSigningInMemorySignerNotRemotable        = pde("PD020831", "In-memory signer '%s' cannot be used with a signing module outside of Paladin, as its private keys cannot be loaded into memory")
+	MsgSigningAlgorithmNotRemotable             = pde("PD020832", "Algorithm '%s' is implemented by in-memory signer '%s', which cannot be used with a signing module outside of Paladin")
+	MsgSigningRemoteServerNoAuth                = pde("PD020833", "Remote signer requires rpcServer.auth to be enabled, unless allowUnauthenticated is set")
 
 	// Reference markdown PD0209XX
 	MsgReferenceMarkdownMissing = pde("PD020900", "Reference markdown file missing: '%s'")
@@ -151,4 +172,9 @@ var (
 	// SolUtils module PD0210XX
 	MsgSolBuildParseFailed = pde("PD021000", "Invalid link hash at position %d in bytecode. Fully qualified lib name: %s. Placeholder: %s. Lib name hash prefix: %s")
 	MsgSolBuildMissingLink = pde("PD021001", "The solidity build is unlinked and requires an address for '%s'")
+
+	// Tracing PD0211XX
+	MsgTracingUnsupportedExporter = pde("PD021100", "Unsupported tracing exporter type '%s'")
+	MsgTracingExporterInitFailed  = pde("PD021101", "Failed to initialize tracing exporter '%s'")
+	MsgTracingFileOpenFailed      = pde("PD021102", "Failed to open tracing output file '%s'")
 )
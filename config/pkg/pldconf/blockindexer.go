@@ -23,16 +23,40 @@ import (
 )
 
 type BlockIndexerConfig struct {
-	FromBlock             json.RawMessage    `json:"fromBlock,omitempty"`
-	CommitBatchSize       *int               `json:"commitBatchSize"`
-	CommitBatchTimeout    *string            `json:"commitBatchTimeout"`
-	RequiredConfirmations *int               `json:"requiredConfirmations"`
-	ChainHeadCacheLen     *int               `json:"chainHeadCacheLen"`
-	BlockPollingInterval  *string            `json:"blockPollingInterval"`
-	EventStreams          EventStreamsConfig `json:"eventStreams"`
-	Retry                 RetryConfig        `json:"retry"`
+	FromBlock             json.RawMessage     `json:"fromBlock,omitempty"`
+	CommitBatchSize       *int                `json:"commitBatchSize"`
+	CommitBatchTimeout    *string             `json:"commitBatchTimeout"`
+	RequiredConfirmations *int                `json:"requiredConfirmations"`
+	ChainHeadCacheLen     *int                `json:"chainHeadCacheLen"`
+	BlockPollingInterval  *string             `json:"blockPollingInterval"`
+	BlockListener         BlockListenerConfig `json:"blockListener"`
+	EventStreams          EventStreamsConfig  `json:"eventStreams"`
+	ReceiptFetch          ReceiptFetchConfig  `json:"receiptFetch"`
+	Retry                 RetryConfig         `json:"retry"`
 }
 
+type BlockListenerConfig struct {
+	Transport   string `json:"transport"`
+	BlockFilter *bool  `json:"blockFilter"` // use eth_newBlockFilter to detect new blocks, falling back to polling eth_blockNumber if the node does not support it
+	NewHeads    *bool  `json:"newHeads"`    // subscribe to newHeads over WebSocket, to poll as soon as there is a new block rather than waiting for the polling interval
+}
+
+const (
+	BlockListenerTransportWebSocket string = "websocket" // all calls over a WebSocket, which must connect before the listener starts
+	BlockListenerTransportHTTP      string = "http"      // all calls over HTTP, with a WebSocket only used for newHeads (if enabled and available)
+)
+
+type ReceiptFetchConfig struct {
+	Strategy    string `json:"strategy"`
+	Concurrency *int   `json:"concurrency"` // limit on eth_getTransactionReceipt calls in flight, across all blocks being indexed
+}
+
+const (
+	ReceiptFetchStrategyBlockReceipts  string = "blockReceipts"  // eth_getBlockReceipts for each block (supported by Besu and go-ethereum)
+	ReceiptFetchStrategyPerTransaction string = "perTransaction" // eth_getTransactionReceipt for each transaction in each block
+	ReceiptFetchStrategyLogsOnly       string = "logsOnly"       // eth_getLogs for each block, with receipts only for transactions where the logs are not enough
+)
+
 type EventStreamsConfig struct {
 	BlockDispatchQueueLength *int `json:"blockDispatchQueueLength"`
 	CatchUpQueryPageSize     *int `json:"catchupQueryPageSize"`
@@ -50,4 +74,13 @@ var BlockIndexerDefaults = &BlockIndexerConfig{
 	RequiredConfirmations: confutil.P(0),
 	ChainHeadCacheLen:     confutil.P(50),
 	BlockPollingInterval:  confutil.P("10s"),
+	BlockListener: BlockListenerConfig{
+		Transport:   BlockListenerTransportWebSocket,
+		BlockFilter: confutil.P(true),
+		NewHeads:    confutil.P(true),
+	},
+	ReceiptFetch: ReceiptFetchConfig{
+		Strategy:    ReceiptFetchStrategyBlockReceipts,
+		Concurrency: confutil.P(10),
+	},
 }
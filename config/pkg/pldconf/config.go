@@ -27,6 +27,8 @@ type PaladinConfig struct {
 	DB                     DBConfig               `json:"db"`
 	RPCServer              RPCServerConfig        `json:"rpcServer"`
 	DebugServer            DebugServerConfig      `json:"debugServer"`
+	MetricsServer          MetricsServerConfig    `json:"metricsServer"`
+	Tracing                TracingConfig          `json:"tracing"`
 	StateStore             StateStoreConfig       `json:"statestore"`
 	BlockIndexer           BlockIndexerConfig     `json:"blockIndexer"`
 	TempDir                *string                `json:"tempDir"`
@@ -20,11 +20,31 @@ import (
 )
 
 type EthClientConfig struct {
-	WS                WSClientConfig   `json:"ws"`
-	HTTP              HTTPClientConfig `json:"http"`
-	EstimateGasFactor *float64         `json:"gasEstimateFactor"`
+	WS                WSClientConfig             `json:"ws"`
+	HTTP              HTTPClientConfig           `json:"http"`
+	Endpoints         []EthClientEndpointConfig  `json:"endpoints"` // ordered list of nodes to fail over between - used instead of ws/http when set
+	HealthCheck       EthClientHealthCheckConfig `json:"healthCheck"`
+	EstimateGasFactor *float64                   `json:"gasEstimateFactor"`
+}
+
+type EthClientEndpointConfig struct {
+	WS   WSClientConfig   `json:"ws"`
+	HTTP HTTPClientConfig `json:"http"`
+}
+
+type EthClientHealthCheckConfig struct {
+	Interval    *string `json:"interval"`
+	Timeout     *string `json:"timeout"`     // unhealthy if an endpoint does not respond to the health check within this time
+	MaxBlockLag *int    `json:"maxBlockLag"` // unhealthy if further than this behind the highest block reported by any endpoint
+	StaleHead   *string `json:"staleHead"`   // unhealthy if the head block does not advance for this long (0 to disable, as chains can be idle)
 }
 
 var EthClientDefaults = &EthClientConfig{
 	EstimateGasFactor: confutil.P(2.0),
+	HealthCheck: EthClientHealthCheckConfig{
+		Interval:    confutil.P("10s"),
+		Timeout:     confutil.P("5s"),
+		MaxBlockLag: confutil.P(10),
+		StaleHead:   confutil.P("0"),
+	},
 }
@@ -21,17 +21,17 @@ import (
 )
 
 type HTTPBasicAuthConfig struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `docstruct:"HTTPBasicAuthConfig" json:"username"`
+	Password string `docstruct:"HTTPBasicAuthConfig" json:"password"`
 }
 
 type HTTPClientConfig struct {
-	URL               string                 `json:"url"`
-	HTTPHeaders       map[string]interface{} `json:"httpHeaders"`
-	Auth              HTTPBasicAuthConfig    `json:"auth"`
-	TLS               TLSConfig              `json:"tls"`
-	RequestTimeout    *string                `json:"requestTimeout,omitempty"`
-	ConnectionTimeout *string                `json:"connectionTimeout,omitempty"`
+	URL               string                 `docstruct:"HTTPClientConfig" json:"url"`
+	HTTPHeaders       map[string]interface{} `docstruct:"HTTPClientConfig" json:"httpHeaders"`
+	Auth              HTTPBasicAuthConfig    `docstruct:"HTTPClientConfig" json:"auth"`
+	TLS               TLSConfig              `docstruct:"HTTPClientConfig" json:"tls"`
+	RequestTimeout    *string                `docstruct:"HTTPClientConfig" json:"requestTimeout,omitempty"`
+	ConnectionTimeout *string                `docstruct:"HTTPClientConfig" json:"connectionTimeout,omitempty"`
 }
 
 var DefaultHTTPConfig = &HTTPClientConfig{
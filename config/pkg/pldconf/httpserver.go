@@ -62,3 +62,14 @@ type DebugServerConfig struct {
 var DebugServerDefaults = &DebugServerConfig{
 	Enabled: confutil.P(false),
 }
+
+// The metrics are always available on the debug server (if enabled) under /metrics,
+// the dedicated metrics server allows them to be exposed without the profiling endpoints
+type MetricsServerConfig struct {
+	Enabled *bool `json:"enabled"`
+	HTTPServerConfig
+}
+
+var MetricsServerDefaults = &MetricsServerConfig{
+	Enabled: confutil.P(false),
+}
@@ -28,16 +28,29 @@ type KeyManagerManagerConfig struct {
 }
 
 type WalletConfig struct {
-	Name        string        `json:"name"`
-	KeySelector string        `json:"keySelector"`
-	SignerType  string        `json:"signerType"`
-	Signer      *SignerConfig `json:"signer"` // embedded only
+	Name            string              `json:"name"`
+	KeySelector     string              `json:"keySelector"`
+	SignerType      string              `json:"signerType"`
+	Signer          *SignerConfig       `json:"signer"`          // embedded only
+	Remote          *RemoteSignerConfig `json:"remote"`          // remote only
+	Plugin          *PluginConfig       `json:"plugin"`          // plugin only
+	Config          map[string]any      `json:"config"`          // plugin only - passed to the signing module plugin
+	InMemorySigners []string            `json:"inMemorySigners"` // remote and plugin only - prefixes (such as "domain") of in-memory signers the signing module hosts itself
 }
 
 const (
 	WalletSignerTypeEmbedded string = "embedded"
+	WalletSignerTypeRemote   string = "remote"
+	WalletSignerTypePlugin   string = "plugin"
 )
 
+// Connection to a signing module running in a separate process, that exposes the
+// signerapi functions over JSON/RPC. Exactly one of HTTP or WS must be configured.
+type RemoteSignerConfig struct {
+	HTTP HTTPClientConfig `json:"http"`
+	WS   WSClientConfig   `json:"ws"`
+}
+
 var WalletDefaults = &WalletConfig{
 	KeySelector: `.*`,                     // catch-all
 	SignerType:  WalletSignerTypeEmbedded, // uses the embedded signing module running in the Paladin process
@@ -19,7 +19,15 @@ import (
 )
 
 type PluginManagerConfig struct {
-	GRPC GRPCConfig `json:"grpc"`
+	GRPC          GRPCConfig          `json:"grpc"`
+	Restart       RetryConfig         `json:"restart"`       // backoff for restarting crashed process plugins, and reconnecting remote plugins
+	RemotePlugins RemotePluginsConfig `json:"remotePlugins"` // how we authenticate when asking remote plugins to start
+}
+
+// Remote plugins require a client certificate (mTLS) or a shared token, or both
+type RemotePluginsConfig struct {
+	TLS           TLSConfig `json:"tls"`
+	AuthTokenFile string    `json:"authTokenFile"`
 }
 
 type GRPCConfig struct {
@@ -30,6 +38,12 @@ var DefaultGRPCConfig = &GRPCConfig{
 	ShutdownTimeout: confutil.P("10s"),
 }
 
+var DefaultPluginRestartConfig = &RetryConfig{
+	InitialDelay: confutil.P("1s"),
+	MaxDelay:     confutil.P("30s"),
+	Factor:       confutil.P(2.0),
+}
+
 type PluginConfig struct {
 	Type    string  `json:"type"`
 	Library string  `json:"library"`
@@ -45,6 +45,8 @@ var PrivateTxManagerDefaults = &PrivateTxManagerConfig{
 		MaxPendingEvents:                    confutil.P(500),
 		RoundRobinCoordinatorBlockRangeSize: confutil.P(100),
 		AssembleRequestTimeout:              confutil.P("1s"),
+		HeartbeatInterval:                   confutil.P("5s"),
+		HeartbeatFailureThreshold:           confutil.P(3),
 	},
 	RequestTimeout: confutil.P("1s"),
 }
@@ -58,4 +60,6 @@ type PrivateTxManagerSequencerConfig struct {
 	StaleTimeout                        *string `json:"staleTimeout,omitempty"`
 	RoundRobinCoordinatorBlockRangeSize *int    `json:"roundRobinCoordinatorBlockRangeSize,omitempty"`
 	AssembleRequestTimeout              *string `json:"assembleRequestTimeout,omitempty"`
+	HeartbeatInterval                   *string `json:"heartbeatInterval,omitempty"`
+	HeartbeatFailureThreshold           *int    `json:"heartbeatFailureThreshold,omitempty"`
 }
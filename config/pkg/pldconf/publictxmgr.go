@@ -58,6 +58,8 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 		MaxInFlight:          confutil.P(500),
 		Interval:             confutil.P("5s"),
 		ResubmitInterval:     confutil.P("5m"),
+		ResubmitBlocks:       confutil.P(0),
+		StuckAfterAttempts:   confutil.P(10),
 		StaleTimeout:         confutil.P("5m"),
 		StageRetryTime:       confutil.P("10s"),
 		PersistenceRetryTime: confutil.P("5s"),
@@ -74,6 +76,13 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 		IncreaseMax:        nil,
 		IncreasePercentage: confutil.P(0),
 		FixedGasPrice:      nil,
+		FeeHistory: FeeHistoryConfig{
+			Enabled:           confutil.P(false),
+			BlockCount:        confutil.P(20),
+			RewardPercentile:  confutil.P(50.0),
+			BaseFeeMultiplier: confutil.P(2.0),
+			CacheTTL:          confutil.P("5s"),
+		},
 		Cache: CacheConfig{
 			Capacity: confutil.P(100),
 			// TODO: Enable a KB based cache with TTL in Paladin
@@ -147,11 +156,23 @@ type AutoFuelingConfig struct {
 }
 
 type GasPriceConfig struct {
-	IncreaseMax        *string            `json:"increaseMax"`
-	IncreasePercentage *int               `json:"increasePercentage"`
-	FixedGasPrice      any                `json:"fixedGasPrice"` // number or object
-	GasOracleAPI       GasOracleAPIConfig `json:"gasOracleAPI"`
-	Cache              CacheConfig        `json:"cache"`
+	IncreaseMax             *string            `json:"increaseMax"`
+	IncreasePercentage      *int               `json:"increasePercentage"`
+	MaxFeePerGasCap         *string            `json:"maxFeePerGasCap"`         // upper limit for the EIP-1559 maxFeePerGas of any transaction
+	MaxPriorityFeePerGasCap *string            `json:"maxPriorityFeePerGasCap"` // upper limit for the EIP-1559 maxPriorityFeePerGas of any transaction
+	FixedGasPrice           any                `json:"fixedGasPrice"`           // number or object
+	FeeHistory              FeeHistoryConfig   `json:"feeHistory"`
+	GasOracleAPI            GasOracleAPIConfig `json:"gasOracleAPI"`
+	Cache                   CacheConfig        `json:"cache"`
+}
+
+// FeeHistoryConfig configures EIP-1559 gas pricing derived from eth_feeHistory
+type FeeHistoryConfig struct {
+	Enabled           *bool    `json:"enabled"`
+	BlockCount        *int     `json:"blockCount"`        // number of recent blocks to sample
+	RewardPercentile  *float64 `json:"rewardPercentile"`  // percentile of the priority fees paid in each block, used for maxPriorityFeePerGas
+	BaseFeeMultiplier *float64 `json:"baseFeeMultiplier"` // headroom over the next base fee in maxFeePerGas, to stay valid as the base fee rises
+	CacheTTL          *string  `json:"cacheTTL"`          // how long a result is reused before querying the node again, as the base fee changes every block
 }
 
 type GasLimitConfig struct {
@@ -167,6 +188,8 @@ type PublicTxManagerOrchestratorConfig struct {
 	MaxInFlight               *int               `json:"maxInFlight"`
 	Interval                  *string            `json:"interval"`
 	ResubmitInterval          *string            `json:"resubmitInterval"`
+	ResubmitBlocks            *int               `json:"resubmitBlocks"`     // resubmit with increased fees after this many blocks without being mined (0 = only use the resubmit interval)
+	StuckAfterAttempts        *int               `json:"stuckAfterAttempts"` // a pending transaction is reported as stuck after this many submissions (0 = never)
 	StaleTimeout              *string            `json:"staleTimeout"`
 	StageRetryTime            *string            `json:"stageRetryTime"`
 	PersistenceRetryTime      *string            `json:"persistenceRetryTime"`
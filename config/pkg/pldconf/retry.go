@@ -19,14 +19,14 @@ package pldconf
 import "github.com/kaleido-io/paladin/config/pkg/confutil"
 
 type RetryConfig struct {
-	InitialDelay *string  `json:"initialDelay"`
-	MaxDelay     *string  `json:"maxDelay"`
-	Factor       *float64 `json:"factor"`
+	InitialDelay *string  `docstruct:"RetryConfig" json:"initialDelay"`
+	MaxDelay     *string  `docstruct:"RetryConfig" json:"maxDelay"`
+	Factor       *float64 `docstruct:"RetryConfig" json:"factor"`
 }
 
 type RetryConfigWithMax struct {
 	RetryConfig
-	MaxAttempts *int `json:"maxAttempts"`
+	MaxAttempts *int `docstruct:"RetryConfigWithMax" json:"maxAttempts"`
 }
 
 var GenericRetryDefaults = &RetryConfigWithMax{
@@ -0,0 +1,73 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldconf
+
+import (
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+)
+
+// RPCAuthConfig controls authentication of callers to the JSON/RPC server, and authorization
+// of the methods they can call and the keys they can use.
+//
+// When enabled, every HTTP request and WebSocket connection must present credentials accepted by
+// at least one of the configured authentication methods, which resolve the caller to a principal.
+type RPCAuthConfig struct {
+	Enabled      *bool                    `json:"enabled"`
+	StaticTokens []RPCAuthStaticToken     `json:"staticTokens,omitempty"` // bearer tokens mapped directly to a principal
+	JWT          RPCAuthJWTConfig         `json:"jwt"`                    // bearer JWTs validated against a local JWKS file
+	MTLS         RPCAuthMTLSConfig        `json:"mtls"`                   // client certificate identity (requires tls.clientAuth on the servers)
+	Policies     []RPCAuthorizationPolicy `json:"policies,omitempty"`     // if none are configured, any authenticated principal may call any method
+}
+
+type RPCAuthStaticToken struct {
+	Principal string `json:"principal"`
+	Token     string `json:"token,omitempty"`
+	TokenFile string `json:"tokenFile,omitempty"`
+}
+
+type RPCAuthJWTConfig struct {
+	JWKSFile       string  `json:"jwksFile,omitempty"` // JWT validation is enabled when this is set
+	Issuer         string  `json:"issuer,omitempty"`
+	Audience       string  `json:"audience,omitempty"`
+	PrincipalClaim *string `json:"principalClaim"`
+	Leeway         *string `json:"leeway"`
+}
+
+type RPCAuthMTLSConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// RPCAuthorizationPolicy grants the matching principals access to the matching methods, using
+// the matching keys. All matching uses glob patterns, such as "ptx_*" or "app1.*".
+//
+// The keys are checked against any "from" field in the parameters of the request, and against the
+// keys that a method resolves itself - such as a key passed positionally, or the sender of an existing
+// transaction that the method acts on by ID. So an empty list of keys permits only those requests that
+// do not use a signing key.
+type RPCAuthorizationPolicy struct {
+	Principals []string `json:"principals"`
+	Methods    []string `json:"methods"`
+	Keys       []string `json:"keys,omitempty"`
+}
+
+var RPCAuthDefaults = &RPCAuthConfig{
+	Enabled: confutil.P(false),
+	JWT: RPCAuthJWTConfig{
+		PrincipalClaim: confutil.P("sub"),
+		Leeway:         confutil.P("1m"),
+	},
+}
@@ -44,4 +44,5 @@ type RPCServerConfigWS struct {
 type RPCServerConfig struct {
 	HTTP RPCServerConfigHTTP `json:"http,omitempty"`
 	WS   RPCServerConfigWS   `json:"ws,omitempty"`
+	Auth RPCAuthConfig       `json:"auth,omitempty"`
 }
@@ -17,14 +17,14 @@
 package pldconf
 
 type TLSConfig struct {
-	Enabled                bool              `json:"enabled"`
-	ClientAuth             bool              `json:"clientAuth,omitempty"`
-	CAFile                 string            `json:"caFile,omitempty"`
-	CA                     string            `json:"ca,omitempty"`
-	CertFile               string            `json:"certFile,omitempty"`
-	Cert                   string            `json:"cert,omitempty"`
-	KeyFile                string            `json:"keyFile,omitempty"`
-	Key                    string            `json:"key,omitempty"`
-	InsecureSkipHostVerify bool              `json:"insecureSkipHostVerify,omitempty"`
-	RequiredDNAttributes   map[string]string `json:"requiredDNAttributes,omitempty"`
+	Enabled                bool              `docstruct:"TLSConfig" json:"enabled"`
+	ClientAuth             bool              `docstruct:"TLSConfig" json:"clientAuth,omitempty"`
+	CAFile                 string            `docstruct:"TLSConfig" json:"caFile,omitempty"`
+	CA                     string            `docstruct:"TLSConfig" json:"ca,omitempty"`
+	CertFile               string            `docstruct:"TLSConfig" json:"certFile,omitempty"`
+	Cert                   string            `docstruct:"TLSConfig" json:"cert,omitempty"`
+	KeyFile                string            `docstruct:"TLSConfig" json:"keyFile,omitempty"`
+	Key                    string            `docstruct:"TLSConfig" json:"key,omitempty"`
+	InsecureSkipHostVerify bool              `docstruct:"TLSConfig" json:"insecureSkipHostVerify,omitempty"`
+	RequiredDNAttributes   map[string]string `docstruct:"TLSConfig" json:"requiredDNAttributes,omitempty"`
 }
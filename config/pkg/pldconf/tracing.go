@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldconf
+
+import "github.com/kaleido-io/paladin/config/pkg/confutil"
+
+type TracingConfig struct {
+	// enables OpenTelemetry tracing
+	Enabled *bool `json:"enabled"`
+	// the service name reported on all spans
+	ServiceName *string `json:"serviceName"`
+	// the ratio of new traces to sample (0.0 to 1.0) - traces started by another node are sampled if the parent was
+	SampleRatio *float64 `json:"sampleRatio"`
+	// the exporter to send spans to
+	Exporter TracingExporterConfig `json:"exporter"`
+}
+
+const (
+	TracingExporterOTLPGRPC = "otlp-grpc"
+	TracingExporterOTLPHTTP = "otlp-http"
+	TracingExporterStdout   = "stdout"
+	TracingExporterFile     = "file"
+)
+
+type TracingExporterConfig struct {
+	// the exporter type ('otlp-grpc', 'otlp-http', 'stdout', 'file')
+	Type *string `json:"type"`
+	// the OTLP collector endpoint, as host:port
+	Endpoint string `json:"endpoint"`
+	// disables TLS when connecting to the OTLP collector
+	Insecure *bool `json:"insecure"`
+	// additional headers to send to the OTLP collector, such as authentication tokens
+	Headers map[string]string `json:"headers"`
+	// the file to write spans to, for the 'file' exporter
+	File *string `json:"file"`
+}
+
+var TracingDefaults = &TracingConfig{
+	Enabled:     confutil.P(false),
+	ServiceName: confutil.P("paladin"),
+	SampleRatio: confutil.P(1.0),
+	Exporter: TracingExporterConfig{
+		Type:     confutil.P(TracingExporterOTLPGRPC),
+		Insecure: confutil.P(false),
+		File:     confutil.P("paladin-traces.json"),
+	},
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kaleido-io/paladin/core/pkg/bootstrap"
+)
+
+// Runs Paladin as a standalone Go executable, without the JVM.
+// Plugins of type c-shared are loaded into this process by the built-in Go plugin loader.
+func main() {
+	grpcTarget := flag.String("grpc-target", "", "plugin gRPC target (defaults to a socket file in the configured tempDir)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] <config.paladin.yaml> <engine|testbed>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(int(bootstrap.RC_FAIL))
+	}
+	os.Exit(int(bootstrap.RunStandalone(*grpcTarget, flag.Arg(0), flag.Arg(1))))
+}
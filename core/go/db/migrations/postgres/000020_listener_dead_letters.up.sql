@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE listener_dead_letters (
+    "id"                 UUID    NOT NULL,
+    "listener_type"      TEXT    NOT NULL,
+    "listener"           TEXT    NOT NULL,
+    "created"            BIGINT  NOT NULL,
+    "batch_id"           TEXT    NOT NULL,
+    "attempts"           INT     NOT NULL,
+    "error"              TEXT    NOT NULL,
+    "batch"              TEXT    NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX listener_dead_letters_listener ON listener_dead_letters ("listener_type", "listener");
+
+COMMIT;
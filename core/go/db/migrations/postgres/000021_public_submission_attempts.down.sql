@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_submissions" DROP COLUMN "attempts";
+
+COMMIT;
\ No newline at end of file
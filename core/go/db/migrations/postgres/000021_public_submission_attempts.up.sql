@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_submissions" ADD "attempts" INT NOT NULL DEFAULT 1;
+
+COMMIT;
\ No newline at end of file
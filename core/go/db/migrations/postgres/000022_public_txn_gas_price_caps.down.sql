@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_txns" DROP COLUMN "gas_price_caps";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_txns" ADD "gas_price_caps" TEXT;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE "public_submissions" DROP COLUMN "cancellation";
+ALTER TABLE "public_txns" DROP COLUMN "cancelled";
+
+COMMIT;
\ No newline at end of file
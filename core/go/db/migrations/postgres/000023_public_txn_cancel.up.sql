@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE "public_txns" ADD "cancelled" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "public_submissions" ADD "cancellation" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "public_submissions" DROP COLUMN "attempts";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "public_submissions" ADD "attempts" INT NOT NULL DEFAULT 1;
\ No newline at end of file
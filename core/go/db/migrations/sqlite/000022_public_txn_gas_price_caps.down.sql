@@ -0,0 +1 @@
+ALTER TABLE "public_txns" DROP COLUMN "gas_price_caps";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "public_txns" ADD "gas_price_caps" TEXT;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "public_submissions" DROP COLUMN "cancellation";
+ALTER TABLE "public_txns" DROP COLUMN "cancelled";
\ No newline at end of file
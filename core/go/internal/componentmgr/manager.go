@@ -28,6 +28,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/groupmgr"
 	"github.com/kaleido-io/paladin/core/internal/identityresolver"
 	"github.com/kaleido-io/paladin/core/internal/keymanager"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/internal/plugins"
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr"
@@ -45,6 +46,7 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/httpserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 )
 
 type ComponentManager interface {
@@ -63,6 +65,9 @@ type componentManager struct {
 	conf *pldconf.PaladinConfig
 	// debug server
 	debugServer httpserver.Server
+	// metrics
+	metricsManager metrics.Metrics
+	metricsServer  httpserver.Server
 	// pre-init
 	keyManager       components.KeyManager
 	ethClientFactory ethclient.EthClientFactory
@@ -104,6 +109,13 @@ type closeable interface {
 	Close()
 }
 
+// flushes any spans still buffered for export on close
+type tracingProvider tracing.Shutdown
+
+func (tp tracingProvider) Close() {
+	tp(context.Background())
+}
+
 func NewComponentManager(bgCtx context.Context, grpcTarget string, instanceUUID uuid.UUID, conf *pldconf.PaladinConfig,
 	// the testbed registers itself into the lifecycle as an additional manager
 	additionalManagers ...components.AdditionalManager,
@@ -115,6 +127,7 @@ func NewComponentManager(bgCtx context.Context, grpcTarget string, instanceUUID
 		bgCtx:                 bgCtx,
 		conf:                  conf,
 		additionalManagers:    additionalManagers,
+		metricsManager:        metrics.NewMetricsManager(bgCtx),
 		initResults:           make(map[string]*components.ManagerInitResult),
 		started:               make(map[string]stoppable),
 		opened:                make(map[string]closeable),
@@ -132,6 +145,16 @@ func (cm *componentManager) startDebugServer() (httpserver.Server, error) {
 	server, err := httpserver.NewDebugServer(cm.bgCtx, &cm.conf.DebugServer.HTTPServerConfig)
 	if err == nil {
 		server.Router().PathPrefix("/debug/javadump").HandlerFunc(http.HandlerFunc(cm.javaDump))
+		server.Router().Path(httpserver.MetricsPath).Handler(httpserver.MetricsHandler(cm.metricsManager.Registry()))
+		err = server.Start()
+	}
+	return server, err
+}
+
+func (cm *componentManager) startMetricsServer() (httpserver.Server, error) {
+	cm.conf.MetricsServer.Port = confutil.P(confutil.Int(cm.conf.MetricsServer.Port, 0)) // if enabled with no port, we allocate one
+	server, err := httpserver.NewMetricsServer(cm.bgCtx, &cm.conf.MetricsServer.HTTPServerConfig, cm.metricsManager.Registry())
+	if err == nil {
 		err = server.Start()
 	}
 	return server, err
@@ -143,6 +166,15 @@ func (cm *componentManager) Init() (err error) {
 		cm.debugServer, err = cm.startDebugServer()
 		err = cm.addIfStarted("debugServer", cm.debugServer, err, msgs.MsgComponentDebugServerStartError)
 	}
+	if err == nil && confutil.Bool(cm.conf.MetricsServer.Enabled, *pldconf.MetricsServerDefaults.Enabled) {
+		cm.metricsServer, err = cm.startMetricsServer()
+		err = cm.addIfStarted("metricsServer", cm.metricsServer, err, msgs.MsgComponentMetricsServerStartError)
+	}
+	if err == nil {
+		var shutdown tracing.Shutdown
+		shutdown, err = tracing.InitTracing(cm.bgCtx, &cm.conf.Tracing, tracing.AttrNode.String(cm.conf.NodeName))
+		err = cm.addIfOpened("tracing", tracingProvider(shutdown), err, msgs.MsgComponentTracingInitError)
+	}
 
 	if err == nil {
 		cm.ethClientFactory, err = ethclient.NewEthClientFactory(cm.bgCtx, &cm.conf.Blockchain)
@@ -154,9 +186,17 @@ func (cm *componentManager) Init() (err error) {
 		err = cm.addIfOpened("database", cm.persistence, err, msgs.MsgComponentDBInitError)
 	}
 	if err == nil {
-		cm.blockIndexer, err = blockindexer.NewBlockIndexer(cm.bgCtx, &cm.conf.BlockIndexer, &cm.conf.Blockchain.WS, cm.persistence)
+		err = persistence.RegisterMetrics(cm.bgCtx, cm.persistence, cm.metricsManager.Registry())
+		err = cm.wrapIfErr(err, msgs.MsgComponentMetricsInitError, "database")
+	}
+	if err == nil {
+		cm.blockIndexer, err = blockindexer.NewBlockIndexerForEthClientFactory(cm.bgCtx, &cm.conf.BlockIndexer, cm.ethClientFactory, cm.persistence)
 		err = cm.wrapIfErr(err, msgs.MsgComponentBlockIndexerInitError)
 	}
+	if err == nil {
+		err = cm.metricsManager.Registry().Register(cm.blockIndexer.MetricsCollector())
+		err = cm.wrapIfErr(err, msgs.MsgComponentMetricsInitError, "block_indexer")
+	}
 	if err == nil {
 		cm.rpcServer, err = rpcserver.NewRPCServer(cm.bgCtx, &cm.conf.RPCServer)
 		err = cm.wrapIfErr(err, msgs.MsgComponentRPCServerInitError)
@@ -509,6 +549,10 @@ func (cm *componentManager) StateManager() components.StateManager {
 	return cm.stateManager
 }
 
+func (cm *componentManager) MetricsManager() metrics.Metrics {
+	return cm.metricsManager
+}
+
 func (cm *componentManager) RPCServer() rpcserver.RPCServer {
 	return cm.rpcServer
 }
@@ -19,9 +19,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"path"
 	"testing"
 
 	"github.com/google/uuid"
@@ -104,6 +106,16 @@ func TestInitOK(t *testing.T) {
 				Port: confutil.P(debugPort),
 			},
 		},
+		MetricsServer: pldconf.MetricsServerConfig{
+			Enabled: confutil.P(true),
+		},
+		Tracing: pldconf.TracingConfig{
+			Enabled: confutil.P(true),
+			Exporter: pldconf.TracingExporterConfig{
+				Type: confutil.P(pldconf.TracingExporterFile),
+				File: confutil.P(path.Join(t.TempDir(), "traces.json")),
+			},
+		},
 	}
 
 	mockExtraManager := componentmocks.NewAdditionalManager(t)
@@ -129,12 +141,26 @@ func TestInitOK(t *testing.T) {
 	assert.NotNil(t, cm.TxManager())
 	assert.NotNil(t, cm.GroupManager())
 	assert.NotNil(t, cm.IdentityResolver())
+	assert.NotNil(t, cm.MetricsManager())
 
 	// Check we can send a request for a javadump - even just after init (not start)
 	resp, err := http.Get(fmt.Sprintf("http://localhost:%d/debug/javadump", debugPort))
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
 
+	// Metrics are available on both the debug server, and the dedicated metrics server
+	resp, err = http.Get(fmt.Sprintf("http://localhost:%d/metrics", debugPort))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, err = http.Get(fmt.Sprintf("http://%s/metrics", cm.metricsServer.Addr()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Regexp(t, "go_goroutines", string(b))
+
+	assert.NotNil(t, cm.opened["tracing"])
+
 	cm.Stop()
 
 }
@@ -274,6 +300,19 @@ func TestBuildInternalEventStreamsPreCommitPostCommit(t *testing.T) {
 
 }
 
+func TestInitTracingFail(t *testing.T) {
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{
+		Tracing: pldconf.TracingConfig{
+			Enabled: confutil.P(true),
+			Exporter: pldconf.TracingExporterConfig{
+				Type: confutil.P("wrong"),
+			},
+		},
+	}, nil).(*componentManager)
+	err := cm.Init()
+	assert.Regexp(t, "PD010038.*PD021100", err)
+}
+
 func TestErrorWrapping(t *testing.T) {
 	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{}, nil).(*componentManager)
 
@@ -18,12 +18,20 @@ package components
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
 )
 
+type KeyManagerToSigningModule interface {
+	plugintk.SigningModuleAPI
+	Initialized()
+}
+
 type KeyResolver interface {
 	ResolveKey(ctx context.Context, identifier, algorithm, verifierType string) (mapping *pldapi.KeyMappingAndVerifier, err error)
 }
@@ -51,10 +59,14 @@ type KeyManager interface {
 	// Convenience when all you want is the EthAddress, and to know the reverse lookup will later be possible
 	ResolveEthAddressBatchNewDatabaseTX(ctx context.Context, identifiers []string) (ethAddresses []*pldtypes.EthAddress, err error)
 
-	// Domains register their signers during PostCommit
-	AddInMemorySigner(prefix string, signer signerapi.InMemorySigner)
+	// Domains register their signers during PostInit, which fails if no wallet can use them
+	AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error
 
 	ReverseKeyLookup(ctx context.Context, dbTX persistence.DBTX, algorithm, verifierType, verifier string) (mapping *pldapi.KeyMappingAndVerifier, err error)
 
 	Sign(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error)
+
+	// Wallets with signerType "plugin" are loaded by the plugin manager, keyed by wallet name
+	ConfiguredSigningModules() map[string]*pldconf.PluginConfig
+	SigningModuleRegistered(name string, id uuid.UUID, toSigningModule KeyManagerToSigningModule) (fromSigningModule plugintk.SigningModuleCallbacks, err error)
 }
@@ -42,12 +42,22 @@ type PrivateTxEndorsementStatus struct {
 	EndorsementReceived bool   `json:"endorsementReceived"`
 }
 
+type PrivateTxCoordinatorStatus struct {
+	Node          string `json:"node"`
+	Available     bool   `json:"available"`
+	LastHeartbeat string `json:"lastHeartbeat,omitempty"`
+	FailedTime    string `json:"failedTime,omitempty"`
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
 type PrivateTxStatus struct {
 	TxID           string                       `json:"transactionId"`
 	Status         string                       `json:"status"`
 	LatestEvent    string                       `json:"latestEvent"`
 	LatestError    string                       `json:"latestError"`
 	Endorsements   []PrivateTxEndorsementStatus `json:"endorsements"`
+	Coordinator    string                       `json:"coordinator,omitempty"`
+	Coordinators   []PrivateTxCoordinatorStatus `json:"coordinators,omitempty"`
 	Transaction    *PrivateTransaction          `json:"transaction,omitempty"`
 	FailureMessage string                       `json:"failureMessage,omitempty"`
 }
@@ -83,6 +93,8 @@ type PrivateTxManager interface {
 	//Synchronous functions to submit a new private transaction
 	HandleNewTx(ctx context.Context, dbTX persistence.DBTX, tx *ValidatedTransaction) error
 	GetTxStatus(ctx context.Context, domainAddress string, txID uuid.UUID) (status PrivateTxStatus, err error)
+	// Cancels a transaction that has not yet been dispatched, releasing its state locks and writing a failure receipt
+	CancelTransaction(ctx context.Context, domain string, contractAddr pldtypes.EthAddress, txID uuid.UUID) error
 
 	// Synchronous function to call an existing deployed smart contract
 	CallPrivateSmartContract(ctx context.Context, call *ResolvedTransaction) (*abi.ComponentValue, error)
@@ -94,6 +106,8 @@ type PrivateTxManager interface {
 	NotifyFailedPublicTx(ctx context.Context, dbTX persistence.DBTX, confirms []*PublicTxMatch) error
 
 	PrivateTransactionConfirmed(ctx context.Context, receipt *TxCompletion)
+	// Called after the on-chain configuration of a private smart contract has been changed by an event
+	PrivateSmartContractConfigChanged(ctx context.Context, contractAddr pldtypes.EthAddress)
 
 	BuildStateDistributions(ctx context.Context, tx *PrivateTransaction) (*StateDistributionSet, error)
 	BuildNullifier(ctx context.Context, kr KeyResolver, s *StateDistributionWithData) (*NullifierUpsert, error)
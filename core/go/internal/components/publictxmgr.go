@@ -51,6 +51,7 @@ type PaladinTXReference struct {
 type PublicTxMatch struct {
 	PaladinTXReference
 	*blockindexer.IndexedTransactionNotify
+	Cancellation bool // the mined transaction is the zero-value replacement of a cancelled transaction
 }
 
 type PublicTxManager interface {
@@ -60,6 +61,8 @@ type PublicTxManager interface {
 	QueryPublicTxForTransactions(ctx context.Context, dbTX persistence.DBTX, boundToTxns []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error)
 	QueryPublicTxWithBindings(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error)
 	GetPublicTransactionForHash(ctx context.Context, dbTX persistence.DBTX, hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error)
+	QueryStuckPublicTransactions(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error)
+	GetNonceGaps(ctx context.Context, from pldtypes.EthAddress) ([]*pldapi.PublicTxNonceGap, error)
 
 	// Perform (potentially expensive) transaction level validation, such as gas estimation. Call before starting a DB transaction
 	ValidateTransaction(ctx context.Context, dbTX persistence.DBTX, transaction *PublicTxSubmission) error
@@ -72,4 +75,9 @@ type PublicTxManager interface {
 	NotifyConfirmPersisted(ctx context.Context, confirms []*PublicTxMatch)
 
 	UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error
+	// Replaces the transaction with a zero-value transaction to the sending address, using the same nonce.
+	// The match for the replacement is flagged as a cancellation when it is confirmed.
+	CancelTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress) error
+	// Submits a zero-value transaction to the sending address, using a nonce that is not held by any other transaction
+	FillNonceGap(ctx context.Context, from pldtypes.EthAddress, nonce uint64) (*pldapi.PublicTx, error)
 }
@@ -127,6 +127,12 @@ type DomainContext interface {
 	// The dbTX is passed in to allow re-use of a connection during read operations.
 	FindAvailableNullifiers(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON) (Schema, []*pldapi.State, error)
 
+	// FindInfoStates finds the info states recorded by confirmed transactions. Info states are
+	// never available, as they are not confirmed or spent on the ledger.
+	//
+	// The dbTX is passed in to allow re-use of a connection during read operations.
+	FindInfoStates(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON) (Schema, []*pldapi.State, error)
+
 	// AddStateLocks updates the in-memory state of the domain context, to record a set of locks
 	// that affect queries on available states and nullifiers.
 	//
@@ -119,6 +119,7 @@ type TXManager interface {
 	CreateReceiptListener(ctx context.Context, spec *pldapi.TransactionReceiptListener) error
 	GetReceiptListener(ctx context.Context, name string) *pldapi.TransactionReceiptListener
 	QueryReceiptListeners(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.TransactionReceiptListener, error)
+	QueryReceiptListenerDeadLetters(ctx context.Context, dbTX persistence.DBTX, name string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error)
 	StartReceiptListener(ctx context.Context, name string) error
 	StopReceiptListener(ctx context.Context, name string) error
 	DeleteReceiptListener(ctx context.Context, name string) error
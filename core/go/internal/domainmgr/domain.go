@@ -329,6 +329,8 @@ func (d *domain) FindAvailableStates(ctx context.Context, req *prototk.FindAvail
 	var states []*pldapi.State
 	if req.UseNullifiers != nil && *req.UseNullifiers {
 		_, states, err = c.dCtx.FindAvailableNullifiers(c.dbTX, schemaID, &query)
+	} else if req.InfoStates != nil && *req.InfoStates {
+		_, states, err = c.dCtx.FindInfoStates(c.dbTX, schemaID, &query)
 	} else {
 		_, states, err = c.dCtx.FindAvailableStates(c.dbTX, schemaID, &query)
 	}
@@ -717,6 +719,14 @@ func (d *domain) toEndorsableListBase(states []*pldapi.StateBase) []*prototk.End
 	return endorsableList
 }
 
+func (d *domain) toNullifierIDs(nullifiers []*pldapi.StateNullifier) []string {
+	ids := make([]string, len(nullifiers))
+	for i, n := range nullifiers {
+		ids[i] = n.ID.String()
+	}
+	return ids
+}
+
 func (d *domain) CustomHashFunction() bool {
 	// note config assured to be non-nil by GetDomainByName() not returning a domain until init complete
 	return d.config.CustomHashFunction
@@ -773,12 +783,13 @@ func (d *domain) BuildDomainReceipt(ctx context.Context, dbTX persistence.DBTX,
 
 	// As long as we have some knowledge, we call to the domain and see what it builds with what we have available
 	res, err := d.api.BuildReceipt(ctx, &prototk.BuildReceiptRequest{
-		TransactionId: pldtypes.Bytes32UUIDFirst16(txID).String(),
-		Complete:      txStates.Unavailable == nil, // important for the domain to know if we have everything (it may fail with partial knowledge)
-		InputStates:   d.toEndorsableListBase(txStates.Spent),
-		ReadStates:    d.toEndorsableListBase(txStates.Read),
-		OutputStates:  d.toEndorsableListBase(txStates.Confirmed),
-		InfoStates:    d.toEndorsableListBase(txStates.Info),
+		TransactionId:   pldtypes.Bytes32UUIDFirst16(txID).String(),
+		Complete:        txStates.Unavailable == nil, // important for the domain to know if we have everything (it may fail with partial knowledge)
+		InputStates:     d.toEndorsableListBase(txStates.Spent),
+		ReadStates:      d.toEndorsableListBase(txStates.Read),
+		OutputStates:    d.toEndorsableListBase(txStates.Confirmed),
+		InfoStates:      d.toEndorsableListBase(txStates.Info),
+		InputNullifiers: d.toNullifierIDs(txStates.Nullifiers),
 	})
 	if err != nil {
 		return nil, err
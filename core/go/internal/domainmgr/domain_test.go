@@ -589,6 +589,21 @@ func TestDomainFindAvailableStatesOK(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Len(t, states.States, 0)
+
+	// Info states miss (the state is an output state)
+	infoStates := true
+	states, err = td.d.FindAvailableStates(td.ctx, &prototk.FindAvailableStatesRequest{
+		StateQueryContext: td.c.id,
+		SchemaId:          td.tp.stateSchemas[0].Id,
+		QueryJson: `{
+		  "eq": [
+		    { "field": "owner", "value": "` + state1.Owner.String() + `" }
+		  ]
+		}`,
+		InfoStates: &infoStates,
+	})
+	require.NoError(t, err)
+	assert.Len(t, states.States, 0)
 }
 
 func TestDomainInitDeployOK(t *testing.T) {
@@ -1206,6 +1221,7 @@ func TestGetDomainReceiptAllAvailable(t *testing.T) {
 	stateID2 := pldtypes.HexBytes(pldtypes.RandBytes(32))
 	stateID3 := pldtypes.HexBytes(pldtypes.RandBytes(32))
 	stateID4 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	nullifier1 := pldtypes.HexBytes(pldtypes.RandBytes(32))
 
 	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
 		mc.stateStore.On("GetTransactionStates", mock.Anything, mock.Anything, txID).
@@ -1214,6 +1230,9 @@ func TestGetDomainReceiptAllAvailable(t *testing.T) {
 				Read:      []*pldapi.StateBase{{ID: stateID2}},
 				Confirmed: []*pldapi.StateBase{{ID: stateID3}},
 				Info:      []*pldapi.StateBase{{ID: stateID4}},
+				Nullifiers: []*pldapi.StateNullifier{
+					{State: stateID1, ID: nullifier1},
+				},
 			}, nil)
 	})
 	defer done()
@@ -1229,6 +1248,7 @@ func TestGetDomainReceiptAllAvailable(t *testing.T) {
 		require.Equal(t, stateID3.String(), req.OutputStates[0].Id)
 		require.Len(t, req.InfoStates, 1)
 		require.Equal(t, stateID4.String(), req.InfoStates[0].Id)
+		require.Equal(t, []string{nullifier1.String()}, req.InputNullifiers)
 
 		return &prototk.BuildReceiptResponse{
 			ReceiptJson: `{"some":"receipt"}`,
@@ -196,11 +196,18 @@ func (d *domain) handleEventBatch(ctx context.Context, dbTX persistence.DBTX, ba
 	if err != nil {
 		return err
 	}
+	var configChanges []pldtypes.EthAddress
 	for addr, batch := range batchesByAddress {
 		res, err := d.handleEventBatchForContract(ctx, dbTX, addr, batch)
 		if err != nil {
 			return err
 		}
+		if len(res.ContractConfig) > 0 {
+			if err := d.updateContractConfig(ctx, dbTX, addr, res.ContractConfig); err != nil {
+				return err
+			}
+			configChanges = append(configChanges, addr)
+		}
 		for _, txCompletionEvent := range res.TransactionsComplete {
 			var txHash pldtypes.Bytes32
 			txID, err := d.recoverTransactionID(ctx, txCompletionEvent.TransactionId)
@@ -258,10 +265,30 @@ func (d *domain) handleEventBatch(ctx context.Context, dbTX persistence.DBTX, ba
 
 	dbTX.AddPostCommit(func(txCtx context.Context) {
 		d.dm.notifyTransactions(txCompletions)
+		d.dm.notifyContractConfigChanges(configChanges)
 	})
 	return nil
 }
 
+// Stores new on-chain configuration for a contract, supplied by the domain while processing its events
+func (d *domain) updateContractConfig(ctx context.Context, dbTX persistence.DBTX, addr pldtypes.EthAddress, configBytes pldtypes.HexBytes) error {
+	log.L(ctx).Infof("Updating configuration of private smart contract %s: %s", addr, configBytes)
+	return dbTX.DB().
+		Table("private_smart_contracts").
+		WithContext(ctx).
+		Where("address = ?", addr).
+		Update("config_bytes", configBytes).
+		Error
+}
+
+func (dm *domainManager) notifyContractConfigChanges(addrs []pldtypes.EthAddress) {
+	for _, addr := range addrs {
+		// Next lookup will load the new config from the DB, and initialize the contract with the domain again
+		dm.contractCache.Delete(addr)
+		dm.privateTxManager.PrivateSmartContractConfigChanged(dm.bgCtx, addr)
+	}
+}
+
 func (d *domain) recoverTransactionID(ctx context.Context, txIDString string) (*uuid.UUID, error) {
 	txIDBytes, err := pldtypes.ParseBytes32Ctx(ctx, txIDString)
 	if err != nil {
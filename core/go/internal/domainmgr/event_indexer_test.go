@@ -1113,6 +1113,95 @@ func TestHandleEventBatchUpsertStateFail(t *testing.T) {
 	assert.EqualError(t, err, "pop")
 }
 
+func TestHandleEventBatchContractConfigChanged(t *testing.T) {
+	batchID := uuid.New()
+	contract1 := pldtypes.RandAddress()
+	newConfig := pldtypes.RandBytes(64)
+
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(mc *mockComponents) {
+		mc.privateTxManager.On("PrivateSmartContractConfigChanged", mock.Anything, *contract1).Return()
+	})
+	defer done()
+
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*private_smart_contracts").WillReturnRows(sqlmock.NewRows(
+		[]string{"address", "domain_address"},
+	).AddRow(contract1, td.d.registryAddress))
+	mp.Mock.ExpectExec("UPDATE.*private_smart_contracts.*config_bytes").WillReturnResult(driver.ResultNoRows)
+	mp.Mock.ExpectCommit()
+
+	td.tp.Functions.HandleEventBatch = func(ctx context.Context, req *prototk.HandleEventBatchRequest) (*prototk.HandleEventBatchResponse, error) {
+		return &prototk.HandleEventBatchResponse{
+			ContractConfig: newConfig,
+		}, nil
+	}
+	td.tp.Functions.InitContract = func(ctx context.Context, icr *prototk.InitContractRequest) (*prototk.InitContractResponse, error) {
+		return &prototk.InitContractResponse{Valid: true, ContractConfig: &prototk.ContractConfig{}}, nil
+	}
+
+	err = mp.P.Transaction(context.Background(), func(ctx context.Context, dbTX persistence.DBTX) error {
+		return td.d.handleEventBatch(td.ctx, dbTX, &blockindexer.EventDeliveryBatch{
+			BatchID: batchID,
+			Events: []*pldapi.EventWithData{
+				{
+					Address:      *contract1,
+					IndexedEvent: &pldapi.IndexedEvent{},
+					Data:         pldtypes.RawJSON(`{"result": "success"}`),
+				},
+			},
+		})
+	})
+	require.NoError(t, err)
+	require.NoError(t, mp.Mock.ExpectationsWereMet())
+
+	// The contract was cached while processing the events, and must be evicted now the config has changed
+	_, isCached := td.dm.contractCache.Get(*contract1)
+	assert.False(t, isCached)
+}
+
+func TestHandleEventBatchContractConfigUpdateFail(t *testing.T) {
+	batchID := uuid.New()
+	contract1 := pldtypes.RandAddress()
+
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+
+	mp.Mock.ExpectBegin()
+	mp.Mock.ExpectQuery("SELECT.*private_smart_contracts").WillReturnRows(sqlmock.NewRows(
+		[]string{"address", "domain_address"},
+	).AddRow(contract1, td.d.registryAddress))
+	mp.Mock.ExpectExec("UPDATE.*private_smart_contracts").WillReturnError(fmt.Errorf("pop"))
+
+	td.tp.Functions.HandleEventBatch = func(ctx context.Context, req *prototk.HandleEventBatchRequest) (*prototk.HandleEventBatchResponse, error) {
+		return &prototk.HandleEventBatchResponse{
+			ContractConfig: pldtypes.RandBytes(64),
+		}, nil
+	}
+	td.tp.Functions.InitContract = func(ctx context.Context, icr *prototk.InitContractRequest) (*prototk.InitContractResponse, error) {
+		return &prototk.InitContractResponse{Valid: true, ContractConfig: &prototk.ContractConfig{}}, nil
+	}
+
+	err = mp.P.Transaction(context.Background(), func(ctx context.Context, dbTX persistence.DBTX) error {
+		return td.d.handleEventBatch(td.ctx, dbTX, &blockindexer.EventDeliveryBatch{
+			BatchID: batchID,
+			Events: []*pldapi.EventWithData{
+				{
+					Address:      *contract1,
+					IndexedEvent: &pldapi.IndexedEvent{},
+					Data:         pldtypes.RawJSON(`{"result": "success"}`),
+				},
+			},
+		})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
 func TestReceiptSorting(t *testing.T) {
 	// Note the detail of the sorting code is in pldtypes.OnChainLocation
 	receiptList := txCompletionsOrdered{
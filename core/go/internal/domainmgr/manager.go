@@ -112,7 +112,9 @@ func (dm *domainManager) PostInit(c components.AllComponents) error {
 
 	// Register ourselves as a signing on the key manager
 	dm.domainSigner = &domainSigner{dm: dm}
-	c.KeyManager().AddInMemorySigner("domain", dm.domainSigner)
+	if err := c.KeyManager().AddInMemorySigner("domain", dm.domainSigner); err != nil {
+		return err
+	}
 
 	for name, d := range dm.conf.Domains {
 		if _, err := pldtypes.ParseEthAddress(d.RegistryAddress); err != nil {
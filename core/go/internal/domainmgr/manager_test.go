@@ -17,6 +17,7 @@ package domainmgr
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -72,7 +73,7 @@ func newTestDomainManager(t *testing.T, realDB bool, conf *pldconf.DomainManager
 	mc.ethClientFactory.On("HTTPClient").Return(mc.ethClient).Maybe()
 	mc.ethClientFactory.On("WSClient").Return(mc.ethClient).Maybe()
 	componentMocks.On("BlockIndexer").Return(mc.blockIndexer)
-	mc.keyManager.On("AddInMemorySigner", "domain", mock.Anything).Return().Maybe()
+	mc.keyManager.On("AddInMemorySigner", "domain", mock.Anything).Return(nil).Maybe()
 	componentMocks.On("KeyManager").Return(mc.keyManager)
 	componentMocks.On("TxManager").Return(mc.txManager)
 	componentMocks.On("PrivateTxManager").Return(mc.privateTxManager)
@@ -191,7 +192,7 @@ func TestDomainMissingRegistryAddress(t *testing.T) {
 	mc.ethClientFactory.On("HTTPClient").Return(mc.ethClient).Maybe()
 	mc.ethClientFactory.On("WSClient").Return(mc.ethClient).Maybe()
 	componentMocks.On("BlockIndexer").Return(mc.blockIndexer)
-	mc.keyManager.On("AddInMemorySigner", "domain", mock.Anything).Return().Maybe()
+	mc.keyManager.On("AddInMemorySigner", "domain", mock.Anything).Return(nil).Maybe()
 	componentMocks.On("KeyManager").Return(mc.keyManager)
 	componentMocks.On("TxManager").Return(mc.txManager)
 	componentMocks.On("PrivateTxManager").Return(mc.privateTxManager)
@@ -208,6 +209,24 @@ func TestDomainMissingRegistryAddress(t *testing.T) {
 	assert.Regexp(t, "PD011606", err)
 }
 
+func TestDomainManagerNoWalletForSigner(t *testing.T) {
+	keyManager := componentmocks.NewKeyManager(t)
+	keyManager.On("AddInMemorySigner", "domain", mock.Anything).Return(fmt.Errorf("pop"))
+	componentMocks := componentmocks.NewAllComponents(t)
+	componentMocks.On("StateManager").Return(componentmocks.NewStateManager(t))
+	componentMocks.On("TxManager").Return(componentmocks.NewTXManager(t))
+	componentMocks.On("PrivateTxManager").Return(componentmocks.NewPrivateTxManager(t))
+	componentMocks.On("Persistence").Return(nil)
+	componentMocks.On("EthClientFactory").Return(ethclientmocks.NewEthClientFactory(t))
+	componentMocks.On("BlockIndexer").Return(componentmocks.NewBlockIndexer(t))
+	componentMocks.On("KeyManager").Return(keyManager)
+	componentMocks.On("TransportManager").Return(componentmocks.NewTransportManager(t))
+
+	dm := NewDomainManager(context.Background(), &pldconf.DomainManagerConfig{})
+	err := dm.PostInit(componentMocks)
+	assert.Regexp(t, "pop", err)
+}
+
 func TestGetDomainNotFound(t *testing.T) {
 	ctx, dm, _, done := newTestDomainManager(t, false, &pldconf.DomainManagerConfig{
 		Domains: map[string]*pldconf.DomainConfig{
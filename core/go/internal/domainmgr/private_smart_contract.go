@@ -574,26 +574,39 @@ func (dc *domainContract) PrepareTransaction(dCtx components.DomainContext, read
 	return nil
 }
 
-func (dc *domainContract) InitCall(ctx context.Context, callTx *components.ResolvedTransaction) ([]*prototk.ResolveVerifierRequest, error) {
+func (dc *domainContract) InitCall(dCtx components.DomainContext, readTX persistence.DBTX, callTx *components.ResolvedTransaction) (*prototk.InitCallResponse, error) {
 
+	ctx := dCtx.Ctx()
 	txSpec, err := dc.buildTransactionSpecification(ctx, callTx, prototk.TransactionSpecification_CALL)
 	if err != nil {
 		return nil, err
 	}
 
+	// The domain might need to query the state store, to plan the signatures for the call
+	c := dc.d.newInFlightDomainRequest(readTX, dCtx, true)
+	defer c.close()
+
 	// Call the domain
 	res, err := dc.api.InitCall(ctx, &prototk.InitCallRequest{
-		Transaction: txSpec,
+		StateQueryContext: c.id,
+		Transaction:       txSpec,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return res.RequiredVerifiers, nil
+	// Only local signatures can be gathered synchronously for a call
+	for _, ar := range res.AttestationPlan {
+		if ar.AttestationType != prototk.AttestationType_SIGN {
+			return nil, i18n.NewError(ctx, msgs.MsgDomainCallAttestationUnsupported, ar.Name, ar.AttestationType)
+		}
+	}
+
+	return res, nil
 
 }
 
-func (dc *domainContract) ExecCall(dCtx components.DomainContext, readTX persistence.DBTX, callTx *components.ResolvedTransaction, verifiers []*prototk.ResolvedVerifier) (*abi.ComponentValue, error) {
+func (dc *domainContract) ExecCall(dCtx components.DomainContext, readTX persistence.DBTX, callTx *components.ResolvedTransaction, verifiers []*prototk.ResolvedVerifier, signatures []*prototk.AttestationResult) (*abi.ComponentValue, error) {
 
 	txSpec, err := dc.buildTransactionSpecification(dCtx.Ctx(), callTx, prototk.TransactionSpecification_CALL)
 	if err != nil {
@@ -609,6 +622,7 @@ func (dc *domainContract) ExecCall(dCtx components.DomainContext, readTX persist
 		StateQueryContext: c.id,
 		ResolvedVerifiers: verifiers,
 		Transaction:       txSpec,
+		AttestationResult: signatures,
 	})
 	if err != nil {
 		return nil, err
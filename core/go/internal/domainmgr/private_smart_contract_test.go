@@ -1300,6 +1300,7 @@ func TestInitCallOk(t *testing.T) {
 	td.tp.Functions.InitCall = func(ctx context.Context, icr *prototk.InitCallRequest) (*prototk.InitCallResponse, error) {
 		assert.JSONEq(t, `{"address":"0xf2c41ae275a9ace65e1fb78b97270a61d86aa0ed"}`, icr.Transaction.FunctionParamsJson)
 		assert.Equal(t, "function getBalance(address address) external returns (uint256 amount) { }", icr.Transaction.FunctionSignature)
+		assert.NotEmpty(t, icr.StateQueryContext)
 		return &prototk.InitCallResponse{
 			RequiredVerifiers: []*prototk.ResolveVerifierRequest{
 				{
@@ -1313,14 +1314,40 @@ func TestInitCallOk(t *testing.T) {
 
 	txi := goodPrivateCallWithInputsAndOutputs(psc)
 
-	requiredVerifiers, err := psc.InitCall(td.ctx, txi)
+	initRes, err := psc.InitCall(td.c.dCtx, td.c.dbTX, txi)
 	require.NoError(t, err)
+	requiredVerifiers := initRes.RequiredVerifiers
 	require.Len(t, requiredVerifiers, 1)
 	assert.Equal(t, "lookup1", requiredVerifiers[0].Lookup)
 	assert.Equal(t, algorithms.ECDSA_SECP256K1, requiredVerifiers[0].Algorithm)
 	assert.Equal(t, verifiers.ETH_ADDRESS, requiredVerifiers[0].VerifierType)
 }
 
+func TestInitCallUnsupportedAttestation(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), mockBlockHeight)
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	psc := goodPSC(t, td)
+
+	td.tp.Functions.InitCall = func(ctx context.Context, icr *prototk.InitCallRequest) (*prototk.InitCallResponse, error) {
+		return &prototk.InitCallResponse{
+			AttestationPlan: []*prototk.AttestationRequest{
+				{
+					Name:            "endorse1",
+					AttestationType: prototk.AttestationType_ENDORSE,
+					Parties:         []string{"party1"},
+				},
+			},
+		}, nil
+	}
+
+	txi := goodPrivateCallWithInputsAndOutputs(psc)
+
+	_, err := psc.InitCall(td.c.dCtx, td.c.dbTX, txi)
+	assert.Regexp(t, "PD011667.*endorse1", err)
+}
+
 func TestInitCallBadInput(t *testing.T) {
 	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), mockBlockHeight)
 	defer done()
@@ -1328,7 +1355,7 @@ func TestInitCallBadInput(t *testing.T) {
 
 	psc := goodPSC(t, td)
 
-	_, err := psc.InitCall(td.ctx, &components.ResolvedTransaction{
+	_, err := psc.InitCall(td.c.dCtx, td.c.dbTX, &components.ResolvedTransaction{
 		Transaction: &pldapi.Transaction{
 			TransactionBase: pldapi.TransactionBase{
 				Domain: psc.d.name,
@@ -1364,7 +1391,7 @@ func TestInitCallError(t *testing.T) {
 
 	txi := goodPrivateCallWithInputsAndOutputs(psc)
 
-	_, err := psc.InitCall(td.ctx, txi)
+	_, err := psc.InitCall(td.c.dCtx, td.c.dbTX, txi)
 	assert.Regexp(t, "pop", err)
 }
 
@@ -1383,6 +1410,8 @@ func TestExecCall(t *testing.T) {
 		assert.Equal(t, "0xf2c41ae275a9ace65e1fb78b97270a61d86aa0ed", cr.ResolvedVerifiers[0].Verifier)
 		assert.JSONEq(t, `{"address":"0xf2c41ae275a9ace65e1fb78b97270a61d86aa0ed"}`, cr.Transaction.FunctionParamsJson)
 		assert.Equal(t, "function getBalance(address address) external returns (uint256 amount) { }", cr.Transaction.FunctionSignature)
+		require.Len(t, cr.AttestationResult, 1)
+		assert.Equal(t, "sign1", cr.AttestationResult[0].Name)
 		return &prototk.ExecCallResponse{
 			ResultJson: `{"amount": 11223344556677889900}`,
 		}, nil
@@ -1397,6 +1426,12 @@ func TestExecCall(t *testing.T) {
 			VerifierType: verifiers.ETH_ADDRESS,
 			Verifier:     "0xf2c41ae275a9ace65e1fb78b97270a61d86aa0ed",
 		},
+	}, []*prototk.AttestationResult{
+		{
+			Name:            "sign1",
+			AttestationType: prototk.AttestationType_SIGN,
+			Payload:         []byte("signature"),
+		},
 	})
 	require.NoError(t, err)
 	jv, err := cv.JSON()
@@ -1429,7 +1464,7 @@ func TestExecCallBadInput(t *testing.T) {
 				},
 			},
 		},
-	}, []*prototk.ResolvedVerifier{})
+	}, []*prototk.ResolvedVerifier{}, nil)
 	assert.Regexp(t, "PD011612", err)
 }
 
@@ -1450,7 +1485,7 @@ func TestExecCallBadOutput(t *testing.T) {
 
 	txi := goodPrivateCallWithInputsAndOutputs(psc)
 
-	_, err := psc.ExecCall(td.c.dCtx, td.c.dbTX, txi, []*prototk.ResolvedVerifier{})
+	_, err := psc.ExecCall(td.c.dCtx, td.c.dbTX, txi, []*prototk.ResolvedVerifier{}, nil)
 	assert.Regexp(t, "PD011653", err)
 }
 
@@ -1470,7 +1505,7 @@ func TestExecCallNilOutputOk(t *testing.T) {
 	localTx := goodPrivateCallWithInputsAndOutputs(psc)
 	localTx.Function.Definition.Outputs = nil
 
-	_, err := psc.ExecCall(td.c.dCtx, td.c.dbTX, localTx, []*prototk.ResolvedVerifier{})
+	_, err := psc.ExecCall(td.c.dCtx, td.c.dbTX, localTx, []*prototk.ResolvedVerifier{}, nil)
 	require.NoError(t, err)
 }
 
@@ -1487,7 +1522,7 @@ func TestExecCallFail(t *testing.T) {
 
 	txi := goodPrivateCallWithInputsAndOutputs(psc)
 
-	_, err := psc.ExecCall(td.c.dCtx, td.c.dbTX, txi, []*prototk.ResolvedVerifier{})
+	_, err := psc.ExecCall(td.c.dCtx, td.c.dbTX, txi, []*prototk.ResolvedVerifier{}, nil)
 	assert.Regexp(t, "pop", err)
 }
 
@@ -22,6 +22,7 @@ import (
 	"hash/fnv"
 	"time"
 
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -97,6 +98,7 @@ type writer[T Writeable[R], R any] struct {
 	workerCount  int
 	workQueues   []chan *op[T, R]
 	workersDone  []chan struct{}
+	metrics      *writerMetrics
 }
 
 type batch[T Writeable[R], R any] struct {
@@ -109,8 +111,10 @@ type batch[T Writeable[R], R any] struct {
 
 func NewWriter[T Writeable[R], R any](
 	bgCtx context.Context,
+	name string,
 	handler BatchHandler[T, R],
 	p persistence.Persistence,
+	m metrics.Metrics,
 	conf *pldconf.FlushWriterConfig,
 	defaults *pldconf.FlushWriterConfig,
 ) Writer[T, R] {
@@ -124,6 +128,7 @@ func NewWriter[T Writeable[R], R any](
 		workerCount:  workerCount,
 		batchTimeout: batchTimeout,
 		batchMaxSize: batchMaxSize,
+		metrics:      newWriterMetrics(name, m),
 	}
 	w.bgCtx, w.cancelCtx = context.WithCancel(bgCtx)
 	return w
@@ -273,6 +278,7 @@ func (w *writer[T, R]) runBatch(ctx context.Context, b *batch[T, R]) {
 	var txErr error
 
 	var results []Result[R]
+	startTime := time.Now()
 	txErr = w.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
 		results, err = w.handler(ctx, dbTX, values)
 		return err
@@ -284,6 +290,7 @@ func (w *writer[T, R]) runBatch(ctx context.Context, b *batch[T, R]) {
 		log.L(ctx).Errorf("Invalid results (values=%d,results=%d): %+v", len(values), len(results), results)
 		err = i18n.NewError(ctx, msgs.MsgFlushWriterInvalidResults)
 	}
+	w.metrics.recordBatch(len(values), startTime, err)
 
 	// Mark all the ops complete - for good or bad
 	for i, op := range b.ops {
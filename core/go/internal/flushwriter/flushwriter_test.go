@@ -26,6 +26,8 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
@@ -55,7 +57,7 @@ func newTestWriter(t *testing.T, conf *pldconf.FlushWriterConfig, handler BatchH
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	p, err := mockpersistence.NewSQLMockProvider()
 	require.NoError(t, err)
-	w := NewWriter(ctx, handler, p.P, conf, testDefaults)
+	w := NewWriter(ctx, "test", handler, p.P, metrics.NewMetricsManager(ctx), conf, testDefaults)
 	w.Start()
 	return ctx, w.(*writer[*testWritable, *testResult]), p.Mock, func() {
 		panicked := recover()
@@ -297,3 +299,17 @@ func TestShutdownAfterClose(t *testing.T) {
 	tw.cancelCtx()
 	tw.Shutdown()
 }
+
+func TestWriterMetrics(t *testing.T) {
+	m := metrics.NewMetricsManager(context.Background())
+
+	// Two writers share the collectors, with a label for each
+	wm1 := newWriterMetrics("writer1", m)
+	wm2 := newWriterMetrics("writer2", m)
+	wm1.recordBatch(5, time.Now(), nil)
+	wm2.recordBatch(1, time.Now(), fmt.Errorf("pop"))
+
+	count, err := testutil.GatherAndCount(m.Registry(), "paladin_flushwriter_batch_size", "paladin_flushwriter_batch_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flushwriter
+
+import (
+	"time"
+
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsSubsystem = "flushwriter"
+
+// All flush writers share the same collectors, and are distinguished by the writer name label
+type writerMetrics struct {
+	batchSize     prometheus.Observer
+	batchDuration prometheus.ObserverVec
+}
+
+func newWriterMetrics(name string, m metrics.Metrics) *writerMetrics {
+	registry := m.Registry()
+	batchSize := metrics.MustRegister(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "batch_size",
+		Help:      "Number of operations written to the database in each batch",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10), // 1 to 512
+	}, []string{"writer"}))
+	batchDuration := metrics.MustRegister(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "batch_duration_seconds",
+		Help:      "Duration of the database transaction for each batch",
+	}, []string{"writer", "result"}))
+	return &writerMetrics{
+		batchSize:     batchSize.WithLabelValues(name),
+		batchDuration: batchDuration.MustCurryWith(prometheus.Labels{"writer": name}),
+	}
+}
+
+func (wm *writerMetrics) recordBatch(size int, startTime time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	wm.batchSize.Observe(float64(size))
+	wm.batchDuration.WithLabelValues(result).Observe(time.Since(startTime).Seconds())
+}
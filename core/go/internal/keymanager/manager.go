@@ -48,6 +48,7 @@ type keyManager struct {
 	verifierReverseCache    cache.Cache[string, *pldapi.KeyMappingAndVerifier]
 	walletsOrdered          []*wallet
 	walletsByName           map[string]*wallet
+	pluginSigners           map[string]*pluginSigner
 
 	allocLock       sync.Mutex
 	allocLockHolder *keyResolver
@@ -63,6 +64,7 @@ func NewKeyManager(bgCtx context.Context, conf *pldconf.KeyManagerConfig) compon
 		verifierByIdentityCache: cache.NewCache[string, *pldapi.KeyVerifier](&conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
 		verifierReverseCache:    cache.NewCache[string, *pldapi.KeyMappingAndVerifier](&conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
 		walletsByName:           make(map[string]*wallet),
+		pluginSigners:           make(map[string]*pluginSigner),
 	}
 }
 
@@ -96,6 +98,9 @@ func (km *keyManager) Start() error {
 }
 
 func (km *keyManager) Stop() {
+	for _, ps := range km.pluginSigners {
+		ps.close()
+	}
 }
 
 func (km *keyManager) Sign(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error) {
@@ -150,11 +155,22 @@ func (km *keyManager) unlockAllocation(ctx context.Context, kr *keyResolver) {
 	}
 }
 
-func (km *keyManager) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) {
-	// Called during PostInit phase by domain manager
-	for _, w := range km.walletsByName {
-		w.signingModule.AddInMemorySigner(prefix, signer)
+func (km *keyManager) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error {
+	// Called during PostInit phase by domain manager.
+	// Wallets with keys outside of Paladin can only use the signer if they host it themselves,
+	// and reject its algorithms otherwise. So we only fail if there is nowhere to use it.
+	supported := 0
+	for _, w := range km.walletsOrdered {
+		if err := w.signingModule.AddInMemorySigner(prefix, signer); err != nil {
+			log.L(km.bgCtx).Warnf("Wallet '%s' cannot use in-memory signer '%s': %s", w.name, prefix, err)
+		} else {
+			supported++
+		}
 	}
+	if supported == 0 && len(km.walletsOrdered) > 0 {
+		return i18n.NewError(km.bgCtx, msgs.MsgKeyManagerNoWalletForInMemorySigner, prefix)
+	}
+	return nil
 }
 
 // Convenience function
@@ -429,9 +429,10 @@ func TestAddInMemorySignerAndSign(t *testing.T) {
 			return "custom-thing", nil
 		},
 	}
-	km.AddInMemorySigner("test", s)
+	err := km.AddInMemorySigner("test", s)
+	require.NoError(t, err)
 
-	err := km.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+	err = km.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
 
 		mapping, err := km.KeyResolverForDBTX(dbTX).ResolveKey(ctx, "my-custom-thing", "test:blue", "thingies")
 		require.NoError(t, err)
@@ -445,6 +446,43 @@ func TestAddInMemorySignerAndSign(t *testing.T) {
 
 }
 
+func TestAddInMemorySignerRemoteWallets(t *testing.T) {
+	remoteWallet := func(name string, inMemorySigners ...string) *pldconf.WalletConfig {
+		return &pldconf.WalletConfig{
+			Name:       name,
+			SignerType: pldconf.WalletSignerTypeRemote,
+			Remote: &pldconf.RemoteSignerConfig{
+				HTTP: pldconf.HTTPClientConfig{URL: "http://localhost:9645"},
+			},
+			InMemorySigners: inMemorySigners,
+		}
+	}
+
+	// No wallet can use the signer
+	_, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{remoteWallet("remote1")},
+	})
+	err := km.AddInMemorySigner("domain", &testSigner{})
+	assert.Regexp(t, "PD010519.*domain", err)
+	done()
+
+	// The remote signer hosts it
+	_, km, _, done = newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{remoteWallet("remote1", "domain")},
+	})
+	err = km.AddInMemorySigner("domain", &testSigner{})
+	require.NoError(t, err)
+	done()
+
+	// An embedded wallet can use it, alongside a remote wallet that cannot
+	_, km, _, done = newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{remoteWallet("remote1"), hdWalletConfig("hdwallet1", "")},
+	})
+	err = km.AddInMemorySigner("domain", &testSigner{})
+	require.NoError(t, err)
+	done()
+}
+
 func TestResolveKeyNewDatabaseTXFail(t *testing.T) {
 	ctx, km, mc, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
 		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
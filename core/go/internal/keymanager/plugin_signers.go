@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package keymanager
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// A wallet backed by a signing module loaded by the plugin manager.
+// The wallet exists from PostInit, but requests fail until the plugin has
+// connected and accepted its configuration.
+type pluginSigner struct {
+	km         *keyManager
+	walletName string
+	conf       *pldconf.WalletConfig
+
+	mux        sync.Mutex
+	api        components.KeyManagerToSigningModule
+	cancelInit context.CancelFunc
+}
+
+// The key manager does not currently expose any callbacks to signing modules
+type signingModuleCallbacks struct{}
+
+func (km *keyManager) newPluginSigner(ctx context.Context, walletConf *pldconf.WalletConfig) (*pluginSigner, error) {
+	if walletConf.Plugin == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerPluginSignerNoConfig, walletConf.Name)
+	}
+	ps := &pluginSigner{
+		km:         km,
+		walletName: walletConf.Name,
+		conf:       walletConf,
+	}
+	km.pluginSigners[walletConf.Name] = ps
+	return ps, nil
+}
+
+func (km *keyManager) ConfiguredSigningModules() map[string]*pldconf.PluginConfig {
+	pluginConf := make(map[string]*pldconf.PluginConfig)
+	for name, ps := range km.pluginSigners {
+		pluginConf[name] = ps.conf.Plugin
+	}
+	return pluginConf
+}
+
+func (km *keyManager) SigningModuleRegistered(name string, id uuid.UUID, toSigningModule components.KeyManagerToSigningModule) (fromSigningModule plugintk.SigningModuleCallbacks, err error) {
+	ps := km.pluginSigners[name]
+	if ps == nil {
+		return nil, i18n.NewError(km.bgCtx, msgs.MsgKeyManagerNotPluginWallet, name)
+	}
+
+	ctx, cancelCtx := context.WithCancel(log.WithLogField(km.bgCtx, "wallet", name))
+	ps.mux.Lock()
+	if ps.cancelInit != nil {
+		// A previous instance of the plugin might still be initializing
+		ps.cancelInit()
+	}
+	ps.api = nil
+	ps.cancelInit = cancelCtx
+	ps.mux.Unlock()
+
+	log.L(ctx).Infof("Signing module plugin %s registered for wallet", id)
+	go ps.init(ctx, toSigningModule)
+	return &signingModuleCallbacks{}, nil
+}
+
+func (ps *pluginSigner) init(ctx context.Context, toSigningModule components.KeyManagerToSigningModule) {
+	// We block retrying until the plugin accepts our configuration, or a new instance of the plugin registers
+	initRetry := retry.NewRetryIndefinite(&pldconf.GenericRetryDefaults.RetryConfig)
+	err := initRetry.Do(ctx, func(attempt int) (bool, error) {
+		confJSON, _ := json.Marshal(&ps.conf.Config)
+		_, err := toSigningModule.ConfigureSigningModule(ctx, &prototk.ConfigureSigningModuleRequest{
+			Name:       ps.walletName,
+			ConfigJson: string(confJSON),
+		})
+		return true, err
+	})
+	if err != nil {
+		log.L(ctx).Debugf("signing module initialization cancelled before completion: %s", err)
+		return
+	}
+
+	ps.mux.Lock()
+	ps.api = toSigningModule
+	ps.mux.Unlock()
+	log.L(ctx).Debugf("signing module initialization complete")
+	// Inform the plugin manager callback
+	toSigningModule.Initialized()
+}
+
+func (ps *pluginSigner) getAPI(ctx context.Context) (components.KeyManagerToSigningModule, error) {
+	ps.mux.Lock()
+	defer ps.mux.Unlock()
+	if ps.api == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerPluginSignerNotReady, ps.walletName)
+	}
+	return ps.api, nil
+}
+
+func (ps *pluginSigner) ConfigureSigningModule(ctx context.Context, req *prototk.ConfigureSigningModuleRequest) (*prototk.ConfigureSigningModuleResponse, error) {
+	api, err := ps.getAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.ConfigureSigningModule(ctx, req)
+}
+
+func (ps *pluginSigner) ResolveKey(ctx context.Context, req *prototk.ResolveKeyRequest) (*prototk.ResolveKeyResponse, error) {
+	api, err := ps.getAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.ResolveKey(ctx, req)
+}
+
+func (ps *pluginSigner) Sign(ctx context.Context, req *prototk.SignWithKeyRequest) (*prototk.SignWithKeyResponse, error) {
+	api, err := ps.getAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.Sign(ctx, req)
+}
+
+func (ps *pluginSigner) ListKeys(ctx context.Context, req *prototk.ListKeysRequest) (*prototk.ListKeysResponse, error) {
+	api, err := ps.getAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.ListKeys(ctx, req)
+}
+
+func (ps *pluginSigner) close() {
+	ps.mux.Lock()
+	defer ps.mux.Unlock()
+	if ps.cancelInit != nil {
+		ps.cancelInit()
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package keymanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func pluginWalletConfig(name string) *pldconf.WalletConfig {
+	return &pldconf.WalletConfig{
+		Name:       name,
+		SignerType: pldconf.WalletSignerTypePlugin,
+		Plugin: &pldconf.PluginConfig{
+			Type:    "c-shared",
+			Library: "/tmp/not/real",
+		},
+		Config: map[string]any{"some": "config"},
+	}
+}
+
+func TestPluginWalletConfigErrors(t *testing.T) {
+	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{})
+	defer done()
+
+	_, err := km.newWallet(ctx, &pldconf.WalletConfig{
+		Name:       "wallet1",
+		SignerType: pldconf.WalletSignerTypePlugin,
+	})
+	assert.Regexp(t, "PD010516", err)
+}
+
+func TestPluginWalletLifecycle(t *testing.T) {
+	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{pluginWalletConfig("wallet1")},
+	})
+	defer done()
+
+	assert.Equal(t, map[string]*pldconf.PluginConfig{
+		"wallet1": pluginWalletConfig("wallet1").Plugin,
+	}, km.ConfiguredSigningModules())
+
+	w, err := km.getWalletByName(ctx, "wallet1")
+	require.NoError(t, err)
+
+	// Not yet registered
+	resolveReq := &signerapi.ResolveKeyRequest{
+		Name: "key1",
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{
+			{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		},
+	}
+	_, err = w.signingModule.Resolve(ctx, resolveReq)
+	assert.Regexp(t, "PD010517", err)
+	_, err = w.signingModule.Sign(ctx, &signerapi.SignRequest{})
+	assert.Regexp(t, "PD010517", err)
+	_, err = w.signingModule.List(ctx, &signerapi.ListKeysRequest{})
+	assert.Regexp(t, "PD010517", err)
+	_, err = km.pluginSigners["wallet1"].ConfigureSigningModule(ctx, &prototk.ConfigureSigningModuleRequest{})
+	assert.Regexp(t, "PD010517", err)
+
+	_, err = km.SigningModuleRegistered("unknown", uuid.New(), componentmocks.NewKeyManagerToSigningModule(t))
+	assert.Regexp(t, "PD010518", err)
+
+	initialized := make(chan struct{})
+	tsm := componentmocks.NewKeyManagerToSigningModule(t)
+	tsm.On("ConfigureSigningModule", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	tsm.On("ConfigureSigningModule", mock.Anything, mock.MatchedBy(func(req *prototk.ConfigureSigningModuleRequest) bool {
+		return req.Name == "wallet1" && req.ConfigJson == `{"some":"config"}`
+	})).Return(&prototk.ConfigureSigningModuleResponse{}, nil)
+	tsm.On("Initialized").Run(func(args mock.Arguments) {
+		close(initialized)
+	})
+	tsm.On("ResolveKey", mock.Anything, mock.Anything).Return(&prototk.ResolveKeyResponse{
+		KeyHandle: "key1",
+		Identifiers: []*prototk.PublicKeyIdentifier{
+			{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS, Verifier: "0x6331ccb948aaf903a69d6054fd718062bd0d535c"},
+		},
+	}, nil)
+	tsm.On("Sign", mock.Anything, mock.Anything).Return(&prototk.SignWithKeyResponse{Payload: []byte("signed")}, nil)
+	tsm.On("ListKeys", mock.Anything, mock.Anything).Return(&prototk.ListKeysResponse{}, nil)
+
+	callbacks, err := km.SigningModuleRegistered("wallet1", uuid.New(), tsm)
+	require.NoError(t, err)
+	assert.NotNil(t, callbacks)
+	<-initialized
+
+	res, err := w.signingModule.Resolve(ctx, resolveReq)
+	require.NoError(t, err)
+	assert.Equal(t, "key1", res.KeyHandle)
+	assert.Equal(t, "0x6331ccb948aaf903a69d6054fd718062bd0d535c", res.Identifiers[0].Verifier)
+
+	signRes, err := w.signingModule.Sign(ctx, &signerapi.SignRequest{
+		KeyHandle:   "key1",
+		Algorithm:   algorithms.ECDSA_SECP256K1,
+		PayloadType: signpayloads.OPAQUE_TO_RSV,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signed"), signRes.Payload)
+
+	_, err = w.signingModule.List(ctx, &signerapi.ListKeysRequest{})
+	require.NoError(t, err)
+
+	_, err = km.pluginSigners["wallet1"].ConfigureSigningModule(ctx, &prototk.ConfigureSigningModuleRequest{})
+	require.NoError(t, err)
+}
+
+func TestPluginWalletInitCancelled(t *testing.T) {
+	_, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{pluginWalletConfig("wallet1")},
+	})
+
+	tsm := componentmocks.NewKeyManagerToSigningModule(t)
+	tsm.On("ConfigureSigningModule", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Maybe()
+
+	_, err := km.SigningModuleRegistered("wallet1", uuid.New(), tsm)
+	require.NoError(t, err)
+
+	// Stopping the key manager cancels the init loop without the plugin being marked initialized
+	done()
+	_, err = km.pluginSigners["wallet1"].getAPI(km.bgCtx)
+	assert.Regexp(t, "PD010517", err)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/remotesigner"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signer"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
 )
@@ -52,16 +53,30 @@ func (km *keyManager) newWallet(ctx context.Context, walletConf *pldconf.WalletC
 	}
 
 	signerType := confutil.StringNotEmpty(&walletConf.SignerType, pldconf.WalletDefaults.SignerType)
-	if signerType != pldconf.WalletSignerTypeEmbedded {
-		// Until we support remoting of the signer API over HTTP/WebSockets etc.
+	switch signerType {
+	case pldconf.WalletSignerTypeEmbedded:
+		w.signingModule, err = signer.NewSigningModule(ctx, (*signerapi.ConfigNoExt)(walletConf.Signer))
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgKeyManagerEmbeddedSignerFailInit, w.name)
+		}
+	case pldconf.WalletSignerTypeRemote:
+		if walletConf.Remote == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgKeyManagerRemoteSignerFailInit, w.name)
+		}
+		w.signingModule, err = remotesigner.NewRemoteSigningModule(ctx, walletConf.Remote, walletConf.InMemorySigners...)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgKeyManagerRemoteSignerFailInit, w.name)
+		}
+	case pldconf.WalletSignerTypePlugin:
+		ps, err := km.newPluginSigner(ctx, walletConf)
+		if err != nil {
+			return nil, err
+		}
+		w.signingModule = signer.WrapPluginAPI(ps, walletConf.InMemorySigners...)
+	default:
 		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerInvalidWalletSignerType, signerType, w.name)
 	}
 
-	w.signingModule, err = signer.NewSigningModule(ctx, (*signerapi.ConfigNoExt)(walletConf.Signer))
-	if err != nil {
-		return nil, i18n.WrapError(ctx, err, msgs.MsgKeyManagerEmbeddedSignerFailInit, w.name)
-	}
-
 	return w, nil
 
 }
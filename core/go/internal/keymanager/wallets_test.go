@@ -46,6 +46,19 @@ func TestNewWalletConfigErrors(t *testing.T) {
 	})
 	assert.Regexp(t, "PD010506", err)
 
+	_, err = km.newWallet(ctx, &pldconf.WalletConfig{
+		Name:       "wallet1",
+		SignerType: pldconf.WalletSignerTypeRemote,
+	})
+	assert.Regexp(t, "PD010515", err)
+
+	_, err = km.newWallet(ctx, &pldconf.WalletConfig{
+		Name:       "wallet1",
+		SignerType: pldconf.WalletSignerTypeRemote,
+		Remote:     &pldconf.RemoteSignerConfig{},
+	})
+	assert.Regexp(t, "PD010515.*PD020828", err)
+
 	_, err = km.newWallet(ctx, &pldconf.WalletConfig{
 		Name: "wallet1",
 		Signer: &pldconf.SignerConfig{
@@ -60,6 +73,23 @@ func TestNewWalletConfigErrors(t *testing.T) {
 	assert.Regexp(t, "PD010501", err)
 }
 
+func TestNewWalletRemote(t *testing.T) {
+	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{})
+	defer done()
+
+	w, err := km.newWallet(ctx, &pldconf.WalletConfig{
+		Name:       "wallet1",
+		SignerType: pldconf.WalletSignerTypeRemote,
+		Remote: &pldconf.RemoteSignerConfig{
+			HTTP: pldconf.HTTPClientConfig{
+				URL: "http://localhost:9645",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, w.signingModule)
+}
+
 func TestResolveKeyAndVerifierErr(t *testing.T) {
 
 	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
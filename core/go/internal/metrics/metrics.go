@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// All Paladin metrics are prefixed with this namespace, with each manager using its own subsystem
+const Namespace = "paladin"
+
+// Metrics holds the Prometheus registry for a single Paladin runtime.
+//
+// A registry is allocated per runtime (rather than using the global default registry) so that
+// multiple Paladin nodes can run in the same process, as they do in the component tests.
+type Metrics interface {
+	Registry() *prometheus.Registry
+}
+
+type metricsManager struct {
+	registry *prometheus.Registry
+}
+
+func NewMetricsManager(ctx context.Context) Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	log.L(ctx).Debugf("Metrics registry initialized")
+	return &metricsManager{registry: registry}
+}
+
+func (mm *metricsManager) Registry() *prometheus.Registry {
+	return mm.registry
+}
+
+// MustRegister registers a collector, or returns the equivalent collector that was registered
+// previously by another instance of the same component (such as a second flush writer).
+func MustRegister[C prometheus.Collector](registry prometheus.Registerer, c C) C {
+	if err := registry.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return c
+}
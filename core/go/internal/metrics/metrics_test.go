@@ -0,0 +1,62 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsManager(t *testing.T) {
+	mm := NewMetricsManager(context.Background())
+
+	families, err := mm.Registry().Gather()
+	require.NoError(t, err)
+	assert.NotEmpty(t, families)
+
+	// A separate instance has its own registry
+	mm2 := NewMetricsManager(context.Background())
+	assert.NotSame(t, mm.Registry(), mm2.Registry())
+}
+
+func TestMustRegisterExisting(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	newCounter := func() *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "test_total",
+			Help:      "Test counter",
+		}, []string{"label"})
+	}
+
+	c1 := MustRegister(registry, newCounter())
+	c2 := MustRegister(registry, newCounter())
+	assert.Same(t, c1, c2)
+
+	// A different collector type for the same name is a programming error
+	assert.Panics(t, func() {
+		_ = MustRegister(registry, prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "test_total",
+			Help:      "Test gauge",
+		}))
+	})
+}
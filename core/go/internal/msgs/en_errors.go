@@ -76,6 +76,9 @@ var (
 	MsgComponentDebugServerStartError      = pde("PD010033", "Error starting debug server")
 	MsgComponentGroupManagerInitError      = pde("PD010034", "Error initializing privacy group manager")
 	MsgComponentGroupManagerStartError     = pde("PD010035", "Error starting group manager ")
+	MsgComponentMetricsServerStartError    = pde("PD010036", "Error starting metrics server")
+	MsgComponentMetricsInitError           = pde("PD010037", "Error initializing metrics for %s")
+	MsgComponentTracingInitError           = pde("PD010038", "Error initializing tracing")
 
 	// States PD0101XX
 	MsgStateInvalidLength             = pde("PD010101", "Invalid hash len expected=%d actual=%d")
@@ -151,6 +154,11 @@ var (
 	MsgKeyManagerIdentifierPathNotFound     = pde("PD010512", "Identifier path segment '%s' not found in database")
 	MsgKeyManagerExistingIdentifierNotFound = pde("PD010513", "Identifier '%s' not found in database")
 	MsgKeyManagerMissingDatabaseTxn         = pde("PD010514", "Missing database transaction context")
+	MsgKeyManagerRemoteSignerFailInit       = pde("PD010515", "Initialization of remote signer for wallet '%s' failed")
+	MsgKeyManagerPluginSignerNoConfig       = pde("PD010516", "Wallet '%s' has signerType plugin, but no plugin configuration")
+	MsgKeyManagerPluginSignerNotReady       = pde("PD010517", "Signing module plugin for wallet '%s' is not initialized")
+	MsgKeyManagerNotPluginWallet            = pde("PD010518", "Wallet '%s' is not configured to use a signing module plugin")
+	MsgKeyManagerNoWalletForInMemorySigner  = pde("PD010519", "None of the configured wallets can host in-memory signer '%s', so at least one wallet must use an embedded signer")
 
 	// Comms bus PD0106XX
 	MsgDestinationNotFound     = pde("PD010600", "Destination not found: %s")
@@ -190,6 +198,15 @@ var (
 	MsgPluginBadResponseBody   = pde("PD011205", "%s %s returned invalid response body %T")
 	MsgPluginError             = pde("PD011206", "%s %s returned error: %s")
 	MsgPluginLoadFailed        = pde("PD011207", "Plugin load failed: %s")
+	MsgPluginLoaderConnect     = pde("PD011208", "Go plugin loader failed to connect to the plugin controller")
+	MsgPluginLibraryLoadFailed = pde("PD011209", "Failed to load c-shared library '%s'")
+	MsgPluginJarRequiresJVM    = pde("PD011210", "Plugin '%s' is a jar plugin, which requires a JVM plugin loader")
+	MsgPluginLibTypeNotSupport = pde("PD011211", "Plugin '%s' has library type %s which is not supported by the Go plugin loader")
+	MsgPluginExited            = pde("PD011212", "Plugin '%s' exited with rc=%d")
+	MsgPluginProcessStart      = pde("PD011213", "Failed to start process '%s' for plugin '%s'")
+	MsgPluginProcessExited     = pde("PD011214", "Process for plugin '%s' exited: %v")
+	MsgPluginRemoteStart       = pde("PD011215", "Failed to start remote plugin '%s' at '%s'")
+	MsgPluginRemoteDisconnect  = pde("PD011216", "Remote plugin '%s' disconnected")
 
 	// BlockIndexer PD0113XX
 	MsgBlockIndexerInvalidFromBlock         = pde("PD011300", "Invalid from block '%s' (must be 'latest' or number)")
@@ -203,6 +220,8 @@ var (
 	MsgBlockIndexerConfirmedBlockNotFound   = pde("PD011310", "Block %s (%d) not found on retrieval after detection and requested number of confirmations")
 	MsgBlockIndexerLimitRequired            = pde("PD011311", "limit is required on all queries")
 	MsgBlockIndexerEventStreamNotFound      = pde("PD011312", "Event stream not found: %s")
+	MsgBlockIndexerInvalidReceiptFetch      = pde("PD011313", "Invalid receipt fetch strategy '%s'")
+	MsgBlockIndexerInvalidTransport         = pde("PD011314", "Invalid block listener transport '%s'")
 
 	// EthClient module PD0115XX
 	MsgEthClientInvalidInput            = pde("PD011500", "Unable to convert to ABI function input (func=%s)")
@@ -223,6 +242,9 @@ var (
 	MsgEthClientReturnValueNotDecoded   = pde("PD011515", "Error return value for custom error: %s")
 	MsgEthClientReturnValueNotAvailable = pde("PD011516", "Error return value unavailable")
 	MsgEthClientNoConnection            = pde("PD011517", "No JSON/RPC connection is available to this client")
+	MsgEthClientEndpointChainID         = pde("PD011518", "Endpoint %s returned chain ID %d, expected %d")
+	MsgEthClientEndpointBehind          = pde("PD011519", "Endpoint %s at block %d is more than %d blocks behind block %d")
+	MsgEthClientEndpointStaleHead       = pde("PD011520", "Endpoint %s has not advanced from block %d in %s")
 
 	// DomainManager module PD0116XX
 	MsgDomainNotFound                         = pde("PD011600", "Domain %q not found")
@@ -288,6 +310,7 @@ var (
 	MsgDomainInvalidPGroupGenesisABI          = pde("PD011664", "Domain generated an invalid privacy group genesis ABI parameter schema")
 	MsgDomainInvalidPGroupTxTypeNotPrivate    = pde("PD011665", "Resulting wrapped function call for privacy group must be a private transaction (type=%s)")
 	MsgDomainInvalidPGroupTxCannotRedirect    = pde("PD011666", "Resulting wrapped function call must target the same smart contract (contract=%s,addr=%s)")
+	MsgDomainCallAttestationUnsupported       = pde("PD011667", "Attestation '%s' of type %s is not supported on a call - only SIGN attestations can be requested")
 
 	// Entrypoint PD0117XX
 	MsgEntrypointUnknownRunMode = pde("PD011700", "Unknown run mode '%s'")
@@ -332,6 +355,10 @@ var (
 	MsgPrivateTxMgrFunctionNotProvided           = pde("PD011836", "Function abi not provided in transaction input")
 	MsgPrivateTxMgrAssembleRequestInvalid        = pde("PD011837", "Assemble request is invalid for transaction %s")
 	MsgPrivateTxMgrAssembleTxnNotFound           = pde("PD011838", "Transaction %s not found in local node")
+	MsgPrivateTxMgrCancelDispatched              = pde("PD011839", "Transaction %s cannot be cancelled as it has been dispatched to the base ledger")
+	MsgPrivateTxMgrCoordinatorHeartbeatTimeout   = pde("PD011840", "Coordinator node '%s' has not sent a heartbeat for %s")
+	MsgPrivateTxMgrCallSignerNotLocal            = pde("PD011841", "Signature '%s' for a call was requested from party '%s', which is not local to this node")
+	MsgPrivateTxMgrCallSignerNotCaller           = pde("PD011842", "Signature '%s' for a call was requested from party '%s', which is not the caller '%s' or one of its keys")
 
 	// Public Transaction Manager PD0119XX
 	MsgInsufficientBalance             = pde("PD011900", "Balance %s of fueling source address %s is below the required amount %s")
@@ -366,6 +393,11 @@ var (
 	MsgUpdateGasPriceLower             = pde("PD011938", "Gas price cannot be lowered for transaction (current=%s requested=%s)")
 	MsgUpdateMaxFeePerGasLower         = pde("PD011939", "Max fee per gas cannot be lowered for transaction (current=%s requested=%s)")
 	MsgUpdateNoFixedPricing            = pde("PD011940", "Cannot unset gas price for transaction with fixed gas pricing")
+	MsgFeeHistoryNoBaseFee             = pde("PD011941", "Fee history returned by the node does not include a base fee")
+	MsgPublicTxStuck                   = pde("PD011942", "PubTx[STUCK] from=%s nonce=%d not mined after %d submissions")
+	MsgNonceNotInGap                   = pde("PD011943", "Nonce %d for %s is not in a nonce gap (chain nonce=%d)")
+	MsgNonceGapSignerNotManaged        = pde("PD011944", "Signing address %s is not a key managed by this node")
+	MsgTransactionCancelled            = pde("PD011945", "Transaction %s cannot be updated as it has been cancelled")
 
 	// TransportManager module PD0120XX
 	MsgTransportInvalidMessage                 = pde("PD012000", "Invalid message")
@@ -453,6 +485,12 @@ var (
 	MsgTxMgrBlockchainEventListenerInvalidTimeout = pde("PD012250", "Error parsing batch timeout '%s': %s")
 	MsgTxMgrBlockchainEventListenerNoSources      = pde("PD012251", "Blockchain event listener '%s' has no sources configured")
 	MsgTxMgrBlockchainEventListenerNoABIs         = pde("PD012252", "Blockchain event listener '%s' has a source with no ABI configured")
+	MsgTxMgrTransactionCancelled                  = pde("PD012253", "Transaction cancelled")
+	MsgTxMgrCancelAlreadyComplete                 = pde("PD012254", "Transaction %s cannot be cancelled as it is already complete")
+	MsgTxMgrCancelPrivateDeploy                   = pde("PD012255", "Private contract deployment transactions cannot be cancelled")
+	MsgTxMgrWebhookRequestFailed                  = pde("PD012256", "Webhook delivery of batch %s for listener '%s' failed")
+	MsgTxMgrWebhookBadStatus                      = pde("PD012257", "Webhook delivery of batch %s for listener '%s' failed with HTTP status %d: %s")
+	MsgTxMgrWebhookListenerNoReceivers            = pde("PD012258", "Listener '%s' delivers to a webhook, and does not accept subscriptions")
 
 	// FlushWriter module PD0123XX
 	MsgFlushWriterQuiescing      = pde("PD012300", "Writer shutting down")
@@ -0,0 +1,93 @@
+//go:build cgo
+
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+/*
+#cgo linux LDFLAGS: -ldl
+#include <dlfcn.h>
+#include <stdlib.h>
+
+// Signatures of the functions exported by a Go c-shared plugin (GoInt is a 64bit integer)
+typedef long long (*plugin_run_fn)(char*, char*);
+typedef void (*plugin_stop_fn)(char*);
+
+static long long call_plugin_run(void *fn, char *grpcTarget, char *pluginID) {
+	return ((plugin_run_fn)fn)(grpcTarget, pluginID);
+}
+
+static void call_plugin_stop(void *fn, char *pluginID) {
+	((plugin_stop_fn)fn)(pluginID);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+type dlopenLibrary struct {
+	run  unsafe.Pointer
+	stop unsafe.Pointer
+}
+
+// The location follows the dlopen(3) search rules, so can be an absolute path or a library
+// name resolved against LD_LIBRARY_PATH (DYLD_LIBRARY_PATH on macOS).
+func dlopenCSharedLibrary(location string) (cSharedLibrary, error) {
+	cLocation := C.CString(location)
+	defer C.free(unsafe.Pointer(cLocation))
+
+	// Libraries are never closed, as a Go runtime cannot be unloaded from a process
+	handle := C.dlopen(cLocation, C.RTLD_NOW|C.RTLD_LOCAL)
+	if handle == nil {
+		return nil, errors.New(C.GoString(C.dlerror()))
+	}
+	lib := &dlopenLibrary{}
+	var err error
+	lib.run, err = dlsym(handle, "Run")
+	if err == nil {
+		lib.stop, err = dlsym(handle, "Stop")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lib, nil
+}
+
+func dlsym(handle unsafe.Pointer, name string) (unsafe.Pointer, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	sym := C.dlsym(handle, cName)
+	if sym == nil {
+		return nil, errors.New(C.GoString(C.dlerror()))
+	}
+	return sym, nil
+}
+
+func (l *dlopenLibrary) Run(grpcTarget, pluginID string) int {
+	cGRPCTarget := C.CString(grpcTarget)
+	defer C.free(unsafe.Pointer(cGRPCTarget))
+	cPluginID := C.CString(pluginID)
+	defer C.free(unsafe.Pointer(cPluginID))
+	return int(C.call_plugin_run(l.run, cGRPCTarget, cPluginID))
+}
+
+func (l *dlopenLibrary) Stop(pluginID string) {
+	cPluginID := C.CString(pluginID)
+	defer C.free(unsafe.Pointer(cPluginID))
+	C.call_plugin_stop(l.stop, cPluginID)
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type GoPluginLoader interface {
+	Start() error
+	Stop()
+}
+
+// The exported functions of a plugin built with plugintk.NewPluginLibraryEntrypoint
+type cSharedLibrary interface {
+	Run(grpcTarget, pluginID string) int
+	Stop(pluginID string)
+}
+
+// Overridden in unit tests, to run Go coded plugins without compiling them to a c-shared library
+var openCSharedLibrary = dlopenCSharedLibrary
+
+type goPluginLoader struct {
+	bgCtx      context.Context
+	cancelCtx  context.CancelFunc
+	pm         components.PluginManager
+	grpcTarget string
+	conn       *grpc.ClientConn
+	client     prototk.PluginControllerClient
+	loopDone   chan struct{}
+
+	mux       sync.Mutex
+	libraries map[string]cSharedLibrary // by library location - a library can host multiple plugins
+	running   map[string]cSharedLibrary // by plugin ID
+	wg        sync.WaitGroup
+}
+
+// The Go plugin loader runs inside the Paladin process, in place of the JVM loader that hosts Paladin
+// when it is built as a c-shared library. It connects to the plugin controller in exactly the same way
+// as any other loader, and loads c-shared plugins into this process with dlopen.
+//
+// Jar plugins cannot be loaded, and are reported back to the plugin controller as a load failure.
+func NewGoPluginLoader(bgCtx context.Context, pm components.PluginManager) GoPluginLoader {
+	gl := &goPluginLoader{
+		pm:        pm,
+		libraries: make(map[string]cSharedLibrary),
+		running:   make(map[string]cSharedLibrary),
+	}
+	gl.bgCtx, gl.cancelCtx = context.WithCancel(log.WithLogField(bgCtx, "role", "plugin_loader"))
+	return gl
+}
+
+// Must be called after the plugin manager is started
+func (gl *goPluginLoader) Start() (err error) {
+	gl.grpcTarget = gl.pm.GRPCTargetURL()
+	log.L(gl.bgCtx).Infof("Go plugin loader connecting to %s", gl.grpcTarget)
+	var stream grpc.ServerStreamingClient[prototk.PluginLoad]
+	gl.conn, err = grpc.NewClient(gl.grpcTarget, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err == nil {
+		gl.client = prototk.NewPluginControllerClient(gl.conn)
+		stream, err = gl.client.InitLoader(gl.bgCtx, &prototk.PluginLoaderInit{
+			Id: gl.pm.LoaderID().String(),
+		})
+	}
+	if err != nil {
+		return i18n.WrapError(gl.bgCtx, err, msgs.MsgPluginLoaderConnect)
+	}
+	gl.loopDone = make(chan struct{})
+	go gl.loaderLoop(stream)
+	return nil
+}
+
+func (gl *goPluginLoader) Stop() {
+	gl.cancelCtx()
+	if gl.conn != nil {
+		_ = gl.conn.Close()
+	}
+	if gl.loopDone != nil {
+		<-gl.loopDone
+	}
+
+	gl.mux.Lock()
+	running := make(map[string]cSharedLibrary, len(gl.running))
+	for pluginID, lib := range gl.running {
+		running[pluginID] = lib
+	}
+	gl.mux.Unlock()
+
+	for pluginID, lib := range running {
+		log.L(gl.bgCtx).Infof("Stopping plugin [%s]", pluginID)
+		lib.Stop(pluginID)
+	}
+	gl.wg.Wait()
+}
+
+func (gl *goPluginLoader) loaderLoop(stream grpc.ServerStreamingClient[prototk.PluginLoad]) {
+	defer close(gl.loopDone)
+	for {
+		loadInstruction, err := stream.Recv()
+		if err != nil {
+			log.L(gl.bgCtx).Infof("Go plugin loader stream closed: %s", err)
+			return
+		}
+		if loadInstruction.SysCommand != nil {
+			gl.systemCommand(*loadInstruction.SysCommand)
+			continue
+		}
+		gl.load(loadInstruction)
+	}
+}
+
+func (gl *goPluginLoader) systemCommand(cmd prototk.PluginLoad_SysCommand) {
+	switch cmd {
+	case prototk.PluginLoad_THREAD_DUMP:
+		_ = pprof.Lookup("goroutine").WriteTo(os.Stderr, 2)
+	default:
+		log.L(gl.bgCtx).Warnf("Unrecognized system command %s", cmd)
+	}
+}
+
+func (gl *goPluginLoader) load(loadInstruction *prototk.PluginLoad) {
+	ctx := gl.bgCtx
+	plugin := loadInstruction.Plugin
+	log.L(ctx).Infof("Load instruction for %s %s [%s] libType=%s location=%s",
+		plugin.PluginType, plugin.Name, plugin.Id, loadInstruction.LibType, loadInstruction.LibLocation)
+
+	var err error
+	switch loadInstruction.LibType {
+	case prototk.PluginLoad_C_SHARED:
+		err = gl.loadCShared(ctx, loadInstruction)
+	case prototk.PluginLoad_JAR:
+		err = i18n.NewError(ctx, msgs.MsgPluginJarRequiresJVM, plugin.Name)
+	default:
+		err = i18n.NewError(ctx, msgs.MsgPluginLibTypeNotSupport, plugin.Name, loadInstruction.LibType)
+	}
+	if err != nil {
+		gl.loadFailed(ctx, plugin, err)
+	}
+}
+
+func (gl *goPluginLoader) loadFailed(ctx context.Context, plugin *prototk.PluginInfo, err error) {
+	log.L(ctx).Errorf("Plugin load %s (type=%s) failed: %s", plugin.Name, plugin.PluginType, err)
+	_, lfErr := gl.client.LoadFailed(ctx, &prototk.PluginLoadFailed{
+		Plugin:       plugin,
+		ErrorMessage: err.Error(),
+	})
+	if lfErr != nil {
+		log.L(ctx).Errorf("Failed to report load failure for plugin %s: %s", plugin.Name, lfErr)
+	}
+}
+
+func (gl *goPluginLoader) openLibrary(ctx context.Context, location string) (cSharedLibrary, error) {
+	gl.mux.Lock()
+	defer gl.mux.Unlock()
+	lib := gl.libraries[location]
+	if lib == nil {
+		var err error
+		log.L(ctx).Infof("Loading c-shared library %s", location)
+		lib, err = openCSharedLibrary(location)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgPluginLibraryLoadFailed, location)
+		}
+		gl.libraries[location] = lib
+	}
+	return lib, nil
+}
+
+func (gl *goPluginLoader) loadCShared(ctx context.Context, loadInstruction *prototk.PluginLoad) error {
+	lib, err := gl.openLibrary(ctx, loadInstruction.LibLocation)
+	if err != nil {
+		return err
+	}
+
+	plugin := loadInstruction.Plugin
+	gl.mux.Lock()
+	gl.running[plugin.Id] = lib
+	gl.mux.Unlock()
+
+	gl.wg.Add(1)
+	go func() {
+		defer gl.wg.Done()
+		log.L(ctx).Infof("Starting %s %s [%s]", plugin.PluginType, plugin.Name, plugin.Id)
+		rc := lib.Run(gl.grpcTarget, plugin.Id)
+
+		gl.mux.Lock()
+		delete(gl.running, plugin.Id)
+		gl.mux.Unlock()
+
+		if rc != 0 && ctx.Err() == nil {
+			gl.loadFailed(ctx, plugin, i18n.NewError(ctx, msgs.MsgPluginExited, plugin.Name, rc))
+		} else {
+			log.L(ctx).Infof("Plugin %s %s [%s] stopped rc=%d", plugin.PluginType, plugin.Name, plugin.Id, rc)
+		}
+	}()
+	return nil
+}
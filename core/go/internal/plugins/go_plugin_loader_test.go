@@ -0,0 +1,203 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Runs a Go coded plugin as if it had been loaded from a c-shared library
+type testCSharedLibrary struct {
+	plugin plugintk.Plugin
+	rc     int
+}
+
+func (tl *testCSharedLibrary) Run(grpcTarget, pluginID string) int {
+	if tl.plugin != nil {
+		tl.plugin.Run(grpcTarget, pluginID)
+	}
+	return tl.rc
+}
+
+func (tl *testCSharedLibrary) Stop(pluginID string) {
+	if tl.plugin != nil {
+		tl.plugin.Stop()
+	}
+}
+
+func mockOpenCSharedLibrary(t *testing.T, fn func(location string) (cSharedLibrary, error)) {
+	origOpen := openCSharedLibrary
+	openCSharedLibrary = fn
+	t.Cleanup(func() {
+		openCSharedLibrary = origOpen
+	})
+}
+
+func newTestGoPluginLoader(t *testing.T, pc *pluginManager) *goPluginLoader {
+	gl := NewGoPluginLoader(context.Background(), pc).(*goPluginLoader)
+	err := gl.Start()
+	require.NoError(t, err)
+	t.Cleanup(gl.Stop)
+	return gl
+}
+
+func TestGoPluginLoaderCShared(t *testing.T) {
+
+	registered := make(chan string, 1)
+	tdm := &testDomainManager{
+		domains: map[string]plugintk.Plugin{
+			"domain1": &mockPlugin[prototk.DomainMessage]{
+				t:                   t,
+				allowRegisterErrors: true,
+				connectFactory:      domainConnectFactory,
+				headerAccessor:      domainHeaderAccessor,
+				preRegister: func(domainID string) *prototk.DomainMessage {
+					return &prototk.DomainMessage{
+						Header: &prototk.Header{
+							MessageType: prototk.Header_REGISTER,
+							PluginId:    domainID,
+							MessageId:   uuid.NewString(),
+						},
+					}
+				},
+			},
+		},
+	}
+	tdm.domainRegistered = func(name string, toDomain components.DomainManagerToDomain) (plugintk.DomainCallbacks, error) {
+		registered <- name
+		return nil, fmt.Errorf("pop")
+	}
+
+	var openedLocations []string
+	mockOpenCSharedLibrary(t, func(location string) (cSharedLibrary, error) {
+		openedLocations = append(openedLocations, location)
+		return &testCSharedLibrary{plugin: tdm.domains["domain1"]}, nil
+	})
+
+	pc := newTestPluginManager(t, &testManagers{testDomainManager: tdm})
+	defer pc.Stop()
+	gl := newTestGoPluginLoader(t, pc)
+
+	assert.Equal(t, "domain1", <-registered)
+	assert.Equal(t, []string{"/tmp/not/applicable"}, openedLocations)
+
+	// Library is cached on a second load
+	lib, err := gl.openLibrary(context.Background(), "/tmp/not/applicable")
+	require.NoError(t, err)
+	assert.NotNil(t, lib)
+	assert.Len(t, openedLocations, 1)
+
+	pc.SendSystemCommandToLoader(prototk.PluginLoad_THREAD_DUMP)
+	gl.systemCommand(prototk.PluginLoad_SysCommand(99))
+}
+
+func newTestPluginManagerWithDomainConfig(t *testing.T, conf *pldconf.PluginConfig) *pluginManager {
+	return newTestPluginManagerWithConfig(t, &pldconf.PluginManagerConfig{}, conf)
+}
+
+func newTestPluginManagerWithConfig(t *testing.T, pmConf *pldconf.PluginManagerConfig, conf *pldconf.PluginConfig) *pluginManager {
+	mdm := componentmocks.NewDomainManager(t)
+	mdm.On("ConfiguredDomains").Return(map[string]*pldconf.PluginConfig{"domain1": conf})
+	mc := componentmocks.NewAllComponents(t)
+	mc.On("DomainManager").Return(mdm)
+	mc.On("TransportManager").Return((&testTransportManager{}).mock(t))
+	mc.On("RegistryManager").Return((&testRegistryManager{}).mock(t))
+	mc.On("KeyManager").Return((&testKeyManager{}).mock(t))
+
+	pc := NewPluginManager(context.Background(), tempUDS(t), uuid.New(), pmConf)
+	err := pc.PostInit(mc)
+	require.NoError(t, err)
+	err = pc.Start()
+	require.NoError(t, err)
+	t.Cleanup(pc.Stop)
+	return pc.(*pluginManager)
+}
+
+func TestGoPluginLoaderRejectsJar(t *testing.T) {
+	pc := newTestPluginManagerWithDomainConfig(t, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeJar),
+		Library: "domain1.jar",
+	})
+	newTestGoPluginLoader(t, pc)
+
+	err := pc.WaitForInit(context.Background())
+	assert.Regexp(t, "PD011207.*PD011210.*domain1", err)
+}
+
+func TestGoPluginLoaderUnsupportedLibType(t *testing.T) {
+	pc := newTestPluginManager(t, &testManagers{})
+	defer pc.Stop()
+	gl := newTestGoPluginLoader(t, pc)
+
+	gl.load(&prototk.PluginLoad{
+		Plugin:  &prototk.PluginInfo{Id: uuid.NewString(), Name: "domain1"},
+		LibType: prototk.PluginLoad_LibType(99),
+	})
+	loadFailed := <-pc.loadingProgressed
+	assert.Regexp(t, "PD011211.*domain1", loadFailed.ErrorMessage)
+}
+
+func TestGoPluginLoaderLibraryLoadFail(t *testing.T) {
+	mockOpenCSharedLibrary(t, func(location string) (cSharedLibrary, error) {
+		return nil, fmt.Errorf("pop")
+	})
+
+	pc := newTestPluginManagerWithDomainConfig(t, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeCShared),
+		Library: "libdomain1.so",
+	})
+	newTestGoPluginLoader(t, pc)
+
+	err := pc.WaitForInit(context.Background())
+	assert.Regexp(t, "PD011209.*libdomain1.so.*pop", err)
+}
+
+func TestGoPluginLoaderPluginExitsWithError(t *testing.T) {
+	mockOpenCSharedLibrary(t, func(location string) (cSharedLibrary, error) {
+		return &testCSharedLibrary{rc: 1}, nil
+	})
+
+	pc := newTestPluginManagerWithDomainConfig(t, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeCShared),
+		Library: "libdomain1.so",
+	})
+	newTestGoPluginLoader(t, pc)
+
+	err := pc.WaitForInit(context.Background())
+	assert.Regexp(t, "PD011212.*domain1.*rc=1", err)
+}
+
+func TestGoPluginLoaderConnectFail(t *testing.T) {
+	mpm := componentmocks.NewPluginManager(t)
+	mpm.On("GRPCTargetURL").Return("unix:" + t.TempDir() + "/missing.sock")
+	mpm.On("LoaderID").Return(uuid.New())
+
+	gl := NewGoPluginLoader(context.Background(), mpm)
+	defer gl.Stop()
+	err := gl.Start()
+	assert.Regexp(t, "PD011208", err)
+}
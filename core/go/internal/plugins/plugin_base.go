@@ -24,9 +24,11 @@ import (
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/inflight"
 	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 	"google.golang.org/grpc"
 )
 
@@ -57,6 +59,9 @@ type plugin[M any] struct {
 	id   uuid.UUID
 	def  *prototk.PluginLoad
 
+	libType    pldtypes.LibraryType
+	supervisor *pluginSupervisor // for plugins run outside of the plugin loader
+
 	initializing bool
 	registered   bool
 	initialized  bool
@@ -93,9 +98,21 @@ type pluginInfo struct {
 	instanceID string
 }
 
+func (p *plugin[CB]) notifyRegistered() {
+	p.pc.mux.Lock()
+	p.registered = true
+	if p.supervisor != nil {
+		p.supervisor.connectedLocked()
+	}
+	p.pc.mux.Unlock()
+}
+
 func (p *plugin[CB]) notifyInitialized() {
 	p.pc.mux.Lock()
 	p.initialized = true
+	if p.supervisor != nil {
+		p.supervisor.initializedLocked()
+	}
 	p.pc.mux.Unlock()
 	log.L(p.pc.bgCtx).Infof("Plugin load %s [%s] (type=%s) completed", p.def.Plugin, p.id, p.def.Plugin.PluginType)
 	p.pc.tapLoadingProgressed()
@@ -105,6 +122,9 @@ func (p *plugin[CB]) notifyStopped() {
 	p.pc.mux.Lock()
 	p.registered = false
 	p.initialized = false
+	if p.supervisor != nil {
+		p.supervisor.disconnectedLocked()
+	}
 	p.pc.mux.Unlock()
 	log.L(p.pc.bgCtx).Infof("Plugin stopped %s [%s] (type=%s)", p.def.Plugin, p.id, p.def.Plugin.PluginType)
 	p.pc.tapLoadingProgressed()
@@ -173,6 +193,7 @@ func (ph *pluginHandler[M]) serve() error {
 				// Close the connection to this plugin
 				return err
 			}
+			plugin.notifyRegistered()
 			// Update the context for us, and for request/reply, to include details of the plugin
 			debugInfo := fmt.Sprintf("%s[%s/%s]", plugin.def.Plugin.PluginType, plugin.name, plugin.id)
 			ph.ctx = log.WithLogField(ph.ctx, "plugin", debugInfo) // dirty write - as it's just debug info being added
@@ -243,10 +264,13 @@ func (ph *pluginHandler[M]) close() {
 
 // Go routine started for each request
 func (ph *pluginHandler[M]) handleRequestFromPlugin(ctx context.Context, pi *pluginInfo, req plugintk.PluginMessage[M]) {
-	// Call the manager
+	// Call the manager, continuing the trace of the plugin (which is itself normally handling a request from us)
 	startTime := time.Now()
 	log.L(ctx).Infof("[%s] ==> %T", req.Header().MessageId, req.RequestToPlugin())
+	ctx, span := tracing.StartSpan(tracing.ExtractMap(ctx, req.Header().TraceContext), plugintk.PluginMessageSpanName(pi.pluginType, req.RequestToPlugin()),
+		tracing.AttrPluginType.String(pi.pluginType), tracing.AttrPluginID.String(pi.name))
 	resFn, err := ph.pluginToManager.RequestReply(ctx, req)
+	tracing.EndSpan(span, err)
 
 	// Build and send the reply (success or error)
 	replyID := uuid.NewString()
@@ -268,7 +292,7 @@ func (ph *pluginHandler[M]) handleRequestFromPlugin(ctx context.Context, pi *plu
 	ph.send(res)
 }
 
-func (ph *pluginHandler[M]) RequestReply(ctx context.Context, reqFn func(plugintk.PluginMessage[M]), resFn func(plugintk.PluginMessage[M]) (ok bool)) error {
+func (ph *pluginHandler[M]) RequestReply(ctx context.Context, reqFn func(plugintk.PluginMessage[M]), resFn func(plugintk.PluginMessage[M]) (ok bool)) (err error) {
 	// Log under our context so we get the plugin ID
 	reqID := uuid.New()
 	l := log.L(ph.ctx)
@@ -277,14 +301,18 @@ func (ph *pluginHandler[M]) RequestReply(ctx context.Context, reqFn func(plugint
 	req := ph.wrapper.Wrap(new(M))
 	reqFn(req)
 
-	// We are responsible for the header
+	// We are responsible for the header, including propagating our trace to the plugin
 	pi := ph.pluginInfo.Load()
+	ctx, span := tracing.StartSpan(ctx, plugintk.PluginMessageSpanName(pi.pluginType, req.RequestToPlugin()),
+		tracing.AttrPluginType.String(pi.pluginType), tracing.AttrPluginID.String(pi.name))
+	defer func() { tracing.EndSpan(span, err) }()
 	header := req.Header()
 	header.PluginId = pi.instanceID
 	header.CorrelationId = nil
 	header.MessageId = reqID.String()
 	header.MessageType = prototk.Header_REQUEST_TO_PLUGIN
 	header.ErrorMessage = nil
+	header.TraceContext = tracing.InjectMap(ctx)
 
 	// Create the in-flight record - under the request context (inflight manager will be cancelled if we end)
 	inflight := ph.inflight.AddInflight(ctx, reqID)
@@ -31,7 +31,9 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 	"google.golang.org/grpc"
 )
 
@@ -39,6 +41,8 @@ func MapLibraryTypeToProto(t pldtypes.Enum[pldtypes.LibraryType]) (prototk.Plugi
 	return pldtypes.MapEnum(t, map[pldtypes.LibraryType]prototk.PluginLoad_LibType{
 		pldtypes.LibraryTypeCShared: prototk.PluginLoad_C_SHARED,
 		pldtypes.LibraryTypeJar:     prototk.PluginLoad_JAR,
+		pldtypes.LibraryTypeProcess: prototk.PluginLoad_PROCESS,
+		pldtypes.LibraryTypeRemote:  prototk.PluginLoad_REMOTE,
 	})
 }
 
@@ -54,6 +58,10 @@ type pluginManager struct {
 	network         string
 	address         string
 	shutdownTimeout time.Duration
+	restartRetry    *retry.Retry
+	remoteConf      *pldconf.RemotePluginsConfig
+	remoteDialOpts  []grpc.DialOption
+	rpcModule       *rpcserver.RPCModule
 
 	domainManager components.DomainManager
 	domainPlugins map[uuid.UUID]*plugin[prototk.DomainMessage]
@@ -64,6 +72,9 @@ type pluginManager struct {
 	registryManager components.RegistryManager
 	registryPlugins map[uuid.UUID]*plugin[prototk.RegistryMessage]
 
+	keyManager           components.KeyManager
+	signingModulePlugins map[uuid.UUID]*plugin[prototk.SigningModuleMessage]
+
 	notifyPluginsUpdated chan bool
 	notifySystemCommand  chan prototk.PluginLoad_SysCommand
 	pluginLoaderDone     chan struct{}
@@ -82,11 +93,15 @@ func NewPluginManager(bgCtx context.Context,
 		grpcTarget:      grpcTarget,
 		loaderID:        loaderID,
 		shutdownTimeout: confutil.DurationMin(conf.GRPC.ShutdownTimeout, 0, *pldconf.DefaultGRPCConfig.ShutdownTimeout),
+		restartRetry:    retry.NewRetryIndefinite(&conf.Restart, pldconf.DefaultPluginRestartConfig),
+		remoteConf:      &conf.RemotePlugins,
 
 		domainPlugins:    make(map[uuid.UUID]*plugin[prototk.DomainMessage]),
 		transportPlugins: make(map[uuid.UUID]*plugin[prototk.TransportMessage]),
 		registryPlugins:  make(map[uuid.UUID]*plugin[prototk.RegistryMessage]),
 
+		signingModulePlugins: make(map[uuid.UUID]*plugin[prototk.SigningModuleMessage]),
+
 		serverDone:           make(chan error),
 		notifyPluginsUpdated: make(chan bool, 1),
 		notifySystemCommand:  make(chan prototk.PluginLoad_SysCommand, 1),
@@ -131,13 +146,17 @@ func (pm *pluginManager) parseGRPCAddress(ctx context.Context, serverAddr string
 }
 
 func (pm *pluginManager) PreInit(pic components.PreInitComponents) (*components.ManagerInitResult, error) {
-	return &components.ManagerInitResult{}, nil
+	pm.initRPC()
+	return &components.ManagerInitResult{
+		RPCModules: []*rpcserver.RPCModule{pm.rpcModule},
+	}, nil
 }
 
 func (pm *pluginManager) PostInit(c components.AllComponents) error {
 	pm.domainManager = c.DomainManager()
 	pm.transportManager = c.TransportManager()
 	pm.registryManager = c.RegistryManager()
+	pm.keyManager = c.KeyManager()
 
 	if err := pm.ReloadPluginList(); err != nil {
 		return err
@@ -146,7 +165,10 @@ func (pm *pluginManager) PostInit(c components.AllComponents) error {
 	if err := pm.parseGRPCAddress(pm.bgCtx, pm.grpcTarget); err != nil {
 		return err
 	}
-	return nil
+
+	var err error
+	pm.remoteDialOpts, err = buildRemoteDialOptions(pm.bgCtx, pm.remoteConf)
+	return err
 }
 
 func (pm *pluginManager) Start() (err error) {
@@ -163,6 +185,11 @@ func (pm *pluginManager) Start() (err error) {
 
 	log.L(ctx).Infof("server listening at %v", pm.listener.Addr())
 	go pm.runServer(ctx)
+
+	// Plugins that run outside of the loader can only be started once we are listening
+	for _, ps := range pm.supervisors() {
+		ps.start()
+	}
 	return nil
 }
 
@@ -178,6 +205,10 @@ func (pm *pluginManager) Stop() {
 	ctx := pm.bgCtx
 	log.L(ctx).Infof("Stop")
 
+	for _, ps := range pm.supervisors() {
+		ps.stop()
+	}
+
 	gracefullyStopped := make(chan struct{})
 	go func() {
 		defer close(gracefullyStopped)
@@ -223,6 +254,11 @@ func (pm *pluginManager) ReloadPluginList() (err error) {
 			err = initPlugin(pm.bgCtx, pm, pm.registryPlugins, name, prototk.PluginInfo_REGISTRY, tp)
 		}
 	}
+	for name, sp := range pm.keyManager.ConfiguredSigningModules() {
+		if err == nil {
+			err = initPlugin(pm.bgCtx, pm, pm.signingModulePlugins, name, prototk.PluginInfo_SIGNING_MODULE, sp)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -236,8 +272,10 @@ func (pm *pluginManager) ReloadPluginList() (err error) {
 
 func (pm *pluginManager) WaitForInit(ctx context.Context) error {
 	for {
+		// Signing modules must be available before any signing can occur, so we wait for them alongside domains
 		unloadedDomainPlugins, _ := unloadedPlugins(pm, pm.domainPlugins, prototk.PluginInfo_DOMAIN, false)
-		unloadedCount := len(unloadedDomainPlugins)
+		unloadedSigningModulePlugins, _ := unloadedPlugins(pm, pm.signingModulePlugins, prototk.PluginInfo_SIGNING_MODULE, false)
+		unloadedCount := len(unloadedDomainPlugins) + len(unloadedSigningModulePlugins)
 		if unloadedCount == 0 {
 			return nil
 		}
@@ -307,14 +345,39 @@ func initPlugin[CB any](ctx context.Context, pm *pluginManager, pluginMap map[uu
 		LibLocation: conf.Library,
 		Class:       conf.Class,
 	}
-	pluginType, err := pldtypes.LibraryType(conf.Type).Enum().Validate()
+	libType, err := pldtypes.LibraryType(conf.Type).Enum().Validate()
+	if err == nil {
+		plugin.libType = libType
+		plugin.def.LibType, err = MapLibraryTypeToProto(libType.Enum())
+	}
 	if err == nil {
-		plugin.def.LibType, err = MapLibraryTypeToProto(pluginType.Enum())
+		switch libType {
+		case pldtypes.LibraryTypeProcess, pldtypes.LibraryTypeRemote:
+			plugin.supervisor = newPluginSupervisor(pm, plugin.def)
+		}
 		pluginMap[plugin.id] = plugin
 	}
 	return err
 }
 
+func (pm *pluginManager) supervisors() []*pluginSupervisor {
+	pm.mux.Lock()
+	defer pm.mux.Unlock()
+	supervisors := supervisorsOf(nil, pm.domainPlugins)
+	supervisors = supervisorsOf(supervisors, pm.transportPlugins)
+	supervisors = supervisorsOf(supervisors, pm.registryPlugins)
+	return supervisorsOf(supervisors, pm.signingModulePlugins)
+}
+
+func supervisorsOf[CB any](supervisors []*pluginSupervisor, pluginMap map[uuid.UUID]*plugin[CB]) []*pluginSupervisor {
+	for _, p := range pluginMap {
+		if p.supervisor != nil {
+			supervisors = append(supervisors, p.supervisor)
+		}
+	}
+	return supervisors
+}
+
 func (pm *pluginManager) tapLoadingProgressed() {
 	select {
 	case pm.loadingProgressed <- nil:
@@ -329,7 +392,8 @@ func unloadedPlugins[CB any](pm *pluginManager, pluginMap map[uuid.UUID]*plugin[
 	for name, plugin := range pluginMap {
 		pluginList = append(pluginList, fmt.Sprintf("%s:%s", plugin.def.Plugin.PluginType, name))
 		if !plugin.initialized {
-			if !plugin.initializing {
+			// Supervised plugins are never sent to the loader
+			if !plugin.initializing && plugin.supervisor == nil {
 				notInitializing = append(notInitializing, plugin)
 				if setInitializing {
 					plugin.initializing = true
@@ -388,6 +452,12 @@ func (pm *pluginManager) sendPluginsToLoader(stream prototk.PluginController_Ini
 				err = stream.Send(plugin.def)
 			}
 		}
+		_, notInitializingSigningModules := unloadedPlugins(pm, pm.signingModulePlugins, prototk.PluginInfo_SIGNING_MODULE, true)
+		for _, plugin := range notInitializingSigningModules {
+			if err == nil {
+				err = stream.Send(plugin.def)
+			}
+		}
 		if err == nil {
 			select {
 			case <-ctx.Done():
@@ -63,6 +63,7 @@ type testManagers struct {
 	testDomainManager    *testDomainManager
 	testTransportManager *testTransportManager
 	testRegistryManager  *testRegistryManager
+	testKeyManager       *testKeyManager
 }
 
 func (tm *testManagers) componentMocks(t *testing.T) *componentmocks.AllComponents {
@@ -79,6 +80,10 @@ func (tm *testManagers) componentMocks(t *testing.T) *componentmocks.AllComponen
 		tm.testRegistryManager = &testRegistryManager{}
 	}
 	mc.On("RegistryManager").Return(tm.testRegistryManager.mock(t)).Maybe()
+	if tm.testKeyManager == nil {
+		tm.testKeyManager = &testKeyManager{}
+	}
+	mc.On("KeyManager").Return(tm.testKeyManager.mock(t)).Maybe()
 	return mc
 }
 
@@ -93,6 +98,9 @@ func (ts *testManagers) allPlugins() map[string]plugintk.Plugin {
 	for name, td := range ts.testRegistryManager.registries {
 		testPlugins[name] = td
 	}
+	for name, td := range ts.testKeyManager.signingModules {
+		testPlugins[name] = td
+	}
 	return testPlugins
 }
 
@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+func (pm *pluginManager) initRPC() {
+	pm.rpcModule = rpcserver.NewRPCModule("plugin").
+		Add("plugin_listPlugins", pm.rpcListPlugins())
+}
+
+func (pm *pluginManager) rpcListPlugins() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.PluginStatus, error) {
+		return pm.listPlugins(), nil
+	})
+}
+
+func (pm *pluginManager) listPlugins() []*pldapi.PluginStatus {
+	pm.mux.Lock()
+	defer pm.mux.Unlock()
+	plugins := pluginStatuses(nil, pm.domainPlugins)
+	plugins = pluginStatuses(plugins, pm.transportPlugins)
+	plugins = pluginStatuses(plugins, pm.registryPlugins)
+	plugins = pluginStatuses(plugins, pm.signingModulePlugins)
+	sort.Slice(plugins, func(i, j int) bool {
+		if plugins[i].PluginType != plugins[j].PluginType {
+			return plugins[i].PluginType < plugins[j].PluginType
+		}
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// Called with the plugin manager mutex held
+func pluginStatuses[CB any](plugins []*pldapi.PluginStatus, pluginMap map[uuid.UUID]*plugin[CB]) []*pldapi.PluginStatus {
+	for _, p := range pluginMap {
+		status := &pldapi.PluginStatus{
+			Name:        p.name,
+			ID:          p.id,
+			PluginType:  p.def.Plugin.PluginType.String(),
+			LibraryType: string(p.libType),
+			Library:     p.def.LibLocation,
+			Initialized: p.initialized,
+		}
+		if ps := p.supervisor; ps != nil {
+			status.PID = ps.pid
+			status.Restarts = ps.restarts
+			status.LastError = ps.lastError
+			status.LastErrorTime = ps.lastErrorTime
+		}
+		plugins = append(plugins, status)
+	}
+	return plugins
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRPCServer(t *testing.T, ctx context.Context, pc *pluginManager) (rpcclient.Client, func()) {
+
+	s, err := rpcserver.NewRPCServer(ctx, &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+	})
+	require.NoError(t, err)
+	err = s.Start()
+	require.NoError(t, err)
+
+	s.Register(pc.rpcModule)
+
+	c := rpcclient.WrapRestyClient(resty.New().SetBaseURL(fmt.Sprintf("http://%s", s.HTTPAddr())))
+
+	return c, s.Stop
+
+}
+
+func TestRPCListPlugins(t *testing.T) {
+	ctx := context.Background()
+	pc := newTestPluginManager(t, &testManagers{
+		testDomainManager: &testDomainManager{domains: map[string]plugintk.Plugin{
+			"domain1": &mockPlugin[prototk.DomainMessage]{t: t},
+		}},
+		testTransportManager: &testTransportManager{transports: map[string]plugintk.Plugin{
+			"transport1": &mockPlugin[prototk.TransportMessage]{t: t},
+		}},
+	})
+	defer pc.Stop()
+
+	client, rpcDone := newTestRPCServer(t, ctx, pc)
+	defer rpcDone()
+
+	plugins, err := pldclient.Wrap(client).Plugins().ListPlugins(ctx)
+	require.NoError(t, err)
+	require.Len(t, plugins, 2)
+	assert.Equal(t, "domain1", plugins[0].Name)
+	assert.Equal(t, "DOMAIN", plugins[0].PluginType)
+	assert.Equal(t, "c-shared", plugins[0].LibraryType)
+	assert.Equal(t, "/tmp/not/applicable", plugins[0].Library)
+	assert.False(t, plugins[0].Initialized)
+	assert.Zero(t, plugins[0].Restarts)
+	assert.Equal(t, "transport1", plugins[1].Name)
+	assert.Equal(t, "TRANSPORT", plugins[1].PluginType)
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/tlsconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// The plugin supervisor takes the place of the plugin loader for plugins that run outside of the
+// Paladin process, so that a crash in the plugin cannot take down the node.
+//
+//   - process: we spawn the executable with the gRPC target and plugin ID, and restart it with
+//     backoff whenever it exits.
+//   - remote: the plugin is already running at a gRPC address, and we ask it to start an instance
+//     that connects back to us. We re-request with backoff whenever that instance disconnects.
+type pluginSupervisor struct {
+	ctx          context.Context
+	cancelCtx    context.CancelFunc
+	pm           *pluginManager
+	def          *prototk.PluginLoad
+	disconnected chan struct{}
+	done         chan struct{}
+
+	// protected by the plugin manager mutex
+	connections      int
+	initializedInRun bool
+	pid              int
+	restarts         int
+	lastError        string
+	lastErrorTime    *pldtypes.Timestamp
+}
+
+// Remote plugins only accept requests from a client that presents a certificate they trust,
+// or the token they share with us, so that nobody else can redirect them to another controller.
+func buildRemoteDialOptions(ctx context.Context, conf *pldconf.RemotePluginsConfig) ([]grpc.DialOption, error) {
+	tlsConfig, err := tlsconf.BuildTLSConfig(ctx, &conf.TLS, tlsconf.ClientType)
+	if err != nil {
+		return nil, err
+	}
+	transportCreds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		transportCreds = credentials.NewTLS(tlsConfig)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(transportCreds)}
+	if conf.AuthTokenFile != "" {
+		token, err := plugintk.ReadAuthTokenFile(ctx, conf.AuthTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(plugintk.AuthTokenCredentials(token)))
+	}
+	return opts, nil
+}
+
+func newPluginSupervisor(pm *pluginManager, def *prototk.PluginLoad) *pluginSupervisor {
+	ps := &pluginSupervisor{
+		pm:           pm,
+		def:          def,
+		disconnected: make(chan struct{}, 1),
+	}
+	ps.ctx, ps.cancelCtx = context.WithCancel(log.WithLogField(pm.bgCtx, "plugin", def.Plugin.Name))
+	return ps
+}
+
+func (ps *pluginSupervisor) start() {
+	ps.done = make(chan struct{})
+	go ps.run()
+}
+
+func (ps *pluginSupervisor) stop() {
+	ps.cancelCtx()
+	if ps.done != nil {
+		<-ps.done
+	}
+}
+
+func (ps *pluginSupervisor) run() {
+	defer close(ps.done)
+	failures := 0
+	for {
+		ps.pm.mux.Lock()
+		ps.initializedInRun = false
+		ps.pm.mux.Unlock()
+
+		var err error
+		switch ps.def.LibType {
+		case prototk.PluginLoad_PROCESS:
+			err = ps.runProcess()
+		default:
+			err = ps.runRemote()
+		}
+		if ps.ctx.Err() != nil {
+			log.L(ps.ctx).Infof("Supervisor for %s %s stopped", ps.def.Plugin.PluginType, ps.def.Plugin.Name)
+			return
+		}
+
+		// The backoff only builds up while the plugin is failing to get to initialized
+		ps.pm.mux.Lock()
+		if ps.initializedInRun {
+			failures = 0
+		}
+		failures++
+		ps.restarts++
+		ps.lastError = err.Error()
+		ps.lastErrorTime = confutil.P(pldtypes.TimestampNow())
+		ps.pm.mux.Unlock()
+
+		log.L(ps.ctx).Errorf("Plugin %s %s failed (failures=%d): %s", ps.def.Plugin.PluginType, ps.def.Plugin.Name, failures, err)
+		if ps.pm.restartRetry.WaitDelay(ps.ctx, failures) != nil {
+			return
+		}
+	}
+}
+
+func (ps *pluginSupervisor) runProcess() error {
+	cmd := exec.CommandContext(ps.ctx, ps.def.LibLocation,
+		"-grpc-target", ps.pm.GRPCTargetURL(),
+		"-plugin-id", ps.def.Plugin.Id,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Give the plugin the chance to close its stream cleanly on shutdown, before it is killed
+	cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+	cmd.WaitDelay = ps.pm.shutdownTimeout
+	setPluginProcessAttributes(cmd)
+
+	log.L(ps.ctx).Infof("Starting %s %s [%s]: %s", ps.def.Plugin.PluginType, ps.def.Plugin.Name, ps.def.Plugin.Id, cmd)
+	if err := cmd.Start(); err != nil {
+		err = i18n.WrapError(ps.ctx, err, msgs.MsgPluginProcessStart, ps.def.LibLocation, ps.def.Plugin.Name)
+		// Unblock anybody waiting for the plugin to initialize, as this is unlikely to resolve itself
+		_, _ = ps.pm.LoadFailed(ps.ctx, &prototk.PluginLoadFailed{Plugin: ps.def.Plugin, ErrorMessage: err.Error()})
+		return err
+	}
+	ps.pm.mux.Lock()
+	ps.pid = cmd.Process.Pid
+	ps.pm.mux.Unlock()
+
+	err := cmd.Wait()
+	ps.pm.mux.Lock()
+	ps.pid = 0
+	ps.pm.mux.Unlock()
+	if err == nil {
+		// A plugin must never exit on its own, so even a clean exit is a failure
+		err = i18n.NewError(ps.ctx, msgs.MsgPluginProcessExited, ps.def.Plugin.Name, cmd.ProcessState)
+	} else {
+		err = i18n.NewError(ps.ctx, msgs.MsgPluginProcessExited, ps.def.Plugin.Name, err)
+	}
+	return err
+}
+
+func (ps *pluginSupervisor) runRemote() error {
+	// Clear any stale notification, and check if we still have a connection from a previous request
+	// (plugin instances retry their connection indefinitely, so might have reconnected on their own)
+	select {
+	case <-ps.disconnected:
+	default:
+	}
+	ps.pm.mux.Lock()
+	connected := ps.connections > 0
+	ps.pm.mux.Unlock()
+
+	if !connected {
+		if err := ps.startRemote(); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-ps.disconnected:
+		return i18n.NewError(ps.ctx, msgs.MsgPluginRemoteDisconnect, ps.def.Plugin.Name)
+	case <-ps.ctx.Done():
+		return ps.ctx.Err()
+	}
+}
+
+func (ps *pluginSupervisor) startRemote() error {
+	log.L(ps.ctx).Infof("Requesting start of %s %s [%s] at %s", ps.def.Plugin.PluginType, ps.def.Plugin.Name, ps.def.Plugin.Id, ps.def.LibLocation)
+	conn, err := grpc.NewClient(ps.def.LibLocation, ps.pm.remoteDialOpts...)
+	if err == nil {
+		defer func() { _ = conn.Close() }()
+		_, err = prototk.NewPluginRunnerClient(conn).StartPlugin(ps.ctx, &prototk.StartPluginRequest{
+			Plugin:     ps.def.Plugin,
+			GrpcTarget: ps.pm.GRPCTargetURL(),
+		})
+	}
+	if err != nil {
+		return i18n.WrapError(ps.ctx, err, msgs.MsgPluginRemoteStart, ps.def.Plugin.Name, ps.def.LibLocation)
+	}
+	return nil
+}
+
+// Called with the plugin manager mutex held
+func (ps *pluginSupervisor) connectedLocked() {
+	ps.connections++
+}
+
+// Called with the plugin manager mutex held
+func (ps *pluginSupervisor) initializedLocked() {
+	ps.initializedInRun = true
+}
+
+// Called with the plugin manager mutex held
+func (ps *pluginSupervisor) disconnectedLocked() {
+	ps.connections--
+	if ps.connections == 0 {
+		select {
+		case ps.disconnected <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var testRestartConfig = &pldconf.PluginManagerConfig{
+	Restart: pldconf.RetryConfig{
+		InitialDelay: confutil.P("1ms"),
+		MaxDelay:     confutil.P("1ms"),
+	},
+}
+
+func testPluginScript(t *testing.T, script string) string {
+	scriptFile := path.Join(t.TempDir(), "plugin.sh")
+	err := os.WriteFile(scriptFile, []byte("#!/bin/sh\n"+script+"\n"), 0755)
+	require.NoError(t, err)
+	return scriptFile
+}
+
+func waitForPluginStatus(t *testing.T, pc *pluginManager, check func(status *pldapi.PluginStatus) bool) *pldapi.PluginStatus {
+	for {
+		plugins := pc.listPlugins()
+		require.Len(t, plugins, 1)
+		if check(plugins[0]) {
+			return plugins[0]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSupervisedProcessRestartsOnExit(t *testing.T) {
+	script := testPluginScript(t, "exit 3")
+	pc := newTestPluginManagerWithConfig(t, testRestartConfig, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeProcess),
+		Library: script,
+	})
+
+	status := waitForPluginStatus(t, pc, func(status *pldapi.PluginStatus) bool {
+		return status.Restarts >= 2
+	})
+	assert.Equal(t, "domain1", status.Name)
+	assert.Equal(t, "DOMAIN", status.PluginType)
+	assert.Equal(t, "process", status.LibraryType)
+	assert.Equal(t, script, status.Library)
+	assert.False(t, status.Initialized)
+	assert.Regexp(t, "PD011214.*domain1.*exit status 3", status.LastError)
+	assert.NotNil(t, status.LastErrorTime)
+}
+
+func TestSupervisedProcessArgsAndStop(t *testing.T) {
+	argsFile := path.Join(t.TempDir(), "args.txt")
+	script := testPluginScript(t, `echo "$@" > `+argsFile+"\nexec sleep 10")
+	pc := newTestPluginManagerWithConfig(t, testRestartConfig, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeProcess),
+		Library: script,
+	})
+
+	status := waitForPluginStatus(t, pc, func(status *pldapi.PluginStatus) bool {
+		return status.PID != 0
+	})
+	assert.Zero(t, status.Restarts)
+
+	var args []byte
+	for len(args) == 0 {
+		time.Sleep(5 * time.Millisecond)
+		args, _ = os.ReadFile(argsFile)
+	}
+	assert.Equal(t, []string{
+		"-grpc-target", pc.GRPCTargetURL(),
+		"-plugin-id", status.ID.String(),
+	}, strings.Fields(string(args)))
+
+	// Supervised plugins are never sent to the loader
+	_, notInitializing := unloadedPlugins(pc, pc.domainPlugins, prototk.PluginInfo_DOMAIN, true)
+	assert.Empty(t, notInitializing)
+
+	// Stopping the supervisor terminates the process
+	pc.domainPlugins[status.ID].supervisor.stop()
+	assert.Zero(t, pc.listPlugins()[0].PID)
+}
+
+func TestSupervisedProcessStartFail(t *testing.T) {
+	pc := newTestPluginManagerWithConfig(t, testRestartConfig, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeProcess),
+		Library: path.Join(t.TempDir(), "missing"),
+	})
+
+	err := pc.WaitForInit(context.Background())
+	assert.Regexp(t, "PD011207.*PD011213.*missing.*domain1", err)
+}
+
+type testPluginRunner struct {
+	prototk.UnimplementedPluginRunnerServer
+	requests       chan *prototk.StartPluginRequest
+	authorizations chan []string
+}
+
+func (tr *testPluginRunner) StartPlugin(ctx context.Context, req *prototk.StartPluginRequest) (*prototk.EmptyResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	select {
+	case tr.authorizations <- md.Get("authorization"):
+	default:
+	}
+	tr.requests <- req
+	return &prototk.EmptyResponse{}, nil
+}
+
+func newTestPluginRunner(t *testing.T) (*testPluginRunner, string) {
+	tr := &testPluginRunner{
+		requests:       make(chan *prototk.StartPluginRequest, 10),
+		authorizations: make(chan []string, 10),
+	}
+	udsTarget := tempUDS(t)
+	listener, err := net.Listen("unix", strings.TrimPrefix(udsTarget, "unix:"))
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	prototk.RegisterPluginRunnerServer(server, tr)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return tr, udsTarget
+}
+
+func TestSupervisedRemoteRestartsOnDisconnect(t *testing.T) {
+	tr, runnerTarget := newTestPluginRunner(t)
+	pc := newTestPluginManagerWithConfig(t, testRestartConfig, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeRemote),
+		Library: runnerTarget,
+	})
+
+	req := <-tr.requests
+	assert.Equal(t, "domain1", req.Plugin.Name)
+	assert.Equal(t, pc.GRPCTargetURL(), req.GrpcTarget)
+
+	// Simulate the plugin connecting back, and initializing
+	p := pc.domainPlugins[uuid.MustParse(req.Plugin.Id)]
+	p.notifyRegistered()
+	p.notifyInitialized()
+	require.NoError(t, pc.WaitForInit(context.Background()))
+	status := pc.listPlugins()[0]
+	assert.True(t, status.Initialized)
+	assert.Equal(t, "remote", status.LibraryType)
+	assert.Zero(t, status.Restarts)
+
+	// When it disconnects, we ask for it to be started again
+	p.notifyStopped()
+	req = <-tr.requests
+	assert.Equal(t, p.id.String(), req.Plugin.Id)
+	status = pc.listPlugins()[0]
+	assert.False(t, status.Initialized)
+	assert.Equal(t, 1, status.Restarts)
+	assert.Regexp(t, "PD011216.*domain1", status.LastError)
+}
+
+func TestSupervisedRemoteAuthToken(t *testing.T) {
+	tokenFile := path.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+
+	tr, runnerTarget := newTestPluginRunner(t)
+	newTestPluginManagerWithConfig(t, &pldconf.PluginManagerConfig{
+		Restart:       testRestartConfig.Restart,
+		RemotePlugins: pldconf.RemotePluginsConfig{AuthTokenFile: tokenFile},
+	}, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeRemote),
+		Library: runnerTarget,
+	})
+
+	assert.Equal(t, []string{"Bearer secret"}, <-tr.authorizations)
+	<-tr.requests
+}
+
+func TestInitPluginManagerBadRemoteAuthTokenFile(t *testing.T) {
+	pc := NewPluginManager(context.Background(), tempUDS(t), uuid.New(), &pldconf.PluginManagerConfig{
+		RemotePlugins: pldconf.RemotePluginsConfig{AuthTokenFile: path.Join(t.TempDir(), "missing")},
+	})
+	err := pc.PostInit((&testManagers{}).componentMocks(t))
+	assert.Regexp(t, "PD020306", err)
+}
+
+func TestInitPluginManagerBadRemoteTLS(t *testing.T) {
+	pc := NewPluginManager(context.Background(), tempUDS(t), uuid.New(), &pldconf.PluginManagerConfig{
+		RemotePlugins: pldconf.RemotePluginsConfig{TLS: pldconf.TLSConfig{
+			Enabled: true,
+			CAFile:  path.Join(t.TempDir(), "missing"),
+		}},
+	})
+	err := pc.PostInit((&testManagers{}).componentMocks(t))
+	assert.Regexp(t, "PD020401", err)
+}
+
+func TestSupervisedRemoteAlreadyConnected(t *testing.T) {
+	tr, runnerTarget := newTestPluginRunner(t)
+	pc := newTestPluginManagerWithConfig(t, testRestartConfig, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeRemote),
+		Library: runnerTarget,
+	})
+
+	req := <-tr.requests
+	p := pc.domainPlugins[uuid.MustParse(req.Plugin.Id)]
+
+	// Two connections overlap, such as when a plugin reconnects before we notice the old stream fail
+	p.notifyRegistered()
+	p.notifyRegistered()
+	p.notifyStopped()
+	select {
+	case <-tr.requests:
+		assert.Fail(t, "unexpected start request while still connected")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	p.notifyStopped()
+	<-tr.requests
+}
+
+func TestSupervisedRemoteStartFail(t *testing.T) {
+	pc := newTestPluginManagerWithConfig(t, testRestartConfig, &pldconf.PluginConfig{
+		Type:    string(pldtypes.LibraryTypeRemote),
+		Library: "unix:" + path.Join(t.TempDir(), "missing.sock"),
+	})
+
+	status := waitForPluginStatus(t, pc, func(status *pldapi.PluginStatus) bool {
+		return status.Restarts >= 2
+	})
+	assert.Regexp(t, "PD011215.*domain1", status.LastError)
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type testKeyManager struct {
+	signingModules          map[string]plugintk.Plugin
+	signingModuleRegistered func(name string, id uuid.UUID, toSigningModule components.KeyManagerToSigningModule) (fromSigningModule plugintk.SigningModuleCallbacks, err error)
+}
+
+func signingModuleConnectFactory(ctx context.Context, client prototk.PluginControllerClient) (grpc.BidiStreamingClient[prototk.SigningModuleMessage, prototk.SigningModuleMessage], error) {
+	return client.ConnectSigningModule(context.Background())
+}
+
+func signingModuleHeaderAccessor(msg *prototk.SigningModuleMessage) *prototk.Header {
+	if msg.Header == nil {
+		msg.Header = &prototk.Header{}
+	}
+	return msg.Header
+}
+
+func (tp *testKeyManager) mock(t *testing.T) *componentmocks.KeyManager {
+	mkm := componentmocks.NewKeyManager(t)
+	pluginMap := make(map[string]*pldconf.PluginConfig)
+	for name := range tp.signingModules {
+		pluginMap[name] = &pldconf.PluginConfig{
+			Type:    string(pldtypes.LibraryTypeCShared),
+			Library: "/tmp/not/applicable",
+		}
+	}
+	mkm.On("ConfiguredSigningModules").Return(pluginMap).Maybe()
+	msr := mkm.On("SigningModuleRegistered", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	msr.Run(func(args mock.Arguments) {
+		m2p, err := tp.signingModuleRegistered(args[0].(string), args[1].(uuid.UUID), args[2].(components.KeyManagerToSigningModule))
+		msr.Return(m2p, err)
+	})
+	return mkm
+}
+
+func newTestSigningModulePluginManager(t *testing.T, setup *testManagers) (context.Context, *pluginManager, func()) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	pc := newTestPluginManager(t, setup)
+
+	tpl, err := NewUnitTestPluginLoader(pc.GRPCTargetURL(), pc.loaderID.String(), setup.allPlugins())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tpl.Run()
+	}()
+
+	return ctx, pc, func() {
+		recovered := recover()
+		if recovered != nil {
+			fmt.Fprintf(os.Stderr, "%v: %s", recovered, debug.Stack())
+			panic(recovered)
+		}
+		cancelCtx()
+		pc.Stop()
+		tpl.Stop()
+		<-done
+	}
+
+}
+
+func TestSigningModuleRequestsOK(t *testing.T) {
+
+	waitForAPI := make(chan components.KeyManagerToSigningModule, 1)
+
+	signingModuleFunctions := &plugintk.SigningModuleAPIFunctions{
+		ConfigureSigningModule: func(ctx context.Context, csr *prototk.ConfigureSigningModuleRequest) (*prototk.ConfigureSigningModuleResponse, error) {
+			assert.Equal(t, "wallet1", csr.Name)
+			return &prototk.ConfigureSigningModuleResponse{}, nil
+		},
+		ResolveKey: func(ctx context.Context, rkr *prototk.ResolveKeyRequest) (*prototk.ResolveKeyResponse, error) {
+			assert.Equal(t, "key1", rkr.Name)
+			return &prototk.ResolveKeyResponse{KeyHandle: "handle1"}, nil
+		},
+		Sign: func(ctx context.Context, swr *prototk.SignWithKeyRequest) (*prototk.SignWithKeyResponse, error) {
+			assert.Equal(t, "handle1", swr.KeyHandle)
+			return &prototk.SignWithKeyResponse{Payload: []byte("signed")}, nil
+		},
+		ListKeys: func(ctx context.Context, lkr *prototk.ListKeysRequest) (*prototk.ListKeysResponse, error) {
+			assert.Equal(t, int32(10), lkr.Limit)
+			return &prototk.ListKeysResponse{Items: []*prototk.ListKeyEntry{{KeyHandle: "handle1"}}}, nil
+		},
+	}
+
+	tkm := &testKeyManager{
+		signingModules: map[string]plugintk.Plugin{
+			"wallet1": plugintk.NewSigningModule(func(callbacks plugintk.SigningModuleCallbacks) plugintk.SigningModuleAPI {
+				return &plugintk.SigningModuleAPIBase{Functions: signingModuleFunctions}
+			}),
+		},
+	}
+	tkm.signingModuleRegistered = func(name string, id uuid.UUID, toSigningModule components.KeyManagerToSigningModule) (plugintk.SigningModuleCallbacks, error) {
+		assert.Equal(t, "wallet1", name)
+		waitForAPI <- toSigningModule
+		return struct{}{}, nil
+	}
+
+	ctx, pc, done := newTestSigningModulePluginManager(t, &testManagers{
+		testKeyManager: tkm,
+	})
+	defer done()
+
+	signingModuleAPI := <-waitForAPI
+
+	_, err := signingModuleAPI.ConfigureSigningModule(ctx, &prototk.ConfigureSigningModuleRequest{
+		Name: "wallet1",
+	})
+	require.NoError(t, err)
+
+	rkr, err := signingModuleAPI.ResolveKey(ctx, &prototk.ResolveKeyRequest{
+		Name: "key1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "handle1", rkr.KeyHandle)
+
+	swr, err := signingModuleAPI.Sign(ctx, &prototk.SignWithKeyRequest{
+		KeyHandle: "handle1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signed"), swr.Payload)
+
+	lkr, err := signingModuleAPI.ListKeys(ctx, &prototk.ListKeysRequest{
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "handle1", lkr.Items[0].KeyHandle)
+
+	// This is the point the key manager would call us to say the signing module is initialized
+	// (once it has accepted the wallet configuration)
+	signingModuleAPI.Initialized()
+	require.NoError(t, pc.WaitForInit(ctx))
+
+}
+
+func TestSigningModuleRegisterFail(t *testing.T) {
+
+	waitForError := make(chan error, 1)
+
+	tkm := &testKeyManager{
+		signingModules: map[string]plugintk.Plugin{
+			"wallet1": &mockPlugin[prototk.SigningModuleMessage]{
+				t:              t,
+				connectFactory: signingModuleConnectFactory,
+				headerAccessor: signingModuleHeaderAccessor,
+				preRegister: func(signingModuleID string) *prototk.SigningModuleMessage {
+					return &prototk.SigningModuleMessage{
+						Header: &prototk.Header{
+							MessageType: prototk.Header_REGISTER,
+							PluginId:    signingModuleID,
+							MessageId:   uuid.NewString(),
+						},
+					}
+				},
+				expectClose: func(err error) {
+					waitForError <- err
+				},
+			},
+		},
+	}
+	tkm.signingModuleRegistered = func(name string, id uuid.UUID, toSigningModule components.KeyManagerToSigningModule) (plugintk.SigningModuleCallbacks, error) {
+		return nil, fmt.Errorf("pop")
+	}
+
+	_, _, done := newTestSigningModulePluginManager(t, &testManagers{
+		testKeyManager: tkm,
+	})
+	defer done()
+
+	assert.Regexp(t, "pop", <-waitForError)
+}
+
+func TestFromSigningModuleRequestBadReq(t *testing.T) {
+
+	waitForResponse := make(chan struct{}, 1)
+
+	msgID := uuid.NewString()
+	tkm := &testKeyManager{
+		signingModules: map[string]plugintk.Plugin{
+			"wallet1": &mockPlugin[prototk.SigningModuleMessage]{
+				t:              t,
+				connectFactory: signingModuleConnectFactory,
+				headerAccessor: signingModuleHeaderAccessor,
+				sendRequest: func(pluginID string) *prototk.SigningModuleMessage {
+					return &prototk.SigningModuleMessage{
+						Header: &prototk.Header{
+							PluginId:    pluginID,
+							MessageId:   msgID,
+							MessageType: prototk.Header_REQUEST_FROM_PLUGIN,
+						},
+					}
+				},
+				handleResponse: func(dm *prototk.SigningModuleMessage) {
+					assert.Equal(t, msgID, *dm.Header.CorrelationId)
+					assert.Regexp(t, "PD011203", *dm.Header.ErrorMessage)
+					close(waitForResponse)
+				},
+			},
+		},
+	}
+	tkm.signingModuleRegistered = func(name string, id uuid.UUID, toSigningModule components.KeyManagerToSigningModule) (fromSigningModule plugintk.SigningModuleCallbacks, err error) {
+		return struct{}{}, nil
+	}
+
+	_, _, done := newTestSigningModulePluginManager(t, &testManagers{
+		testKeyManager: tkm,
+	})
+	defer done()
+
+	<-waitForResponse
+
+}
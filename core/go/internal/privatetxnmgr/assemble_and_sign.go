@@ -18,6 +18,7 @@ package privatetxnmgr
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
@@ -26,6 +27,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 )
 
 // assemble a transaction that we are not coordinating, using the provided state locks
@@ -87,7 +89,10 @@ func (s *Sequencer) resolveLocalTransaction(ctx context.Context, transactionID u
 	return locallyResolvedTx, err
 }
 
-func (s *Sequencer) assembleAndSign(ctx context.Context, transactionID uuid.UUID, preAssembly *components.TransactionPreAssembly, domainContext components.DomainContext) (*components.TransactionPostAssembly, error) {
+func (s *Sequencer) assembleAndSign(ctx context.Context, transactionID uuid.UUID, preAssembly *components.TransactionPreAssembly, domainContext components.DomainContext) (_ *components.TransactionPostAssembly, err error) {
+	defer func(startTime time.Time) { s.metrics.recordAssemble(startTime, err) }(time.Now())
+	ctx, span := tracing.StartTransactionSpan(ctx, "privatetx assemble", transactionID)
+	defer func() { tracing.EndSpan(span, err) }()
 	//Assembles the transaction and synchronously fulfills any local signature attestation requests
 	// Given that the coordinator is single threading calls to assemble, there may be benefits to performance if we were to fulfill the signature request async
 	// but that would introduce levels of complexity that may not be justified so this is open as a potential for future optimization where we would need to think about
@@ -0,0 +1,209 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package privatetxnmgr
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
+)
+
+// For contracts where the coordinator is chosen from the set of endorsers, every node that has
+// a sequencer loaded for the contract heartbeats to the other candidate nodes (and to any node that
+// has delegated a transaction to it) over the transport manager. Heartbeats are only accepted from
+// the candidate nodes, which are the endorser nodes of the contract that we know of.
+//
+// A node that a transaction has been delegated to, and that has not heartbeated for
+// heartbeatInterval*heartbeatFailureThreshold, is marked as failed. The coordinator selector
+// then skips over it to the next candidate in the deterministic order that all nodes agree on,
+// until a heartbeat is received from that node again.
+type coordinatorLiveness struct {
+	mux               sync.Mutex
+	clock             ptmgrtypes.Clock
+	localNode         string
+	heartbeatInterval time.Duration
+	failureTimeout    time.Duration
+	peers             map[string]*peerLiveness
+	candidates        map[string]bool
+}
+
+type peerLiveness struct {
+	lastHeartbeat time.Time
+	failedTime    time.Time
+	failureReason string
+}
+
+func newCoordinatorLiveness(localNode string, sequencerConfig *pldconf.PrivateTxManagerSequencerConfig) *coordinatorLiveness {
+	heartbeatInterval := confutil.DurationMin(sequencerConfig.HeartbeatInterval, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.HeartbeatInterval)
+	failureThreshold := confutil.IntMin(sequencerConfig.HeartbeatFailureThreshold, 1, *pldconf.PrivateTxManagerDefaults.Sequencer.HeartbeatFailureThreshold)
+	return &coordinatorLiveness{
+		clock:             ptmgrtypes.RealClock(),
+		localNode:         localNode,
+		heartbeatInterval: heartbeatInterval,
+		failureTimeout:    heartbeatInterval * time.Duration(failureThreshold),
+		peers:             make(map[string]*peerLiveness),
+		candidates:        make(map[string]bool),
+	}
+}
+
+// addCandidates registers the endorser nodes of the contract, which are the candidate coordinators
+// that we accept heartbeats from (as well as heartbeating to them)
+func (cl *coordinatorLiveness) addCandidates(nodes ...string) {
+	if cl == nil {
+		return
+	}
+	cl.addPeers(nodes...)
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	for _, node := range nodes {
+		if node != cl.localNode {
+			cl.candidates[node] = true
+		}
+	}
+}
+
+// addPeers registers nodes that we heartbeat to, and whose liveness we track. Nodes that have delegated
+// to us are peers, but are not candidates unless they are also endorsers.
+func (cl *coordinatorLiveness) addPeers(nodes ...string) {
+	if cl == nil {
+		return
+	}
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	for _, node := range nodes {
+		if node != cl.localNode && cl.peers[node] == nil {
+			cl.peers[node] = &peerLiveness{}
+		}
+	}
+}
+
+func (cl *coordinatorLiveness) peerNodes() []string {
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	nodes := make([]string, 0, len(cl.peers))
+	for node := range cl.peers {
+		nodes = append(nodes, node)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+// heartbeatReceived records a heartbeat from a candidate node, returning true if the node was previously
+// marked as failed. Heartbeats from any other node are not accepted.
+func (cl *coordinatorLiveness) heartbeatReceived(ctx context.Context, node string) (accepted, recovered bool) {
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	peer := cl.peers[node]
+	if !cl.candidates[node] || peer == nil {
+		return false, false
+	}
+	peer.lastHeartbeat = cl.clock.Now()
+	if !peer.failedTime.IsZero() {
+		log.L(ctx).Infof("Coordinator node %s is available again after failure at %s", node, peer.failedTime)
+		peer.failedTime = time.Time{}
+		peer.failureReason = ""
+		return true, true
+	}
+	return true, false
+}
+
+func (cl *coordinatorLiveness) isAvailable(node string) bool {
+	if cl == nil || node == cl.localNode {
+		return true
+	}
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	peer := cl.peers[node]
+	return peer == nil || peer.failedTime.IsZero()
+}
+
+// checkDelegate is called for each node that we have delegated a transaction to. If no heartbeat has been
+// received within the failure timeout (of the later of the delegation and the last heartbeat) then the node
+// is marked as failed, and the reason is returned.
+func (cl *coordinatorLiveness) checkDelegate(ctx context.Context, node string, delegationTime time.Time) (failed bool, reason string) {
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	peer := cl.peers[node]
+	if peer == nil {
+		peer = &peerLiveness{}
+		cl.peers[node] = peer
+	}
+	if !peer.failedTime.IsZero() {
+		return true, peer.failureReason
+	}
+	lastSeen := peer.lastHeartbeat
+	if delegationTime.After(lastSeen) {
+		lastSeen = delegationTime
+	}
+	now := cl.clock.Now()
+	if now.Sub(lastSeen) <= cl.failureTimeout {
+		return false, ""
+	}
+	peer.failedTime = now
+	peer.failureReason = i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxMgrCoordinatorHeartbeatTimeout), node, now.Sub(lastSeen).Round(time.Millisecond))
+	log.L(ctx).Warnf("Coordinator node %s marked as failed: %s", node, peer.failureReason)
+	return true, peer.failureReason
+}
+
+// selectCandidate returns the first available node, starting at the preferred index and wrapping
+// around the ordered list of candidates. If every candidate has failed, the preferred node is returned.
+func (cl *coordinatorLiveness) selectCandidate(ctx context.Context, candidateNodes []string, preferredIndex int) string {
+	for i := range candidateNodes {
+		node := candidateNodes[(preferredIndex+i)%len(candidateNodes)]
+		if cl.isAvailable(node) {
+			if i > 0 {
+				log.L(ctx).Infof("SelectCoordinatorNode: failing over from %s to %s", candidateNodes[preferredIndex], node)
+			}
+			return node
+		}
+	}
+	log.L(ctx).Warnf("SelectCoordinatorNode: all candidate coordinators have failed, using preferred node %s", candidateNodes[preferredIndex])
+	return candidateNodes[preferredIndex]
+}
+
+func (cl *coordinatorLiveness) status() []components.PrivateTxCoordinatorStatus {
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	statuses := make([]components.PrivateTxCoordinatorStatus, 0, len(cl.peers))
+	for node, peer := range cl.peers {
+		status := components.PrivateTxCoordinatorStatus{
+			Node:          node,
+			Available:     peer.failedTime.IsZero(),
+			FailureReason: peer.failureReason,
+		}
+		if !peer.lastHeartbeat.IsZero() {
+			status.LastHeartbeat = peer.lastHeartbeat.Format(time.RFC3339Nano)
+		}
+		if !peer.failedTime.IsZero() {
+			status.FailedTime = peer.failedTime.Format(time.RFC3339Nano)
+		}
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b components.PrivateTxCoordinatorStatus) int {
+		return strings.Compare(a.Node, b.Node)
+	})
+	return statuses
+}
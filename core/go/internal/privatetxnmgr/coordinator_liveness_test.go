@@ -0,0 +1,229 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package privatetxnmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSequencerEnvironment struct {
+	blockHeight int64
+}
+
+func (e *testSequencerEnvironment) GetBlockHeight() int64 {
+	return e.blockHeight
+}
+
+func newTestCoordinatorLiveness() (*coordinatorLiveness, *fakeClock) {
+	cl := newCoordinatorLiveness("node1", &pldconf.PrivateTxManagerSequencerConfig{
+		HeartbeatInterval:         confutil.P("1s"),
+		HeartbeatFailureThreshold: confutil.P(3),
+	})
+	clock := &fakeClock{}
+	cl.clock = clock
+	return cl, clock
+}
+
+func endorsedTestTransaction(parties ...string) *components.PrivateTransaction {
+	return &components.PrivateTransaction{
+		PostAssembly: &components.TransactionPostAssembly{
+			AttestationPlan: []*prototk.AttestationRequest{{
+				Name:            "endorse",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Parties:         parties,
+			}},
+		},
+	}
+}
+
+func TestCoordinatorLivenessDefaults(t *testing.T) {
+	cl := newCoordinatorLiveness("node1", &pldconf.PrivateTxManagerSequencerConfig{})
+	assert.Equal(t, 5*time.Second, cl.heartbeatInterval)
+	assert.Equal(t, 15*time.Second, cl.failureTimeout)
+}
+
+func TestCoordinatorLivenessFailAndRecover(t *testing.T) {
+	ctx := context.Background()
+	cl, clock := newTestCoordinatorLiveness()
+
+	cl.addCandidates("node1", "node2", "node3")
+	assert.Equal(t, []string{"node2", "node3"}, cl.peerNodes())
+	assert.True(t, cl.isAvailable("node1"))
+	assert.True(t, cl.isAvailable("node2"))
+	assert.True(t, cl.isAvailable("unknown"))
+
+	// Within the failure timeout of the delegation
+	delegationTime := time.Now()
+	failed, _ := cl.checkDelegate(ctx, "node2", delegationTime)
+	assert.False(t, failed)
+
+	// Heartbeats keep the node alive past the original delegation time
+	clock.timePassed = 2 * time.Second
+	accepted, recovered := cl.heartbeatReceived(ctx, "node2")
+	assert.True(t, accepted)
+	assert.False(t, recovered)
+	clock.timePassed = 4 * time.Second
+	failed, _ = cl.checkDelegate(ctx, "node2", delegationTime)
+	assert.False(t, failed)
+
+	// Until they stop
+	clock.timePassed = 6 * time.Second
+	failed, reason := cl.checkDelegate(ctx, "node2", delegationTime)
+	assert.True(t, failed)
+	assert.Regexp(t, "PD011840.*node2", reason)
+	assert.False(t, cl.isAvailable("node2"))
+	failed, _ = cl.checkDelegate(ctx, "node2", time.Now().Add(clock.timePassed))
+	assert.True(t, failed)
+
+	status := cl.status()
+	require.Len(t, status, 2)
+	assert.Equal(t, "node2", status[0].Node)
+	assert.False(t, status[0].Available)
+	assert.NotEmpty(t, status[0].LastHeartbeat)
+	assert.NotEmpty(t, status[0].FailedTime)
+	assert.Regexp(t, "PD011840", status[0].FailureReason)
+	assert.Equal(t, "node3", status[1].Node)
+	assert.True(t, status[1].Available)
+	assert.Empty(t, status[1].LastHeartbeat)
+
+	// A heartbeat brings it back
+	accepted, recovered = cl.heartbeatReceived(ctx, "node2")
+	assert.True(t, accepted)
+	assert.True(t, recovered)
+	assert.True(t, cl.isAvailable("node2"))
+
+	// Heartbeats from nodes that are not endorsers are not accepted, and we do not heartbeat back to them
+	accepted, _ = cl.heartbeatReceived(ctx, "node4")
+	assert.False(t, accepted)
+	assert.Equal(t, []string{"node2", "node3"}, cl.peerNodes())
+
+	// Including nodes that have delegated to us
+	cl.addPeers("node5")
+	accepted, _ = cl.heartbeatReceived(ctx, "node5")
+	assert.False(t, accepted)
+	assert.Equal(t, []string{"node2", "node3", "node5"}, cl.peerNodes())
+
+	// Heartbeats from ourselves are not accepted either
+	accepted, _ = cl.heartbeatReceived(ctx, "node1")
+	assert.False(t, accepted)
+}
+
+func TestCoordinatorLivenessCheckUnknownNode(t *testing.T) {
+	ctx := context.Background()
+	cl, clock := newTestCoordinatorLiveness()
+
+	delegationTime := time.Now()
+	clock.timePassed = 10 * time.Second
+	failed, _ := cl.checkDelegate(ctx, "node2", delegationTime)
+	assert.True(t, failed)
+	assert.Equal(t, []string{"node2"}, cl.peerNodes())
+}
+
+func TestCoordinatorLivenessSelectCandidate(t *testing.T) {
+	ctx := context.Background()
+	cl, clock := newTestCoordinatorLiveness()
+	candidates := []string{"node1", "node2", "node3"}
+
+	assert.Equal(t, "node2", cl.selectCandidate(ctx, candidates, 1))
+
+	clock.timePassed = 10 * time.Second
+	failed, _ := cl.checkDelegate(ctx, "node2", time.Now())
+	require.True(t, failed)
+	assert.Equal(t, "node3", cl.selectCandidate(ctx, candidates, 1))
+
+	failed, _ = cl.checkDelegate(ctx, "node3", time.Now())
+	require.True(t, failed)
+	assert.Equal(t, "node1", cl.selectCandidate(ctx, candidates, 1)) // wraps, and the local node is always available
+
+	// If all remote candidates have failed, we stay with the preferred node
+	assert.Equal(t, "node2", cl.selectCandidate(ctx, []string{"node2", "node3"}, 0))
+
+	// No liveness tracking
+	var noLiveness *coordinatorLiveness
+	noLiveness.addPeers("node2")
+	noLiveness.addCandidates("node2")
+	assert.Equal(t, "node2", noLiveness.selectCandidate(ctx, candidates, 1))
+}
+
+func TestHashedSelectionFailover(t *testing.T) {
+	ctx := context.Background()
+	cl, clock := newTestCoordinatorLiveness()
+
+	selector := &endorsementSetHashSelection{
+		localNode: "node1",
+		liveness:  cl,
+	}
+	env := &testSequencerEnvironment{blockHeight: 100}
+
+	// Before assembly, we coordinate locally
+	_, node, err := selector.SelectCoordinatorNode(ctx, &components.PrivateTransaction{}, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node1", node)
+
+	tx := endorsedTestTransaction("alice@node1", "bob@node2", "carol@node3")
+	_, node, err = selector.SelectCoordinatorNode(ctx, tx, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node3", node)
+	assert.Equal(t, []string{"node2", "node3"}, cl.peerNodes())
+
+	// Fails over to the next candidate, wrapping around
+	clock.timePassed = 10 * time.Second
+	failed, _ := cl.checkDelegate(ctx, "node3", time.Now())
+	require.True(t, failed)
+	_, node, err = selector.SelectCoordinatorNode(ctx, tx, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node1", node)
+
+	// Recovers back to the preferred node
+	accepted, recovered := cl.heartbeatReceived(ctx, "node3")
+	assert.True(t, accepted)
+	assert.True(t, recovered)
+	_, node, err = selector.SelectCoordinatorNode(ctx, tx, env)
+	require.NoError(t, err)
+	assert.Equal(t, "node3", node)
+}
+
+func TestRoundRobinSelectionFailover(t *testing.T) {
+	ctx := context.Background()
+	cl, clock := newTestCoordinatorLiveness()
+
+	selector := &roundRobinCoordinatorSelectorPolicy{
+		localNode: "node1",
+		rangeSize: 10,
+		liveness:  cl,
+	}
+	tx := endorsedTestTransaction("alice@node1", "bob@node2", "carol@node3")
+
+	_, node, err := selector.SelectCoordinatorNode(ctx, tx, &testSequencerEnvironment{blockHeight: 15})
+	require.NoError(t, err)
+	assert.Equal(t, "node2", node)
+
+	clock.timePassed = 10 * time.Second
+	failed, _ := cl.checkDelegate(ctx, "node2", time.Now())
+	require.True(t, failed)
+	_, node, err = selector.SelectCoordinatorNode(ctx, tx, &testSequencerEnvironment{blockHeight: 15})
+	require.NoError(t, err)
+	assert.Equal(t, "node3", node)
+}
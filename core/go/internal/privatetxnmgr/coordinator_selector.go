@@ -46,6 +46,10 @@ import (
 // 1+1 - core option set for Noto
 // 2+1 - core option set for Pente
 // 3+2 - core option set for Zeto
+//
+// For endorser coordinated contracts, the candidate nodes are placed in an order that every node
+// agrees on, and the first candidate in that order that has not failed to heartbeat is selected.
+// So if the preferred coordinator fails, transactions fail over to the next candidate.
 
 type CoordinatorSelectionMode int
 
@@ -57,7 +61,7 @@ const (
 // Override only intended for unit tests currently
 var EndorsementCoordinatorSelectionMode CoordinatorSelectionMode = HashedSelection
 
-func NewCoordinatorSelector(ctx context.Context, nodeName string, contractConfig *prototk.ContractConfig, sequencerConfig pldconf.PrivateTxManagerSequencerConfig) (ptmgrtypes.CoordinatorSelector, error) {
+func NewCoordinatorSelector(ctx context.Context, nodeName string, contractConfig *prototk.ContractConfig, sequencerConfig pldconf.PrivateTxManagerSequencerConfig, liveness *coordinatorLiveness) (ptmgrtypes.CoordinatorSelector, error) {
 	if contractConfig.GetCoordinatorSelection() == prototk.ContractConfig_COORDINATOR_SENDER {
 		return &staticCoordinatorSelectorPolicy{
 			nodeName: nodeName,
@@ -82,11 +86,13 @@ func NewCoordinatorSelector(ctx context.Context, nodeName string, contractConfig
 			return &roundRobinCoordinatorSelectorPolicy{
 				localNode: nodeName,
 				rangeSize: confutil.Int(sequencerConfig.RoundRobinCoordinatorBlockRangeSize, *pldconf.PrivateTxManagerDefaults.Sequencer.RoundRobinCoordinatorBlockRangeSize),
+				liveness:  liveness,
 			}, nil
 		}
-		// TODO: More work is required to perform leader election of an endorser, so right now a simple hash algorithm is used.
+		// A hash of the endorser identities chooses the preferred coordinator, with the other endorsing nodes following in order
 		return &endorsementSetHashSelection{
 			localNode: nodeName,
+			liveness:  liveness,
 		}, nil
 	}
 	return nil, i18n.NewError(ctx, msgs.MsgDomainInvalidCoordinatorSelection, contractConfig.GetCoordinatorSelection())
@@ -97,8 +103,10 @@ type staticCoordinatorSelectorPolicy struct {
 }
 
 type endorsementSetHashSelection struct {
-	localNode  string
-	chosenNode string
+	localNode      string
+	liveness       *coordinatorLiveness
+	candidateNodes []string
+	preferredIndex int
 }
 
 func (s *staticCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.Context, _ *components.PrivateTransaction, environment ptmgrtypes.SequencerEnvironment) (int64, string, error) {
@@ -108,7 +116,7 @@ func (s *staticCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.Cont
 
 func (s *endorsementSetHashSelection) SelectCoordinatorNode(ctx context.Context, transaction *components.PrivateTransaction, environment ptmgrtypes.SequencerEnvironment) (int64, string, error) {
 	blockHeight := environment.GetBlockHeight()
-	if s.chosenNode == "" {
+	if len(s.candidateNodes) == 0 {
 		if transaction.PostAssembly == nil {
 			//if we don't know the candidate nodes, and the transaction hasn't been assembled yet, then we can't select a coordinator so just assume we are the coordinator
 			// until we get the transaction assembled and then re-evaluate
@@ -147,15 +155,18 @@ func (s *endorsementSetHashSelection) SelectCoordinatorNode(ctx context.Context,
 			h.Write([]byte(identity))
 		}
 		// Use that as an index into the chosen node set
-		s.chosenNode = candidateNodes[int(h.Sum32())%len(candidateNodes)]
+		s.candidateNodes = candidateNodes
+		s.preferredIndex = int(h.Sum32()) % len(candidateNodes)
+		s.liveness.addCandidates(candidateNodes...)
 	}
 
-	return blockHeight, s.chosenNode, nil
+	return blockHeight, s.liveness.selectCandidate(ctx, s.candidateNodes, s.preferredIndex), nil
 
 }
 
 type roundRobinCoordinatorSelectorPolicy struct {
 	localNode      string
+	liveness       *coordinatorLiveness
 	candidateNodes []string
 	rangeSize      int
 }
@@ -191,6 +202,7 @@ func (s *roundRobinCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.
 				s.candidateNodes = append(s.candidateNodes, candidateNode)
 			}
 			slices.Sort(s.candidateNodes)
+			s.liveness.addCandidates(s.candidateNodes...)
 		}
 	}
 
@@ -203,7 +215,7 @@ func (s *roundRobinCoordinatorSelectorPolicy) SelectCoordinatorNode(ctx context.
 	rangeIndex := blockHeight / int64(s.rangeSize)
 
 	coordinatorIndex := int(rangeIndex) % len(s.candidateNodes)
-	coordinatorNode := s.candidateNodes[coordinatorIndex]
+	coordinatorNode := s.liveness.selectCandidate(ctx, s.candidateNodes, coordinatorIndex)
 	log.L(ctx).Debugf("SelectCoordinatorNode: selected coordinator node %s using round robin algorithm for blockHeight: %d and rangeSize %d ", coordinatorNode, blockHeight, s.rangeSize)
 
 	return blockHeight, coordinatorNode, nil
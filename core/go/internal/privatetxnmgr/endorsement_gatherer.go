@@ -18,6 +18,7 @@ package privatetxnmgr
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
@@ -28,29 +29,35 @@ import (
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 )
 
-func NewEndorsementGatherer(p persistence.Persistence, psc components.DomainSmartContract, dCtx components.DomainContext, keyMgr components.KeyManager) ptmgrtypes.EndorsementGatherer {
+func NewEndorsementGatherer(p persistence.Persistence, psc components.DomainSmartContract, dCtx components.DomainContext, keyMgr components.KeyManager, metrics *privateTxManagerMetrics) ptmgrtypes.EndorsementGatherer {
 	return &endorsementGatherer{
-		p:      p,
-		psc:    psc,
-		dCtx:   dCtx,
-		keyMgr: keyMgr,
+		p:       p,
+		psc:     psc,
+		dCtx:    dCtx,
+		keyMgr:  keyMgr,
+		metrics: metrics,
 	}
 }
 
 type endorsementGatherer struct {
-	p      persistence.Persistence
-	psc    components.DomainSmartContract
-	dCtx   components.DomainContext
-	keyMgr components.KeyManager
+	p       persistence.Persistence
+	psc     components.DomainSmartContract
+	dCtx    components.DomainContext
+	keyMgr  components.KeyManager
+	metrics *privateTxManagerMetrics
 }
 
 func (e *endorsementGatherer) DomainContext() components.DomainContext {
 	return e.dCtx
 }
 
-func (e *endorsementGatherer) GatherEndorsement(ctx context.Context, transactionSpecification *prototk.TransactionSpecification, verifiers []*prototk.ResolvedVerifier, signatures []*prototk.AttestationResult, inputStates []*prototk.EndorsableState, readStates []*prototk.EndorsableState, outputStates []*prototk.EndorsableState, infoStates []*prototk.EndorsableState, partyName string, endorsementRequest *prototk.AttestationRequest) (*prototk.AttestationResult, *string, error) {
+func (e *endorsementGatherer) GatherEndorsement(ctx context.Context, transactionSpecification *prototk.TransactionSpecification, verifiers []*prototk.ResolvedVerifier, signatures []*prototk.AttestationResult, inputStates []*prototk.EndorsableState, readStates []*prototk.EndorsableState, outputStates []*prototk.EndorsableState, infoStates []*prototk.EndorsableState, partyName string, endorsementRequest *prototk.AttestationRequest) (_ *prototk.AttestationResult, _ *string, err error) {
+	defer func(startTime time.Time) { e.metrics.recordEndorse(startTime, err) }(time.Now())
+	ctx, span := tracing.StartSpan(ctx, "privatetx endorse", tracing.AttrTransactionID.String(transactionSpecification.TransactionId))
+	defer func() { tracing.EndSpan(span, err) }()
 
 	unqualifiedLookup, err := pldtypes.PrivateIdentityLocator(partyName).Identity(ctx)
 	if err != nil {
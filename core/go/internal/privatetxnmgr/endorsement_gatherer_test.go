@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -43,7 +44,7 @@ func TestGatherEndorsementFailResolveKey(t *testing.T) {
 
 	mocks.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "alice", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).Return(nil, fmt.Errorf("test error"))
 
-	eg := NewEndorsementGatherer(mocks.db.P, mocks.domainSmartContract, mocks.domainContext, mocks.keyManager)
+	eg := NewEndorsementGatherer(mocks.db.P, mocks.domainSmartContract, mocks.domainContext, mocks.keyManager, newPrivateTxManagerMetrics(metrics.NewMetricsManager(ctx)))
 	endorsementReq := &prototk.AttestationRequest{
 		Algorithm:    algorithms.ECDSA_SECP256K1,
 		VerifierType: verifiers.ETH_ADDRESS,
@@ -71,7 +72,7 @@ func TestGatherEndorsementFailEndorseTransaction(t *testing.T) {
 			Verifier:           &pldapi.KeyVerifier{Verifier: "something"},
 		}, nil)
 	mocks.domainSmartContract.On("EndorseTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test error"))
-	eg := NewEndorsementGatherer(mocks.db.P, mocks.domainSmartContract, mocks.domainContext, mocks.keyManager)
+	eg := NewEndorsementGatherer(mocks.db.P, mocks.domainSmartContract, mocks.domainContext, mocks.keyManager, newPrivateTxManagerMetrics(metrics.NewMetricsManager(ctx)))
 	_, _, err = eg.GatherEndorsement(ctx, &prototk.TransactionSpecification{}, []*prototk.ResolvedVerifier{}, []*prototk.AttestationResult{}, []*prototk.EndorsableState{}, []*prototk.EndorsableState{}, []*prototk.EndorsableState{}, []*prototk.EndorsableState{}, "alice", endorsementReq)
 	require.ErrorContains(t, err, "PD011801: Unexpected error in engine failed to endorse for party alice")
 }
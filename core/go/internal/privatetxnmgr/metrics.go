@@ -0,0 +1,101 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package privatetxnmgr
+
+import (
+	"time"
+
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsSubsystem = "privatetxmgr"
+
+var (
+	sequencersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "sequencers"),
+		"Number of active sequencers, each of which handles the transactions for one private contract", nil, nil)
+	sequencerInFlightTxsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "inflight_transactions"),
+		"Number of incomplete transactions being processed by the sequencer for each contract", []string{"contract"}, nil)
+	sequencerPendingEventsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "pending_events"),
+		"Number of events queued for processing by the sequencer for each contract", []string{"contract"}, nil)
+)
+
+type privateTxManagerMetrics struct {
+	assembleDuration *prometheus.HistogramVec
+	endorseDuration  *prometheus.HistogramVec
+}
+
+func newPrivateTxManagerMetrics(m metrics.Metrics) *privateTxManagerMetrics {
+	registry := m.Registry()
+	return &privateTxManagerMetrics{
+		assembleDuration: metrics.MustRegister(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "assemble_duration_seconds",
+			Help:      "Duration of assembling and signing private transactions",
+		}, []string{"result"})),
+		endorseDuration: metrics.MustRegister(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "endorse_duration_seconds",
+			Help:      "Duration of gathering endorsements from the local node",
+		}, []string{"result"})),
+	}
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+func (m *privateTxManagerMetrics) recordAssemble(startTime time.Time, err error) {
+	m.assembleDuration.WithLabelValues(resultLabel(err)).Observe(time.Since(startTime).Seconds())
+}
+
+func (m *privateTxManagerMetrics) recordEndorse(startTime time.Time, err error) {
+	m.endorseDuration.WithLabelValues(resultLabel(err)).Observe(time.Since(startTime).Seconds())
+}
+
+// The sequencer state is read at scrape time, as sequencers come and go with the
+// activity on each contract
+type sequencersCollector struct {
+	p *privateTxManager
+}
+
+func (sc *sequencersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sequencersDesc
+	ch <- sequencerInFlightTxsDesc
+	ch <- sequencerPendingEventsDesc
+}
+
+func (sc *sequencersCollector) Collect(ch chan<- prometheus.Metric) {
+	sc.p.sequencersLock.RLock()
+	defer sc.p.sequencersLock.RUnlock()
+
+	ch <- prometheus.MustNewConstMetric(sequencersDesc, prometheus.GaugeValue, float64(len(sc.p.sequencers)))
+	for contractAddr, s := range sc.p.sequencers {
+		s.incompleteTxProcessMapMutex.Lock()
+		inFlight := len(s.incompleteTxSProcessMap)
+		s.incompleteTxProcessMapMutex.Unlock()
+		ch <- prometheus.MustNewConstMetric(sequencerInFlightTxsDesc, prometheus.GaugeValue, float64(inFlight), contractAddr)
+		ch <- prometheus.MustNewConstMetric(sequencerPendingEventsDesc, prometheus.GaugeValue, float64(len(s.pendingTransactionEvents)), contractAddr)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package privatetxnmgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateTxManagerMetrics(t *testing.T) {
+	m := newPrivateTxManagerMetrics(metrics.NewMetricsManager(context.Background()))
+
+	m.recordAssemble(time.Now(), nil)
+	m.recordAssemble(time.Now(), fmt.Errorf("pop"))
+	m.recordEndorse(time.Now(), nil)
+	assert.Equal(t, 2, testutil.CollectAndCount(m.assembleDuration))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.endorseDuration))
+}
+
+func TestSequencersCollector(t *testing.T) {
+	pendingEvents := make(chan ptmgrtypes.PrivateTransactionEvent, 10)
+	pendingEvents <- &ptmgrtypes.TransactionSubmittedEvent{}
+	p := &privateTxManager{
+		sequencers: map[string]*Sequencer{
+			"0x05d936207f04d81a85881b72a0d17854ee8be45a": {
+				incompleteTxSProcessMap: map[string]ptmgrtypes.TransactionFlow{
+					"tx1": nil,
+					"tx2": nil,
+				},
+				pendingTransactionEvents: pendingEvents,
+			},
+		},
+	}
+
+	err := testutil.CollectAndCompare(&sequencersCollector{p: p}, strings.NewReader(`
+# HELP paladin_privatetxmgr_inflight_transactions Number of incomplete transactions being processed by the sequencer for each contract
+# TYPE paladin_privatetxmgr_inflight_transactions gauge
+paladin_privatetxmgr_inflight_transactions{contract="0x05d936207f04d81a85881b72a0d17854ee8be45a"} 2
+# HELP paladin_privatetxmgr_pending_events Number of events queued for processing by the sequencer for each contract
+# TYPE paladin_privatetxmgr_pending_events gauge
+paladin_privatetxmgr_pending_events{contract="0x05d936207f04d81a85881b72a0d17854ee8be45a"} 1
+# HELP paladin_privatetxmgr_sequencers Number of active sequencers, each of which handles the transactions for one private contract
+# TYPE paladin_privatetxmgr_sequencers gauge
+paladin_privatetxmgr_sequencers 1
+`))
+	require.NoError(t, err)
+}
@@ -18,6 +18,7 @@ package privatetxnmgr
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -27,6 +28,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/syncpoints"
 
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
@@ -57,6 +59,7 @@ type privateTxManager struct {
 	subscribersLock      sync.Mutex
 	syncPoints           syncpoints.SyncPoints
 	blockHeight          int64
+	metrics              *privateTxManagerMetrics
 }
 
 // Init implements Engine.
@@ -77,7 +80,9 @@ func (p *privateTxManager) PreInit(c components.PreInitComponents) (*components.
 func (p *privateTxManager) PostInit(c components.AllComponents) error {
 	p.components = c
 	p.nodeName = p.components.TransportManager().LocalNodeName()
-	p.syncPoints = syncpoints.NewSyncPoints(p.ctx, &p.config.Writer, c.Persistence(), c.TxManager(), c.PublicTxManager(), c.TransportManager())
+	p.metrics = newPrivateTxManagerMetrics(c.MetricsManager())
+	metrics.MustRegister(c.MetricsManager().Registry(), &sequencersCollector{p: p})
+	p.syncPoints = syncpoints.NewSyncPoints(p.ctx, &p.config.Writer, c.Persistence(), c.MetricsManager(), c.TxManager(), c.PublicTxManager(), c.TransportManager())
 	return nil
 }
 
@@ -159,6 +164,7 @@ func (p *privateTxManager) getSequencerForContract(ctx context.Context, dbTX per
 				transportWriter,
 				confutil.DurationMin(p.config.RequestTimeout, 0, *pldconf.PrivateTxManagerDefaults.RequestTimeout),
 				p.blockHeight,
+				p.metrics,
 			)
 			if err != nil {
 				log.L(ctx).Errorf("Failed to create sequencer for contract %s: %s", contractAddr.String(), err)
@@ -178,7 +184,10 @@ func (p *privateTxManager) getSequencerForContract(ctx context.Context, dbTX per
 				log.L(ctx).Infof("Sequencer for contract %s has stopped", contractAddr.String())
 				p.sequencersLock.Lock()
 				defer p.sequencersLock.Unlock()
-				delete(p.sequencers, contractAddr.String())
+				// a configuration change might already have replaced this sequencer
+				if p.sequencers[contractAddr.String()] == newSequencer {
+					delete(p.sequencers, contractAddr.String())
+				}
 			}()
 		}
 	}
@@ -196,7 +205,7 @@ func (p *privateTxManager) getEndorsementGathererForContract(ctx context.Context
 	if p.endorsementGatherers[contractAddr.String()] == nil {
 		// TODO: Consider scope of state in privateTxManager threading model
 		dCtx := p.components.StateManager().NewDomainContext(p.ctx /* background context */, domainSmartContract.Domain(), contractAddr)
-		endorsementGatherer := NewEndorsementGatherer(p.components.Persistence(), domainSmartContract, dCtx, p.components.KeyManager())
+		endorsementGatherer := NewEndorsementGatherer(p.components.Persistence(), domainSmartContract, dCtx, p.components.KeyManager(), p.metrics)
 		p.endorsementGatherers[contractAddr.String()] = endorsementGatherer
 	}
 	return p.endorsementGatherers[contractAddr.String()], nil
@@ -308,6 +317,8 @@ func (p *privateTxManager) handleDelegatedTransaction(ctx context.Context, dbTX
 	if err != nil {
 		return err
 	}
+	// The delegating node needs our heartbeats, to know that we are still coordinating its transaction
+	sequencer.coordinatorLiveness.addPeers(delegatingNodeName)
 	queued := sequencer.ProcessInFlightTransaction(ctx, tx, &delegationBlockHeight)
 	if queued {
 		log.L(ctx).Debugf("Delegated Transaction with ID %s queued in database", tx.ID)
@@ -510,6 +521,48 @@ func (p *privateTxManager) GetTxStatus(ctx context.Context, domainAddress string
 
 }
 
+func (p *privateTxManager) CancelTransaction(ctx context.Context, domain string, contractAddr pldtypes.EthAddress, txID uuid.UUID) error {
+	p.sequencersLock.RLock()
+	targetSequencer := p.sequencers[contractAddr.String()]
+	p.sequencersLock.RUnlock()
+
+	if targetSequencer != nil {
+		inFlight, err := targetSequencer.CancelTransaction(ctx, txID)
+		if inFlight || err != nil {
+			return err
+		}
+	}
+
+	// The transaction is not in flight in memory, so there are no state locks to release. However, it might
+	// have been dispatched or prepared before it was removed from memory, in which case it cannot be cancelled.
+	log.L(ctx).Infof("Cancelling private transaction %s that is not in flight", txID)
+	return p.components.Persistence().Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		var dispatches int64
+		err := dbTX.DB().
+			WithContext(ctx).
+			Table("dispatches").
+			Where("private_transaction_id = ?", txID.String()).
+			Count(&dispatches).
+			Error
+		if err != nil {
+			return err
+		}
+		prepared, err := p.components.TxManager().GetPreparedTransactionByID(ctx, dbTX, txID)
+		if err != nil {
+			return err
+		}
+		if dispatches > 0 || prepared != nil {
+			return i18n.NewError(ctx, msgs.MsgPrivateTxMgrCancelDispatched, txID)
+		}
+		return p.components.TxManager().FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{{
+			ReceiptType:    components.RT_FailedWithMessage,
+			Domain:         domain,
+			TransactionID:  txID,
+			FailureMessage: i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgTxMgrTransactionCancelled)),
+		}})
+	})
+}
+
 func (p *privateTxManager) HandleNewEvent(ctx context.Context, event ptmgrtypes.PrivateTransactionEvent) {
 	p.sequencersLock.RLock()
 	defer p.sequencersLock.RUnlock()
@@ -935,6 +988,65 @@ func (p *privateTxManager) handleAssembleError(ctx context.Context, messagePaylo
 	})
 }
 
+func (p *privateTxManager) handleCancelTransactionRequest(ctx context.Context, messagePayload []byte, fromNode string) {
+	cancelRequest := &pbEngine.CancelTransactionRequest{}
+	err := proto.Unmarshal(messagePayload, cancelRequest)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to unmarshal cancel transaction request: %s", err)
+		return
+	}
+
+	log.L(ctx).Infof("Received cancel transaction request: ContractAddress: %s, TransactionId: %s, From: %s", cancelRequest.ContractAddress, cancelRequest.TransactionId, fromNode)
+
+	p.HandleNewEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID:   cancelRequest.TransactionId,
+			ContractAddress: cancelRequest.ContractAddress,
+		},
+		Originator: fromNode,
+	})
+}
+
+func (p *privateTxManager) handleCancelTransactionAcknowledgment(ctx context.Context, messagePayload []byte, fromNode string) {
+	cancelAck := &pbEngine.CancelTransactionAcknowledgment{}
+	err := proto.Unmarshal(messagePayload, cancelAck)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to unmarshal cancel transaction acknowledgment: %s", err)
+		return
+	}
+
+	log.L(ctx).Infof("Received cancel transaction acknowledgment: ContractAddress: %s, TransactionId: %s, Cancelled: %t, From: %s", cancelAck.ContractAddress, cancelAck.TransactionId, cancelAck.Cancelled, fromNode)
+
+	p.HandleNewEvent(ctx, &ptmgrtypes.TransactionCancelAcknowledgedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID:   cancelAck.TransactionId,
+			ContractAddress: cancelAck.ContractAddress,
+		},
+		Node:      fromNode,
+		Cancelled: cancelAck.Cancelled,
+	})
+}
+
+func (p *privateTxManager) handleCoordinatorHeartbeat(ctx context.Context, messagePayload []byte, fromNode string) {
+	heartbeat := &pbEngine.CoordinatorHeartbeat{}
+	err := proto.Unmarshal(messagePayload, heartbeat)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to unmarshal coordinator heartbeat: %s", err)
+		return
+	}
+
+	// We only track liveness for contracts that we have a sequencer in memory for. If we have delegated
+	// transactions to the heartbeating node then the sequencer will be loaded.
+	p.sequencersLock.RLock()
+	targetSequencer := p.sequencers[heartbeat.ContractAddress]
+	p.sequencersLock.RUnlock()
+	if targetSequencer == nil {
+		log.L(ctx).Debugf("Ignoring coordinator heartbeat from %s for contract %s with no sequencer", fromNode, heartbeat.ContractAddress)
+		return
+	}
+	targetSequencer.OnCoordinatorHeartbeat(ctx, fromNode)
+}
+
 // For now, this is here to help with testing but it seems like it could be useful thing to have
 // in the future if we want to have an eventing interface but at such time we would need to put more effort
 // into the reliability of the event delivery or maybe there is only a consumer of the event and it is responsible
@@ -991,6 +1103,57 @@ func (p *privateTxManager) PrivateTransactionConfirmed(ctx context.Context, rece
 	}
 }
 
+func (p *privateTxManager) PrivateSmartContractConfigChanged(ctx context.Context, contractAddr pldtypes.EthAddress) {
+	// The sequencer holds the contract configuration it was created with (including the coordinator selection),
+	// so we stop it to have it re-created with the new configuration on the next transaction
+	p.sequencersLock.Lock()
+	sequencer := p.sequencers[contractAddr.String()]
+	delete(p.sequencers, contractAddr.String())
+	p.sequencersLock.Unlock()
+	if sequencer != nil {
+		log.L(ctx).Infof("Stopping sequencer for contract %s after a configuration change", contractAddr)
+		sequencer.Stop()
+		go p.requeueTransactions(ctx, contractAddr, sequencer)
+	}
+}
+
+// requeueTransactions waits for a stopped sequencer to exit, then submits the transactions that were in flight
+// and not yet dispatched to a new sequencer, which initializes them against the new contract configuration.
+// Only transactions that originated on this node are re-queued. Transactions delegated to us by other nodes are
+// dropped, as the originating node processes the same configuration change and re-queues them itself.
+func (p *privateTxManager) requeueTransactions(ctx context.Context, contractAddr pldtypes.EthAddress, stopped *Sequencer) {
+	<-stopped.sequencerLoopDone
+	for _, txID := range stopped.undispatchedTransactions(ctx) {
+		tx, err := p.components.TxManager().GetResolvedTransactionByID(ctx, txID)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to load transaction %s to re-queue after configuration change of contract %s: %s", txID, contractAddr, err)
+			continue
+		}
+		if tx == nil {
+			log.L(ctx).Infof("Dropping transaction %s delegated by another node after configuration change of contract %s", txID, contractAddr)
+			continue
+		}
+		log.L(ctx).Infof("Re-queuing transaction %s after configuration change of contract %s", txID, contractAddr)
+		err = p.HandleNewTx(ctx, p.components.Persistence().NOTX(), &components.ValidatedTransaction{ResolvedTransaction: *tx})
+		if err != nil {
+			// the transaction is no longer valid against the new configuration, so it fails rather than being left pending
+			log.L(ctx).Errorf("Failed to re-queue transaction %s after configuration change of contract %s: %s", txID, contractAddr, err)
+			failureMessage := err.Error()
+			err = p.components.Persistence().Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+				return p.components.TxManager().FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{{
+					ReceiptType:    components.RT_FailedWithMessage,
+					Domain:         tx.Transaction.Domain,
+					TransactionID:  txID,
+					FailureMessage: failureMessage,
+				}})
+			})
+			if err != nil {
+				log.L(ctx).Errorf("Failed to write failure receipt for transaction %s: %s", txID, err)
+			}
+		}
+	}
+}
+
 func (p *privateTxManager) CallPrivateSmartContract(ctx context.Context, call *components.ResolvedTransaction) (*abi.ComponentValue, error) {
 
 	callTx := call.Transaction
@@ -1005,16 +1168,20 @@ func (p *privateTxManager) CallPrivateSmartContract(ctx context.Context, call *c
 	}
 	callTx.Domain = domainName
 
-	// Initialize the call, returning at list of required verifiers
-	requiredVerifiers, err := psc.InitCall(ctx, call)
+	// Create a throwaway domain context for this call
+	dCtx := p.components.StateManager().NewDomainContext(ctx, psc.Domain(), psc.Address())
+	defer dCtx.Close()
+
+	// Initialize the call, returning at list of required verifiers and signatures
+	initRes, err := psc.InitCall(dCtx, p.components.Persistence().NOTX(), call)
 	if err != nil {
 		return nil, err
 	}
 
 	// Do the verification in-line and synchronously for call (there is caching in the identity resolver)
 	identityResolver := p.components.IdentityResolver()
-	verifiers := make([]*prototk.ResolvedVerifier, len(requiredVerifiers))
-	for i, r := range requiredVerifiers {
+	verifiers := make([]*prototk.ResolvedVerifier, len(initRes.RequiredVerifiers))
+	for i, r := range initRes.RequiredVerifiers {
 		verifier, err := identityResolver.ResolveVerifier(ctx, r.Lookup, r.Algorithm, r.VerifierType)
 		if err != nil {
 			return nil, err
@@ -1027,12 +1194,59 @@ func (p *privateTxManager) CallPrivateSmartContract(ctx context.Context, call *c
 		}
 	}
 
-	// Create a throwaway domain context for this call
-	dCtx := p.components.StateManager().NewDomainContext(ctx, psc.Domain(), psc.Address())
-	defer dCtx.Close()
+	// Signatures are also gathered in-line, and can only be provided by local keys of the caller
+	signatures, err := p.signForCall(ctx, callTx.From, initRes.AttestationPlan)
+	if err != nil {
+		return nil, err
+	}
 
 	// Do the actual call
-	return psc.ExecCall(dCtx, p.components.Persistence().NOTX(), call, verifiers)
+	return psc.ExecCall(dCtx, p.components.Persistence().NOTX(), call, verifiers, signatures)
+}
+
+// signForCall produces the signatures a domain requires for a call. The caller is authorized only for the key in
+// the "from" of the call (by the JSON/RPC authorization policies), so every signature must be made with that key,
+// or with a key in the hierarchy beneath it.
+func (p *privateTxManager) signForCall(ctx context.Context, from string, attestationPlan []*prototk.AttestationRequest) ([]*prototk.AttestationResult, error) {
+	keyMgr := p.components.KeyManager()
+	var signatures []*prototk.AttestationResult
+	for _, attRequest := range attestationPlan {
+		for _, partyName := range attRequest.Parties {
+			unqualifiedLookup, signerNode, err := pldtypes.PrivateIdentityLocator(partyName).Validate(ctx, p.nodeName, true)
+			if err != nil {
+				return nil, err
+			}
+			if signerNode != p.nodeName {
+				return nil, i18n.NewError(ctx, msgs.MsgPrivateTxMgrCallSignerNotLocal, attRequest.Name, partyName)
+			}
+			callerLookup, callerNode, err := pldtypes.PrivateIdentityLocator(from).Validate(ctx, p.nodeName, true)
+			if err != nil || callerNode != p.nodeName ||
+				(unqualifiedLookup != callerLookup && !strings.HasPrefix(unqualifiedLookup, callerLookup+".")) {
+				return nil, i18n.NewError(ctx, msgs.MsgPrivateTxMgrCallSignerNotCaller, attRequest.Name, partyName, from)
+			}
+			resolvedKey, err := keyMgr.ResolveKeyNewDatabaseTX(ctx, unqualifiedLookup, attRequest.Algorithm, attRequest.VerifierType)
+			if err != nil {
+				return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateTxManagerResolveError, unqualifiedLookup, attRequest.Algorithm)
+			}
+			signaturePayload, err := keyMgr.Sign(ctx, resolvedKey, attRequest.PayloadType, attRequest.Payload)
+			if err != nil {
+				return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateTxManagerSignError, unqualifiedLookup, resolvedKey.Verifier.Verifier, attRequest.Algorithm)
+			}
+			signatures = append(signatures, &prototk.AttestationResult{
+				Name:            attRequest.Name,
+				AttestationType: attRequest.AttestationType,
+				Verifier: &prototk.ResolvedVerifier{
+					Lookup:       partyName,
+					Algorithm:    attRequest.Algorithm,
+					Verifier:     resolvedKey.Verifier.Verifier,
+					VerifierType: attRequest.VerifierType,
+				},
+				Payload:     signaturePayload,
+				PayloadType: &attRequest.PayloadType,
+			})
+		}
+	}
+	return signatures, nil
 }
 
 func (p *privateTxManager) BuildStateDistributions(ctx context.Context, tx *components.PrivateTransaction) (*components.StateDistributionSet, error) {
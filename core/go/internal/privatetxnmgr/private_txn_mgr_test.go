@@ -41,6 +41,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -2497,15 +2498,17 @@ func TestCallPrivateSmartContractOk(t *testing.T) {
 	bobAddr := pldtypes.RandAddress()
 	m.identityResolver.On("ResolveVerifier", mock.Anything, "bob@node1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
 		Return(bobAddr.String(), nil)
-	mPSC.On("InitCall", mock.Anything, mock.Anything).Return(
-		[]*prototk.ResolveVerifierRequest{
-			{Lookup: "bob@node1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
+		&prototk.InitCallResponse{
+			RequiredVerifiers: []*prototk.ResolveVerifierRequest{
+				{Lookup: "bob@node1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+			},
 		}, nil,
 	)
 	mPSC.On("ExecCall", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(verifiers []*prototk.ResolvedVerifier) bool {
 		require.Equal(t, bobAddr.String(), verifiers[0].Verifier)
 		return true
-	})).Return(
+	}), []*prototk.AttestationResult(nil)).Return(
 		resultCV, nil,
 	)
 
@@ -2579,7 +2582,7 @@ func TestCallPrivateSmartContractInitCallFail(t *testing.T) {
 
 	_, mPSC := mockDomainSmartContractAndCtx(t, m)
 
-	mPSC.On("InitCall", mock.Anything, mock.Anything).Return(
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
 		nil, fmt.Errorf("pop"),
 	)
 
@@ -2602,9 +2605,11 @@ func TestCallPrivateSmartContractResolveFail(t *testing.T) {
 
 	_, mPSC := mockDomainSmartContractAndCtx(t, m)
 
-	mPSC.On("InitCall", mock.Anything, mock.Anything).Return(
-		[]*prototk.ResolveVerifierRequest{
-			{Lookup: "bob@node1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
+		&prototk.InitCallResponse{
+			RequiredVerifiers: []*prototk.ResolveVerifierRequest{
+				{Lookup: "bob@node1", Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+			},
 		}, nil,
 	)
 	m.identityResolver.On("ResolveVerifier", mock.Anything, "bob@node1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
@@ -2629,10 +2634,10 @@ func TestCallPrivateSmartContractExecCallFail(t *testing.T) {
 
 	_, mPSC := mockDomainSmartContractAndCtx(t, m)
 
-	mPSC.On("InitCall", mock.Anything, mock.Anything).Return(
-		[]*prototk.ResolveVerifierRequest{}, nil,
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
+		&prototk.InitCallResponse{}, nil,
 	)
-	mPSC.On("ExecCall", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	mPSC.On("ExecCall", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		nil, fmt.Errorf("pop"),
 	)
 
@@ -2648,6 +2653,169 @@ func TestCallPrivateSmartContractExecCallFail(t *testing.T) {
 
 }
 
+func TestCallPrivateSmartContractWithSignatures(t *testing.T) {
+
+	ctx := context.Background()
+	ptx, m := NewPrivateTransactionMgrForPackageTesting(t, "node1")
+
+	_, mPSC := mockDomainSmartContractAndCtx(t, m)
+
+	fnDef := &abi.Entry{Name: "getIt", Type: abi.Function, Outputs: abi.ParameterArray{
+		{Name: "it", Type: "string"},
+	}}
+	resultCV, err := fnDef.Outputs.ParseJSON([]byte(`["thing"]`))
+	require.NoError(t, err)
+
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
+		&prototk.InitCallResponse{
+			AttestationPlan: []*prototk.AttestationRequest{
+				{
+					Name:            "viewer",
+					AttestationType: prototk.AttestationType_SIGN,
+					Algorithm:       algorithms.ECDSA_SECP256K1,
+					VerifierType:    verifiers.ETH_ADDRESS,
+					PayloadType:     "payload1",
+					Payload:         []byte("payload"),
+					Parties:         []string{"bob.viewer@node1"},
+				},
+			},
+		}, nil,
+	)
+	bobKeyMapping := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{
+			Identifier: "bob.viewer",
+		}},
+		Verifier: &pldapi.KeyVerifier{Verifier: "0x1234"},
+	}
+	m.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "bob.viewer", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).Return(bobKeyMapping, nil)
+	m.keyManager.On("Sign", mock.Anything, bobKeyMapping, "payload1", []byte("payload")).Return([]byte("signed"), nil)
+	mPSC.On("ExecCall", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(signatures []*prototk.AttestationResult) bool {
+		require.Len(t, signatures, 1)
+		require.Equal(t, "viewer", signatures[0].Name)
+		require.Equal(t, "bob.viewer@node1", signatures[0].Verifier.Lookup)
+		require.Equal(t, "0x1234", signatures[0].Verifier.Verifier)
+		require.Equal(t, []byte("signed"), signatures[0].Payload)
+		return true
+	})).Return(
+		resultCV, nil,
+	)
+
+	_, err = ptx.CallPrivateSmartContract(ctx, &components.ResolvedTransaction{
+		Transaction: &pldapi.Transaction{
+			TransactionBase: pldapi.TransactionBase{
+				From: "bob@node1",
+				To:   confutil.P(mPSC.Address()),
+				Data: pldtypes.RawJSON(`{}`),
+			},
+		},
+		Function: &components.ResolvedFunction{
+			Definition: fnDef,
+		},
+	})
+	require.NoError(t, err)
+
+}
+
+func TestCallPrivateSmartContractSignerNotLocal(t *testing.T) {
+
+	ctx := context.Background()
+	ptx, m := NewPrivateTransactionMgrForPackageTesting(t, "node1")
+
+	_, mPSC := mockDomainSmartContractAndCtx(t, m)
+
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
+		&prototk.InitCallResponse{
+			AttestationPlan: []*prototk.AttestationRequest{
+				{
+					Name:            "viewer",
+					AttestationType: prototk.AttestationType_SIGN,
+					Parties:         []string{"bob@node2"},
+				},
+			},
+		}, nil,
+	)
+
+	_, err := ptx.CallPrivateSmartContract(ctx, &components.ResolvedTransaction{
+		Transaction: &pldapi.Transaction{
+			TransactionBase: pldapi.TransactionBase{
+				To:   confutil.P(mPSC.Address()),
+				Data: pldtypes.RawJSON(`{}`),
+			},
+		},
+	})
+	require.Regexp(t, "PD011841.*bob@node2", err)
+
+}
+
+func TestCallPrivateSmartContractSignFail(t *testing.T) {
+
+	ctx := context.Background()
+	ptx, m := NewPrivateTransactionMgrForPackageTesting(t, "node1")
+
+	_, mPSC := mockDomainSmartContractAndCtx(t, m)
+
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
+		&prototk.InitCallResponse{
+			AttestationPlan: []*prototk.AttestationRequest{
+				{
+					Name:            "viewer",
+					AttestationType: prototk.AttestationType_SIGN,
+					Algorithm:       algorithms.ECDSA_SECP256K1,
+					VerifierType:    verifiers.ETH_ADDRESS,
+					Parties:         []string{"bob"},
+				},
+			},
+		}, nil,
+	)
+	m.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "bob", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ptx.CallPrivateSmartContract(ctx, &components.ResolvedTransaction{
+		Transaction: &pldapi.Transaction{
+			TransactionBase: pldapi.TransactionBase{
+				From: "bob",
+				To:   confutil.P(mPSC.Address()),
+				Data: pldtypes.RawJSON(`{}`),
+			},
+		},
+	})
+	require.Regexp(t, "pop", err)
+
+}
+
+func TestCallPrivateSmartContractSignerNotCaller(t *testing.T) {
+
+	ctx := context.Background()
+	ptx, m := NewPrivateTransactionMgrForPackageTesting(t, "node1")
+
+	_, mPSC := mockDomainSmartContractAndCtx(t, m)
+
+	mPSC.On("InitCall", mock.Anything, mock.Anything, mock.Anything).Return(
+		&prototk.InitCallResponse{
+			AttestationPlan: []*prototk.AttestationRequest{
+				{
+					Name:            "viewer",
+					AttestationType: prototk.AttestationType_SIGN,
+					Parties:         []string{"bob.viewer@node1"},
+				},
+			},
+		}, nil,
+	)
+
+	for _, from := range []string{"", "alice@node1", "bo", "bob.viewer.other", "bob@node2"} {
+		_, err := ptx.CallPrivateSmartContract(ctx, &components.ResolvedTransaction{
+			Transaction: &pldapi.Transaction{
+				TransactionBase: pldapi.TransactionBase{
+					From: from,
+					To:   confutil.P(mPSC.Address()),
+					Data: pldtypes.RawJSON(`{}`),
+				},
+			},
+		})
+		require.Regexp(t, "PD011842.*bob.viewer@node1", err)
+	}
+
+}
+
 /* Unit tests */
 
 /* Utils */
@@ -2881,6 +3049,7 @@ func NewPrivateTransactionMgrForPackageTesting(t *testing.T, nodeName string) (p
 	mocks.allComponents.On("TxManager").Return(mocks.txManager).Maybe()
 	mocks.allComponents.On("PublicTxManager").Return(mocks.publicTxManager).Maybe()
 	mocks.allComponents.On("Persistence").Return(mocks.persistence).Maybe()
+	mocks.allComponents.On("MetricsManager").Return(metrics.NewMetricsManager(ctx)).Maybe()
 	mocks.domainSmartContract.On("Domain").Return(mocks.domain).Maybe()
 	mocks.domainSmartContract.On("LockStates", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	mocks.domainMgr.On("GetDomainByName", mock.Anything, "domain1").Return(mocks.domain, nil).Maybe()
@@ -158,6 +158,43 @@ func (event *ResolveVerifierResponseEvent) Validate(ctx context.Context) error {
 	return nil
 }
 
+// A request to cancel a transaction that has not been dispatched. Originator is empty when the request
+// was made on the local node, or is the name of the node that delegated the transaction to us.
+// Result is set for a request made on the local node, to return the outcome from the sequencer event loop.
+type TransactionCancelledEvent struct {
+	PrivateTransactionEventBase
+	Originator string
+	Result     chan<- TransactionCancelResult
+}
+
+type TransactionCancelResult struct {
+	InFlight bool
+	Err      error
+}
+
+// Respond returns the outcome of a cancel to the local caller, if there is one
+func (e *TransactionCancelledEvent) Respond(inFlight bool, err error) {
+	if e.Result != nil {
+		e.Result <- TransactionCancelResult{InFlight: inFlight, Err: err}
+	}
+}
+
+// The coordinator that a transaction was delegated to has processed our request to cancel it.
+// Cancelled is false if the coordinator could not release the transaction, because it has been dispatched.
+type TransactionCancelAcknowledgedEvent struct {
+	PrivateTransactionEventBase
+	Node      string
+	Cancelled bool
+}
+
+// The node that a transaction was delegated to has failed to heartbeat, so the transaction
+// must fail over to the next available coordinator
+type CoordinatorUnavailableEvent struct {
+	PrivateTransactionEventBase
+	Node   string
+	Reason string
+}
+
 type TransactionFinalizedEvent struct {
 	PrivateTransactionEventBase
 }
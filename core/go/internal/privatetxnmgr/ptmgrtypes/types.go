@@ -104,6 +104,9 @@ type TransportWriter interface {
 	SendDelegationRequestAcknowledgment(ctx context.Context, delegatingNodeName string, delegationId string, delegateNodeName string, transactionID string) error
 	SendEndorsementRequest(ctx context.Context, idempotencyKey string, party string, targetNode string, contractAddress string, transactionID string, attRequest *prototk.AttestationRequest, transactionSpecification *prototk.TransactionSpecification, verifiers []*prototk.ResolvedVerifier, signatures []*prototk.AttestationResult, inputStates []*components.FullState, outputStates []*components.FullState, infoStates []*components.FullState) error
 	SendAssembleRequest(ctx context.Context, assemblingNode string, assembleRequestID string, txID uuid.UUID, contractAddress string, preAssembly *components.TransactionPreAssembly, stateLocksJSON []byte, blockHeight int64) error
+	SendCancelTransactionRequest(ctx context.Context, coordinatorNode string, transactionID string) error
+	SendCancelTransactionAcknowledgment(ctx context.Context, originatorNode string, transactionID string, cancelled bool) error
+	SendCoordinatorHeartbeat(ctx context.Context, targetNode string, blockHeight int64) error
 }
 
 type TransactionFlowStatus int
@@ -124,7 +127,9 @@ type TransactionFlow interface {
 	PrepareTransaction(ctx context.Context, defaultSigner string) (*components.PrivateTransaction, error)
 	GetStateDistributions(ctx context.Context) (*components.StateDistributionSet, error)
 	CoordinatingLocally(ctx context.Context) bool
+	LocalCoordinatorSelected(ctx context.Context) bool
 	IsComplete(ctx context.Context) bool
+	IsFinalizing(ctx context.Context) bool
 	ReadyForSequencing(ctx context.Context) bool
 	Dispatched(ctx context.Context) bool
 	ID(ctx context.Context) uuid.UUID
@@ -132,6 +137,7 @@ type TransactionFlow interface {
 	InputStateIDs(ctx context.Context) []string
 	OutputStateIDs(ctx context.Context) []string
 	Signer(ctx context.Context) string
+	DelegatedTo(ctx context.Context) (node string, delegationTime time.Time)
 }
 
 type Clock interface {
@@ -32,6 +32,7 @@ import (
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 )
 
 /*
@@ -114,6 +115,7 @@ type Sequencer struct {
 	delegateDomainContext    components.DomainContext
 	components               components.AllComponents
 	endorsementGatherer      ptmgrtypes.EndorsementGatherer
+	metrics                  *privateTxManagerMetrics
 	publisher                ptmgrtypes.Publisher
 	identityResolver         components.IdentityResolver
 	syncPoints               syncpoints.SyncPoints
@@ -121,6 +123,7 @@ type Sequencer struct {
 	graph                    Graph
 	requestTimeout           time.Duration
 	coordinatorSelector      ptmgrtypes.CoordinatorSelector
+	coordinatorLiveness      *coordinatorLiveness // only for endorser coordinated contracts
 	newBlockEvents           chan int64
 	assembleCoordinator      ptmgrtypes.AssembleCoordinator
 	environment              *sequencerEnvironment
@@ -141,6 +144,7 @@ func NewSequencer(
 	transportWriter ptmgrtypes.TransportWriter,
 	requestTimeout time.Duration,
 	blockHeight int64,
+	metrics *privateTxManagerMetrics,
 
 ) (*Sequencer, error) {
 
@@ -155,6 +159,7 @@ func NewSequencer(
 		stateEntryTime:       time.Now(),
 
 		incompleteTxSProcessMap: make(map[string]ptmgrtypes.TransactionFlow),
+		metrics:                 metrics,
 		persistenceRetryTimeout: confutil.DurationMin(sequencerConfig.PersistenceRetryTimeout, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.PersistenceRetryTimeout),
 
 		staleTimeout:                 confutil.DurationMin(sequencerConfig.StaleTimeout, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.StaleTimeout),
@@ -185,7 +190,11 @@ func NewSequencer(
 
 	log.L(ctx).Debugf("NewSequencer for contract address %s created: %+v", newSequencer.contractAddress, newSequencer)
 
-	coordinatorSelector, err := NewCoordinatorSelector(ctx, nodeName, domainAPI.ContractConfig(), *sequencerConfig)
+	if domainAPI.ContractConfig().GetCoordinatorSelection() == prototk.ContractConfig_COORDINATOR_ENDORSER {
+		newSequencer.coordinatorLiveness = newCoordinatorLiveness(nodeName, sequencerConfig)
+	}
+
+	coordinatorSelector, err := NewCoordinatorSelector(ctx, nodeName, domainAPI.ContractConfig(), *sequencerConfig, newSequencer.coordinatorLiveness)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to create coordinator selector: %s", err)
 		return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateTxManagerNewSequencerError, domainAPI.ContractConfig().GetCoordinatorSelection())
@@ -244,6 +253,20 @@ func (s *Sequencer) removeTransactionProcessor(txID string) {
 	delete(s.incompleteTxSProcessMap, txID)
 }
 
+// undispatchedTransactions returns the transactions in memory that are still to be dispatched, and are not
+// already being finalized
+func (s *Sequencer) undispatchedTransactions(ctx context.Context) []uuid.UUID {
+	s.incompleteTxProcessMapMutex.Lock()
+	defer s.incompleteTxProcessMapMutex.Unlock()
+	txIDs := make([]uuid.UUID, 0, len(s.incompleteTxSProcessMap))
+	for _, txProc := range s.incompleteTxSProcessMap {
+		if !txProc.Dispatched(ctx) && !txProc.IsComplete(ctx) && !txProc.IsFinalizing(ctx) {
+			txIDs = append(txIDs, txProc.ID(ctx))
+		}
+	}
+	return txIDs
+}
+
 func (s *Sequencer) OnNewBlockHeight(ctx context.Context, blockHeight int64) {
 	log.L(ctx).Debugf("Sequencer OnNewBlockHeight %d", blockHeight)
 	s.environment.blockHeight = blockHeight
@@ -303,6 +326,28 @@ func (s *Sequencer) ProcessInFlightTransaction(ctx context.Context, tx *componen
 	return false
 }
 
+// CancelTransaction passes a cancel through the event loop to a transaction that is in flight in this
+// sequencer, returning false if it is not. A transaction that has already been dispatched cannot be cancelled.
+func (s *Sequencer) CancelTransaction(ctx context.Context, txID uuid.UUID) (inFlight bool, err error) {
+	result := make(chan ptmgrtypes.TransactionCancelResult, 1)
+	s.HandleEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID:   txID.String(),
+			ContractAddress: s.contractAddress.String(),
+		},
+		Result: result,
+	})
+	select {
+	case r := <-result:
+		return r.InFlight, r.Err
+	case <-s.sequencerLoopDone:
+		// the sequencer stopped before processing the cancel, so the transaction is no longer in memory
+		return false, nil
+	case <-ctx.Done():
+		return false, i18n.NewError(ctx, msgs.MsgContextCanceled)
+	}
+}
+
 func (s *Sequencer) HandleEvent(ctx context.Context, event ptmgrtypes.PrivateTransactionEvent) {
 	s.pendingTransactionEvents <- event
 }
@@ -343,7 +388,11 @@ func (s *Sequencer) GetTxStatus(ctx context.Context, txID uuid.UUID) (components
 	s.incompleteTxProcessMapMutex.Lock()
 	defer s.incompleteTxProcessMapMutex.Unlock()
 	if txProc, ok := s.incompleteTxSProcessMap[txID.String()]; ok {
-		return txProc.GetTxStatus(ctx)
+		status, err := txProc.GetTxStatus(ctx)
+		if err == nil && s.coordinatorLiveness != nil {
+			status.Coordinators = s.coordinatorLiveness.status()
+		}
+		return status, err
 	}
 	persistedTxn, err := s.components.TxManager().GetTransactionByIDFull(ctx, txID)
 	if err != nil {
@@ -38,6 +38,15 @@ func (s *Sequencer) evaluationLoop() {
 	defer close(s.sequencerLoopDone)
 
 	ticker := time.NewTicker(s.evalInterval)
+
+	// heartbeats are only exchanged for endorser coordinated contracts
+	var heartbeatTicks <-chan time.Time
+	if s.coordinatorLiveness != nil {
+		heartbeatTicker := time.NewTicker(s.coordinatorLiveness.heartbeatInterval)
+		defer heartbeatTicker.Stop()
+		heartbeatTicks = heartbeatTicker.C
+	}
+
 	for {
 		// an InFlight
 		select {
@@ -48,6 +57,9 @@ func (s *Sequencer) evaluationLoop() {
 			s.handleTransactionEvent(ctx, pendingEvent)
 		case <-s.orchestrationEvalRequestChan:
 		case <-ticker.C:
+		case <-heartbeatTicks:
+			s.sendCoordinatorHeartbeats(ctx)
+			s.checkCoordinatorLiveness(ctx)
 		case <-ctx.Done():
 			log.L(ctx).Infof("Sequencer loop exit due to canceled context, it processed %d transaction during its lifetime.", s.totalCompleted)
 			return
@@ -62,6 +74,18 @@ func (s *Sequencer) evaluationLoop() {
 	}
 }
 
+// A cancel for a transaction we do not have in memory still needs an answer. The local caller checks the
+// persisted state of the transaction instead. A node that delegated the transaction to us is told we could not
+// cancel it, as we cannot tell whether we dispatched it before it was removed from memory.
+func (s *Sequencer) transactionNotInFlightForCancel(ctx context.Context, cancel *ptmgrtypes.TransactionCancelledEvent) {
+	cancel.Respond(false, nil)
+	if cancel.Originator != "" {
+		if err := s.transportWriter.SendCancelTransactionAcknowledgment(ctx, cancel.Originator, cancel.TransactionID, false); err != nil {
+			log.L(ctx).Errorf("Failed to acknowledge cancel for transaction %s to %s: %s", cancel.TransactionID, cancel.Originator, err)
+		}
+	}
+}
+
 func (s *Sequencer) handleTransactionEvent(ctx context.Context, event ptmgrtypes.PrivateTransactionEvent) {
 	//For any event that is specific to a single transaction,
 	// find (or create) the transaction processor for that transaction
@@ -81,6 +105,9 @@ func (s *Sequencer) handleTransactionEvent(ctx context.Context, event ptmgrtypes
 		// in case of (b) we ignore it because an event for a completed transaction is redundant.
 		// most likely it is a tardy response for something we timed out waiting for and failed or retried successfully
 		log.L(ctx).Warnf("Received an event for a transaction that is not in flight %s", transactionID)
+		if cancel, ok := event.(*ptmgrtypes.TransactionCancelledEvent); ok {
+			s.transactionNotInFlightForCancel(ctx, cancel)
+		}
 		return
 	}
 
@@ -146,6 +173,9 @@ func (s *Sequencer) handleTransactionEvent(ctx context.Context, event ptmgrtypes
 		log.L(ctx).Debug("No dispatchable transactions")
 		return
 	}
+	if !s.stillCoordinating(ctx, dispatchableTransactions) {
+		return
+	}
 	err = s.DispatchTransactions(ctx, dispatchableTransactions)
 	if err != nil {
 		log.L(ctx).Errorf("Error dispatching transaction: %s", err)
@@ -0,0 +1,104 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package privatetxnmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
+)
+
+// OnCoordinatorHeartbeat is called from the transport receiver when another node heartbeats for this contract
+func (s *Sequencer) OnCoordinatorHeartbeat(ctx context.Context, fromNode string) {
+	if s.coordinatorLiveness == nil {
+		log.L(ctx).Warnf("Ignoring coordinator heartbeat from %s for contract %s that is not endorser coordinated", fromNode, s.contractAddress)
+		return
+	}
+	accepted, recovered := s.coordinatorLiveness.heartbeatReceived(ctx, fromNode)
+	if !accepted {
+		log.L(ctx).Warnf("Ignoring coordinator heartbeat from %s that is not a known endorser of contract %s", fromNode, s.contractAddress)
+		return
+	}
+	if recovered {
+		// new transactions will select the recovered node again
+		s.TriggerSequencerEvaluation()
+	}
+}
+
+// sendCoordinatorHeartbeats runs on the sequencer event loop, to let the other candidate coordinators
+// (and any node that has delegated to us) know that we are alive.
+func (s *Sequencer) sendCoordinatorHeartbeats(ctx context.Context) {
+	for _, node := range s.coordinatorLiveness.peerNodes() {
+		if err := s.transportWriter.SendCoordinatorHeartbeat(ctx, node, s.environment.GetBlockHeight()); err != nil {
+			// we will try again on the next heartbeat
+			log.L(ctx).Warnf("Failed to send coordinator heartbeat to %s: %s", node, err)
+		}
+	}
+}
+
+// checkCoordinatorLiveness runs on the sequencer event loop, and fails over any transaction that has been
+// delegated to a coordinator that has stopped heartbeating. The transaction flow handles the event by
+// re-selecting a coordinator, and sending a new DelegationRequest to it (or coordinating locally).
+func (s *Sequencer) checkCoordinatorLiveness(ctx context.Context) {
+	failedOver := make([]*ptmgrtypes.CoordinatorUnavailableEvent, 0)
+	s.incompleteTxProcessMapMutex.Lock()
+	for txID, txProc := range s.incompleteTxSProcessMap {
+		node, delegationTime := txProc.DelegatedTo(ctx)
+		if node == "" {
+			continue
+		}
+		if failed, reason := s.coordinatorLiveness.checkDelegate(ctx, node, delegationTime); failed {
+			failedOver = append(failedOver, &ptmgrtypes.CoordinatorUnavailableEvent{
+				PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+					TransactionID:   txID,
+					ContractAddress: s.contractAddress.String(),
+				},
+				Node:   node,
+				Reason: reason,
+			})
+		}
+	}
+	s.incompleteTxProcessMapMutex.Unlock()
+
+	for _, event := range failedOver {
+		log.L(ctx).Warnf("Transaction %s failing over from coordinator %s", event.TransactionID, event.Node)
+		s.handleTransactionEvent(ctx, event)
+	}
+}
+
+// stillCoordinating runs on the sequencer event loop before dispatching, to check that this node is still
+// the selected coordinator for every transaction. A node loses the coordinator role when another candidate
+// is selected in its place, such as when the preferred coordinator recovers after we took over from it.
+// Nothing is dispatched in that case, as the sequence might depend on the transactions that we are no longer
+// coordinating. Those transactions are nudged, so that their next action hands them over to the new coordinator.
+func (s *Sequencer) stillCoordinating(ctx context.Context, dispatchableTransactions ptmgrtypes.DispatchableTransactions) bool {
+	if s.coordinatorLiveness == nil {
+		return true
+	}
+	coordinating := true
+	for _, transactionFlows := range dispatchableTransactions {
+		for _, transactionFlow := range transactionFlows {
+			if !transactionFlow.LocalCoordinatorSelected(ctx) {
+				txID := transactionFlow.ID(ctx).String()
+				log.L(ctx).Warnf("Not dispatching transaction %s as this node is no longer its coordinator", txID)
+				s.publisher.PublishNudgeEvent(ctx, txID)
+				coordinating = false
+			}
+		}
+	}
+	return coordinating
+}
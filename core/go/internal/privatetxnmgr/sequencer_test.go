@@ -24,6 +24,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/syncpoints"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/mocks/privatetxnmgrmocks"
@@ -97,8 +99,9 @@ func newSequencerForTesting(t *testing.T, ctx context.Context, domainAddress *pl
 	mocks.stateStore.On("NewDomainContext", mock.Anything, mocks.domain, *domainAddress, mock.Anything).Return(mocks.domainContext).Maybe()
 	//mocks.domain.On("Configuration").Return(&prototk.DomainConfig{}).Maybe()
 
-	syncPoints := syncpoints.NewSyncPoints(ctx, &pldconf.FlushWriterConfig{}, p, mocks.txManager, mocks.pubTxManager, mocks.transportManager)
-	o, err := NewSequencer(ctx, mocks.privateTxManager, pldtypes.RandHex(16), *domainAddress, &pldconf.PrivateTxManagerSequencerConfig{}, mocks.allComponents, mocks.domainSmartContract, mocks.endorsementGatherer, mocks.publisher, syncPoints, mocks.identityResolver, mocks.transportWriter, 30*time.Second, 0)
+	metricsManager := metrics.NewMetricsManager(ctx)
+	syncPoints := syncpoints.NewSyncPoints(ctx, &pldconf.FlushWriterConfig{}, p, metricsManager, mocks.txManager, mocks.pubTxManager, mocks.transportManager)
+	o, err := NewSequencer(ctx, mocks.privateTxManager, pldtypes.RandHex(16), *domainAddress, &pldconf.PrivateTxManagerSequencerConfig{}, mocks.allComponents, mocks.domainSmartContract, mocks.endorsementGatherer, mocks.publisher, syncPoints, mocks.identityResolver, mocks.transportWriter, 30*time.Second, 0, newPrivateTxManagerMetrics(metricsManager))
 	require.NoError(t, err)
 	ocDone, err := o.Start(ctx)
 	require.NoError(t, err)
@@ -203,3 +206,157 @@ func TestSequencerPollingLoopCancelContext(t *testing.T) {
 
 	cancel()
 }
+
+func TestSequencerStoppedOnContractConfigChange(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testOc, mocks, ocDone := newSequencerForTesting(t, ctx, nil)
+	defer ocDone()
+
+	p := &privateTxManager{
+		components: mocks.allComponents,
+		sequencers: map[string]*Sequencer{
+			testOc.contractAddress.String(): testOc,
+		},
+	}
+
+	// No-op for a contract without a sequencer
+	p.PrivateSmartContractConfigChanged(ctx, *pldtypes.RandAddress())
+
+	// Transactions in flight in the sequencer when the configuration changes
+	inFlight := func(txID uuid.UUID, dispatched, finalizing bool) {
+		txProc := privatetxnmgrmocks.NewTransactionFlow(t)
+		txProc.On("Dispatched", mock.Anything).Return(dispatched)
+		if !dispatched {
+			txProc.On("IsComplete", mock.Anything).Return(false)
+			txProc.On("IsFinalizing", mock.Anything).Return(finalizing)
+		}
+		if !dispatched && !finalizing {
+			txProc.On("ID", mock.Anything).Return(txID)
+		}
+		testOc.incompleteTxProcessMapMutex.Lock()
+		defer testOc.incompleteTxProcessMapMutex.Unlock()
+		testOc.incompleteTxSProcessMap[txID.String()] = txProc
+	}
+	localTxID := uuid.New()
+	inFlight(localTxID, false, false)
+	remoteTxID := uuid.New()
+	inFlight(remoteTxID, false, false)
+	inFlight(uuid.New(), true, false)
+	inFlight(uuid.New(), false, true)
+
+	// The local transaction is re-submitted, and fails as it is no longer valid
+	mocks.txManager.On("GetResolvedTransactionByID", mock.Anything, localTxID).Return(&components.ResolvedTransaction{
+		Transaction: &pldapi.Transaction{
+			ID: &localTxID,
+			TransactionBase: pldapi.TransactionBase{
+				Domain: "domain1",
+				To:     &testOc.contractAddress,
+			},
+		},
+	}, nil)
+	finalized := make(chan bool, 1)
+	mocks.txManager.On("FinalizeTransactions", mock.Anything, mock.Anything, mock.MatchedBy(func(receipts []*components.ReceiptInput) bool {
+		return len(receipts) == 1 && receipts[0].TransactionID == localTxID && receipts[0].ReceiptType == components.RT_FailedWithMessage
+	})).
+		Run(func(args mock.Arguments) {
+			assert.Regexp(t, "PD011836", args[2].([]*components.ReceiptInput)[0].FailureMessage)
+			finalized <- true
+		}).
+		Return(nil).Once()
+
+	// The delegated transaction is not in our database, so it is left for its originating node to re-submit
+	dropped := make(chan bool, 1)
+	mocks.txManager.On("GetResolvedTransactionByID", mock.Anything, remoteTxID).
+		Run(func(args mock.Arguments) { dropped <- true }).
+		Return(nil, nil).Once()
+
+	p.PrivateSmartContractConfigChanged(ctx, testOc.contractAddress)
+	assert.Empty(t, p.sequencers)
+	<-testOc.sequencerLoopDone
+	waitForChannel(t, finalized)
+	waitForChannel(t, dropped)
+
+}
+
+func TestSequencerCancelTransactionNotInFlight(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testOc, mocks, ocDone := newSequencerForTesting(t, ctx, nil)
+	defer ocDone()
+
+	// A cancel from the local API is answered by the event loop
+	inFlight, err := testOc.CancelTransaction(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.False(t, inFlight)
+
+	// A node that delegated a transaction we do not have is told we could not cancel it
+	txID := uuid.New()
+	acked := make(chan bool, 1)
+	mocks.transportWriter.On("SendCancelTransactionAcknowledgment", mock.Anything, "node1", txID.String(), false).
+		Run(func(args mock.Arguments) { acked <- true }).
+		Return(nil).Once()
+	testOc.HandleEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			TransactionID:   txID.String(),
+			ContractAddress: testOc.contractAddress.String(),
+		},
+		Originator: "node1",
+	})
+	waitForChannel(t, acked)
+
+	// Nothing is in flight once the sequencer has stopped
+	testOc.Stop()
+	<-testOc.sequencerLoopDone
+	inFlight, err = testOc.CancelTransaction(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.False(t, inFlight)
+}
+
+func TestCancelTransactionNotInFlightChecksState(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testOc, mocks, ocDone := newSequencerForTesting(t, ctx, nil)
+	defer ocDone()
+
+	p := &privateTxManager{
+		components: mocks.allComponents,
+		sequencers: map[string]*Sequencer{
+			testOc.contractAddress.String(): testOc,
+		},
+	}
+
+	// Neither dispatched nor prepared, so the failure receipt is written
+	txID := uuid.New()
+	mocks.txManager.On("GetPreparedTransactionByID", mock.Anything, mock.Anything, txID).Return(nil, nil)
+	mocks.txManager.On("FinalizeTransactions", mock.Anything, mock.Anything, mock.MatchedBy(func(receipts []*components.ReceiptInput) bool {
+		return len(receipts) == 1 && receipts[0].TransactionID == txID && receipts[0].ReceiptType == components.RT_FailedWithMessage
+	})).Return(nil).Once()
+	err := p.CancelTransaction(ctx, "domain1", testOc.contractAddress, txID)
+	require.NoError(t, err)
+
+	// Dispatched before it was removed from memory
+	dispatchedID := uuid.New()
+	err = mocks.allComponents.Persistence().DB().Table("dispatches").Create(map[string]any{
+		"public_transaction_address": pldtypes.RandAddress().String(),
+		"public_transaction_id":      1,
+		"private_transaction_id":     dispatchedID.String(),
+		"id":                         uuid.NewString(),
+	}).Error
+	require.NoError(t, err)
+	err = p.CancelTransaction(ctx, "domain1", testOc.contractAddress, dispatchedID)
+	assert.Regexp(t, "PD011839", err)
+
+	// Prepared before it was removed from memory
+	preparedID := uuid.New()
+	mocks.txManager.On("GetPreparedTransactionByID", mock.Anything, mock.Anything, preparedID).Return(&pldapi.PreparedTransaction{
+		PreparedTransactionBase: &pldapi.PreparedTransactionBase{ID: preparedID},
+	}, nil)
+	err = p.CancelTransaction(ctx, "domain1", testOc.contractAddress, preparedID)
+	assert.Regexp(t, "PD011839", err)
+
+	testOc.Stop()
+}
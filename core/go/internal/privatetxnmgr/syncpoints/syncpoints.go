@@ -24,6 +24,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/flushwriter"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -71,13 +72,13 @@ type syncPoints struct {
 	transportMgr components.TransportManager
 }
 
-func NewSyncPoints(ctx context.Context, conf *pldconf.FlushWriterConfig, p persistence.Persistence, txMgr components.TXManager, pubTxMgr components.PublicTxManager, transportMgr components.TransportManager) SyncPoints {
+func NewSyncPoints(ctx context.Context, conf *pldconf.FlushWriterConfig, p persistence.Persistence, m metrics.Metrics, txMgr components.TXManager, pubTxMgr components.PublicTxManager, transportMgr components.TransportManager) SyncPoints {
 	s := &syncPoints{
 		txMgr:        txMgr,
 		pubTxMgr:     pubTxMgr,
 		transportMgr: transportMgr,
 	}
-	s.writer = flushwriter.NewWriter(ctx, s.runBatch, p, conf, &WriterConfigDefaults)
+	s.writer = flushwriter.NewWriter(ctx, "private_tx_syncpoints", s.runBatch, p, m, conf, &WriterConfigDefaults)
 	return s
 }
 
@@ -109,6 +109,7 @@ type transactionFlow struct {
 	delegateRequestBlockHeight  int64
 	delegated                   bool
 	delegateRequestTimer        *time.Timer
+	delegateNode                string
 	assemblePending             bool
 	complete                    bool
 	requestedVerifierResolution bool                                      //TODO add precision here so that we can track individual requests and implement retry as per endorsement
@@ -117,6 +118,10 @@ type transactionFlow struct {
 	localCoordinator            bool
 	dispatched                  bool
 	prepared                    bool
+	cancelled                   bool
+	cancelPending               bool // waiting for the coordinator to acknowledge the cancel of a delegated transaction
+	cancelRequestTime           time.Time
+	cancelRequestTimer          *time.Timer
 	clock                       ptmgrtypes.Clock
 	requestTimeout              time.Duration
 	selectCoordinator           ptmgrtypes.CoordinatorSelector
@@ -130,8 +135,14 @@ func (tf *transactionFlow) IsComplete(_ context.Context) bool {
 	return tf.complete
 }
 
+// IsFinalizing returns true once the transaction has been reverted, or has otherwise reached a final outcome
+// that is being written to the database
+func (tf *transactionFlow) IsFinalizing(_ context.Context) bool {
+	return tf.finalizeRequired
+}
+
 func (tf *transactionFlow) ReadyForSequencing(ctx context.Context) bool {
-	return tf.transaction.PostAssembly != nil
+	return tf.transaction.PostAssembly != nil && !tf.cancelled
 }
 
 func (tf *transactionFlow) Dispatched(_ context.Context) bool {
@@ -146,6 +157,29 @@ func (tf *transactionFlow) CoordinatingLocally(_ context.Context) bool {
 	return tf.localCoordinator
 }
 
+// LocalCoordinatorSelected repeats the coordinator selection for the transaction, returning false if another
+// node would now be selected as the coordinator (for example after a failed coordinator has recovered)
+func (tf *transactionFlow) LocalCoordinatorSelected(ctx context.Context) bool {
+	_, coordinatorNode, err := tf.selectCoordinator.SelectCoordinatorNode(ctx, tf.transaction, tf.environment)
+	if err != nil {
+		// the next action will handle the error in the same way as for the original selection
+		log.L(ctx).Errorf("Failed to select coordinator node for transaction %s: %s", tf.transaction.ID, err)
+		return false
+	}
+	return coordinatorNode == tf.nodeName || coordinatorNode == ""
+}
+
+// DelegatedTo returns the node that this transaction is delegated (or being delegated) to, and when the
+// latest delegation request was sent. The node is empty if the transaction is not delegated.
+func (tf *transactionFlow) DelegatedTo(_ context.Context) (node string, delegationTime time.Time) {
+	tf.statusLock.RLock()
+	defer tf.statusLock.RUnlock()
+	if !tf.delegated && !tf.delegatePending {
+		return "", time.Time{}
+	}
+	return tf.delegateNode, tf.delegateRequestTime
+}
+
 func (tf *transactionFlow) PrepareTransaction(ctx context.Context, defaultSigner string) (*components.PrivateTransaction, error) {
 
 	if tf.transaction.Signer == "" {
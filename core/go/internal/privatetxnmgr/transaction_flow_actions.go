@@ -78,6 +78,14 @@ func (tf *transactionFlow) Action(ctx context.Context) {
 		return
 	}
 
+	if tf.cancelPending {
+		if tf.clock.Now().Sub(tf.cancelRequestTime) >= tf.requestTimeout {
+			tf.requestCancel(ctx)
+		}
+		tf.logActionInfo(ctx, "Waiting for the coordinator to acknowledge the cancel")
+		return
+	}
+
 	if tf.dispatched {
 		tf.logActionInfo(ctx, "Transaction is dispatched")
 		return
@@ -239,6 +247,30 @@ func (tf *transactionFlow) setTransactionSigner(ctx context.Context) (reDelegate
 	return false, nil
 }
 
+// requestCancel asks the coordinator to release a delegated transaction, and nudges us to ask again if it does not answer
+func (tf *transactionFlow) requestCancel(ctx context.Context) {
+	tf.cancelRequestTime = tf.clock.Now()
+	if err := tf.transportWriter.SendCancelTransactionRequest(ctx, tf.delegateNode, tf.transaction.ID.String()); err != nil {
+		log.L(ctx).Errorf("Failed to send cancel for transaction %s to coordinator %s: %s", tf.transaction.ID, tf.delegateNode, err)
+	}
+	if tf.cancelRequestTimer != nil {
+		tf.cancelRequestTimer.Stop()
+	}
+	tf.cancelRequestTimer = time.AfterFunc(tf.requestTimeout, func() {
+		tf.publisher.PublishNudgeEvent(ctx, tf.transaction.ID.String())
+	})
+}
+
+// acknowledgeCancel answers a node that delegated a transaction to us and asked us to cancel it
+func (tf *transactionFlow) acknowledgeCancel(ctx context.Context, originator string, cancelled bool) {
+	if originator == "" {
+		return
+	}
+	if err := tf.transportWriter.SendCancelTransactionAcknowledgment(ctx, originator, tf.transaction.ID.String(), cancelled); err != nil {
+		log.L(ctx).Errorf("Failed to acknowledge cancel for transaction %s to %s: %s", tf.transaction.ID, originator, err)
+	}
+}
+
 func (tf *transactionFlow) revertTransaction(ctx context.Context, revertReason string) {
 	log.L(ctx).Errorf("Reverting transaction %s: %s", tf.transaction.ID.String(), revertReason)
 	//trigger a finalize and update the transaction state so that finalize can be retried if it fails
@@ -325,7 +357,9 @@ func (tf *transactionFlow) delegateIfRequired(ctx context.Context) (doContinue b
 	// TODO persist the delegation and send the request on the callback
 	if coordinatorNode == tf.nodeName || coordinatorNode == "" {
 		// we are the coordinator so we should continue
+		// (this might be after failing over from a remote coordinator)
 		tf.logActionDebug(ctx, "Local coordinator")
+		tf.localCoordinator = true
 		return true
 	}
 	tf.localCoordinator = false
@@ -357,6 +391,7 @@ func (tf *transactionFlow) delegateIfRequired(ctx context.Context) (doContinue b
 		tf.logActionError(ctx, "Failed to send delegation request", err)
 	}
 	tf.pendingDelegationRequestID = delegationRequestID
+	tf.delegateNode = coordinatorNode
 	tf.delegatePending = true
 	tf.delegateRequestBlockHeight = blockHeight
 	tf.delegateRequestTime = tf.clock.Now()
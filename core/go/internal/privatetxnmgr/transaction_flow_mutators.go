@@ -22,6 +22,7 @@ import (
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/ptmgrtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 )
 
@@ -65,6 +66,12 @@ func (tf *transactionFlow) ApplyEvent(ctx context.Context, event ptmgrtypes.Priv
 		tf.applyTransactionNudgeEvent(ctx, event)
 	case *ptmgrtypes.DelegationForInFlightEvent:
 		tf.applyDelegationForInFlightEvent(ctx, event)
+	case *ptmgrtypes.TransactionCancelledEvent:
+		tf.applyTransactionCancelledEvent(ctx, event)
+	case *ptmgrtypes.TransactionCancelAcknowledgedEvent:
+		tf.applyTransactionCancelAcknowledgedEvent(ctx, event)
+	case *ptmgrtypes.CoordinatorUnavailableEvent:
+		tf.applyCoordinatorUnavailableEvent(ctx, event)
 
 	default:
 		log.L(ctx).Warnf("Unknown event type: %T", event)
@@ -285,3 +292,109 @@ func (tf *transactionFlow) applyDelegationForInFlightEvent(ctx context.Context,
 	}
 
 }
+
+func (tf *transactionFlow) applyCoordinatorUnavailableEvent(ctx context.Context, event *ptmgrtypes.CoordinatorUnavailableEvent) {
+	log.L(ctx).Infof("applyCoordinatorUnavailableEvent transaction %s node=%s", tf.transaction.ID, event.Node)
+	tf.latestEvent = "CoordinatorUnavailableEvent"
+	if (!tf.delegated && !tf.delegatePending) || tf.delegateNode != event.Node {
+		log.L(ctx).Infof("Transaction %s is not delegated to %s", tf.transaction.ID, event.Node)
+		return
+	}
+	if tf.cancelPending {
+		// The node might still dispatch the transaction if it recovers, so we must not hand it to another
+		// coordinator. We keep asking it to cancel until it answers.
+		log.L(ctx).Infof("Transaction %s is waiting for %s to acknowledge a cancel", tf.transaction.ID, event.Node)
+		return
+	}
+
+	// Revert to the state before delegation, so that the next action selects a new coordinator and hands the
+	// transaction over to it. Any acknowledgment that arrives late from the failed node will be ignored.
+	tf.latestError = event.Reason
+	tf.status = "new"
+	tf.delegated = false
+	tf.delegatePending = false
+	tf.pendingDelegationRequestID = ""
+	if tf.delegateRequestTimer != nil {
+		tf.delegateRequestTimer.Stop()
+	}
+	tf.delegateRequestTimer = nil
+	log.L(ctx).Infof("delegation to %s abandoned for transaction %s", event.Node, tf.transaction.ID)
+}
+
+func (tf *transactionFlow) applyTransactionCancelledEvent(ctx context.Context, event *ptmgrtypes.TransactionCancelledEvent) {
+	log.L(ctx).Infof("applyTransactionCancelledEvent transaction %s originator=%s", tf.transaction.ID, event.Originator)
+	tf.latestEvent = "TransactionCancelledEvent"
+
+	if event.Originator != "" {
+		// A remote node can only cancel a transaction that it delegated to us
+		originator, err := pldtypes.PrivateIdentityLocator(tf.transaction.PreAssembly.TransactionSpecification.From).Node(ctx, false)
+		if err != nil || originator != event.Originator {
+			log.L(ctx).Warnf("Ignoring cancel for transaction %s from node %s that did not submit it", tf.transaction.ID, event.Originator)
+			return
+		}
+	}
+
+	if tf.dispatched {
+		log.L(ctx).Warnf("Cannot cancel transaction %s as it has been dispatched", tf.transaction.ID)
+		tf.acknowledgeCancel(ctx, event.Originator, false)
+		event.Respond(true, i18n.NewError(ctx, msgs.MsgPrivateTxMgrCancelDispatched, tf.transaction.ID))
+		return
+	}
+
+	if tf.finalizeRequired || tf.complete || tf.cancelPending {
+		log.L(ctx).Warnf("Ignoring cancel for transaction %s with status %s", tf.transaction.ID, tf.status)
+		tf.acknowledgeCancel(ctx, event.Originator, true)
+		event.Respond(true, nil)
+		return
+	}
+
+	if tf.delegated || tf.delegatePending {
+		// The coordinator might dispatch the transaction at any point until it has processed the cancel, so we
+		// only finalize the transaction once the coordinator acknowledges that it has released it
+		tf.status = "cancelling"
+		tf.cancelPending = true
+		tf.requestCancel(ctx)
+		event.Respond(true, nil)
+		return
+	}
+
+	tf.status = "cancelled"
+	tf.cancelled = true
+	tf.revertTransaction(ctx, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgTxMgrTransactionCancelled)))
+	tf.acknowledgeCancel(ctx, event.Originator, true)
+	event.Respond(true, nil)
+}
+
+func (tf *transactionFlow) applyTransactionCancelAcknowledgedEvent(ctx context.Context, event *ptmgrtypes.TransactionCancelAcknowledgedEvent) {
+	log.L(ctx).Infof("applyTransactionCancelAcknowledgedEvent transaction %s node=%s cancelled=%t", tf.transaction.ID, event.Node, event.Cancelled)
+	tf.latestEvent = "TransactionCancelAcknowledgedEvent"
+
+	if !tf.cancelPending || event.Node != tf.delegateNode {
+		log.L(ctx).Warnf("Ignoring cancel acknowledgment for transaction %s from node %s", tf.transaction.ID, event.Node)
+		return
+	}
+	tf.cancelPending = false
+	if tf.cancelRequestTimer != nil {
+		tf.cancelRequestTimer.Stop()
+	}
+	tf.cancelRequestTimer = nil
+
+	if !event.Cancelled {
+		// The coordinator has dispatched the transaction, so it will complete on the base ledger
+		tf.status = "delegated"
+		tf.latestError = i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxMgrCancelDispatched), tf.transaction.ID)
+		log.L(ctx).Warnf("Coordinator %s could not cancel transaction %s", event.Node, tf.transaction.ID)
+		return
+	}
+
+	tf.delegated = false
+	tf.delegatePending = false
+	if tf.delegateRequestTimer != nil {
+		tf.delegateRequestTimer.Stop()
+	}
+	tf.delegateRequestTimer = nil
+
+	tf.status = "cancelled"
+	tf.cancelled = true
+	tf.revertTransaction(ctx, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgTxMgrTransactionCancelled)))
+}
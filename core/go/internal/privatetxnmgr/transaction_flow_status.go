@@ -42,12 +42,18 @@ func (tf *transactionFlow) GetTxStatus(ctx context.Context) (components.PrivateT
 		}
 	}
 
+	coordinator := tf.nodeName
+	if tf.delegated || tf.delegatePending {
+		coordinator = tf.delegateNode
+	}
+
 	return components.PrivateTxStatus{
 		TxID:         tf.transaction.ID.String(),
 		Status:       tf.status,
 		LatestEvent:  tf.latestEvent,
 		LatestError:  tf.latestError,
 		Endorsements: endorsementStatus,
+		Coordinator:  coordinator,
 		Transaction:  tf.transaction,
 	}, nil
 }
@@ -17,6 +17,8 @@ package privatetxnmgr
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -1235,6 +1237,261 @@ func TestEndorsementResponseAfterRevert(t *testing.T) {
 
 }
 
+func newCancelTestTransaction() *components.PrivateTransaction {
+	txID := uuid.New()
+	return &components.PrivateTransaction{
+		ID:      txID,
+		Address: *pldtypes.RandAddress(),
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From:          "alice@node1",
+				TransactionId: txID.String(),
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{},
+	}
+}
+
+func expectCancelFinalize(mocks *transactionFlowDepencyMocks, txID uuid.UUID) {
+	mocks.syncPoints.On("QueueTransactionFinalize", mock.Anything, mock.Anything, mock.Anything, txID,
+		mock.MatchedBy(func(reason string) bool { return strings.Contains(reason, "PD012253") }),
+		mock.Anything, mock.Anything).Return().Once()
+}
+
+func TestTransactionCancelledLocal(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	tp.localCoordinator = true
+	assert.True(t, tp.ReadyForSequencing(ctx))
+
+	expectCancelFinalize(mocks, testTx.ID)
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+	})
+
+	assert.Equal(t, "cancelled", tp.status)
+	assert.True(t, tp.finalizeRequired)
+	assert.True(t, tp.finalizePending)
+	assert.False(t, tp.ReadyForSequencing(ctx))
+
+	// A second cancel is ignored, as is the action loop while the finalize is pending
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+	})
+	tp.Action(ctx)
+}
+
+func TestTransactionCancelledDelegated(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	tp.status = "delegated"
+	tp.delegated = true
+	tp.delegateNode = "node2"
+
+	mocks.transportWriter.On("SendCancelTransactionRequest", mock.Anything, "node2", testTx.ID.String()).Return(fmt.Errorf("pop")).Once()
+	result := make(chan ptmgrtypes.TransactionCancelResult, 1)
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Result:                      result,
+	})
+	assert.Equal(t, ptmgrtypes.TransactionCancelResult{InFlight: true}, <-result)
+
+	// Nothing is finalized until the coordinator acknowledges the cancel
+	assert.Equal(t, "cancelling", tp.status)
+	assert.True(t, tp.cancelPending)
+	assert.False(t, tp.finalizeRequired)
+
+	// The request is sent again if the coordinator does not answer, even if it has stopped heartbeating
+	tp.ApplyEvent(ctx, &ptmgrtypes.CoordinatorUnavailableEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Node:                        "node2",
+	})
+	assert.True(t, tp.delegated)
+	mocks.transportWriter.On("SendCancelTransactionRequest", mock.Anything, "node2", testTx.ID.String()).Return(nil).Once()
+	tp.cancelRequestTime = time.Now().Add(-tp.requestTimeout)
+	tp.Action(ctx)
+
+	// Acknowledgments from other nodes are ignored
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelAcknowledgedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Node:                        "node3",
+		Cancelled:                   true,
+	})
+	assert.True(t, tp.cancelPending)
+
+	expectCancelFinalize(mocks, testTx.ID)
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelAcknowledgedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Node:                        "node2",
+		Cancelled:                   true,
+	})
+	assert.Equal(t, "cancelled", tp.status)
+	assert.False(t, tp.cancelPending)
+	assert.False(t, tp.delegated)
+	assert.True(t, tp.finalizePending)
+}
+
+func TestTransactionCancelledDelegatedAlreadyDispatched(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	tp.status = "delegated"
+	tp.delegated = true
+	tp.delegateNode = "node2"
+
+	mocks.transportWriter.On("SendCancelTransactionRequest", mock.Anything, "node2", testTx.ID.String()).Return(nil).Once()
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+	})
+	assert.True(t, tp.cancelPending)
+
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelAcknowledgedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Node:                        "node2",
+		Cancelled:                   false,
+	})
+	assert.Equal(t, "delegated", tp.status)
+	assert.False(t, tp.cancelPending)
+	assert.True(t, tp.delegated)
+	assert.False(t, tp.finalizeRequired)
+	assert.Regexp(t, "PD011839", tp.latestError)
+}
+
+func TestTransactionCancelledRemote(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node2")
+
+	// Only the node that submitted the transaction can cancel it
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Originator:                  "node3",
+	})
+	assert.False(t, tp.cancelled)
+
+	expectCancelFinalize(mocks, testTx.ID)
+	mocks.transportWriter.On("SendCancelTransactionAcknowledgment", mock.Anything, "node1", testTx.ID.String(), true).Return(nil).Once()
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Originator:                  "node1",
+	})
+	assert.True(t, tp.cancelled)
+}
+
+func TestTransactionCancelledRemoteAfterDispatch(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node2")
+	tp.dispatched = true
+
+	mocks.transportWriter.On("SendCancelTransactionAcknowledgment", mock.Anything, "node1", testTx.ID.String(), false).Return(fmt.Errorf("pop")).Once()
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Originator:                  "node1",
+	})
+	assert.False(t, tp.cancelled)
+}
+
+func TestTransactionCancelledAfterDispatch(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, _ := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	tp.dispatched = true
+
+	result := make(chan ptmgrtypes.TransactionCancelResult, 1)
+	tp.ApplyEvent(ctx, &ptmgrtypes.TransactionCancelledEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Result:                      result,
+	})
+	r := <-result
+	assert.True(t, r.InFlight)
+	assert.Regexp(t, "PD011839", r.Err)
+	assert.False(t, tp.cancelled)
+	assert.False(t, tp.finalizeRequired)
+}
+
+func TestCoordinatorUnavailableFailover(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+
+	node, _ := tp.DelegatedTo(ctx)
+	assert.Empty(t, node)
+
+	delegationTime := time.Now()
+	tp.status = "delegated"
+	tp.delegated = true
+	tp.delegateNode = "node2"
+	tp.delegateRequestTime = delegationTime
+	tp.pendingDelegationRequestID = "delegation1"
+	tp.localCoordinator = false
+	node, since := tp.DelegatedTo(ctx)
+	assert.Equal(t, "node2", node)
+	assert.Equal(t, delegationTime, since)
+
+	// Ignored for a node we have not delegated to
+	tp.ApplyEvent(ctx, &ptmgrtypes.CoordinatorUnavailableEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Node:                        "node3",
+		Reason:                      "pop",
+	})
+	assert.Equal(t, "delegated", tp.status)
+	assert.True(t, tp.delegated)
+
+	tp.ApplyEvent(ctx, &ptmgrtypes.CoordinatorUnavailableEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{TransactionID: testTx.ID.String()},
+		Node:                        "node2",
+		Reason:                      "pop",
+	})
+	assert.Equal(t, "new", tp.status)
+	assert.False(t, tp.delegated)
+	assert.Empty(t, tp.pendingDelegationRequestID)
+	node, _ = tp.DelegatedTo(ctx)
+	assert.Empty(t, node)
+
+	// The next action hands the transaction over to the next candidate
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", mock.Anything, testTx, mock.Anything).Return(int64(100), "node3", nil).Once()
+	mocks.transportWriter.On("SendDelegationRequest", mock.Anything, mock.Anything, "node3", testTx, int64(100)).Return(nil).Once()
+	tp.Action(ctx)
+	assert.Equal(t, "delegating", tp.status)
+	assert.Equal(t, "node3", tp.delegateNode)
+	assert.True(t, tp.delegatePending)
+
+	status, err := tp.GetTxStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "node3", status.Coordinator)
+	assert.Equal(t, "pop", status.LatestError)
+}
+
+func TestStopDispatchWhenNoLongerCoordinator(t *testing.T) {
+	ctx := context.Background()
+	testTx := newCancelTestTransaction()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	cl, _ := newTestCoordinatorLiveness()
+	s := &Sequencer{
+		coordinatorLiveness: cl,
+		publisher:           mocks.publisher,
+	}
+	dispatchable := ptmgrtypes.DispatchableTransactions{"signer1": {tp}}
+
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", mock.Anything, testTx, mock.Anything).Return(int64(100), "node1", nil).Once()
+	assert.True(t, s.stillCoordinating(ctx, dispatchable))
+
+	// The preferred coordinator has recovered, so the transaction is nudged to hand it over
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", mock.Anything, testTx, mock.Anything).Return(int64(100), "node2", nil).Once()
+	mocks.publisher.On("PublishNudgeEvent", mock.Anything, testTx.ID.String()).Return().Once()
+	assert.False(t, s.stillCoordinating(ctx, dispatchable))
+
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", mock.Anything, testTx, mock.Anything).Return(int64(0), "", fmt.Errorf("pop")).Once()
+	assert.False(t, tp.LocalCoordinatorSelected(ctx))
+
+	// Not endorser coordinated
+	s.coordinatorLiveness = nil
+	assert.True(t, s.stillCoordinating(ctx, dispatchable))
+}
+
 func TestEndorsementResponseAfterReassemble(t *testing.T) {
 	// Similar to TestEndorsementResponseAfterRevert:
 	//  We send out 2 endorsement requests , the first one back causes a revert
@@ -20,6 +20,7 @@ import (
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 )
 
 func (p *privateTxManager) HandlePaladinMsg(ctx context.Context, message *components.ReceivedMessage) {
@@ -30,21 +31,30 @@ func (p *privateTxManager) HandlePaladinMsg(ctx context.Context, message *compon
 	messagePayload := message.Payload
 	fromNode := message.FromNode
 
+	// Handling is asynchronous under our own context, but continues the trace of the sending node
+	hCtx := tracing.WithSpanFrom(p.ctx, ctx)
+
 	switch message.MessageType {
 	case "EndorsementRequest":
-		go p.handleEndorsementRequest(p.ctx, messagePayload, fromNode)
+		go p.handleEndorsementRequest(hCtx, messagePayload, fromNode)
 	case "EndorsementResponse":
-		go p.handleEndorsementResponse(p.ctx, messagePayload)
+		go p.handleEndorsementResponse(hCtx, messagePayload)
 	case "DelegationRequest":
-		go p.handleDelegationRequest(p.ctx, messagePayload, fromNode)
+		go p.handleDelegationRequest(hCtx, messagePayload, fromNode)
 	case "DelegationRequestAcknowledgment":
-		go p.handleDelegationRequestAcknowledgment(p.ctx, messagePayload)
+		go p.handleDelegationRequestAcknowledgment(hCtx, messagePayload)
 	case "AssembleRequest":
-		go p.handleAssembleRequest(p.ctx, messagePayload, fromNode)
+		go p.handleAssembleRequest(hCtx, messagePayload, fromNode)
 	case "AssembleResponse":
-		go p.handleAssembleResponse(p.ctx, messagePayload)
+		go p.handleAssembleResponse(hCtx, messagePayload)
 	case "AssembleError":
-		go p.handleAssembleError(p.ctx, messagePayload)
+		go p.handleAssembleError(hCtx, messagePayload)
+	case "CancelTransactionRequest":
+		go p.handleCancelTransactionRequest(hCtx, messagePayload, fromNode)
+	case "CancelTransactionAcknowledgment":
+		go p.handleCancelTransactionAcknowledgment(hCtx, messagePayload, fromNode)
+	case "CoordinatorHeartbeat":
+		go p.handleCoordinatorHeartbeat(hCtx, messagePayload, fromNode)
 	default:
 		log.L(ctx).Errorf("Unknown message type: %s", message.MessageType)
 	}
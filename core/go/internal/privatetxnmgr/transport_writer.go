@@ -26,6 +26,7 @@ import (
 	pb "github.com/kaleido-io/paladin/core/pkg/proto/engine"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
@@ -46,6 +47,15 @@ type transportWriter struct {
 	contractAddress  *pldtypes.EthAddress
 }
 
+// send wraps each message in a span tagged with the transaction, which the transport manager
+// propagates to the receiving node
+func (tw *transportWriter) send(ctx context.Context, transactionID string, msg *components.FireAndForgetMessageSend) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "privatetx send "+msg.MessageType,
+		tracing.AttrTransactionID.String(transactionID), tracing.AttrNode.String(msg.Node))
+	defer func() { tracing.EndSpan(span, err) }()
+	return tw.transportManager.Send(ctx, msg)
+}
+
 func (tw *transportWriter) SendDelegationRequest(
 	ctx context.Context,
 	delegationId string,
@@ -73,7 +83,7 @@ func (tw *transportWriter) SendDelegationRequest(
 		return err
 	}
 
-	if err = tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+	if err = tw.send(ctx, transaction.ID.String(), &components.FireAndForgetMessageSend{
 		MessageType: "DelegationRequest",
 		Payload:     delegationRequestBytes,
 		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
@@ -105,7 +115,7 @@ func (tw *transportWriter) SendDelegationRequestAcknowledgment(
 		return err
 	}
 
-	if err = tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+	if err = tw.send(ctx, transactionID, &components.FireAndForgetMessageSend{
 		MessageType: "DelegationRequestAcknowledgment",
 		Payload:     delegationRequestAcknowledgmentBytes,
 		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
@@ -200,7 +210,7 @@ func (tw *transportWriter) SendEndorsementRequest(ctx context.Context, idempoten
 		log.L(ctx).Error("Error marshalling endorsement request", err)
 		return err
 	}
-	err = tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+	err = tw.send(ctx, transactionID, &components.FireAndForgetMessageSend{
 		MessageType: "EndorsementRequest",
 		Node:        targetNode,
 		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
@@ -230,7 +240,7 @@ func (tw *transportWriter) SendAssembleRequest(ctx context.Context, assemblingNo
 		log.L(ctx).Error("Error marshalling assemble request", err)
 		return err
 	}
-	err = tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+	err = tw.send(ctx, txID.String(), &components.FireAndForgetMessageSend{
 		MessageType: "AssembleRequest",
 		Node:        assemblingNode,
 		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
@@ -238,3 +248,60 @@ func (tw *transportWriter) SendAssembleRequest(ctx context.Context, assemblingNo
 	})
 	return err
 }
+
+func (tw *transportWriter) SendCancelTransactionRequest(ctx context.Context, coordinatorNode string, transactionID string) error {
+	cancelRequest := &engineProto.CancelTransactionRequest{
+		TransactionId:   transactionID,
+		ContractAddress: tw.contractAddress.String(),
+	}
+	cancelRequestBytes, err := proto.Marshal(cancelRequest)
+	if err != nil {
+		log.L(ctx).Error("Error marshalling cancel transaction request", err)
+		return err
+	}
+	return tw.send(ctx, transactionID, &components.FireAndForgetMessageSend{
+		MessageType: "CancelTransactionRequest",
+		Node:        coordinatorNode,
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		Payload:     cancelRequestBytes,
+	})
+}
+
+func (tw *transportWriter) SendCancelTransactionAcknowledgment(ctx context.Context, originatorNode string, transactionID string, cancelled bool) error {
+	cancelAck := &engineProto.CancelTransactionAcknowledgment{
+		TransactionId:   transactionID,
+		ContractAddress: tw.contractAddress.String(),
+		Cancelled:       cancelled,
+	}
+	cancelAckBytes, err := proto.Marshal(cancelAck)
+	if err != nil {
+		log.L(ctx).Error("Error marshalling cancel transaction acknowledgment", err)
+		return err
+	}
+	return tw.send(ctx, transactionID, &components.FireAndForgetMessageSend{
+		MessageType: "CancelTransactionAcknowledgment",
+		Node:        originatorNode,
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		Payload:     cancelAckBytes,
+	})
+}
+
+// Heartbeats are not associated with a transaction, so are sent without a span
+func (tw *transportWriter) SendCoordinatorHeartbeat(ctx context.Context, targetNode string, blockHeight int64) error {
+	heartbeat := &engineProto.CoordinatorHeartbeat{
+		ContractAddress: tw.contractAddress.String(),
+		CoordinatorNode: tw.nodeID,
+		BlockHeight:     blockHeight,
+	}
+	heartbeatBytes, err := proto.Marshal(heartbeat)
+	if err != nil {
+		log.L(ctx).Error("Error marshalling coordinator heartbeat", err)
+		return err
+	}
+	return tw.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
+		MessageType: "CoordinatorHeartbeat",
+		Node:        targetNode,
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		Payload:     heartbeatBytes,
+	})
+}
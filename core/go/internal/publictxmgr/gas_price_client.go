@@ -20,11 +20,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
@@ -47,14 +50,28 @@ type GasPriceClient interface {
 
 // The hybrid gas price client retrieves gas price using the following methods in order and will return as soon as the method succeeded unless there is an override
 //   - Fixed gas price
+//   - Node eth_feeHistory (EIP-1559, if enabled - cached for a limited time)
 //   - Cached gas price
 //   - Gas Oracle
 //   - Node gas_Price
 type HybridGasPriceClient struct {
-	hasZeroGasPrice bool
-	fixedGasPrice   *fftypes.JSONAny
-	ethClient       ethclient.EthClient
-	gasPriceCache   cache.Cache[string, *fftypes.JSONAny]
+	hasZeroGasPrice         bool
+	fixedGasPrice           *fftypes.JSONAny
+	feeHistory              *feeHistoryConfig // nil if the fee history is not enabled
+	maxFeePerGasCap         *big.Int
+	maxPriorityFeePerGasCap *big.Int
+	ethClient               ethclient.EthClient
+	gasPriceCache           cache.Cache[string, *fftypes.JSONAny]
+	feeHistoryMux           sync.Mutex
+	feeHistoryGPO           *pldapi.PublicTxGasPricing
+	feeHistoryTime          time.Time
+}
+
+type feeHistoryConfig struct {
+	blockCount        int
+	rewardPercentile  float64
+	baseFeeMultiplier float64
+	cacheTTL          time.Duration
 }
 
 func (hGpc *HybridGasPriceClient) HasZeroGasPrice(ctx context.Context) bool {
@@ -100,7 +117,17 @@ func (hGpc *HybridGasPriceClient) getGasPriceJSON(ctx context.Context) (gasPrice
 		return
 	}
 
-	// use the cache gas price first
+	// then try the fee history of recent blocks, for EIP-1559 pricing. This is not held in the gas price cache,
+	// which does not expire, as it must follow the base fee as it changes.
+	if hGpc.feeHistory != nil {
+		gpo, err := hGpc.getCachedFeeHistoryGasPrice(ctx)
+		if err == nil {
+			return fftypes.JSONAnyPtr(pldtypes.JSONString(gpo).String()), nil
+		}
+		log.L(ctx).Warnf("Failed to retrieve gas price from the node fee history, falling back to gas price: %s", err)
+	}
+
+	// use the cache gas price
 	cachedGasPrice, _ := hGpc.gasPriceCache.Get("gasPrice")
 	if cachedGasPrice != nil {
 		return cachedGasPrice, nil
@@ -122,6 +149,130 @@ func (hGpc *HybridGasPriceClient) getGasPriceJSON(ctx context.Context) (gasPrice
 	return gasPriceJSON, nil
 
 }
+
+// getCachedFeeHistoryGasPrice returns the gas price from the fee history, only querying the node
+// if the last result is older than the configured TTL
+func (hGpc *HybridGasPriceClient) getCachedFeeHistoryGasPrice(ctx context.Context) (*pldapi.PublicTxGasPricing, error) {
+	hGpc.feeHistoryMux.Lock()
+	defer hGpc.feeHistoryMux.Unlock()
+	if hGpc.feeHistoryGPO != nil && time.Since(hGpc.feeHistoryTime) < hGpc.feeHistory.cacheTTL {
+		return hGpc.feeHistoryGPO, nil
+	}
+	log.L(ctx).Debugf("Retrieving gas price from node fee history")
+	gpo, err := hGpc.getFeeHistoryGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hGpc.feeHistoryGPO = gpo
+	hGpc.feeHistoryTime = time.Now()
+	return gpo, nil
+}
+
+// getFeeHistoryGasPrice derives the EIP-1559 fees from the recent blocks. The priority fee is the average of
+// the configured percentile of the priority fees paid in each block, and the max fee allows for the base fee
+// of the next block to rise by the configured multiplier before the transaction is priced out.
+func (hGpc *HybridGasPriceClient) getFeeHistoryGasPrice(ctx context.Context) (*pldapi.PublicTxGasPricing, error) {
+	feeHistory, err := hGpc.ethClient.FeeHistory(ctx, hGpc.feeHistory.blockCount, "latest", []float64{hGpc.feeHistory.rewardPercentile})
+	if err != nil {
+		return nil, err
+	}
+	if len(feeHistory.BaseFeePerGas) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgFeeHistoryNoBaseFee)
+	}
+	// the last base fee in the history is the one for the next block
+	nextBaseFee := feeHistory.BaseFeePerGas[len(feeHistory.BaseFeePerGas)-1].Int()
+
+	totalReward := big.NewInt(0)
+	rewardCount := 0
+	for _, blockRewards := range feeHistory.Reward {
+		// blocks with no transactions report a zero reward, so are not representative
+		if len(blockRewards) > 0 && blockRewards[0] != nil && blockRewards[0].Int().Sign() > 0 {
+			totalReward.Add(totalReward, blockRewards[0].Int())
+			rewardCount++
+		}
+	}
+	maxPriorityFeePerGas := big.NewInt(0)
+	if rewardCount > 0 {
+		maxPriorityFeePerGas.Div(totalReward, big.NewInt(int64(rewardCount)))
+	}
+
+	maxFeePerGas, _ := new(big.Float).Mul(new(big.Float).SetInt(nextBaseFee), big.NewFloat(hGpc.feeHistory.baseFeeMultiplier)).Int(nil)
+	maxFeePerGas.Add(maxFeePerGas, maxPriorityFeePerGas)
+
+	gpo := capFeesPerGas(&pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         (*pldtypes.HexUint256)(maxFeePerGas),
+		MaxPriorityFeePerGas: (*pldtypes.HexUint256)(maxPriorityFeePerGas),
+	}, hGpc.maxFeePerGasCap, hGpc.maxPriorityFeePerGasCap)
+	log.L(ctx).Debugf("Gas price from fee history (nextBaseFee=%s): maxFeePerGas=%s maxPriorityFeePerGas=%s", nextBaseFee, gpo.MaxFeePerGas, gpo.MaxPriorityFeePerGas)
+	return gpo, nil
+}
+
+// capFeesPerGas applies the configured limits to the EIP-1559 fees of a transaction,
+// keeping the priority fee within the max fee. Legacy gas pricing is returned unchanged.
+func capFeesPerGas(gpo *pldapi.PublicTxGasPricing, maxFeePerGasCap, maxPriorityFeePerGasCap *big.Int) *pldapi.PublicTxGasPricing {
+	if gpo == nil || gpo.MaxFeePerGas == nil || (maxFeePerGasCap == nil && maxPriorityFeePerGasCap == nil) {
+		return gpo
+	}
+	capped := *gpo
+	if maxFeePerGasCap != nil && capped.MaxFeePerGas.Int().Cmp(maxFeePerGasCap) > 0 {
+		capped.MaxFeePerGas = (*pldtypes.HexUint256)(new(big.Int).Set(maxFeePerGasCap))
+	}
+	if capped.MaxPriorityFeePerGas != nil {
+		if maxPriorityFeePerGasCap != nil && capped.MaxPriorityFeePerGas.Int().Cmp(maxPriorityFeePerGasCap) > 0 {
+			capped.MaxPriorityFeePerGas = (*pldtypes.HexUint256)(new(big.Int).Set(maxPriorityFeePerGasCap))
+		}
+		if capped.MaxPriorityFeePerGas.Int().Cmp(capped.MaxFeePerGas.Int()) > 0 {
+			capped.MaxPriorityFeePerGas = capped.MaxFeePerGas
+		}
+	}
+	return &capped
+}
+
+// replacementGasPrice raises each fee of the new gas price to at least 10% above the same fee on the
+// submission being replaced, which is the minimum increase nodes accept for a replacement on the same nonce.
+// A legacy gas price is compared with both fees of an EIP-1559 submission, as it is by the nodes.
+func replacementGasPrice(replaced, gpo *pldapi.PublicTxGasPricing) *pldapi.PublicTxGasPricing {
+	if gpo == nil {
+		return nil
+	}
+	bumped := *gpo
+	if gpo.MaxFeePerGas != nil {
+		bumped.MaxFeePerGas = replacementFee(firstFeeSet(replaced.MaxFeePerGas, replaced.GasPrice), gpo.MaxFeePerGas)
+		bumped.MaxPriorityFeePerGas = replacementFee(firstFeeSet(replaced.MaxPriorityFeePerGas, replaced.GasPrice), gpo.MaxPriorityFeePerGas)
+	} else {
+		bumped.GasPrice = replacementFee(firstFeeSet(replaced.GasPrice, replaced.MaxFeePerGas), gpo.GasPrice)
+	}
+	return &bumped
+}
+
+func firstFeeSet(fee, fallback *pldtypes.HexUint256) *pldtypes.HexUint256 {
+	if fee != nil {
+		return fee
+	}
+	return fallback
+}
+
+func replacementFee(replaced, fee *pldtypes.HexUint256) *pldtypes.HexUint256 {
+	if replaced == nil {
+		return fee
+	}
+	// round up, so that a fee of 1 wei is still increased
+	minFee := new(big.Int).Mul(replaced.Int(), big.NewInt(110))
+	minFee = minFee.Add(minFee, big.NewInt(99))
+	minFee = minFee.Div(minFee, big.NewInt(100))
+	if fee != nil && fee.Int().Cmp(minFee) >= 0 {
+		return fee
+	}
+	return (*pldtypes.HexUint256)(minFee)
+}
+
+func lowerFeeCap(nodeCap *big.Int, txCap *pldtypes.HexUint256) *big.Int {
+	if txCap == nil || (nodeCap != nil && nodeCap.Cmp(txCap.Int()) < 0) {
+		return nodeCap
+	}
+	return txCap.Int()
+}
+
 func (hGpc *HybridGasPriceClient) Init(ctx context.Context, ethClient ethclient.EthClient) {
 	hGpc.ethClient = ethClient
 	// check whether it's a gasless chain
@@ -141,6 +292,9 @@ func (hGpc *HybridGasPriceClient) Init(ctx context.Context, ethClient ethclient.
 
 func (hGpc *HybridGasPriceClient) DeleteCache(ctx context.Context) {
 	hGpc.gasPriceCache.Delete("gasPrice")
+	hGpc.feeHistoryMux.Lock()
+	defer hGpc.feeHistoryMux.Unlock()
+	hGpc.feeHistoryGPO = nil
 }
 
 func NewGasPriceClient(ctx context.Context, conf *pldconf.PublicTxManagerConfig) GasPriceClient {
@@ -153,6 +307,16 @@ func NewGasPriceClient(ctx context.Context, conf *pldconf.PublicTxManagerConfig)
 	if b != nil && string(b) != `null` {
 		gasPriceClient.fixedGasPrice = fftypes.JSONAnyPtrBytes(b)
 	}
+	if confutil.Bool(conf.GasPrice.FeeHistory.Enabled, *pldconf.PublicTxManagerDefaults.GasPrice.FeeHistory.Enabled) {
+		gasPriceClient.feeHistory = &feeHistoryConfig{
+			blockCount:        confutil.IntMin(conf.GasPrice.FeeHistory.BlockCount, 1, *pldconf.PublicTxManagerDefaults.GasPrice.FeeHistory.BlockCount),
+			rewardPercentile:  confutil.Float64Min(conf.GasPrice.FeeHistory.RewardPercentile, 0, *pldconf.PublicTxManagerDefaults.GasPrice.FeeHistory.RewardPercentile),
+			baseFeeMultiplier: confutil.Float64Min(conf.GasPrice.FeeHistory.BaseFeeMultiplier, 1.0, *pldconf.PublicTxManagerDefaults.GasPrice.FeeHistory.BaseFeeMultiplier),
+			cacheTTL:          confutil.DurationMin(conf.GasPrice.FeeHistory.CacheTTL, 0, *pldconf.PublicTxManagerDefaults.GasPrice.FeeHistory.CacheTTL),
+		}
+	}
+	gasPriceClient.maxFeePerGasCap = confutil.BigIntOrNil(conf.GasPrice.MaxFeePerGasCap)
+	gasPriceClient.maxPriorityFeePerGasCap = confutil.BigIntOrNil(conf.GasPrice.MaxPriorityFeePerGasCap)
 	gasPriceClient.gasPriceCache = gasPriceCache
 	return gasPriceClient
 }
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 
 	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
@@ -175,3 +177,160 @@ func TestGasPriceClient(t *testing.T) {
 	assert.Regexp(t, "doesn't work", err)
 	assert.Nil(t, gpo)
 }
+
+func TestFeeHistoryGasPrice(t *testing.T) {
+	ctx := context.Background()
+
+	gasPriceClient := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			FeeHistory: pldconf.FeeHistoryConfig{
+				Enabled:           confutil.P(true),
+				BlockCount:        confutil.P(3),
+				RewardPercentile:  confutil.P(75.0),
+				BaseFeeMultiplier: confutil.P(1.5),
+			},
+		},
+	})
+	hgc := gasPriceClient.(*HybridGasPriceClient)
+
+	mEC := ethclientmocks.NewEthClient(t)
+	hgc.Init(ctx, mEC)
+
+	mEC.On("FeeHistory", ctx, 3, "latest", []float64{75.0}).Return(&ethclient.FeeHistoryResult{
+		BaseFeePerGas: []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(900), pldtypes.Uint64ToUint256(1000)},
+		Reward: [][]*pldtypes.HexUint256{
+			{pldtypes.Uint64ToUint256(10)},
+			{pldtypes.Uint64ToUint256(0)}, // empty block is ignored
+			{pldtypes.Uint64ToUint256(30)},
+		},
+	}, nil).Once()
+	gpo, err := hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, gpo.GasPrice)
+	assert.Equal(t, big.NewInt(20), gpo.MaxPriorityFeePerGas.Int())
+	assert.Equal(t, big.NewInt(1520), gpo.MaxFeePerGas.Int())
+
+	// cached
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1520), gpo.MaxFeePerGas.Int())
+
+	// until the cache TTL passes, so we follow the base fee as it changes
+	assert.Equal(t, 5*time.Second, hgc.feeHistory.cacheTTL)
+	hgc.feeHistoryTime = time.Now().Add(-10 * time.Second)
+	mEC.On("FeeHistory", ctx, 3, "latest", []float64{75.0}).Return(&ethclient.FeeHistoryResult{
+		BaseFeePerGas: []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(2000)},
+		Reward:        [][]*pldtypes.HexUint256{{pldtypes.Uint64ToUint256(20)}},
+	}, nil).Once()
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3020), gpo.MaxFeePerGas.Int())
+
+	// falls back to the gas price when the fee history is not available
+	hgc.DeleteCache(ctx)
+	mEC.On("FeeHistory", ctx, 3, "latest", []float64{75.0}).Return(nil, fmt.Errorf("pop")).Once()
+	mEC.On("GasPrice", ctx).Return(pldtypes.Uint64ToUint256(1000), nil).Once()
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), gpo.GasPrice.Int())
+	assert.Nil(t, gpo.MaxFeePerGas)
+
+	// or when it has no base fee (pre-London chains)
+	hgc.DeleteCache(ctx)
+	mEC.On("FeeHistory", ctx, 3, "latest", []float64{75.0}).Return(&ethclient.FeeHistoryResult{}, nil).Once()
+	mEC.On("GasPrice", ctx).Return(pldtypes.Uint64ToUint256(1000), nil).Once()
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), gpo.GasPrice.Int())
+}
+
+func TestFeeHistoryGasPriceCapped(t *testing.T) {
+	ctx := context.Background()
+
+	gasPriceClient := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			MaxFeePerGasCap:         confutil.P("1000"),
+			MaxPriorityFeePerGasCap: confutil.P("15"),
+			FeeHistory: pldconf.FeeHistoryConfig{
+				Enabled: confutil.P(true),
+			},
+		},
+	})
+	hgc := gasPriceClient.(*HybridGasPriceClient)
+
+	mEC := ethclientmocks.NewEthClient(t)
+	hgc.Init(ctx, mEC)
+
+	mEC.On("FeeHistory", ctx, 20, "latest", []float64{50.0}).Return(&ethclient.FeeHistoryResult{
+		BaseFeePerGas: []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(1000)},
+		Reward:        [][]*pldtypes.HexUint256{{pldtypes.Uint64ToUint256(20)}},
+	}, nil).Once()
+	gpo, err := hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(15), gpo.MaxPriorityFeePerGas.Int())
+	assert.Equal(t, big.NewInt(1000), gpo.MaxFeePerGas.Int())
+}
+
+func TestCapFeesPerGas(t *testing.T) {
+	legacy := &pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(100)}
+	assert.Equal(t, legacy, capFeesPerGas(legacy, big.NewInt(10), big.NewInt(1)))
+
+	gpo := &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(50),
+	}
+	assert.Equal(t, gpo, capFeesPerGas(gpo, nil, nil))
+
+	capped := capFeesPerGas(gpo, big.NewInt(40), nil)
+	assert.Equal(t, big.NewInt(40), capped.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(40), capped.MaxPriorityFeePerGas.Int())
+	assert.Equal(t, big.NewInt(100), gpo.MaxFeePerGas.Int()) // original unchanged
+
+	capped = capFeesPerGas(gpo, nil, big.NewInt(5))
+	assert.Equal(t, big.NewInt(100), capped.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(5), capped.MaxPriorityFeePerGas.Int())
+}
+
+func TestLowerFeeCap(t *testing.T) {
+	assert.Nil(t, lowerFeeCap(nil, nil))
+	assert.Equal(t, big.NewInt(10), lowerFeeCap(big.NewInt(10), nil))
+	assert.Equal(t, big.NewInt(5), lowerFeeCap(nil, pldtypes.Uint64ToUint256(5)))
+	assert.Equal(t, big.NewInt(5), lowerFeeCap(big.NewInt(10), pldtypes.Uint64ToUint256(5)))
+	assert.Equal(t, big.NewInt(10), lowerFeeCap(big.NewInt(10), pldtypes.Uint64ToUint256(50)))
+}
+
+func TestReplacementGasPrice(t *testing.T) {
+	assert.Nil(t, replacementGasPrice(&pldapi.PublicTxGasPricing{}, nil))
+
+	// legacy replacing legacy, rounding up
+	gpo := replacementGasPrice(
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(15)},
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(10)},
+	)
+	assert.Equal(t, uint64(17), gpo.GasPrice.Int().Uint64())
+	assert.Nil(t, gpo.MaxFeePerGas)
+
+	// EIP-1559 replacing EIP-1559, keeping a fee that is already high enough
+	gpo = replacementGasPrice(
+		&pldapi.PublicTxGasPricing{MaxFeePerGas: pldtypes.Uint64ToUint256(100), MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(10)},
+		&pldapi.PublicTxGasPricing{MaxFeePerGas: pldtypes.Uint64ToUint256(200), MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(5)},
+	)
+	assert.Equal(t, uint64(200), gpo.MaxFeePerGas.Int().Uint64())
+	assert.Equal(t, uint64(11), gpo.MaxPriorityFeePerGas.Int().Uint64())
+	assert.Nil(t, gpo.GasPrice)
+
+	// EIP-1559 replacing legacy
+	gpo = replacementGasPrice(
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(100)},
+		&pldapi.PublicTxGasPricing{MaxFeePerGas: pldtypes.Uint64ToUint256(50), MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(1)},
+	)
+	assert.Equal(t, uint64(110), gpo.MaxFeePerGas.Int().Uint64())
+	assert.Equal(t, uint64(110), gpo.MaxPriorityFeePerGas.Int().Uint64())
+
+	// legacy replacing EIP-1559
+	gpo = replacementGasPrice(
+		&pldapi.PublicTxGasPricing{MaxFeePerGas: pldtypes.Uint64ToUint256(100), MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(1)},
+		&pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(50)},
+	)
+	assert.Equal(t, uint64(110), gpo.GasPrice.Int().Uint64())
+}
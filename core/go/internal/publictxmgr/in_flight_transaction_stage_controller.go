@@ -72,6 +72,16 @@ type inFlightTransactionStageController struct {
 
 	updates   []*DBPublicTxn
 	updateMux sync.Mutex
+	// the gas pricing of the last submission, when an update replaces a transaction that has already
+	// been submitted, which the replacement must be priced above for the node to accept it
+	replacedGasPricing *pldapi.PublicTxGasPricing
+
+	// block based resubmission, and stuck reporting
+	lastSubmitSeen  pldtypes.Timestamp
+	lastSubmitBlock uint64
+	stuckReported   bool
+	// submission attempts, including resubmissions of the same transaction hash once the gas price has reached its cap
+	submitAttempts int
 
 	// deleteRequested bool // figure out what's the reliable approach for deletion
 }
@@ -134,6 +144,7 @@ func NewInFlightTransactionStageController(
 		txInflightTime: time.Now(),
 		txInDBTime:     ptx.Created.Time(),
 		txTimeline:     txTimeline,
+		submitAttempts: countSubmitAttempts(ptx.Submissions),
 	}
 
 	ift.MarkTime("wait_in_inflight_queue")
@@ -206,6 +217,12 @@ func (it *inFlightTransactionStageController) ProduceLatestInFlightStageContext(
 		// Process each update in order. If there are multiple updates they will all be recorded in the database, but only the
 		// last one will be acted on
 		for _, update := range updates {
+			if it.replacedGasPricing == nil && it.stateManager.GetTransactionHash() != nil {
+				if gpo := it.stateManager.GetGasPriceObject(); gpo != nil {
+					replaced := *gpo
+					it.replacedGasPricing = &replaced
+				}
+			}
 			it.stateManager.UpdateTransaction(update)
 			madeUpdate = true
 		}
@@ -240,7 +257,11 @@ func (it *inFlightTransactionStageController) ProduceLatestInFlightStageContext(
 		// no running context in flight
 		// The action for each stage can be started asynchronously; however, any transation values from the in memory transaction must
 		// be read within this goroutine so that we know that they haven't been changed by an update part way through.
-		it.startNewStage(ctx, tOut.Cost)
+		var blockHeight uint64
+		if tIn != nil {
+			blockHeight = tIn.BlockHeight
+		}
+		it.startNewStage(ctx, blockHeight, tOut.Cost)
 	}
 	tOut.TransactionSubmitted = it.stateManager.GetTransactionHash() != nil
 
@@ -311,6 +332,8 @@ func (it *inFlightTransactionStageController) processRetrieveGasPriceStageOutput
 		if stageOutput.PersistenceOutput.PersistenceError == nil && !rsc.StageErrored {
 			// new gas price retrieved, the state no longer matches the transaction hash
 			generation.SetValidatedTransactionHashMatchState(ctx, false)
+			// and any replacement has been priced above the submission it replaces
+			it.replacedGasPricing = nil
 			// we've persisted successfully, it's safe to move to the next stage based on the latest state of the managed transaction
 			generation.ClearRunningStageContext(ctx)
 		}
@@ -392,6 +415,8 @@ func (it *inFlightTransactionStageController) processSigningStageOutput(ctx cont
 				Created:         pldtypes.TimestampNow(),
 				TransactionHash: *rsc.StageOutput.SignOutput.TxHash,
 				GasPricing:      gasPriceJSON,
+				Attempts:        1,
+				Cancellation:    rsc.InMemoryTx.IsCancelled(),
 			}
 			rsc.StageOutputsToBePersisted.TxUpdates.TransactionHash = rsc.StageOutput.SignOutput.TxHash
 		}
@@ -422,6 +447,7 @@ func (it *inFlightTransactionStageController) processSubmittingStageOutput(ctx c
 		generation.ClearRunningStageContext(ctx)
 	} else {
 		rsc.StageOutput.SubmitOutput = stageOutput.SubmitOutput
+		it.submitAttempts++
 		// transaction submitted
 		rsc.SetNewPersistenceUpdateOutput()
 		if stageOutput.SubmitOutput.Err != nil {
@@ -492,7 +518,7 @@ func (it *inFlightTransactionStageController) processStatusUpdateStageOutput(ctx
 	return
 }
 
-func (it *inFlightTransactionStageController) startNewStage(ctx context.Context, cost *big.Int) {
+func (it *inFlightTransactionStageController) startNewStage(ctx context.Context, blockHeight uint64, cost *big.Int) {
 	// first check whether the current transaction is before the confirmed nonce
 	if it.newStatus != nil && !it.stateManager.IsReadyToExit() && *it.newStatus != it.stateManager.GetInFlightStatus() { // first apply any status update that's required
 		log.L(ctx).Debugf("Transaction with ID %s entering status update, current status: %s, target status: %s", it.stateManager.GetSignerNonce(), it.stateManager.GetInFlightStatus(), *it.newStatus)
@@ -506,6 +532,10 @@ func (it *inFlightTransactionStageController) startNewStage(ctx context.Context,
 		// no gas price fetched, go and fetch gas price
 		log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as no gas price available.", it.stateManager.GetSignerNonce())
 		it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusReceived)
+	} else if it.replacedGasPricing != nil {
+		// an update replaced a submitted transaction, so it needs a new gas price before it is signed
+		log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price to price the replacement of a submitted transaction.", it.stateManager.GetSignerNonce())
+		it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusReceived)
 	} else if it.stateManager.GetTransactionHash() == nil {
 		if it.stateManager.CanSubmit(ctx, cost) {
 			// no transaction hash, do signing and submission
@@ -526,10 +556,10 @@ func (it *inFlightTransactionStageController) startNewStage(ctx context.Context,
 			}
 		} else {
 			// once we validated the transaction hash matched the transaction state
-			lastSubmitTime := it.stateManager.GetLastSubmitTime()
-			if lastSubmitTime != nil && time.Since(lastSubmitTime.Time()) > it.resubmitInterval {
-				// do a resubmission when exceeded the resubmit interval
-				log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as exceeded resubmit interval of %s.", it.stateManager.GetSignerNonce(), it.resubmitInterval.String())
+			if it.resubmitDue(ctx, blockHeight) {
+				// do a resubmission when exceeded the resubmit interval, or number of blocks
+				log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as exceeded resubmit interval of %s or %d blocks.", it.stateManager.GetSignerNonce(), it.resubmitInterval.String(), it.resubmitBlocks)
+				it.checkStuck(ctx)
 				it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusStale)
 			} else {
 				// check and track the existing transaction hash
@@ -542,10 +572,55 @@ func (it *inFlightTransactionStageController) startNewStage(ctx context.Context,
 	}
 }
 
+// resubmitDue checks whether the last submission has waited longer than the resubmit interval,
+// or (if configured) has not been mined within the configured number of blocks
+func (it *inFlightTransactionStageController) resubmitDue(ctx context.Context, blockHeight uint64) bool {
+	lastSubmitTime := it.stateManager.GetLastSubmitTime()
+	if lastSubmitTime == nil {
+		return false
+	}
+	if time.Since(lastSubmitTime.Time()) > it.resubmitInterval {
+		return true
+	}
+	if it.resubmitBlocks == 0 || blockHeight == 0 {
+		return false
+	}
+	if *lastSubmitTime != it.lastSubmitSeen {
+		// we count blocks from the first time we see each submission
+		it.lastSubmitSeen = *lastSubmitTime
+		it.lastSubmitBlock = blockHeight
+		return false
+	}
+	if blockHeight-it.lastSubmitBlock >= it.resubmitBlocks {
+		log.L(ctx).Debugf("Transaction with ID %s not mined in %d blocks since block %d", it.stateManager.GetSignerNonce(), blockHeight-it.lastSubmitBlock, it.lastSubmitBlock)
+		return true
+	}
+	return false
+}
+
+// checkStuck flags (once) a transaction that has been submitted the configured number of times
+// without being mined, which usually means it is underpriced and has reached the fee cap,
+// or is blocked behind a nonce gap
+func (it *inFlightTransactionStageController) checkStuck(ctx context.Context) {
+	if it.stuckAfterAttempts <= 0 || it.stuckReported {
+		return
+	}
+	submissions := it.submitAttempts
+	if submissions >= it.stuckAfterAttempts {
+		it.stuckReported = true
+		msg := i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPublicTxStuck), it.stateManager.GetFrom(), it.stateManager.GetNonce(), submissions)
+		log.L(ctx).Warn(msg)
+		it.addActivityRecord(it.stateManager.GetPubTxnID(), msg)
+	}
+}
+
 func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.Context, existingGpo *pldapi.PublicTxGasPricing, newGpo *pldapi.PublicTxGasPricing) *pldapi.PublicTxGasPricing {
 	if existingGpo == nil {
 		log.L(ctx).Debugf("First time assigning gas price to transaction with ID: %s, gas price object: %+v.", it.stateManager.GetSignerNonce(), newGpo)
-		return newGpo
+		if it.replacedGasPricing != nil {
+			newGpo = replacementGasPrice(it.replacedGasPricing, newGpo)
+		}
+		return capFeesPerGas(newGpo, it.feeCaps())
 	}
 
 	// The change is not made here to InMemoryTx, but rather pushed to TxUpdates for persisting.
@@ -575,14 +650,35 @@ func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.C
 		if it.gasPriceIncreaseMax != nil && newMaxFeePerGas.Cmp(it.gasPriceIncreaseMax) == 1 {
 			newMaxFeePerGas.Set(it.gasPriceIncreaseMax)
 		}
+		// the priority fee must also increase for the node to accept the resubmission as a replacement,
+		// unless the latest fee history already suggests a higher one
+		newMaxPriorityFeePerGas := existingGpo.MaxPriorityFeePerGas
+		if existingGpo.MaxPriorityFeePerGas != nil {
+			bumped := new(big.Int).Mul(existingGpo.MaxPriorityFeePerGas.Int(), newPercentage)
+			bumped = bumped.Div(bumped, big.NewInt(100))
+			if newGpo.MaxPriorityFeePerGas != nil && newGpo.MaxPriorityFeePerGas.Int().Cmp(bumped) == 1 {
+				bumped.Set(newGpo.MaxPriorityFeePerGas.Int())
+			}
+			newMaxPriorityFeePerGas = (*pldtypes.HexUint256)(bumped)
+		}
 		newGpo = &pldapi.PublicTxGasPricing{
 			GasPrice:             existingGpo.GasPrice, // copy over unchanged (although expected to be unset)
 			MaxFeePerGas:         (*pldtypes.HexUint256)(newMaxFeePerGas),
-			MaxPriorityFeePerGas: existingGpo.MaxPriorityFeePerGas,
+			MaxPriorityFeePerGas: newMaxPriorityFeePerGas,
 		}
 	}
 
-	return newGpo
+	if it.replacedGasPricing != nil {
+		newGpo = replacementGasPrice(it.replacedGasPricing, newGpo)
+	}
+
+	return capFeesPerGas(newGpo, it.feeCaps())
+}
+
+// feeCaps combines the node-wide fee caps with any set on the transaction, using the lower of each
+func (it *inFlightTransactionStageController) feeCaps() (maxFeePerGasCap, maxPriorityFeePerGasCap *big.Int) {
+	txCaps := it.stateManager.GetGasPriceCaps()
+	return lowerFeeCap(it.maxFeePerGasCap, txCaps.MaxFeePerGasCap), lowerFeeCap(it.maxPriorityFeePerGasCap, txCaps.MaxPriorityFeePerGasCap)
 }
 
 func calculateGasRequiredForTransaction(ctx context.Context, gpo *pldapi.PublicTxGasPricing, gasLimit uint64) (gasRequired *big.Int, err error) {
@@ -26,6 +26,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockStatusUpdater struct {
@@ -592,3 +593,118 @@ func TestProduceLatestInFlightStageContextRetrieveGasPanic(t *testing.T) {
 	assert.NotEqual(t, rsc, it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx))
 	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
 }
+
+func TestCalculateNewGasPriceEIP1559PriorityFeeAndCaps(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+	it.gasPriceIncreasePercent = 50
+	it.gasPriceIncreaseMax = nil
+
+	existing := &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(200),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(10),
+	}
+
+	// the priority fee is bumped along with the max fee
+	gpo := it.calculateNewGasPrice(ctx, existing, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(5),
+	})
+	assert.Equal(t, big.NewInt(300), gpo.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(15), gpo.MaxPriorityFeePerGas.Int())
+
+	// unless the new priority fee is higher
+	gpo = it.calculateNewGasPrice(ctx, existing, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(25),
+	})
+	assert.Equal(t, big.NewInt(25), gpo.MaxPriorityFeePerGas.Int())
+
+	// the node-wide caps apply to the bumped fees
+	it.maxFeePerGasCap = big.NewInt(250)
+	it.maxPriorityFeePerGasCap = big.NewInt(12)
+	gpo = it.calculateNewGasPrice(ctx, existing, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(5),
+	})
+	assert.Equal(t, big.NewInt(250), gpo.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(12), gpo.MaxPriorityFeePerGas.Int())
+
+	// and to the first gas price assigned
+	gpo = it.calculateNewGasPrice(ctx, nil, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(400),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(20),
+	})
+	assert.Equal(t, big.NewInt(250), gpo.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(12), gpo.MaxPriorityFeePerGas.Int())
+
+	// and the caps of the transaction, where lower
+	it.stateManager.UpdateTransaction(&DBPublicTxn{
+		Gas: 2000,
+		GasPriceCaps: pldtypes.JSONString(&pldapi.PublicTxGasPriceCaps{
+			MaxFeePerGasCap:         pldtypes.Uint64ToUint256(220),
+			MaxPriorityFeePerGasCap: pldtypes.Uint64ToUint256(50),
+		}),
+	})
+	gpo = it.calculateNewGasPrice(ctx, existing, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(5),
+	})
+	assert.Equal(t, big.NewInt(220), gpo.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(12), gpo.MaxPriorityFeePerGas.Int())
+}
+
+func TestResubmitDueBlocksAndStuck(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1, func(tx *DBPublicTxn) {
+		tx.PublicTxnID = 12345
+		tx.Submissions = []*DBPubTxnSubmission{
+			{TransactionHash: pldtypes.RandBytes32(), Created: pldtypes.TimestampNow(), Attempts: 1},
+		}
+	})
+	it.resubmitInterval = time.Hour
+	it.resubmitBlocks = 3
+	it.stuckAfterAttempts = 2
+
+	// the persisted submission counts as the first attempt
+	assert.Equal(t, 1, it.submitAttempts)
+
+	// not submitted yet
+	mtx := it.stateManager.(*inFlightTransactionState).InMemoryTxStateManager.(*inMemoryTxState).mtx
+	mtx.LastSubmit = nil
+	assert.False(t, it.resubmitDue(ctx, 100))
+
+	// the first time we see a submission we record the block
+	mtx.LastSubmit = &mtx.ptx.Submissions[0].Created
+	assert.False(t, it.resubmitDue(ctx, 100))
+	assert.False(t, it.resubmitDue(ctx, 0)) // unknown height
+	assert.False(t, it.resubmitDue(ctx, 102))
+	assert.True(t, it.resubmitDue(ctx, 103))
+
+	// one submission is not enough to be stuck
+	it.checkStuck(ctx)
+	assert.False(t, it.stuckReported)
+
+	// a resubmission of the same hash (at the gas price cap) restarts the block count
+	it.submitAttempts++
+	resubmitTime := pldtypes.TimestampNow()
+	mtx.LastSubmit = &resubmitTime
+	assert.False(t, it.resubmitDue(ctx, 103))
+	assert.False(t, it.resubmitDue(ctx, 105))
+	assert.True(t, it.resubmitDue(ctx, 106))
+
+	// two attempts flags it stuck, once, even though there is only one distinct hash
+	it.checkStuck(ctx)
+	assert.True(t, it.stuckReported)
+	it.checkStuck(ctx)
+	activity := o.getActivityRecords(12345)
+	require.Len(t, activity, 1)
+	assert.Regexp(t, "PD011942", activity[0].Message)
+
+	// the interval still applies
+	it.resubmitBlocks = 0
+	it.resubmitInterval = 0
+	assert.True(t, it.resubmitDue(ctx, 0))
+}
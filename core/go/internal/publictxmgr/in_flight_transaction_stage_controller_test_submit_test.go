@@ -256,6 +256,9 @@ func TestProduceLatestInFlightStageContextSubmitCompleteAlreadyKnown(t *testing.
 	assert.Equal(t, InFlightTxStageSubmitting, rsc.Stage)
 	assert.NotNil(t, rsc.StageOutputsToBePersisted)
 	assert.Empty(t, rsc.StageOutputsToBePersisted.StatusUpdates)
+
+	// every submission of the same hash counts as an attempt
+	assert.Equal(t, 3, it.submitAttempts)
 }
 
 func TestProduceLatestInFlightStageContextSubmitErrors(t *testing.T) {
@@ -486,7 +489,7 @@ func TestTriggerSubmitTx(t *testing.T) {
 	assert.Nil(t, it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx))
 	called := make(chan struct{})
 
-	sendRawTransactionMock := m.ethClient.On("SendRawTransaction", ctx, mock.Anything)
+	sendRawTransactionMock := m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything)
 	sendRawTransactionMock.Run(func(args mock.Arguments) {
 		sendRawTransactionMock.Return(nil, fmt.Errorf("pop"))
 		close(called)
@@ -47,3 +47,43 @@ func TestTXStageControllerUpdate(t *testing.T) {
 	require.NotNil(t, rsc)
 	assert.Equal(t, InFlightTxStageSigning, rsc.Stage)
 }
+
+func TestTXStageControllerUpdateAfterSubmission(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1, func(tx *DBPublicTxn) {
+		tx.Submissions = []*DBPubTxnSubmission{{
+			TransactionHash: pldtypes.RandBytes32(),
+			Created:         pldtypes.TimestampNow(),
+			GasPricing: pldtypes.JSONString(pldapi.PublicTxGasPricing{
+				GasPrice: pldtypes.Uint64ToUint256(100),
+			}),
+		}}
+	})
+	it.testOnlyNoActionMode = true
+
+	it.UpdateTransaction(ctx, &DBPublicTxn{
+		Gas:       cancelTransactionGas,
+		Cancelled: true,
+	})
+
+	it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{})
+
+	// The replacement is re-priced before it is signed
+	rsc := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	require.NotNil(t, rsc)
+	assert.Equal(t, InFlightTxStageRetrieveGasPrice, rsc.Stage)
+	assert.True(t, it.stateManager.IsCancelled())
+
+	// ... above the price of the submission it replaces, even if the network price has not moved
+	gpo := it.calculateNewGasPrice(ctx, it.stateManager.GetGasPriceObject(), &pldapi.PublicTxGasPricing{
+		GasPrice: pldtypes.Uint64ToUint256(100),
+	})
+	assert.Equal(t, uint64(110), gpo.GasPrice.Int().Uint64())
+
+	// ... and not if it has moved further
+	gpo = it.calculateNewGasPrice(ctx, it.stateManager.GetGasPriceObject(), &pldapi.PublicTxGasPricing{
+		GasPrice: pldtypes.Uint64ToUint256(200),
+	})
+	assert.Equal(t, uint64(200), gpo.GasPrice.Int().Uint64())
+}
@@ -40,7 +40,7 @@ func newTestInFlightTransactionStateVersion(t *testing.T) (*testInFlightTransact
 	mockInMemoryState := NewTestInMemoryTxState(t)
 	mockActionTriggers := publictxmocks.NewInFlightStageActionTriggers(t)
 
-	v := NewInFlightTransactionStateGeneration(&publicTxEngineMetrics{}, balanceManager, mockActionTriggers, mockInMemoryState, ptm, ptm.submissionWriter, false)
+	v := NewInFlightTransactionStateGeneration(ptm.thMetrics, balanceManager, mockActionTriggers, mockInMemoryState, ptm, ptm.submissionWriter, false)
 	return &testInFlightTransactionStateVersionWithMocks{
 		v,
 		mockActionTriggers,
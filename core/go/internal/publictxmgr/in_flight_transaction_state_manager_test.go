@@ -30,7 +30,7 @@ func newTestInFlightTransactionStateManager(t *testing.T) (InFlightTransactionSt
 
 	mockInMemoryState := NewTestInMemoryTxState(t)
 	mockActionTriggers := publictxmocks.NewInFlightStageActionTriggers(t)
-	iftxs := NewInFlightTransactionStateManager(&publicTxEngineMetrics{}, balanceManager, mockActionTriggers, mockInMemoryState, ptm, ptm.submissionWriter, false)
+	iftxs := NewInFlightTransactionStateManager(ptm.thMetrics, balanceManager, mockActionTriggers, mockInMemoryState, ptm, ptm.submissionWriter, false)
 	return iftxs, done
 
 }
@@ -92,7 +92,12 @@ func (imtxs *inMemoryTxState) UpdateTransaction(newPtx *DBPublicTxn) {
 	ptx.Data = newPtx.Data
 	ptx.Gas = newPtx.Gas
 	ptx.FixedGasPricing = newPtx.FixedGasPricing
+	ptx.GasPriceCaps = newPtx.GasPriceCaps
 	ptx.Value = newPtx.Value
+	if newPtx.Cancelled {
+		// once cancelled, a transaction stays cancelled
+		ptx.Cancelled = true
+	}
 }
 
 func (imtxs *inMemoryTxState) ApplyInMemoryUpdates(ctx context.Context, txUpdates *BaseTXUpdates) {
@@ -199,6 +204,14 @@ func (imtxs *inMemoryTxState) BuildEthTX() *ethsigner.Transaction {
 	)
 }
 
+func (imtxs *inMemoryTxState) GetGasPriceCaps() pldapi.PublicTxGasPriceCaps {
+	return recoverGasPriceCaps(imtxs.mtx.ptx.GasPriceCaps)
+}
+
+func (imtxs *inMemoryTxState) IsCancelled() bool {
+	return imtxs.mtx.ptx.Cancelled
+}
+
 func (imtxs *inMemoryTxState) GetFirstSubmit() *pldtypes.Timestamp {
 	return imtxs.mtx.FirstSubmit
 }
@@ -18,46 +18,111 @@ package publictxmgr
 import (
 	"context"
 
-	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+const metricsSubsystem = "publictxmgr"
+
 type PublicTxManagerMetricsManager interface {
-	InitMetrics(ctx context.Context)
 	RecordOperationMetrics(ctx context.Context, operationName string, operationResult string, durationInSeconds float64)
 	RecordStageChangeMetrics(ctx context.Context, stage string, durationInSeconds float64)
-	RecordInFlightTxQueueMetrics(ctx context.Context, usedCountPerStage map[string]int, freeCount int)
+	RecordInFlightOrchestratorPoolMetrics(ctx context.Context, usedCountPerState map[string]int, freeCount int)
+	RecordInFlightTxQueueMetrics(ctx context.Context, signingAddress string, usedCountPerStage map[string]int, freeCount int)
+	ClearInFlightTxQueueMetrics(ctx context.Context, signingAddress string)
 	RecordCompletedTransactionCountMetrics(ctx context.Context, processStatus string)
 }
 
 type publicTxEngineMetrics struct {
+	operationDuration     *prometheus.HistogramVec
+	stageDuration         *prometheus.HistogramVec
+	orchestrators         *prometheus.GaugeVec
+	orchestratorFreeSlots prometheus.Gauge
+	inFlightTxs           *prometheus.GaugeVec
+	inFlightTxFreeSlots   *prometheus.GaugeVec
+	completedTxs          *prometheus.CounterVec
 }
 
-func (thm *publicTxEngineMetrics) InitMetrics(ctx context.Context) {
-	log.L(ctx).Tracef("Init metrics")
-	// TODO
+func newPublicTxEngineMetrics(m metrics.Metrics) *publicTxEngineMetrics {
+	registry := m.Registry()
+	return &publicTxEngineMetrics{
+		operationDuration: metrics.MustRegister(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of operations performed on public transactions, such as signing and submission",
+		}, []string{"operation", "result"})),
+		stageDuration: metrics.MustRegister(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "stage_duration_seconds",
+			Help:      "Time spent by in-flight transactions in each processing stage",
+		}, []string{"stage"})),
+		orchestrators: metrics.MustRegister(registry, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "orchestrators",
+			Help:      "Number of in-flight signing address orchestrators, by state",
+		}, []string{"state"})),
+		orchestratorFreeSlots: metrics.MustRegister(registry, prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "orchestrator_free_slots",
+			Help:      "Number of additional signing address orchestrators that can be started",
+		})),
+		inFlightTxs: metrics.MustRegister(registry, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "inflight_transactions",
+			Help:      "Number of in-flight transactions for each signing address, by stage",
+		}, []string{"signer", "stage"})),
+		inFlightTxFreeSlots: metrics.MustRegister(registry, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "inflight_transaction_free_slots",
+			Help:      "Number of additional transactions that can be in-flight for each signing address",
+		}, []string{"signer"})),
+		completedTxs: metrics.MustRegister(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "completed_transactions_total",
+			Help:      "Number of public transactions that have completed processing, by status",
+		}, []string{"status"})),
+	}
 }
 
 func (thm *publicTxEngineMetrics) RecordOperationMetrics(ctx context.Context, operationName string, operationResult string, durationInSeconds float64) {
-	log.L(ctx).Tracef("RecordOperationMetrics")
-	// TODO
+	thm.operationDuration.WithLabelValues(operationName, operationResult).Observe(durationInSeconds)
 }
 
 func (thm *publicTxEngineMetrics) RecordStageChangeMetrics(ctx context.Context, stage string, durationInSeconds float64) {
-	log.L(ctx).Tracef("RecordStageChangeMetrics")
-	// TODO
+	thm.stageDuration.WithLabelValues(stage).Observe(durationInSeconds)
 }
 
 func (thm *publicTxEngineMetrics) RecordInFlightOrchestratorPoolMetrics(ctx context.Context, usedCountPerState map[string]int, freeCount int) {
-	log.L(ctx).Tracef("RecordInFlightEnginePoolMetrics")
-	// TODO
+	// States with no orchestrators are removed, rather than left at their last value
+	thm.orchestrators.Reset()
+	for state, count := range usedCountPerState {
+		thm.orchestrators.WithLabelValues(state).Set(float64(count))
+	}
+	thm.orchestratorFreeSlots.Set(float64(freeCount))
+}
+
+func (thm *publicTxEngineMetrics) RecordInFlightTxQueueMetrics(ctx context.Context, signingAddress string, usedCountPerStage map[string]int, freeCount int) {
+	thm.inFlightTxs.DeletePartialMatch(prometheus.Labels{"signer": signingAddress})
+	for stage, count := range usedCountPerStage {
+		thm.inFlightTxs.WithLabelValues(signingAddress, stage).Set(float64(count))
+	}
+	thm.inFlightTxFreeSlots.WithLabelValues(signingAddress).Set(float64(freeCount))
 }
 
-func (thm *publicTxEngineMetrics) RecordInFlightTxQueueMetrics(ctx context.Context, usedCountPerStage map[string]int, freeCount int) {
-	log.L(ctx).Tracef("RecordInFlightTxQueueMetrics")
-	// TODO
+// Called when an orchestrator stops, so we do not report stale values for signing
+// addresses that are no longer in-flight
+func (thm *publicTxEngineMetrics) ClearInFlightTxQueueMetrics(ctx context.Context, signingAddress string) {
+	thm.inFlightTxs.DeletePartialMatch(prometheus.Labels{"signer": signingAddress})
+	thm.inFlightTxFreeSlots.DeleteLabelValues(signingAddress)
 }
 
 func (thm *publicTxEngineMetrics) RecordCompletedTransactionCountMetrics(ctx context.Context, processStatus string) {
-	log.L(ctx).Tracef("RecordCompletedTransactionCountMetrics")
-	// TODO
+	thm.completedTxs.WithLabelValues(processStatus).Inc()
 }
@@ -18,17 +18,44 @@ package publictxmgr
 import (
 	"context"
 	"testing"
+
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMetrics(t *testing.T) {
-	// none of the functions are actually implemented, so it's purely for test coverage
-	btem := &publicTxEngineMetrics{}
 	ctx := context.Background()
-	btem.InitMetrics(ctx)
+	mm := metrics.NewMetricsManager(ctx)
+	btem := newPublicTxEngineMetrics(mm)
+
+	// A second instance on the same registry shares the collectors
+	assert.Same(t, btem.completedTxs, newPublicTxEngineMetrics(mm).completedTxs)
+
+	btem.RecordCompletedTransactionCountMetrics(ctx, "success")
 	btem.RecordCompletedTransactionCountMetrics(ctx, "success")
+	assert.Equal(t, float64(2), testutil.ToFloat64(btem.completedTxs.WithLabelValues("success")))
+
 	btem.RecordOperationMetrics(ctx, "test", "success", 12)
 	btem.RecordStageChangeMetrics(ctx, "test", 12)
-	btem.RecordInFlightOrchestratorPoolMetrics(ctx, nil, 1)
-	btem.RecordInFlightTxQueueMetrics(ctx, nil, 1)
-	btem.RecordCompletedTransactionCountMetrics(ctx, "test")
+	assert.Equal(t, 1, testutil.CollectAndCount(btem.operationDuration))
+	assert.Equal(t, 1, testutil.CollectAndCount(btem.stageDuration))
+
+	btem.RecordInFlightOrchestratorPoolMetrics(ctx, map[string]int{"running": 2, "stale": 1}, 7)
+	assert.Equal(t, 2, testutil.CollectAndCount(btem.orchestrators))
+	btem.RecordInFlightOrchestratorPoolMetrics(ctx, map[string]int{"running": 3}, 7)
+	assert.Equal(t, 1, testutil.CollectAndCount(btem.orchestrators))
+	assert.Equal(t, float64(3), testutil.ToFloat64(btem.orchestrators.WithLabelValues("running")))
+	assert.Equal(t, float64(7), testutil.ToFloat64(btem.orchestratorFreeSlots))
+
+	btem.RecordInFlightTxQueueMetrics(ctx, "0xaaaa", map[string]int{"signing": 1, "submitting": 2}, 10)
+	btem.RecordInFlightTxQueueMetrics(ctx, "0xbbbb", map[string]int{"signing": 1}, 5)
+	assert.Equal(t, 3, testutil.CollectAndCount(btem.inFlightTxs))
+	btem.RecordInFlightTxQueueMetrics(ctx, "0xaaaa", map[string]int{"submitting": 3}, 9)
+	assert.Equal(t, 2, testutil.CollectAndCount(btem.inFlightTxs))
+	assert.Equal(t, float64(9), testutil.ToFloat64(btem.inFlightTxFreeSlots.WithLabelValues("0xaaaa")))
+
+	btem.ClearInFlightTxQueueMetrics(ctx, "0xaaaa")
+	assert.Equal(t, 1, testutil.CollectAndCount(btem.inFlightTxs))
+	assert.Equal(t, 1, testutil.CollectAndCount(btem.inFlightTxFreeSlots))
 }
@@ -0,0 +1,131 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"gorm.io/gorm/clause"
+)
+
+// GetNonceGaps compares the nonce the chain expects next from a signing address, with the nonces held by
+// the transactions in our DB. Any nonce below the highest one we hold, that no transaction holds, will
+// block all the transactions above it from being mined.
+func (ptm *pubTxManager) GetNonceGaps(ctx context.Context, from pldtypes.EthAddress) ([]*pldapi.PublicTxNonceGap, error) {
+	chainNonce, err := ptm.ethClient.GetTransactionCount(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	return ptm.getNonceGapsFrom(ctx, from, chainNonce.Uint64())
+}
+
+func (ptm *pubTxManager) getNonceGapsFrom(ctx context.Context, from pldtypes.EthAddress, chainNonce uint64) ([]*pldapi.PublicTxNonceGap, error) {
+	var nonces []uint64
+	err := ptm.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Where(`"from" = ?`, from).
+		Where("nonce >= ?", chainNonce).
+		Order("nonce").
+		Pluck("nonce", &nonces).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	gaps := []*pldapi.PublicTxNonceGap{}
+	expected := chainNonce
+	for _, nonce := range nonces {
+		if nonce > expected {
+			gaps = append(gaps, &pldapi.PublicTxNonceGap{
+				From:       from,
+				FirstNonce: pldtypes.HexUint64(expected),
+				LastNonce:  pldtypes.HexUint64(nonce - 1),
+			})
+		}
+		expected = nonce + 1
+	}
+	log.L(ctx).Debugf("Found %d nonce gaps for %s (chainNonce=%d, pending=%d)", len(gaps), from, chainNonce, len(nonces))
+	return gaps, nil
+}
+
+// FillNonceGap writes a zero-value transfer from the signing address to itself, with a nonce that is in a gap,
+// so that once it is mined the transactions behind it can be mined. It is then managed like any other transaction,
+// so its fees escalate if it is not mined.
+func (ptm *pubTxManager) FillNonceGap(ctx context.Context, from pldtypes.EthAddress, nonce uint64) (*pldapi.PublicTx, error) {
+	// We can only sign for addresses in our own key manager, and must not queue transactions for any other
+	if _, err := ptm.keymgr.ReverseKeyLookup(ctx, ptm.p.NOTX(), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, from.String()); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgNonceGapSignerNotManaged, from)
+	}
+	chainNonce, err := ptm.ethClient.GetTransactionCount(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	gaps, err := ptm.getNonceGapsFrom(ctx, from, chainNonce.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	inGap := false
+	for _, gap := range gaps {
+		if nonce >= gap.FirstNonce.Uint64() && nonce <= gap.LastNonce.Uint64() {
+			inGap = true
+			break
+		}
+	}
+	if !inGap {
+		return nil, i18n.NewError(ctx, msgs.MsgNonceNotInGap, nonce, from, chainNonce.Uint64())
+	}
+
+	log.L(ctx).Infof("Filling nonce gap for %s at nonce %d with a zero-value transaction", from, nonce)
+	ptx := &DBPublicTxn{
+		From:  from,
+		Nonce: &nonce,
+		To:    &from,
+		Gas:   cancelTransactionGas,
+		Value: pldtypes.Uint64ToUint256(0),
+	}
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		// The unique index on from+nonce protects us from a race with another fill of the same gap
+		err := dbTX.DB().
+			WithContext(ctx).
+			Table("public_txns").
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "pub_txn_id"}}}).
+			Create(ptx).
+			Error
+		if err == nil {
+			dbTX.AddPostCommit(func(ctx context.Context) {
+				if oc := ptm.getOrchestratorForAddress(from); oc != nil {
+					oc.notifyNonceGapFilled()
+				} else {
+					ptm.MarkInFlightOrchestratorsStale()
+				}
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapPersistedTransaction(ptx), nil
+}
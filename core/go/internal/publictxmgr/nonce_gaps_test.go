@@ -0,0 +1,183 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func insertTestNonces(t *testing.T, ctx context.Context, ptm *pubTxManager, from pldtypes.EthAddress, nonces ...uint64) []*DBPublicTxn {
+	ptxs := make([]*DBPublicTxn, len(nonces))
+	for i, nonce := range nonces {
+		ptxs[i] = &DBPublicTxn{
+			From:  from,
+			Nonce: confutil.P(nonce),
+			Gas:   21000,
+		}
+	}
+	err := ptm.p.DB().WithContext(ctx).Create(ptxs).Error
+	require.NoError(t, err)
+	return ptxs
+}
+
+func TestNonceGapsRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, "signer1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	from := pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+	insertTestNonces(t, ctx, ptm, *from, 3, 5, 6, 9, 12)
+	m.ethClient.On("GetTransactionCount", mock.Anything, *from).Return(confutil.P(pldtypes.HexUint64(5)), nil)
+
+	gaps, err := ptm.GetNonceGaps(ctx, *from)
+	require.NoError(t, err)
+	assert.Equal(t, []*pldapi.PublicTxNonceGap{
+		{From: *from, FirstNonce: 7, LastNonce: 8},
+		{From: *from, FirstNonce: 10, LastNonce: 11},
+	}, gaps)
+
+	// Fill part of the first gap
+	filled, err := ptm.FillNonceGap(ctx, *from, 8)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), filled.Nonce.Uint64())
+	assert.Equal(t, from, filled.To)
+	assert.Equal(t, uint64(cancelTransactionGas), filled.Gas.Uint64())
+	assert.NotZero(t, *filled.LocalID)
+
+	gaps, err = ptm.GetNonceGaps(ctx, *from)
+	require.NoError(t, err)
+	assert.Equal(t, []*pldapi.PublicTxNonceGap{
+		{From: *from, FirstNonce: 7, LastNonce: 7},
+		{From: *from, FirstNonce: 10, LastNonce: 11},
+	}, gaps)
+
+	// Nonces that are held, already mined, or beyond the highest we have are not gaps
+	for _, nonce := range []uint64{8, 4, 13} {
+		_, err = ptm.FillNonceGap(ctx, *from, nonce)
+		assert.Regexp(t, "PD011943", err)
+	}
+
+	// A signer with nothing pending has no gaps
+	unmanaged := pldtypes.RandAddress()
+	m.ethClient.On("GetTransactionCount", mock.Anything, *unmanaged).Return(confutil.P(pldtypes.HexUint64(0)), nil)
+	gaps, err = ptm.GetNonceGaps(ctx, *unmanaged)
+	require.NoError(t, err)
+	assert.Empty(t, gaps)
+
+	// We cannot fill a gap for a signer that is not one of our keys
+	_, err = ptm.FillNonceGap(ctx, *unmanaged, 0)
+	assert.Regexp(t, "PD011944", err)
+}
+
+func TestNonceGapsChainError(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	m.keyManager.(*componentmocks.KeyManager).On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, mock.Anything).
+		Return(&pldapi.KeyMappingAndVerifier{}, nil)
+
+	_, err := ptm.GetNonceGaps(ctx, *pldtypes.RandAddress())
+	assert.Regexp(t, "pop", err)
+
+	_, err = ptm.FillNonceGap(ctx, *pldtypes.RandAddress(), 1)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNonceGapsDBError(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(5)), nil)
+	m.keyManager.(*componentmocks.KeyManager).On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, mock.Anything).
+		Return(&pldapi.KeyMappingAndVerifier{}, nil)
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.FillNonceGap(ctx, *pldtypes.RandAddress(), 1)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestQueryStuckPublicTransactionsRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Orchestrator.StuckAfterAttempts = confutil.P(2)
+	})
+	defer done()
+
+	from := pldtypes.RandAddress()
+	ptxs := insertTestNonces(t, ctx, ptm, *from, 1, 2)
+
+	// The first has been submitted twice with the same hash (as happens once the gas price reaches its cap),
+	// and the second only once
+	firstHash := pldtypes.RandBytes32()
+	sw := &submissionWriter{}
+	for _, batch := range [][]*DBPubTxnSubmission{
+		{
+			{PublicTxnID: ptxs[0].PublicTxnID, TransactionHash: firstHash, Created: pldtypes.TimestampNow(), Attempts: 1},
+			{PublicTxnID: ptxs[1].PublicTxnID, TransactionHash: pldtypes.RandBytes32(), Created: pldtypes.TimestampNow(), Attempts: 1},
+		},
+		{
+			{PublicTxnID: ptxs[0].PublicTxnID, TransactionHash: firstHash, Created: pldtypes.TimestampNow(), Attempts: 1},
+		},
+	} {
+		err := ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			_, err := sw.runBatch(ctx, dbTX, batch)
+			return err
+		})
+		require.NoError(t, err)
+	}
+
+	stuck, err := ptm.QueryStuckPublicTransactions(ctx, ptm.p.NOTX(), query.NewQueryBuilder().Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, stuck, 1)
+	assert.Equal(t, uint64(1), stuck[0].Nonce.Uint64())
+	assert.True(t, stuck[0].Stuck)
+	assert.Len(t, stuck[0].Submissions, 1)
+
+	all, err := ptm.QueryPublicTxWithBindings(ctx, ptm.p.NOTX(), query.NewQueryBuilder().Limit(10).Sort("nonce").Query())
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.True(t, all[0].Stuck)
+	assert.False(t, all[1].Stuck)
+
+	// Disabled
+	ptm.stuckAfterAttempts = 0
+	stuck, err = ptm.QueryStuckPublicTransactions(ctx, ptm.p.NOTX(), query.NewQueryBuilder().Limit(10).Query())
+	require.NoError(t, err)
+	assert.Empty(t, stuck)
+}
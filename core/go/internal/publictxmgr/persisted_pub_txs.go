@@ -31,9 +31,11 @@ type DBPublicTxn struct {
 	To              *pldtypes.EthAddress   `gorm:"column:to"`
 	Gas             uint64                 `gorm:"column:gas"`
 	FixedGasPricing pldtypes.RawJSON       `gorm:"column:fixed_gas_pricing"`
+	GasPriceCaps    pldtypes.RawJSON       `gorm:"column:gas_price_caps"`
 	Value           *pldtypes.HexUint256   `gorm:"column:value"`
 	Data            pldtypes.HexBytes      `gorm:"column:data"`
 	Suspended       bool                   `gorm:"column:suspended"`                            // excluded from processing because it's suspended by user
+	Cancelled       bool                   `gorm:"column:cancelled"`                            // replaced with a zero-value transaction, and cannot be updated
 	Completed       *DBPublicTxnCompletion `gorm:"foreignKey:pub_txn_id;references:pub_txn_id"` // excluded from processing because it's done
 	Submissions     []*DBPubTxnSubmission  `gorm:"-"`                                           // we do the aggregation, not GORM
 	// Binding is used only on queries by transaction (GORM doesn't seem to allow us to define a separate struct for this)
@@ -59,7 +61,9 @@ type DBPubTxnSubmission struct {
 	PublicTxnID     uint64             `gorm:"column:pub_txn_id"`
 	Created         pldtypes.Timestamp `gorm:"column:created;autoCreateTime:false"` // we set this as we track the record in memory too
 	TransactionHash pldtypes.Bytes32   `gorm:"column:tx_hash;primaryKey"`
-	GasPricing      pldtypes.RawJSON   `gorm:"column:gas_pricing"` // no filtering allowed on this field as it's complex JSON gasPrice/maxFeePerGas/maxPriorityFeePerGas calculation
+	GasPricing      pldtypes.RawJSON   `gorm:"column:gas_pricing"`  // no filtering allowed on this field as it's complex JSON gasPrice/maxFeePerGas/maxPriorityFeePerGas calculation
+	Attempts        int                `gorm:"column:attempts"`     // the same hash is resubmitted once the gas price reaches its cap
+	Cancellation    bool               `gorm:"column:cancellation"` // this submission is the zero-value replacement of a cancelled transaction
 }
 
 func (DBPubTxnSubmission) TableName() string {
@@ -20,9 +20,12 @@ import (
 
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/flushwriter"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -32,20 +35,35 @@ type submissionWriter struct {
 	flushwriter.Writer[*DBPubTxnSubmission, *noResult]
 }
 
-func newSubmissionWriter(bgCtx context.Context, p persistence.Persistence, conf *pldconf.PublicTxManagerConfig) *submissionWriter {
+func newSubmissionWriter(bgCtx context.Context, p persistence.Persistence, m metrics.Metrics, conf *pldconf.PublicTxManagerConfig) *submissionWriter {
 	sw := &submissionWriter{}
-	sw.Writer = flushwriter.NewWriter(bgCtx, sw.runBatch, p, &conf.Manager.SubmissionWriter, &pldconf.PublicTxManagerDefaults.Manager.SubmissionWriter)
+	sw.Writer = flushwriter.NewWriter(bgCtx, "public_tx_submissions", sw.runBatch, p, m, &conf.Manager.SubmissionWriter, &pldconf.PublicTxManagerDefaults.Manager.SubmissionWriter)
 	return sw
 }
 
 func (sw *submissionWriter) runBatch(ctx context.Context, tx persistence.DBTX, values []*DBPubTxnSubmission) ([]flushwriter.Result[*noResult], error) {
+	// A resubmission of an existing hash only increments the attempts on the existing record.
+	// A single insert cannot update the same row twice, so we merge duplicates in the batch first.
+	merged := make([]*DBPubTxnSubmission, 0, len(values))
+	byHash := make(map[pldtypes.Bytes32]*DBPubTxnSubmission, len(values))
+	for _, v := range values {
+		if existing := byHash[v.TransactionHash]; existing != nil {
+			existing.Attempts += v.Attempts
+			continue
+		}
+		submission := *v
+		byHash[v.TransactionHash] = &submission
+		merged = append(merged, &submission)
+	}
 	err := tx.DB().
 		Table("public_submissions").
 		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "tx_hash"}},
-			DoNothing: true, // immutable
+			Columns: []clause.Column{{Name: "tx_hash"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"attempts": gorm.Expr(`"public_submissions"."attempts" + "excluded"."attempts"`),
+			}),
 		}).
-		Create(values).
+		Create(merged).
 		Error
 	if err != nil {
 		return nil, err
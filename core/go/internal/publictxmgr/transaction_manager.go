@@ -41,6 +41,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
@@ -60,6 +61,9 @@ const (
 	UpdateDelete                   // Instructs that the transaction should be removed completely from persistence - generally only returned when TX status is TxStatusDeleteRequested
 )
 
+// The intrinsic gas of a plain value transfer, used for the replacement transaction when cancelling
+const cancelTransactionGas = 21000
+
 type transactionUpdate struct {
 	newPtx  *DBPublicTxn
 	pubTXID uint64
@@ -117,6 +121,9 @@ type pubTxManager struct {
 	// orchestrator config
 	gasPriceIncreaseMax     *big.Int
 	gasPriceIncreasePercent int
+	maxFeePerGasCap         *big.Int
+	maxPriorityFeePerGasCap *big.Int
+	stuckAfterAttempts      int
 
 	// gas limit config
 	gasEstimateFactor float64
@@ -158,6 +165,9 @@ func NewPublicTransactionManager(ctx context.Context, conf *pldconf.PublicTxMana
 		retry:                       retry.NewRetryIndefinite(&conf.Manager.Retry),
 		gasPriceIncreaseMax:         gasPriceIncreaseMax,
 		gasPriceIncreasePercent:     confutil.Int(conf.GasPrice.IncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.IncreasePercentage),
+		maxFeePerGasCap:             confutil.BigIntOrNil(conf.GasPrice.MaxFeePerGasCap),
+		maxPriorityFeePerGasCap:     confutil.BigIntOrNil(conf.GasPrice.MaxPriorityFeePerGasCap),
+		stuckAfterAttempts:          confutil.IntMin(conf.Orchestrator.StuckAfterAttempts, 0, *pldconf.PublicTxManagerDefaults.Orchestrator.StuckAfterAttempts),
 		activityRecordCache:         cache.NewCache[uint64, *txActivityRecords](&conf.Manager.ActivityRecords.CacheConfig, &pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.CacheConfig),
 		maxActivityRecordsPerTx:     confutil.Int(conf.Manager.ActivityRecords.RecordsPerTransaction, *pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.RecordsPerTransaction),
 		gasEstimateFactor:           gasEstimateFactor,
@@ -172,12 +182,13 @@ func (ptm *pubTxManager) PreInit(pic components.PreInitComponents) (result *comp
 func (ptm *pubTxManager) PostInit(pic components.AllComponents) error {
 	ctx := ptm.ctx
 	log.L(ctx).Debugf("Initializing public transaction manager")
+	ptm.thMetrics = newPublicTxEngineMetrics(pic.MetricsManager())
 	ptm.ethClientFactory = pic.EthClientFactory()
 	ptm.keymgr = pic.KeyManager()
 	ptm.p = pic.Persistence()
 	ptm.bIndexer = pic.BlockIndexer()
 	ptm.rootTxMgr = pic.TxManager()
-	ptm.submissionWriter = newSubmissionWriter(ptm.ctx, ptm.p, ptm.conf)
+	ptm.submissionWriter = newSubmissionWriter(ptm.ctx, ptm.p, pic.MetricsManager(), ptm.conf)
 
 	balanceManager, err := NewBalanceManagerWithInMemoryTracking(ctx, ptm.conf, ptm)
 	if err != nil {
@@ -304,6 +315,15 @@ func (ptm *pubTxManager) ValidateTransaction(ctx context.Context, dbTX persisten
 }
 
 func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persistence.DBTX, transactions []*components.PublicTxSubmission) (pubTxns []*pldapi.PublicTx, err error) {
+	var txIDs []uuid.UUID
+	for _, txi := range transactions {
+		for _, bnd := range txi.Bindings {
+			txIDs = append(txIDs, bnd.TransactionID)
+		}
+	}
+	ctx, span := tracing.StartSpan(ctx, "publictx write transactions", tracing.TransactionIDs(txIDs))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	persistedTransactions := make([]*DBPublicTxn, len(transactions))
 	for i, txi := range transactions {
 		persistedTransactions[i] = &DBPublicTxn{
@@ -313,6 +333,7 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 			Value:           txi.Value,
 			Data:            txi.Data,
 			FixedGasPricing: pldtypes.JSONString(txi.PublicTxGasPricing),
+			GasPriceCaps:    pldtypes.JSONString(txi.PublicTxGasPriceCaps),
 		}
 	}
 	// All the nonce processing to this point should have ensured we do not have a conflict on nonces.
@@ -358,12 +379,16 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 	return pubTxns, err
 }
 
-func (ptm *pubTxManager) writeUpdatedTransaction(ctx context.Context, dbTX persistence.DBTX, pubTXID uint64, from pldtypes.EthAddress, newPtx *DBPublicTxn) error {
-	err := dbTX.DB().
+// writeUpdatedTransaction only writes the non-zero fields of newPtx, unless a list of columns is supplied
+func (ptm *pubTxManager) writeUpdatedTransaction(ctx context.Context, dbTX persistence.DBTX, pubTXID uint64, from pldtypes.EthAddress, newPtx *DBPublicTxn, columns ...string) error {
+	q := dbTX.DB().
 		WithContext(ctx).
 		Table("public_txns").
-		Where("pub_txn_id = ?", pubTXID).
-		Updates(newPtx).
+		Where("pub_txn_id = ?", pubTXID)
+	if len(columns) > 0 {
+		q = q.Select(columns)
+	}
+	err := q.Updates(newPtx).
 		Error
 
 	if err == nil {
@@ -403,6 +428,13 @@ func recoverGasPriceOptions(gpoJSON pldtypes.RawJSON) (ptgp pldapi.PublicTxGasPr
 	return
 }
 
+func recoverGasPriceCaps(capsJSON pldtypes.RawJSON) (caps pldapi.PublicTxGasPriceCaps) {
+	if capsJSON != nil {
+		_ = json.Unmarshal(capsJSON, &caps)
+	}
+	return
+}
+
 // Component interface: query public transactions, outside of the scope of a binding to a parent Paladin transaction.
 // Returns each public transaction a maximum of once
 func (ptm *pubTxManager) QueryPublicTxWithBindings(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error) {
@@ -432,10 +464,42 @@ func (ptm *pubTxManager) QueryPublicTxForTransactions(ctx context.Context, dbTX
 	return results, nil
 }
 
-func (ptm *pubTxManager) queryPublicTxWithBinding(ctx context.Context, dbTX persistence.DBTX, scopeToTxns []uuid.UUID, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error) {
+// Component interface: query the pending public transactions that have been submitted the configured number of times
+// without being mined, so are likely to be underpriced or blocked by a nonce gap
+func (ptm *pubTxManager) QueryStuckPublicTransactions(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error) {
+	if ptm.stuckAfterAttempts == 0 {
+		return []*pldapi.PublicTxWithBinding{}, nil
+	}
+	return ptm.queryPublicTxWithBinding(ctx, dbTX, nil, jq, func(q *gorm.DB) *gorm.DB {
+		return q.
+			Where(`"Completed"."tx_hash" IS NULL`).
+			Where(`"public_txns"."pub_txn_id" IN (?)`, dbTX.DB().
+				Table("public_submissions").
+				Select("pub_txn_id").
+				Group("pub_txn_id").
+				Having("SUM(attempts) >= ?", ptm.stuckAfterAttempts),
+			)
+	})
+}
+
+func (ptm *pubTxManager) isStuck(ptx *DBPublicTxn) bool {
+	return ptx.Completed == nil && ptm.stuckAfterAttempts > 0 && countSubmitAttempts(ptx.Submissions) >= ptm.stuckAfterAttempts
+}
+
+// countSubmitAttempts counts every submission, including resubmissions of the same hash
+func countSubmitAttempts(submissions []*DBPubTxnSubmission) int {
+	attempts := 0
+	for _, s := range submissions {
+		attempts += s.Attempts
+	}
+	return attempts
+}
+
+func (ptm *pubTxManager) queryPublicTxWithBinding(ctx context.Context, dbTX persistence.DBTX, scopeToTxns []uuid.UUID, jq *query.QueryJSON, scopes ...func(*gorm.DB) *gorm.DB) ([]*pldapi.PublicTxWithBinding, error) {
 	q := dbTX.DB().Table("public_txns").
 		WithContext(ctx).
-		Joins("Completed")
+		Joins("Completed").
+		Scopes(scopes...)
 	if jq != nil {
 		q = filters.BuildGORM(ctx, jq, q, components.PublicTxFilterFields)
 	}
@@ -451,6 +515,7 @@ func (ptm *pubTxManager) queryPublicTxWithBinding(ctx context.Context, dbTX pers
 			tx.Submissions[iSub] = mapPersistedSubmissionData(pSub)
 		}
 		tx.Activity = ptm.getActivityRecords(ptx.PublicTxnID)
+		tx.Stuck = ptm.isStuck(ptx)
 		results[iTx] = &pldapi.PublicTxWithBinding{
 			PublicTx: tx,
 		}
@@ -556,9 +621,10 @@ func mapPersistedTransaction(ptx *DBPublicTxn) *pldapi.PublicTx {
 		Nonce:   (*pldtypes.HexUint64)(ptx.Nonce),
 		Data:    ptx.Data,
 		PublicTxOptions: pldapi.PublicTxOptions{
-			Gas:                (*pldtypes.HexUint64)(&ptx.Gas),
-			Value:              ptx.Value,
-			PublicTxGasPricing: recoverGasPriceOptions(ptx.FixedGasPricing),
+			Gas:                  (*pldtypes.HexUint64)(&ptx.Gas),
+			Value:                ptx.Value,
+			PublicTxGasPricing:   recoverGasPriceOptions(ptx.FixedGasPricing),
+			PublicTxGasPriceCaps: recoverGasPriceCaps(ptx.GasPriceCaps),
 		},
 	}
 	// We use a separate Table in the DB for the completion data, but
@@ -609,7 +675,7 @@ func (ptm *pubTxManager) ResumeTransaction(ctx context.Context, from pldtypes.Et
 	return nil
 }
 
-func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error {
+func (ptm *pubTxManager) getIncompleteTransaction(ctx context.Context, action string, id uuid.UUID, pubTXID uint64) (*DBPublicTxn, error) {
 	ptxs := []*DBPublicTxn{}
 	err := ptm.p.DB().
 		WithContext(ctx).
@@ -619,21 +685,32 @@ func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pu
 		Find(&ptxs).
 		Error
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(ptxs) == 0 {
-		log.L(ctx).Warnf("UpdateTransaction: Public transaction local id not found: %d (%+v)", pubTXID, id)
-		return i18n.NewError(ctx, msgs.MsgPublicTransactionNotFound, id)
+		log.L(ctx).Warnf("%s: Public transaction local id not found: %d (%+v)", action, pubTXID, id)
+		return nil, i18n.NewError(ctx, msgs.MsgPublicTransactionNotFound, id)
 	}
 
 	// error if the transaction is already completed
 	complete, err := ptm.CheckTransactionCompleted(ctx, pubTXID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if complete {
-		log.L(ctx).Warnf("UpdateTransaction: Public transaction already completed: %d (%+v)", pubTXID, id)
-		return i18n.NewError(ctx, msgs.MsgTransactionAlreadyComplete, id)
+		log.L(ctx).Warnf("%s: Public transaction already completed: %d (%+v)", action, pubTXID, id)
+		return nil, i18n.NewError(ctx, msgs.MsgTransactionAlreadyComplete, id)
+	}
+	return ptxs[0], nil
+}
+
+func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error {
+	ptx, err := ptm.getIncompleteTransaction(ctx, "UpdateTransaction", id, pubTXID)
+	if err != nil {
+		return err
+	}
+	if ptx.Cancelled {
+		return i18n.NewError(ctx, msgs.MsgTransactionCancelled, id)
 	}
 
 	if tx.Gas == nil || *tx.Gas == 0 {
@@ -661,15 +738,53 @@ func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pu
 		Value:           tx.Value,
 		Data:            publicTxData,
 		FixedGasPricing: pldtypes.JSONString(tx.PublicTxOptions.PublicTxGasPricing),
+		GasPriceCaps:    pldtypes.JSONString(tx.PublicTxOptions.PublicTxGasPriceCaps),
 	}
 
+	return ptm.persistAndDispatchUpdate(ctx, pubTXID, from, newPtx, txmgrDBUpdate)
+}
+
+// CancelTransaction replaces an in-flight transaction with a zero-value transfer from the signing address
+// to itself, on the same nonce. Once that replacement is mined, the nonce is consumed and the original
+// transaction can never be mined.
+//
+// The transaction is marked as cancelled, and the submissions of the replacement are flagged so that
+// the caller is only told the transaction was cancelled if it is the replacement that gets mined.
+func (ptm *pubTxManager) CancelTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress) error {
+	ptx, err := ptm.getIncompleteTransaction(ctx, "CancelTransaction", id, pubTXID)
+	if err != nil {
+		return err
+	}
+	if ptx.Cancelled {
+		log.L(ctx).Infof("Public transaction %d (%s) is already cancelled", pubTXID, id)
+		return nil
+	}
+
+	log.L(ctx).Infof("Cancelling public transaction %d (%s) from %s with a zero-value replacement", pubTXID, id, from)
+	newPtx := &DBPublicTxn{
+		From:            *from,
+		To:              from,
+		Gas:             cancelTransactionGas,
+		Value:           pldtypes.Uint64ToUint256(0),
+		FixedGasPricing: ptx.FixedGasPricing, // gas price escalation continues from the previous submissions
+		GasPriceCaps:    ptx.GasPriceCaps,
+		Cancelled:       true,
+	}
+
+	// Every column must be written, as the replacement clears the data of the original
+	return ptm.persistAndDispatchUpdate(ctx, pubTXID, from, newPtx, nil, "to", "gas", "value", "data", "fixed_gas_pricing", "gas_price_caps", "cancelled")
+}
+
+func (ptm *pubTxManager) persistAndDispatchUpdate(ctx context.Context, pubTXID uint64, from *pldtypes.EthAddress, newPtx *DBPublicTxn, txmgrDBUpdate func(dbTX persistence.DBTX) error, columns ...string) error {
 	ptm.updateMux.Lock()
 	defer ptm.updateMux.Unlock()
 
-	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
-		err := txmgrDBUpdate(dbTX)
+	err := ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		if txmgrDBUpdate != nil {
+			err = txmgrDBUpdate(dbTX)
+		}
 		if err == nil {
-			err = ptm.writeUpdatedTransaction(ctx, dbTX, pubTXID, *from, newPtx)
+			err = ptm.writeUpdatedTransaction(ctx, dbTX, pubTXID, *from, newPtx, columns...)
 		}
 		return err
 	})
@@ -787,7 +902,7 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 	var lookups []*bindingsMatchingSubmission
 	err := dbTX.DB().
 		Table("public_txn_bindings").
-		Select(`"transaction"`, `"tx_type"`, `"Submission"."pub_txn_id"`, `"Submission"."tx_hash"`).
+		Select(`"transaction"`, `"tx_type"`, `"Submission"."pub_txn_id"`, `"Submission"."tx_hash"`, `"Submission"."cancellation"`).
 		Joins("Submission").
 		Where(`"Submission"."tx_hash" IN (?)`, txHashes).
 		Find(&lookups).
@@ -810,6 +925,7 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 						TransactionType: match.TransactionType,
 					},
 					IndexedTransactionNotify: txi,
+					Cancellation:             match.Submission.Cancellation,
 				})
 				// completions to insert, in the order of the inputs
 				completions = append(completions, &DBPublicTxnCompletion{
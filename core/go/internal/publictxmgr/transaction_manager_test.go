@@ -33,6 +33,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/keymanager"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
 
@@ -79,6 +80,7 @@ func baseMocks(t *testing.T) *mocksAndTestControl {
 	mocks.ethClientFactory.On("HTTPClient").Return(mocks.ethClient).Maybe()
 	mocks.allComponents.On("BlockIndexer").Return(mocks.blockIndexer).Maybe()
 	mocks.allComponents.On("TxManager").Return(mocks.txManager).Maybe()
+	mocks.allComponents.On("MetricsManager").Return(metrics.NewMetricsManager(context.Background())).Maybe()
 	return mocks
 }
 
@@ -753,6 +755,151 @@ func TestUpdateTransactionRealDB(t *testing.T) {
 	require.Len(t, tx.Submissions, 2)
 }
 
+func TestCancelTransactionRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Manager.Interval = confutil.P("50ms")
+		conf.Orchestrator.Interval = confutil.P("50ms")
+		conf.Manager.OrchestratorIdleTimeout = confutil.P("1ms")
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, "signer1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	resolvedKey := pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+
+	chainID, _ := rand.Int(rand.Reader, big.NewInt(100000000000000))
+	m.ethClient.On("ChainID").Return(chainID.Int64())
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000000000000000"), nil)
+
+	txID := uuid.New()
+	pubTxSub := &components.PublicTxSubmission{
+		Bindings: []*components.PaladinTXReference{
+			{TransactionID: txID, TransactionType: pldapi.TransactionTypePublic.Enum()},
+		},
+		PublicTxInput: pldapi.PublicTxInput{
+			From: resolvedKey,
+			To:   pldtypes.RandAddress(),
+			Data: pldtypes.HexBytes("some data"),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas:   confutil.P(pldtypes.HexUint64(1223451)),
+				Value: pldtypes.Uint64ToUint256(12345),
+			},
+		},
+	}
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(1122334455)), nil)
+
+	confirmations := make(chan *blockindexer.IndexedTransactionNotify, 1)
+	srtx := m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything)
+	srtx.Run(func(args mock.Arguments) {
+		signedMessage := args[1].(pldtypes.HexBytes)
+
+		signer, ethTx, err := ethsigner.RecoverRawTransaction(ctx, ethtypes.HexBytes0xPrefix(signedMessage), m.ethClient.ChainID())
+		require.NoError(t, err)
+		assert.Equal(t, *resolvedKey, pldtypes.EthAddress(*signer))
+
+		if ethTx.GasLimit.Int64() == cancelTransactionGas {
+			// The replacement must be a zero-value transfer to ourselves, on the original nonce
+			assert.Equal(t, resolvedKey.String(), ethTx.To.String())
+			assert.Zero(t, ethTx.Value.BigInt().Sign())
+			assert.Empty(t, ethTx.Data)
+			assert.Equal(t, uint64(1122334455), ethTx.Nonce.Uint64())
+			txHash := calculateTransactionHash(signedMessage)
+			confirmation := &blockindexer.IndexedTransactionNotify{
+				IndexedTransaction: pldapi.IndexedTransaction{
+					Hash:             *txHash,
+					BlockNumber:      11223344,
+					TransactionIndex: 10,
+					From:             resolvedKey,
+					To:               (*pldtypes.EthAddress)(ethTx.To),
+					Nonce:            ethTx.Nonce.Uint64(),
+					Result:           pldapi.TXResult_SUCCESS.Enum(),
+				},
+			}
+			confirmations <- confirmation
+
+			srtx.Return(&confirmation.Hash, nil)
+		} else {
+			srtx.Return(nil, fmt.Errorf("pop"))
+		}
+	})
+
+	pubTx, err := ptm.SingleTransactionSubmit(ctx, pubTxSub)
+	require.NoError(t, err)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	// Wait for the orchestrator to kick off and pick this TX up
+	var ift *inFlightTransactionStageController
+	for ift == nil {
+		<-ticker.C
+		if t.Failed() {
+			panic("test failed")
+		}
+		o := ptm.getOrchestratorForAddress(*resolvedKey)
+		if o != nil {
+			ift = o.getFirstInFlight()
+		}
+	}
+
+	// pub_txn_id not found
+	err = ptm.CancelTransaction(ctx, txID, uint64(2), resolvedKey)
+	assert.Regexp(t, "PD011911", err)
+
+	// cancel the transaction
+	err = ptm.CancelTransaction(ctx, txID, *pubTx.LocalID, resolvedKey)
+	require.NoError(t, err)
+
+	// cancelling again has no effect
+	err = ptm.CancelTransaction(ctx, txID, *pubTx.LocalID, resolvedKey)
+	require.NoError(t, err)
+
+	// a cancelled transaction cannot be updated
+	err = ptm.UpdateTransaction(ctx, txID, *pubTx.LocalID, resolvedKey, &pldapi.TransactionInput{}, nil, func(dbTX persistence.DBTX) error { return nil })
+	assert.Regexp(t, "PD011945", err)
+
+	waitingForConfirmation := true
+	for waitingForConfirmation {
+		select {
+		case confirmation := <-confirmations:
+			match, err := ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), []*blockindexer.IndexedTransactionNotify{confirmation})
+			require.NoError(t, err)
+			// the replacement is flagged, so the caller knows the transaction was cancelled
+			require.Len(t, match, 1)
+			assert.True(t, match[0].Cancellation)
+			ptm.NotifyConfirmPersisted(ctx, match)
+			waitingForConfirmation = false
+
+		case <-ticker.C:
+			if t.Failed() {
+				return
+			}
+		}
+	}
+
+	for ptm.getOrchestratorCount() > 0 {
+		<-ticker.C
+		if t.Failed() {
+			return
+		}
+	}
+
+	txs, err := ptm.QueryPublicTxForTransactions(ctx, ptm.p.NOTX(), []uuid.UUID{txID}, nil)
+	require.NoError(t, err)
+	require.Len(t, txs[txID], 1)
+	tx := txs[txID][0]
+	assert.Equal(t, resolvedKey, tx.To)
+	assert.Empty(t, tx.Data)
+	assert.Equal(t, pldtypes.HexUint64(cancelTransactionGas), *tx.Gas)
+	assert.True(t, *tx.Success)
+
+	// cannot cancel once complete
+	err = ptm.CancelTransaction(ctx, txID, *pubTx.LocalID, resolvedKey)
+	assert.Regexp(t, "PD011937", err)
+}
+
 func TestGasEstimateFactor(t *testing.T) {
 	ctx := context.Background()
 	_, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
@@ -16,8 +16,10 @@
 package publictxmgr
 
 import (
+	"cmp"
 	"context"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -107,6 +109,7 @@ type orchestrator struct {
 
 	// in-flight transaction config
 	resubmitInterval        time.Duration
+	resubmitBlocks          uint64
 	stageRetryTimeout       time.Duration
 	persistenceRetryTimeout time.Duration
 	ethClient               ethclient.EthClient
@@ -145,8 +148,9 @@ type orchestrator struct {
 	nextNonce      *uint64
 
 	// updates
-	updates   []*transactionUpdate
-	updateMux sync.Mutex
+	updates        []*transactionUpdate
+	nonceGapFilled bool
+	updateMux      sync.Mutex
 
 	timeLineLoggingMaxEntries int
 }
@@ -173,6 +177,7 @@ func NewOrchestrator(
 
 		// in-flight transaction configs
 		resubmitInterval:        confutil.DurationMin(conf.Orchestrator.ResubmitInterval, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.ResubmitInterval),
+		resubmitBlocks:          uint64(confutil.IntMin(conf.Orchestrator.ResubmitBlocks, 0, *pldconf.PublicTxManagerDefaults.Orchestrator.ResubmitBlocks)),
 		stageRetryTimeout:       confutil.DurationMin(conf.Orchestrator.StageRetryTime, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.StageRetryTime),
 		persistenceRetryTimeout: confutil.DurationMin(conf.Orchestrator.PersistenceRetryTime, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.PersistenceRetryTime),
 
@@ -197,6 +202,7 @@ func (oc *orchestrator) orchestratorLoop() {
 	log.L(ctx).Infof("Orchestrator for signing address %s started polling based on interval %s", oc.signingAddress, oc.orchestratorPollingInterval)
 
 	defer close(oc.orchestratorLoopDone)
+	defer oc.thMetrics.ClearInFlightTxQueueMetrics(ctx, oc.signingAddress.String())
 
 	if err := oc.initNextNonceFromDBRetry(ctx); err != nil {
 		log.L(ctx).Warnf("Context cancelled while obtaining highest nonce for %s: %s", oc.signingAddress, err)
@@ -247,6 +253,15 @@ func (oc *orchestrator) handleUpdates(ctx context.Context) {
 	}
 }
 
+// notifyNonceGapFilled tells the orchestrator a transaction has been written with a nonce
+// below those it has in flight, which its normal polling would not pick up
+func (oc *orchestrator) notifyNonceGapFilled() {
+	oc.updateMux.Lock()
+	oc.nonceGapFilled = true
+	oc.updateMux.Unlock()
+	oc.MarkInFlightTxStale()
+}
+
 // Used in unit tests
 func (oc *orchestrator) getFirstInFlight() (ift *inFlightTransactionStageController) {
 	oc.inFlightTxsMux.Lock()
@@ -391,6 +406,28 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		}
 	}
 
+	oc.updateMux.Lock()
+	nonceGapFilled := oc.nonceGapFilled
+	oc.nonceGapFilled = false
+	oc.updateMux.Unlock()
+	if nonceGapFilled && highestInFlightNonce != nil {
+		// We add gap fills regardless of the space, as everything we have in flight is waiting for them
+		gapFills, err := oc.pollNonceGapFills(ctx, *highestInFlightNonce)
+		if err != nil {
+			log.L(ctx).Infof("Orchestrator poll and process: context cancelled while retrying")
+			return -1, len(oc.inFlightTxs)
+		}
+		for _, ptx := range gapFills {
+			queueUpdated = true
+			oc.inFlightTxs = append(oc.inFlightTxs, NewInFlightTransactionStageController(oc.pubTxManager, oc, ptx))
+			stageCounts[string(InFlightTxStageQueued)] = stageCounts[string(InFlightTxStageQueued)] + 1
+			log.L(ctx).Infof("Orchestrator added nonce gap fill transaction with PublicTxnID=%d From=%s Nonce=%d", ptx.PublicTxnID, ptx.From, *ptx.Nonce)
+		}
+		slices.SortFunc(oc.inFlightTxs, func(a, b *inFlightTransactionStageController) int {
+			return cmp.Compare(a.stateManager.GetNonce(), b.stateManager.GetNonce())
+		})
+	}
+
 	log.L(ctx).Debugf("Orchestrator poll and process, stage counts: %+v", stageCounts)
 	oldLen := len(oc.inFlightTxs)
 	total = oldLen
@@ -457,7 +494,7 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		if polled > 0 {
 			log.L(ctx).Debugf("InFlight set updated len=%d head-nonce=%d tail-nonce=%d old-tail=%d", len(oc.inFlightTxs), oc.inFlightTxs[0].stateManager.GetNonce(), oc.inFlightTxs[total-1].stateManager.GetNonce(), highestInFlightNonce)
 		}
-		oc.thMetrics.RecordInFlightTxQueueMetrics(ctx, stageCounts, oc.maxInFlightTxs-len(oc.inFlightTxs))
+		oc.thMetrics.RecordInFlightTxQueueMetrics(ctx, oc.signingAddress.String(), stageCounts, oc.maxInFlightTxs-len(oc.inFlightTxs))
 	}
 	log.L(ctx).Debugf("Orchestrator polling from DB took %s", time.Since(pollStart))
 	// now check and process each transaction
@@ -486,6 +523,32 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 	return polled, total
 }
 
+// pollNonceGapFills queries pending transactions with a nonce below the highest we have in flight, that
+// are not already in flight. These are written by FillNonceGap
+func (oc *orchestrator) pollNonceGapFills(ctx context.Context, highestInFlightNonce uint64) (gapFills []*DBPublicTxn, err error) {
+	inFlightIDs := make([]uint64, len(oc.inFlightTxs))
+	for i, it := range oc.inFlightTxs {
+		inFlightIDs[i] = it.stateManager.GetPubTxnID()
+	}
+	err = oc.retry.Do(ctx, func(attempt int) (retry bool, err error) {
+		q := oc.p.DB().
+			WithContext(ctx).
+			Table("public_txns").
+			Joins("Completed").
+			Where(`"Completed"."tx_hash" IS NULL`).
+			Where("suspended IS FALSE").
+			Where(`"from" = ?`, oc.signingAddress).
+			Where("nonce < ?", highestInFlightNonce).
+			Order(`"public_txns"."nonce"`)
+		if len(inFlightIDs) > 0 {
+			q = q.Where(`"public_txns"."pub_txn_id" NOT IN (?)`, inFlightIDs)
+		}
+		gapFills, err = oc.runTransactionQuery(ctx, oc.p.NOTX(), false, nil, q)
+		return true, err
+	})
+	return gapFills, err
+}
+
 // this function should only have one running instance at any given time
 func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*inFlightTransactionStageController) (waitingForBalance bool, err error) {
 	processStart := time.Now()
@@ -515,6 +578,16 @@ func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*
 
 	}
 
+	var blockHeight uint64
+	if oc.resubmitBlocks > 0 {
+		// the height is only used to decide when to resubmit, so we carry on without it if it's not available
+		blockHeight, err = oc.bIndexer.GetBlockListenerHeight(ctx)
+		if err != nil {
+			log.L(ctx).Warnf("Failed to retrieve block height for resubmission check: %s", err)
+			blockHeight, err = 0, nil
+		}
+	}
+
 	previousNonceCostUnknown := false
 	for i, it := range its {
 		log.L(ctx).Debugf("%s ProcessInFlightTransaction for signing address %s processing transaction with ID: %s, index: %d", now.String(), oc.signingAddress, it.stateManager.GetSignerNonce(), i)
@@ -525,6 +598,7 @@ func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*
 		triggerNextStageOutput := it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{
 			AvailableToSpend:         availableToSpend,
 			PreviousNonceCostUnknown: previousNonceCostUnknown,
+			BlockHeight:              blockHeight,
 		})
 		if !skipBalanceCheck {
 			if triggerNextStageOutput.Cost != nil {
@@ -236,3 +236,50 @@ func TestOrchestratorTriggerTopUp(t *testing.T) {
 	o.Stop()
 	<-oDone
 }
+
+func TestOrchestratorPollNonceGapFills(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+
+	it5, _ := newInflightTransaction(o, 5, func(tx *DBPublicTxn) { tx.PublicTxnID = 5 })
+	it6, _ := newInflightTransaction(o, 6, func(tx *DBPublicTxn) { tx.PublicTxnID = 6 })
+	o.inFlightTxs = []*inFlightTransactionStageController{it5, it6}
+
+	o.notifyNonceGapFilled()
+	assert.True(t, o.nonceGapFilled)
+	assert.Len(t, o.InFlightTxsStale, 1)
+
+	m.db.ExpectQuery(`SELECT.*public_txn.*nonce < .*NOT IN`).WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id", "from", "nonce"}).AddRow(
+		10, o.signingAddress, 3,
+	))
+	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	gapFills, err := o.pollNonceGapFills(ctx, 6)
+	require.NoError(t, err)
+	require.Len(t, gapFills, 1)
+	assert.Equal(t, uint64(3), *gapFills[0].Nonce)
+	assert.NoError(t, m.db.ExpectationsWereMet())
+}
+
+func TestOrchestratorBlockHeightForResubmit(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.ResubmitBlocks = confutil.P(5)
+	})
+	defer done()
+	assert.Equal(t, uint64(5), o.resubmitBlocks)
+
+	o.hasZeroGasPrice = true
+	it, mTS := newInflightTransaction(o, 1)
+	it.testOnlyNoActionMode = true
+
+	m.blockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(100), nil).Once()
+	_, err := o.ProcessInFlightTransactions(ctx, []*inFlightTransactionStageController{it})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), mTS.orchestratorContext.BlockHeight)
+
+	// we carry on without the height if it's not available
+	m.blockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(0), fmt.Errorf("pop")).Once()
+	_, err = o.ProcessInFlightTransactions(ctx, []*inFlightTransactionStageController{it})
+	require.NoError(t, err)
+	assert.Zero(t, mTS.orchestratorContext.BlockHeight)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -39,9 +40,11 @@ func calculateTransactionHash(rawTxnData []byte) *pldtypes.Bytes32 {
 	return &hashBytes
 }
 
-func (it *inFlightTransactionStageController) submitTX(ctx context.Context, signedMessage []byte, calculatedTxHash *pldtypes.Bytes32, signerNonce string, lastSubmitTime *pldtypes.Timestamp, cancelled func(context.Context) bool) (*pldtypes.Bytes32, *pldtypes.Timestamp, ethclient.ErrorReason, SubmissionOutcome, error) {
+func (it *inFlightTransactionStageController) submitTX(ctx context.Context, signedMessage []byte, calculatedTxHash *pldtypes.Bytes32, signerNonce string, lastSubmitTime *pldtypes.Timestamp, cancelled func(context.Context) bool) (_ *pldtypes.Bytes32, _ *pldtypes.Timestamp, _ ethclient.ErrorReason, _ SubmissionOutcome, err error) {
 	var txHash *pldtypes.Bytes32
 	sendStart := time.Now()
+	ctx, span := tracing.StartSpan(ctx, "publictx submit", tracing.AttrSigner.String(it.stateManager.GetFrom().String()))
+	defer func() { tracing.EndSpan(span, err) }()
 	if calculatedTxHash == nil {
 		return nil, nil, ethclient.ErrorReasonInvalidInputs, SubmissionOutcomeFailedRequiresRetry, i18n.NewError(ctx, msgs.MsgInvalidStateMissingTXHash)
 	}
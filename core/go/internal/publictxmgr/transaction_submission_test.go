@@ -53,7 +53,7 @@ func TestTxSubmissionWithSignedMessage(t *testing.T) {
 	})
 
 	// successful send with tx hash returned
-	txSendMock := m.ethClient.On("SendRawTransaction", ctx, mock.Anything)
+	txSendMock := m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything)
 	txSendMock.Run(func(args mock.Arguments) {
 		txRawMessage := args[1].(pldtypes.HexBytes)
 		assert.Equal(t, pldtypes.MustParseHexBytes(testHashedSignedMessage), txRawMessage)
@@ -209,7 +209,7 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 	})
 
 	// successful send with tx hash returned
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(&textTxHashByte32, nil).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(&textTxHashByte32, nil).Once()
 
 	txHash, _, errReason, outCome, err := it.submitTX(ctx,
 		[]byte(testTransactionData),
@@ -223,7 +223,7 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 	assert.Equal(t, testTxHash, txHash.String())
 
 	// successful send with tx hash missing
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(
 		nil,
 		nil,
 	).Once()
@@ -240,9 +240,9 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 	assert.Equal(t, testTxHash, txHash.String()) // able to use the calculated hash
 
 	// successful send but tx hash mismatch first time
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(&textWrongTxHashByte32, nil).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(&textWrongTxHashByte32, nil).Once()
 	// but corrected in the retry
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(&textTxHashByte32, nil).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(&textTxHashByte32, nil).Once()
 
 	txHash, _, errReason, outCome, err = it.submitTX(ctx,
 		[]byte(testTransactionData),
@@ -257,7 +257,7 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 
 	// categorized errors should not be retried
 	// underpriced
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(nil, fmt.Errorf("transaction underpriced")).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("transaction underpriced")).Once()
 
 	txHash, _, errReason, outCome, err = it.submitTX(ctx,
 		[]byte(testTransactionData),
@@ -270,7 +270,7 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 	assert.Equal(t, SubmissionOutcomeFailedRequiresRetry, outCome)
 	assert.Equal(t, testTxHash, txHash.String())
 	// reverted
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(nil, fmt.Errorf("execution reverted")).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("execution reverted")).Once()
 
 	txHash, _, errReason, outCome, err = it.submitTX(ctx,
 		[]byte(testTransactionData),
@@ -283,7 +283,7 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 	assert.Equal(t, SubmissionOutcomeFailedRequiresRetry, outCome)
 	assert.Equal(t, testTxHash, txHash.String())
 	// known transaction
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(nil, fmt.Errorf("known transaction")).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("known transaction")).Once()
 
 	txHash, _, errReason, outCome, err = it.submitTX(ctx,
 		[]byte(testTransactionData),
@@ -296,7 +296,7 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 	assert.Equal(t, SubmissionOutcomeAlreadyKnown, outCome)
 	assert.Equal(t, testTxHash, txHash.String()) // able to use the calculated hash
 	// nonce too low
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(nil, fmt.Errorf("nonce too low")).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("nonce too low")).Once()
 
 	txHash, _, errReason, outCome, err = it.submitTX(ctx,
 		[]byte(testTransactionData),
@@ -311,10 +311,10 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 
 	// un-categorized errors should be retried
 	// successful send when first time returned un-categorized error
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(nil, fmt.Errorf("error submitting transaction")).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("error submitting transaction")).Once()
 
 	// but the second time was successful
-	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(&textTxHashByte32, nil).Once()
+	m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything).Return(&textTxHashByte32, nil).Once()
 
 	txHash, _, errReason, outCome, err = it.submitTX(ctx,
 		[]byte(testTransactionData),
@@ -264,6 +264,8 @@ type InMemoryTxStateReadOnly interface {
 	GetValue() *pldtypes.HexUint256
 	BuildEthTX() *ethsigner.Transaction
 	GetGasPriceObject() *pldapi.PublicTxGasPricing
+	GetGasPriceCaps() pldapi.PublicTxGasPriceCaps
+	IsCancelled() bool
 	GetFirstSubmit() *pldtypes.Timestamp
 	GetLastSubmitTime() *pldtypes.Timestamp
 	GetUnflushedSubmission() *DBPubTxnSubmission
@@ -388,6 +390,7 @@ type OrchestratorContext struct {
 	// input from transaction engine
 	AvailableToSpend         *big.Int
 	PreviousNonceCostUnknown bool
+	BlockHeight              uint64 // only set when resubmitting based on blocks, zero if unknown
 }
 
 // output of some stages doesn't get written into the database
@@ -33,6 +33,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"gorm.io/gorm"
 )
 
 type domainContext struct {
@@ -330,6 +331,21 @@ func (dc *domainContext) FindAvailableNullifiers(dbTX persistence.DBTX, schemaID
 	return schema, states, err
 }
 
+func (dc *domainContext) FindInfoStates(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON) (components.Schema, []*pldapi.State, error) {
+	log.L(dc.Context).Debug("domainContext:FindInfoStates")
+	// Info states are never confirmed or spent, and have no locks in the domain context,
+	// so we only return those with an info record from a confirmed transaction
+	return dc.ss.findStates(dc, dbTX, dc.domainName, &dc.contractAddress, schemaID, query, &components.StateQueryOptions{
+		StatusQualifier: pldapi.StateStatusAll,
+		QueryModifier: func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+			return q.Where(`"states"."id" IN (?)`, dbTX.DB().
+				Table("state_info_records").
+				Select("state").
+				Where("domain_name = ?", dc.domainName))
+		},
+	})
+}
+
 func (dc *domainContext) UpsertStates(dbTX persistence.DBTX, stateUpserts ...*components.StateUpsert) (states []*pldapi.State, err error) {
 	return dc.upsertStates(dbTX, false, stateUpserts...)
 }
@@ -606,6 +606,16 @@ func TestStateContextMintSpendWithNullifier(t *testing.T) {
 		}, []*pldapi.StateReadRecord{}, []*pldapi.StateConfirmRecord{}, []*pldapi.StateInfoRecord{})
 	require.NoError(t, err)
 
+	// The spend of the nullifier is resolved to the state in the transaction states
+	txStates, err := ss.GetTransactionStates(ctx, ss.p.NOTX(), transactionID3)
+	require.NoError(t, err)
+	require.Nil(t, txStates.Unavailable)
+	require.Len(t, txStates.Spent, 1)
+	assert.Equal(t, stateID1, txStates.Spent[0].ID)
+	require.Len(t, txStates.Nullifiers, 1)
+	assert.Equal(t, nullifier1, txStates.Nullifiers[0].ID)
+	assert.Equal(t, stateID1, txStates.Nullifiers[0].State)
+
 	// reset the domain context so we're working from the db
 	dc.Reset()
 
@@ -1348,3 +1358,53 @@ func TestGetStatesByIDFail(t *testing.T) {
 	_, _, err := dc.GetStatesByID(dc.ss.p.NOTX(), pldtypes.Bytes32(pldtypes.RandBytes(32)), []string{pldtypes.RandHex(32)})
 	assert.Regexp(t, "pop", err)
 }
+
+func TestFindInfoStates(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	// Info states are upserted without a create lock
+	transactionID1 := uuid.New()
+	infoStates, err := dc.UpsertStates(ss.p.NOTX(),
+		&components.StateUpsert{Schema: schemaID, Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": 10, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`, pldtypes.RandHex(32)))},
+		&components.StateUpsert{Schema: schemaID, Data: pldtypes.RawJSON(fmt.Sprintf(`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`, pldtypes.RandHex(32)))},
+	)
+	require.NoError(t, err)
+	syncFlushContext(t, dc)
+
+	// Not visible until the transaction is confirmed
+	_, states, err := dc.FindInfoStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	err = ss.WriteStateFinalizations(ss.bgCtx, ss.p.NOTX(), []*pldapi.StateSpendRecord{}, []*pldapi.StateReadRecord{}, []*pldapi.StateConfirmRecord{},
+		[]*pldapi.StateInfoRecord{
+			{DomainName: "domain1", State: infoStates[1].ID, Transaction: transactionID1},
+		})
+	require.NoError(t, err)
+
+	_, states, err = dc.FindInfoStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, infoStates[1].ID, states[0].ID)
+	assert.Equal(t, int64(20), parseFakeCoin(t, states[0]).Amount.Int64())
+
+	// Info states are never available
+	_, states, err = dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	// Queries are applied as normal
+	_, states, err = dc.FindInfoStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Equal("amount", 10).Query())
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+}
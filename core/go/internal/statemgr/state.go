@@ -38,6 +38,7 @@ type transactionStateRecord struct {
 	pldapi.StateBase
 	State          pldtypes.HexBytes `gorm:"column:state"`
 	RecordType     string            `gorm:"column:record_type"`
+	Nullifier      pldtypes.HexBytes `gorm:"column:nullifier"`
 	SpentState     pldtypes.HexBytes `gorm:"column:spent_state"`
 	ReadState      pldtypes.HexBytes `gorm:"column:read_state"`
 	ConfirmedState pldtypes.HexBytes `gorm:"column:confirmed_state"`
@@ -137,11 +137,14 @@ func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persisten
 		WithContext(ctx).
 		// This query joins across three tables in a single query - pushing the complexity to the DB.
 		// The reason we have three tables is to make the queries for available states simpler.
+		// Spend records for domains that use nullifiers record the nullifier, so we resolve those to the state
+		// where we have the nullifier locally (it is only known to the node that owned the state).
 		Raw(`SELECT * from "states" RIGHT JOIN ( `+
-			`SELECT "transaction", "state", 'spent'     AS "record_type" FROM "state_spend_records"   WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'read'      AS "record_type" FROM "state_read_records"    WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'confirmed' AS "record_type" FROM "state_confirm_records" WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'info'      AS "record_type" FROM "state_info_records"    WHERE "transaction" = ? ) "records" `+
+			`SELECT "sr"."transaction", COALESCE("n"."state", "sr"."state") AS "state", 'spent' AS "record_type", "n"."id" AS "nullifier" FROM "state_spend_records" "sr" `+
+			`LEFT JOIN "state_nullifiers" "n" ON "n"."domain_name" = "sr"."domain_name" AND "n"."id" = "sr"."state" WHERE "sr"."transaction" = ? UNION ALL `+
+			`SELECT "transaction", "state", 'read'      AS "record_type", NULL AS "nullifier" FROM "state_read_records"    WHERE "transaction" = ? UNION ALL `+
+			`SELECT "transaction", "state", 'confirmed' AS "record_type", NULL AS "nullifier" FROM "state_confirm_records" WHERE "transaction" = ? UNION ALL `+
+			`SELECT "transaction", "state", 'info'      AS "record_type", NULL AS "nullifier" FROM "state_info_records"    WHERE "transaction" = ? ) "records" `+
 			`ON "states"."id" = "records"."state"`,
 			txID, txID, txID, txID).
 		Scan(&records).
@@ -162,6 +165,13 @@ func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persisten
 				unavailable.Spent = append(unavailable.Spent, s.State)
 			} else {
 				txStates.Spent = append(txStates.Spent, &s.StateBase)
+				if s.Nullifier != nil {
+					txStates.Nullifiers = append(txStates.Nullifiers, &pldapi.StateNullifier{
+						DomainName: s.DomainName,
+						State:      s.ID,
+						ID:         s.Nullifier,
+					})
+				}
 			}
 		case "read":
 			if s.ID == nil {
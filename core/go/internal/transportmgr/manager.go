@@ -27,6 +27,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/flushwriter"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -40,6 +41,7 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 )
 
 type transportManager struct {
@@ -137,8 +139,9 @@ func (tm *transportManager) PostInit(c components.AllComponents) error {
 	tm.identityResolver = c.IdentityResolver()
 	tm.groupManager = c.GroupManager()
 	tm.persistence = c.Persistence()
-	tm.reliableMsgWriter = flushwriter.NewWriter(tm.bgCtx, tm.handleReliableMsgBatch, tm.persistence,
+	tm.reliableMsgWriter = flushwriter.NewWriter(tm.bgCtx, "transport_reliable_msgs", tm.handleReliableMsgBatch, tm.persistence, c.MetricsManager(),
 		&tm.conf.ReliableMessageWriter, &pldconf.TransportManagerDefaults.ReliableMessageWriter)
+	metrics.MustRegister(c.MetricsManager().Registry(), &peersCollector{tm: tm})
 	return nil
 }
 
@@ -267,10 +270,11 @@ func (tm *transportManager) Send(ctx context.Context, send *components.FireAndFo
 		send.MessageID = &msgID
 	}
 	msg := &prototk.PaladinMsg{
-		MessageId:   send.MessageID.String(),
-		MessageType: send.MessageType,
-		Component:   send.Component,
-		Payload:     send.Payload,
+		MessageId:    send.MessageID.String(),
+		MessageType:  send.MessageType,
+		Component:    send.Component,
+		Payload:      send.Payload,
+		TraceContext: tracing.InjectMap(ctx),
 	}
 	if send.CorrelationID != nil {
 		cidStr := send.CorrelationID.String()
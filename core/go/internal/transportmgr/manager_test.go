@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
@@ -79,6 +80,7 @@ func newMockComponents(t *testing.T, realDB bool) *mockComponents {
 	mc.c.On("PrivateTxManager").Return(mc.privateTxManager).Maybe()
 	mc.c.On("IdentityResolver").Return(mc.identityResolver).Maybe()
 	mc.c.On("GroupManager").Return(mc.groupManager).Maybe()
+	mc.c.On("MetricsManager").Return(metrics.NewMetricsManager(context.Background())).Maybe()
 	return mc
 }
 
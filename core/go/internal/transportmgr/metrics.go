@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsSubsystem = "transport"
+
+var (
+	peersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "active_peers"),
+		"Number of peers with an active connection", nil, nil)
+	peerSentMsgsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "peer_sent_messages_total"),
+		"Messages sent to each active peer", []string{"peer"}, nil)
+	peerReceivedMsgsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "peer_received_messages_total"),
+		"Messages received from each active peer", []string{"peer"}, nil)
+	peerSentBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "peer_sent_bytes_total"),
+		"Payload bytes sent to each active peer", []string{"peer"}, nil)
+	peerReceivedBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "peer_received_bytes_total"),
+		"Payload bytes received from each active peer", []string{"peer"}, nil)
+	peerReliableBacklogDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "peer_reliable_backlog"),
+		"Reliable messages sent to each active peer that have not yet been acknowledged", []string{"peer"}, nil)
+	peerSendQueueDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, metricsSubsystem, "peer_send_queue_length"),
+		"Messages queued in memory for sending to each active peer", []string{"peer"}, nil)
+)
+
+// Reports the stats of the active peers at scrape time. The counters reset when an
+// inactive peer is reaped, which Prometheus handles as a normal counter reset.
+type peersCollector struct {
+	tm *transportManager
+}
+
+func (pc *peersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- peersDesc
+	ch <- peerSentMsgsDesc
+	ch <- peerReceivedMsgsDesc
+	ch <- peerSentBytesDesc
+	ch <- peerReceivedBytesDesc
+	ch <- peerReliableBacklogDesc
+	ch <- peerSendQueueDesc
+}
+
+func (pc *peersCollector) Collect(ch chan<- prometheus.Metric) {
+	peers := pc.tm.listActivePeers()
+	ch <- prometheus.MustNewConstMetric(peersDesc, prometheus.GaugeValue, float64(len(peers)))
+	for _, p := range peers {
+		p.statsLock.Lock()
+		stats := p.Stats
+		p.statsLock.Unlock()
+
+		var backlog uint64
+		if stats.ReliableHighestSent > stats.ReliableAckBase {
+			backlog = stats.ReliableHighestSent - stats.ReliableAckBase
+		}
+		ch <- prometheus.MustNewConstMetric(peerSentMsgsDesc, prometheus.CounterValue, float64(stats.SentMsgs), p.Name)
+		ch <- prometheus.MustNewConstMetric(peerReceivedMsgsDesc, prometheus.CounterValue, float64(stats.ReceivedMsgs), p.Name)
+		ch <- prometheus.MustNewConstMetric(peerSentBytesDesc, prometheus.CounterValue, float64(stats.SentBytes), p.Name)
+		ch <- prometheus.MustNewConstMetric(peerReceivedBytesDesc, prometheus.CounterValue, float64(stats.ReceivedBytes), p.Name)
+		ch <- prometheus.MustNewConstMetric(peerReliableBacklogDesc, prometheus.GaugeValue, float64(backlog), p.Name)
+		ch <- prometheus.MustNewConstMetric(peerSendQueueDesc, prometheus.GaugeValue, float64(len(p.sendQueue)), p.Name)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeersCollector(t *testing.T) {
+	sendQueue := make(chan *prototk.PaladinMsg, 10)
+	sendQueue <- &prototk.PaladinMsg{}
+	tm := &transportManager{peers: map[string]*peer{}}
+	tm.peers["node2"] = &peer{
+		PeerInfo: pldapi.PeerInfo{
+			Name: "node2",
+			Stats: pldapi.PeerStats{
+				SentMsgs:            10,
+				ReceivedMsgs:        5,
+				SentBytes:           1000,
+				ReceivedBytes:       500,
+				ReliableHighestSent: 8,
+				ReliableAckBase:     6,
+			},
+		},
+		sendQueue: sendQueue,
+	}
+
+	err := testutil.CollectAndCompare(&peersCollector{tm: tm}, strings.NewReader(`
+# HELP paladin_transport_active_peers Number of peers with an active connection
+# TYPE paladin_transport_active_peers gauge
+paladin_transport_active_peers 1
+# HELP paladin_transport_peer_reliable_backlog Reliable messages sent to each active peer that have not yet been acknowledged
+# TYPE paladin_transport_peer_reliable_backlog gauge
+paladin_transport_peer_reliable_backlog{peer="node2"} 2
+# HELP paladin_transport_peer_send_queue_length Messages queued in memory for sending to each active peer
+# TYPE paladin_transport_peer_send_queue_length gauge
+paladin_transport_peer_send_queue_length{peer="node2"} 1
+# HELP paladin_transport_peer_sent_messages_total Messages sent to each active peer
+# TYPE paladin_transport_peer_sent_messages_total counter
+paladin_transport_peer_sent_messages_total{peer="node2"} 10
+`), "paladin_transport_active_peers", "paladin_transport_peer_reliable_backlog", "paladin_transport_peer_send_queue_length", "paladin_transport_peer_sent_messages_total")
+	require.NoError(t, err)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -158,13 +159,18 @@ func parseReceivedMessage(ctx context.Context, fromNode string, msg *prototk.Pal
 }
 
 // Transport callback to the transport manager when a message is received
-func (t *transport) ReceiveMessage(ctx context.Context, req *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+func (t *transport) ReceiveMessage(ctx context.Context, req *prototk.ReceiveMessageRequest) (_ *prototk.ReceiveMessageResponse, err error) {
 	if err := t.checkInit(ctx); err != nil {
 		return nil, err
 	}
 
 	msg := req.Message
 
+	// Continue the trace of the sending node
+	ctx, span := tracing.StartSpan(tracing.ExtractMap(ctx, msg.TraceContext), "transport receive "+msg.MessageType,
+		tracing.AttrNode.String(req.FromNode))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	rMsg, err := parseReceivedMessage(ctx, req.FromNode, msg)
 	if err != nil {
 		return nil, err
@@ -182,7 +188,7 @@ func (t *transport) ReceiveMessage(ctx context.Context, req *prototk.ReceiveMess
 		log.L(ctx).Tracef("transport %s message received: %s", t.name, protoToJSON(msg))
 	}
 
-	if err := t.deliverMessage(ctx, p, msg.Component, rMsg); err != nil {
+	if err = t.deliverMessage(ctx, p, msg.Component, rMsg); err != nil {
 		return nil, err
 	}
 
@@ -18,8 +18,10 @@ package txmgr
 import (
 	"context"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -54,7 +56,7 @@ func (tm *txManager) blockIndexerPreCommit(
 			log.L(ctx).Infof("Writing receipt for transaction %s hash=%s block=%d result=%s",
 				match.TransactionID, match.Hash, match.BlockNumber, match.Result)
 			// Map to the common format for finalizing transactions whether the make it on chain or not
-			finalizeInfo = append(finalizeInfo, tm.mapBlockchainReceipt(match))
+			finalizeInfo = append(finalizeInfo, tm.mapBlockchainReceipt(ctx, match))
 		case pldapi.TransactionTypePrivate:
 			if match.Result.V() != pldapi.TXResult_SUCCESS {
 				log.L(ctx).Infof("Base ledger transaction for private transaction %s FAILED hash=%s block=%d result=%s",
@@ -90,7 +92,7 @@ func (tm *txManager) blockIndexerPreCommit(
 	return nil
 }
 
-func (tm *txManager) mapBlockchainReceipt(pubTx *components.PublicTxMatch) *components.ReceiptInput {
+func (tm *txManager) mapBlockchainReceipt(ctx context.Context, pubTx *components.PublicTxMatch) *components.ReceiptInput {
 	receipt := &components.ReceiptInput{
 		TransactionID: pubTx.TransactionID,
 		OnChain: pldtypes.OnChainLocation{
@@ -102,9 +104,15 @@ func (tm *txManager) mapBlockchainReceipt(pubTx *components.PublicTxMatch) *comp
 		ContractAddress: pubTx.ContractAddress,
 		RevertData:      pubTx.RevertReason,
 	}
-	if pubTx.Result.V() == pldapi.TXResult_SUCCESS {
+	switch {
+	case pubTx.Cancellation:
+		// the zero-value replacement was mined on the nonce, so the original transaction never can be
+		receipt.ReceiptType = components.RT_FailedWithMessage
+		receipt.FailureMessage = i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgTxMgrTransactionCancelled))
+		receipt.RevertData = nil
+	case pubTx.Result.V() == pldapi.TXResult_SUCCESS:
 		receipt.ReceiptType = components.RT_Success
-	} else {
+	default:
 		receipt.ReceiptType = components.RT_FailedOnChainWithRevertData
 		receipt.RevertData = pubTx.RevertReason
 	}
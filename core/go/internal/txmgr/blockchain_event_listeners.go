@@ -54,6 +54,7 @@ type blockchainEventListener struct {
 	cancelCtx context.CancelFunc
 
 	definition *blockindexer.EventStream
+	webhook    *webhookDelivery
 
 	receiverLock    sync.Mutex
 	receivers       []*registeredBlockchainEventReceiver
@@ -108,8 +109,14 @@ func (tm *txManager) loadBlockchainEventListener(ctx context.Context, es *blocki
 		newReceivers: make(chan bool, 1),
 	}
 
-	el.ctx, el.cancelCtx = context.WithCancel(log.WithLogField(el.tm.bgCtx, "blockchain-event-listener", es.Name))
 	var err error
+	if es.Config.Webhook != nil {
+		if el.webhook, err = tm.newWebhookDelivery(ctx, listenerTypeBlockchainEvent, es.Name, es.Config.Webhook); err != nil {
+			return nil, err
+		}
+	}
+
+	el.ctx, el.cancelCtx = context.WithCancel(log.WithLogField(el.tm.bgCtx, "blockchain-event-listener", es.Name))
 	el.definition, err = tm.blockIndexer.AddEventStream(ctx, dbTX, &blockindexer.InternalEventStream{
 		Type:        blockindexer.IESTypeEventStreamNOTX,
 		Definition:  es,
@@ -200,6 +207,9 @@ func (tm *txManager) DeleteBlockchainEventListener(ctx context.Context, name str
 		return i18n.NewError(ctx, msgs.MsgTxMgrBlockchainEventListenerNotLoaded, name)
 	}
 	err := tm.blockIndexer.RemoveEventStream(ctx, el.definition.ID)
+	if err == nil && el.webhook != nil {
+		err = tm.deleteListenerDeadLetters(ctx, listenerTypeBlockchainEvent, name)
+	}
 	if err == nil {
 		delete(tm.blockchainEventListeners, name)
 	}
@@ -230,6 +240,12 @@ func (tm *txManager) validateBlockchainEventListenerSpec(ctx context.Context, sp
 			return i18n.NewError(ctx, msgs.MsgTxMgrBlockchainEventListenerInvalidTimeout, *spec.Options.BatchTimeout, err.Error())
 		}
 	}
+
+	if spec.Options.Webhook != nil {
+		if _, err := tm.newWebhookDelivery(ctx, listenerTypeBlockchainEvent, spec.Name, spec.Options.Webhook); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -241,6 +257,7 @@ func (tm *txManager) mapEventStream(el *pldapi.BlockchainEventListener) *blockin
 		Config: blockindexer.EventStreamConfig{
 			BatchSize:    el.Options.BatchSize,
 			BatchTimeout: el.Options.BatchTimeout,
+			Webhook:      el.Options.Webhook,
 		},
 	}
 
@@ -262,6 +279,7 @@ func (tm *txManager) mapBlockchainEventListener(es *blockindexer.EventStream) *p
 		Options: pldapi.BlockchainEventListenerOptions{
 			BatchSize:    es.Config.BatchSize,
 			BatchTimeout: es.Config.BatchTimeout,
+			Webhook:      es.Config.Webhook,
 		},
 	}
 	for _, source := range es.Sources {
@@ -282,6 +300,9 @@ func (tm *txManager) AddBlockchainEventReceiver(ctx context.Context, name string
 	if l == nil {
 		return nil, i18n.NewError(ctx, msgs.MsgTxMgrBlockchainEventListenerNotLoaded, name)
 	}
+	if l.webhook != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrWebhookListenerNoReceivers, name)
+	}
 
 	return l.addReceiver(r), nil
 }
@@ -345,13 +366,27 @@ func (el *blockchainEventListener) nextReceiver() (r components.BlockchainEventR
 	}
 }
 
-func (el *blockchainEventListener) handleEventBatch(_ context.Context, batch *blockindexer.EventDeliveryBatch) error {
+func (el *blockchainEventListener) handleEventBatch(_ context.Context, batch *blockindexer.EventDeliveryBatch) (blockindexer.PreCheckpointHandler, error) {
+	if el.webhook != nil {
+		log.L(el.ctx).Infof("Delivering blockchain event batch %s (events=%d) to webhook", batch.BatchID, len(batch.Events))
+		deadLetter, err := el.webhook.deliver(el.ctx, batch.BatchID.String(), &pldapi.TransactionEventBatch{
+			BatchID: batch.BatchID,
+			Events:  batch.Events,
+		})
+		if err != nil || deadLetter == nil {
+			return nil, err
+		}
+		return func(ctx context.Context, dbTX persistence.DBTX) error {
+			return writeListenerDeadLetter(ctx, dbTX, deadLetter)
+		}, nil
+	}
+
 	r, err := el.nextReceiver()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	log.L(el.ctx).Infof("Delivering blockchain event batch %s (receipts=%d)", batch.BatchID, len(batch.Events))
 	err = r.DeliverBlockchainEventBatch(el.ctx, batch.BatchID, batch.Events)
 	log.L(el.ctx).Infof("Delivered blockchain event batch %s (err=%v)", batch.BatchID, err)
-	return err
+	return nil, err
 }
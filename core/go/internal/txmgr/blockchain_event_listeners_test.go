@@ -481,7 +481,7 @@ func TestHandleEventBatch(t *testing.T) {
 		},
 	})
 
-	err := el.handleEventBatch(context.Background(), &blockindexer.EventDeliveryBatch{
+	_, err := el.handleEventBatch(context.Background(), &blockindexer.EventDeliveryBatch{
 		BatchID: testBatchID,
 		Events:  testEvents,
 	})
@@ -492,7 +492,7 @@ func TestHandleEventBatch(t *testing.T) {
 	// call again in a goroutine with no listeners and cancel
 	gotError := make(chan bool, 1)
 	go func() {
-		err := el.handleEventBatch(context.Background(), nil)
+		_, err := el.handleEventBatch(context.Background(), nil)
 		require.Error(t, err)
 		gotError <- true
 	}()
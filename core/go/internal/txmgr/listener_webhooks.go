@@ -0,0 +1,240 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"gorm.io/gorm"
+)
+
+const (
+	listenerTypeReceipt         = "receipt"
+	listenerTypeBlockchainEvent = "blockchain_event"
+
+	redactedValue = "***"
+)
+
+type persistedListenerDeadLetter struct {
+	ID           uuid.UUID          `gorm:"column:id;primaryKey"`
+	ListenerType string             `gorm:"column:listener_type"`
+	Listener     string             `gorm:"column:listener"`
+	Created      pldtypes.Timestamp `gorm:"column:created"`
+	BatchID      string             `gorm:"column:batch_id"`
+	Attempts     int                `gorm:"column:attempts"`
+	Error        string             `gorm:"column:error"`
+	Batch        pldtypes.RawJSON   `gorm:"column:batch"`
+}
+
+func (persistedListenerDeadLetter) TableName() string {
+	return "listener_dead_letters"
+}
+
+var listenerDeadLetterFilters = filters.FieldMap{
+	"id":       filters.UUIDField("id"),
+	"created":  filters.TimestampField("created"),
+	"batchId":  filters.StringField("batch_id"),
+	"attempts": filters.Int64Field("attempts"),
+}
+
+// A webhook delivery is used by a receipt or blockchain event listener in place of
+// WebSocket receivers. Each batch is POSTed until a 2xx response is received. By default
+// delivery is retried indefinitely. If maxAttempts is configured, once the retry gives up
+// the batch is reported as delivered along with a dead letter, which the listener writes
+// in the same DB transaction that moves its checkpoint past the batch.
+type webhookDelivery struct {
+	listenerType string
+	listener     string
+	client       *resty.Client
+	retry        *retry.Retry
+}
+
+func (tm *txManager) newWebhookDelivery(ctx context.Context, listenerType, listener string, conf *pldapi.ListenerWebhook) (*webhookDelivery, error) {
+	httpConf := conf.HTTP // copy, as parsing updates the TLS config
+	client, err := rpcclient.ParseHTTPConfig(ctx, &httpConf)
+	if err != nil {
+		return nil, err
+	}
+	wd := &webhookDelivery{
+		listenerType: listenerType,
+		listener:     listener,
+		client:       client,
+	}
+	if conf.Retry.MaxAttempts != nil {
+		wd.retry = retry.NewRetryLimited(&conf.Retry)
+	} else {
+		wd.retry = retry.NewRetryIndefinite(&conf.Retry.RetryConfig)
+	}
+	return wd, nil
+}
+
+// deliver only returns an error if the context is cancelled. When the batch could not be
+// delivered within the configured maxAttempts, a dead letter is returned for the caller to
+// write along with its checkpoint.
+func (wd *webhookDelivery) deliver(ctx context.Context, batchID string, batch any) (*persistedListenerDeadLetter, error) {
+	body := pldtypes.JSONString(batch)
+
+	attempts := 0
+	var lastErr error
+	err := wd.retry.Do(ctx, func(attempt int) (retryable bool, err error) {
+		attempts = attempt
+		lastErr = wd.post(ctx, batchID, body)
+		return true, lastErr
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if ctx.Err() != nil {
+		// We are stopping - the batch is redelivered when the listener restarts
+		return nil, err
+	}
+
+	log.L(ctx).Errorf("Dead-lettering batch %s after %d attempts: %s", batchID, attempts, lastErr)
+	return &persistedListenerDeadLetter{
+		ID:           uuid.New(),
+		ListenerType: wd.listenerType,
+		Listener:     wd.listener,
+		Created:      pldtypes.TimestampNow(),
+		BatchID:      batchID,
+		Attempts:     attempts,
+		Error:        lastErr.Error(),
+		Batch:        body,
+	}, nil
+}
+
+func writeListenerDeadLetter(ctx context.Context, dbTX persistence.DBTX, deadLetter *persistedListenerDeadLetter) error {
+	if deadLetter == nil {
+		return nil
+	}
+	return dbTX.DB().
+		WithContext(ctx).
+		Create(deadLetter).
+		Error
+}
+
+func (wd *webhookDelivery) post(ctx context.Context, batchID string, body pldtypes.RawJSON) error {
+	res, err := wd.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody([]byte(body)).
+		Post("")
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgTxMgrWebhookRequestFailed, batchID, wd.listener)
+	}
+	if !res.IsSuccess() {
+		return i18n.NewError(ctx, msgs.MsgTxMgrWebhookBadStatus, batchID, wd.listener, res.StatusCode(), res.String())
+	}
+	log.L(ctx).Infof("Webhook delivered batch %s (status=%d)", batchID, res.StatusCode())
+	return nil
+}
+
+// redactWebhook returns a copy of the webhook configuration of a listener that is safe to
+// return on the API, without the basic auth password, header values, or the TLS key
+func redactWebhook(webhook *pldapi.ListenerWebhook) *pldapi.ListenerWebhook {
+	if webhook == nil {
+		return nil
+	}
+	redacted := *webhook
+	if len(webhook.HTTP.HTTPHeaders) > 0 {
+		redacted.HTTP.HTTPHeaders = make(map[string]interface{}, len(webhook.HTTP.HTTPHeaders))
+		for k := range webhook.HTTP.HTTPHeaders {
+			redacted.HTTP.HTTPHeaders[k] = redactedValue
+		}
+	}
+	if redacted.HTTP.Auth.Password != "" {
+		redacted.HTTP.Auth.Password = redactedValue
+	}
+	if redacted.HTTP.TLS.Key != "" {
+		redacted.HTTP.TLS.Key = redactedValue
+	}
+	if redacted.HTTP.TLS.KeyFile != "" {
+		redacted.HTTP.TLS.KeyFile = redactedValue
+	}
+	return &redacted
+}
+
+func redactReceiptListener(l *pldapi.TransactionReceiptListener) *pldapi.TransactionReceiptListener {
+	if l == nil || l.Options.Webhook == nil {
+		return l
+	}
+	redacted := *l
+	redacted.Options.Webhook = redactWebhook(l.Options.Webhook)
+	return &redacted
+}
+
+func redactBlockchainEventListener(l *pldapi.BlockchainEventListener) *pldapi.BlockchainEventListener {
+	if l == nil || l.Options.Webhook == nil {
+		return l
+	}
+	redacted := *l
+	redacted.Options.Webhook = redactWebhook(l.Options.Webhook)
+	return &redacted
+}
+
+func (tm *txManager) QueryReceiptListenerDeadLetters(ctx context.Context, dbTX persistence.DBTX, name string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error) {
+	return tm.queryListenerDeadLetters(ctx, dbTX, listenerTypeReceipt, name, jq)
+}
+
+func (tm *txManager) QueryBlockchainEventListenerDeadLetters(ctx context.Context, dbTX persistence.DBTX, name string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error) {
+	return tm.queryListenerDeadLetters(ctx, dbTX, listenerTypeBlockchainEvent, name, jq)
+}
+
+func (tm *txManager) queryListenerDeadLetters(ctx context.Context, dbTX persistence.DBTX, listenerType, name string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error) {
+	qw := &filters.QueryWrapper[persistedListenerDeadLetter, pldapi.ListenerDeadLetter]{
+		P:           tm.p,
+		Table:       "listener_dead_letters",
+		DefaultSort: "-created",
+		Filters:     listenerDeadLetterFilters,
+		Query:       jq,
+		Finalize: func(q *gorm.DB) *gorm.DB {
+			return q.Where("listener_type = ?", listenerType).Where("listener = ?", name)
+		},
+		MapResult: func(pdl *persistedListenerDeadLetter) (*pldapi.ListenerDeadLetter, error) {
+			return &pldapi.ListenerDeadLetter{
+				ID:           pdl.ID,
+				ListenerType: pdl.ListenerType,
+				Listener:     pdl.Listener,
+				Created:      pdl.Created,
+				BatchID:      pdl.BatchID,
+				Attempts:     pdl.Attempts,
+				Error:        pdl.Error,
+				Batch:        pdl.Batch,
+			}, nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
+
+func (tm *txManager) deleteListenerDeadLetters(ctx context.Context, listenerType, name string) error {
+	return tm.p.DB().
+		WithContext(ctx).
+		Where("listener_type = ?", listenerType).
+		Where("listener = ?", name).
+		Delete(&persistedListenerDeadLetter{}).
+		Error
+}
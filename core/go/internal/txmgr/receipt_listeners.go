@@ -18,6 +18,7 @@ package txmgr
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"sync"
 	"time"
 
@@ -97,6 +98,7 @@ type receiptListener struct {
 
 	spec       *pldapi.TransactionReceiptListener
 	checkpoint *uint64
+	webhook    *webhookDelivery
 
 	newReceipts chan bool
 
@@ -114,9 +116,10 @@ type registeredReceiptReceiver struct {
 }
 
 type receiptDeliveryBatch struct {
-	ID       uint64
-	Receipts []*pldapi.TransactionReceiptFull
-	Gaps     []*persistedReceiptGap
+	ID         uint64
+	Receipts   []*pldapi.TransactionReceiptFull
+	Gaps       []*persistedReceiptGap
+	DeadLetter *persistedListenerDeadLetter
 }
 
 func (tm *txManager) receiptsInit() {
@@ -179,6 +182,9 @@ func (tm *txManager) AddReceiptReceiver(ctx context.Context, name string, r comp
 	if l == nil {
 		return nil, i18n.NewError(ctx, msgs.MsgTxMgrReceiptListenerNotLoaded, name)
 	}
+	if l.webhook != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrWebhookListenerNoReceivers, name)
+	}
 
 	return l.addReceiver(r), nil
 }
@@ -256,6 +262,12 @@ func (tm *txManager) DeleteReceiptListener(ctx context.Context, name string) err
 		return err
 	}
 
+	if l.webhook != nil {
+		if err := tm.deleteListenerDeadLetters(ctx, listenerTypeReceipt, name); err != nil {
+			return err
+		}
+	}
+
 	delete(tm.receiptListeners, name)
 	return nil
 }
@@ -368,6 +380,11 @@ func (tm *txManager) validateReceiptListenerSpec(ctx context.Context, spec *plda
 		return err
 	}
 	spec.Options.IncompleteStateReceiptBehavior = icrb.Enum()
+	if spec.Options.Webhook != nil {
+		if _, err := tm.newWebhookDelivery(ctx, listenerTypeReceipt, spec.Name, spec.Options.Webhook); err != nil {
+			return err
+		}
+	}
 	_, err = tm.buildListenerDBQuery(ctx, spec, tm.p.DB())
 	return err
 }
@@ -469,6 +486,11 @@ func (tm *txManager) loadReceiptListener(ctx context.Context, pl *persistedRecei
 		newReceivers: make(chan bool, 1),
 		newReceipts:  make(chan bool, 1),
 	}
+	if spec.Options.Webhook != nil {
+		if l.webhook, err = tm.newWebhookDelivery(ctx, listenerTypeReceipt, spec.Name, spec.Options.Webhook); err != nil {
+			return nil, err
+		}
+	}
 
 	tm.receiptListenerLock.Lock()
 	defer tm.receiptListenerLock.Unlock()
@@ -672,6 +694,16 @@ func (l *receiptListener) nextReceiver(b *receiptDeliveryBatch) (r components.Re
 }
 
 func (l *receiptListener) deliverBatch(b *receiptDeliveryBatch) error {
+	if l.webhook != nil {
+		log.L(l.ctx).Infof("Delivering receipt batch %d (receipts=%d) to webhook", b.ID, len(b.Receipts))
+		deadLetter, err := l.webhook.deliver(l.ctx, strconv.FormatUint(b.ID, 10), &pldapi.TransactionReceiptBatch{
+			BatchID:  b.ID,
+			Receipts: b.Receipts,
+		})
+		b.DeadLetter = deadLetter
+		return err
+	}
+
 	r, err := l.nextReceiver(b)
 	if err != nil {
 		return err
@@ -711,6 +743,9 @@ func (l *receiptListener) updateCheckpoint(batch *receiptDeliveryBatch, newSeque
 				Create(batch.Gaps).
 				Error
 		}
+		if err == nil {
+			err = writeListenerDeadLetter(ctx, dbTX, batch.DeadLetter)
+		}
 		if err != nil {
 			return err
 		}
@@ -783,6 +818,18 @@ func (l *receiptListener) processStaleGaps() error {
 
 }
 
+// updateGap moves a stale gap on after a batch has been delivered from it, writing the
+// dead letter of the batch (if any) in the same DB transaction
+func (l *receiptListener) updateGap(batch *receiptDeliveryBatch, update func(dbTX persistence.DBTX) error) error {
+	return l.tm.p.Transaction(l.ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		err := update(dbTX)
+		if err == nil {
+			err = writeListenerDeadLetter(ctx, dbTX, batch.DeadLetter)
+		}
+		return err
+	})
+}
+
 func (l *receiptListener) processStaleGap(gap *persistedReceiptGap) error {
 
 	for {
@@ -802,32 +849,36 @@ func (l *receiptListener) processStaleGap(gap *persistedReceiptGap) error {
 		if len(batch.Gaps) > 0 {
 			log.L(l.ctx).Infof("Gap for contract %s remains old=%d/%s new=%d/%s", gap.Source, gap.Sequence, gap.Transaction, batch.Gaps[0].Sequence, batch.Gaps[0].Transaction)
 			return l.tm.receiptsRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
-				return true, l.tm.p.DB().
-					WithContext(l.ctx).
-					Clauses(clause.OnConflict{
-						Columns: []clause.Column{
-							{Name: "listener"},
-							{Name: "source"},
-						},
-						DoUpdates: clause.AssignmentColumns([]string{
-							"sequence", // might move forwards
-							"state",    // set to new state
-						}),
-					}).
-					Create(batch.Gaps).
-					Error
+				return true, l.updateGap(batch, func(dbTX persistence.DBTX) error {
+					return dbTX.DB().
+						WithContext(l.ctx).
+						Clauses(clause.OnConflict{
+							Columns: []clause.Column{
+								{Name: "listener"},
+								{Name: "source"},
+							},
+							DoUpdates: clause.AssignmentColumns([]string{
+								"sequence", // might move forwards
+								"state",    // set to new state
+							}),
+						}).
+						Create(batch.Gaps).
+						Error
+				})
 			})
 		}
 
 		if len(page) < l.tm.receiptsReadPageSize {
 			// We are done at this point - delete the gap
 			return l.tm.receiptsRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
-				return true, l.tm.p.DB().
-					WithContext(l.ctx).
-					Where("listener = ?", gap.Listener).
-					Where("source = ?", gap.Source).
-					Delete(&persistedReceiptGap{}).
-					Error
+				return true, l.updateGap(batch, func(dbTX persistence.DBTX) error {
+					return dbTX.DB().
+						WithContext(l.ctx).
+						Where("listener = ?", gap.Listener).
+						Where("source = ?", gap.Source).
+						Delete(&persistedReceiptGap{}).
+						Error
+				})
 			})
 		}
 
@@ -837,16 +888,18 @@ func (l *receiptListener) processStaleGap(gap *persistedReceiptGap) error {
 		gap.StateID = nil
 		gap.State = nil
 		if err := l.tm.receiptsRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
-			return true, l.tm.p.DB().
-				WithContext(l.ctx).
-				Model(&persistedReceiptGap{}).
-				Where("listener = ?", gap.Listener).
-				Where("source = ?", gap.Source).
-				Updates(map[string]any{
-					"sequence": gap.Sequence,
-					"state":    nil, // clear the state ref, so this will be stale immediately
-				}).
-				Error
+			return true, l.updateGap(batch, func(dbTX persistence.DBTX) error {
+				return dbTX.DB().
+					WithContext(l.ctx).
+					Model(&persistedReceiptGap{}).
+					Where("listener = ?", gap.Listener).
+					Where("source = ?", gap.Source).
+					Updates(map[string]any{
+						"sequence": gap.Sequence,
+						"state":    nil, // clear the state ref, so this will be stale immediately
+					}).
+					Error
+			})
 		}); err != nil {
 			return err
 		}
@@ -1113,6 +1113,7 @@ func TestProcessStaleGapFailRetryingUpdateGapForPage(t *testing.T) {
 		mockPublicReceipts(1),
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.db.ExpectExec("INSERT.*receipt_listeners").WillReturnResult(driver.ResultNoRows)
+			mc.db.ExpectBegin()
 			mc.db.ExpectExec("UPDATE.*receipt_listener_gap").WillReturnError(fmt.Errorf("pop"))
 		},
 	)
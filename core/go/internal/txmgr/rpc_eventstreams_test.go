@@ -388,10 +388,10 @@ func TestRPCEventListenerE2E(t *testing.T) {
 		},
 	}
 
-	err = el.handleEventBatch(ctx, batch1)
+	_, err = el.handleEventBatch(ctx, batch1)
 	require.ErrorContains(t, err, "PD012243")
 
-	err = el.handleEventBatch(ctx, batch1)
+	_, err = el.handleEventBatch(ctx, batch1)
 	require.NoError(t, err)
 
 	batch2 := &blockindexer.EventDeliveryBatch{
@@ -404,7 +404,7 @@ func TestRPCEventListenerE2E(t *testing.T) {
 		},
 	}
 
-	err = el.handleEventBatch(ctx, batch2)
+	_, err = el.handleEventBatch(ctx, batch2)
 	require.ErrorContains(t, err, "PD012242")
 
 }
@@ -20,11 +20,15 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 )
 
 func (tm *txManager) buildRPCModule() {
@@ -33,7 +37,8 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_sendTransactions", tm.rpcSendTransactions()).
 		Add("ptx_prepareTransaction", tm.rpcPrepareTransaction()).
 		Add("ptx_prepareTransactions", tm.rpcPrepareTransactions()).
-		Add("ptx_updateTransaction", tm.rpcUpdateTransaction()).
+		AddWithSigningKeys("ptx_updateTransaction", tm.rpcUpdateTransaction(), tm.rpcTransactionSigningKeys()).
+		AddWithSigningKeys("ptx_cancelTransaction", tm.rpcCancelTransaction(), tm.rpcTransactionSigningKeys()).
 		Add("ptx_call", tm.rpcCall()).
 		Add("ptx_getTransaction", tm.rpcGetTransaction()).
 		Add("ptx_getTransactionFull", tm.rpcGetTransactionFull()).
@@ -51,6 +56,9 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_queryPendingPublicTransactions", tm.rpcQueryPendingPublicTransactions()).
 		Add("ptx_getPublicTransactionByNonce", tm.rpcGetPublicTransactionByNonce()).
 		Add("ptx_getPublicTransactionByHash", tm.rpcGetPublicTransactionByHash()).
+		Add("ptx_queryStuckPublicTransactions", tm.rpcQueryStuckPublicTransactions()).
+		AddWithSigningKeys("ptx_getNonceGaps", tm.rpcGetNonceGaps(), tm.rpcSigningAddressKeys()).
+		AddWithSigningKeys("ptx_fillNonceGap", tm.rpcFillNonceGap(), tm.rpcSigningAddressKeys()).
 		Add("ptx_getPreparedTransaction", tm.rpcGetPreparedTransaction()).
 		Add("ptx_queryPreparedTransactions", tm.rpcQueryPreparedTransactions()).
 		Add("ptx_storeABI", tm.rpcStoreABI()).
@@ -66,18 +74,47 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_startReceiptListener", tm.rpcStartReceiptListener()).
 		Add("ptx_stopReceiptListener", tm.rpcStopReceiptListener()).
 		Add("ptx_deleteReceiptListener", tm.rpcDeleteReceiptListener()).
+		Add("ptx_queryReceiptListenerDeadLetters", tm.rpcQueryReceiptListenerDeadLetters()).
 		Add("ptx_createBlockchainEventListener", tm.rpcCreateBlockchainEventListener()).
 		Add("ptx_queryBlockchainEventListeners", tm.rpcQueryBlockchainEventListeners()).
 		Add("ptx_getBlockchainEventListener", tm.rpcGetBlockchainEventListener()).
 		Add("ptx_startBlockchainEventListener", tm.rpcStartBlockchainEventListener()).
 		Add("ptx_stopBlockchainEventListener", tm.rpcStopBlockchainEventListener()).
 		Add("ptx_deleteBlockchainEventListener", tm.rpcDeleteBlockchainEventListener()).
+		Add("ptx_queryBlockchainEventListenerDeadLetters", tm.rpcQueryBlockchainEventListenerDeadLetters()).
 		AddAsync(tm.rpcEventStreams)
 
 	tm.debugRpcModule = rpcserver.NewRPCModule("debug").
 		Add("debug_getTransactionStatus", tm.rpcDebugTransactionStatus())
 }
 
+// rpcTransactionSigningKeys resolves the signing key of an existing transaction, for methods that act on the
+// transaction with the ID in their first parameter
+func (tm *txManager) rpcTransactionSigningKeys() rpcserver.RPCSigningKeys {
+	return rpcserver.SigningKeysParam(0, func(ctx context.Context, id uuid.UUID) ([]string, error) {
+		tx, err := tm.GetTransactionByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if tx == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrTransactionNotFound, id)
+		}
+		return []string{tx.From}, nil
+	})
+}
+
+// rpcSigningAddressKeys resolves the key identifier of a signing address, for methods that take the
+// address rather than the key
+func (tm *txManager) rpcSigningAddressKeys() rpcserver.RPCSigningKeys {
+	return rpcserver.SigningKeysParam(0, func(ctx context.Context, from pldtypes.EthAddress) ([]string, error) {
+		mapping, err := tm.keyManager.ReverseKeyLookup(ctx, tm.p.NOTX(), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, from.String())
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgNonceGapSignerNotManaged, from)
+		}
+		return []string{mapping.Identifier}, nil
+	})
+}
+
 func (tm *txManager) rpcSendTransaction() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		tx pldapi.TransactionInput,
@@ -119,6 +156,14 @@ func (tm *txManager) rpcUpdateTransaction() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcCancelTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		id uuid.UUID,
+	) (uuid.UUID, error) {
+		return tm.CancelTransaction(ctx, id)
+	})
+}
+
 func (tm *txManager) rpcCall() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		tx *pldapi.TransactionCall,
@@ -278,6 +323,31 @@ func (tm *txManager) rpcGetPublicTransactionByHash() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcQueryStuckPublicTransactions() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		query query.QueryJSON,
+	) ([]*pldapi.PublicTxWithBinding, error) {
+		return tm.queryStuckPublicTransactions(ctx, &query)
+	})
+}
+
+func (tm *txManager) rpcGetNonceGaps() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		from pldtypes.EthAddress,
+	) ([]*pldapi.PublicTxNonceGap, error) {
+		return tm.publicTxMgr.GetNonceGaps(ctx, from)
+	})
+}
+
+func (tm *txManager) rpcFillNonceGap() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+	) (*pldapi.PublicTx, error) {
+		return tm.publicTxMgr.FillNonceGap(ctx, from, nonce.Uint64())
+	})
+}
+
 func (tm *txManager) rpcStoreABI() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		a abi.ABI,
@@ -362,7 +432,20 @@ func (tm *txManager) rpcQueryReceiptListeners() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		query query.QueryJSON,
 	) ([]*pldapi.TransactionReceiptListener, error) {
-		return tm.QueryReceiptListeners(ctx, tm.p.NOTX(), &query)
+		listeners, err := tm.QueryReceiptListeners(ctx, tm.p.NOTX(), &query)
+		for i, l := range listeners {
+			listeners[i] = redactReceiptListener(l)
+		}
+		return listeners, err
+	})
+}
+
+func (tm *txManager) rpcQueryReceiptListenerDeadLetters() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		query query.QueryJSON,
+	) ([]*pldapi.ListenerDeadLetter, error) {
+		return tm.QueryReceiptListenerDeadLetters(ctx, tm.p.NOTX(), name, &query)
 	})
 }
 
@@ -370,7 +453,7 @@ func (tm *txManager) rpcGetReceiptListener() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		name string,
 	) (*pldapi.TransactionReceiptListener, error) {
-		return tm.GetReceiptListener(ctx, name), nil
+		return redactReceiptListener(tm.GetReceiptListener(ctx, name)), nil
 	})
 }
 
@@ -411,7 +494,20 @@ func (tm *txManager) rpcQueryBlockchainEventListeners() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		query query.QueryJSON,
 	) ([]*pldapi.BlockchainEventListener, error) {
-		return tm.QueryBlockchainEventListeners(ctx, tm.p.NOTX(), &query)
+		listeners, err := tm.QueryBlockchainEventListeners(ctx, tm.p.NOTX(), &query)
+		for i, l := range listeners {
+			listeners[i] = redactBlockchainEventListener(l)
+		}
+		return listeners, err
+	})
+}
+
+func (tm *txManager) rpcQueryBlockchainEventListenerDeadLetters() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		query query.QueryJSON,
+	) ([]*pldapi.ListenerDeadLetter, error) {
+		return tm.QueryBlockchainEventListenerDeadLetters(ctx, tm.p.NOTX(), name, &query)
 	})
 }
 
@@ -419,7 +515,7 @@ func (tm *txManager) rpcGetBlockchainEventListener() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		name string,
 	) (*pldapi.BlockchainEventListener, error) {
-		return tm.GetBlockchainEventListener(ctx, name), nil
+		return redactBlockchainEventListener(tm.GetBlockchainEventListener(ctx, name)), nil
 	})
 }
 
@@ -498,6 +498,175 @@ func TestPublicTransactionPassthroughQueries(t *testing.T) {
 	assert.Equal(t, sampleTxns[0], txn)
 }
 
+func TestRPCTransactionSigningKeys(t *testing.T) {
+
+	ctx, url, tmr, done := newTestTransactionManagerWithRPC(t, mockSubmitPublicTxOk(t, pldtypes.RandAddress()))
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var txID uuid.UUID
+	err = rpcClient.CallRPC(ctx, &txID, "ptx_sendTransaction", &pldapi.TransactionInput{
+		ABI:      abi.ABI{{Type: abi.Constructor}},
+		Bytecode: pldtypes.MustParseHexBytes("0x11223344"),
+		TransactionBase: pldapi.TransactionBase{
+			From: "sender1",
+			Type: pldapi.TransactionTypePublic.Enum(),
+		},
+	})
+	require.NoError(t, err)
+
+	signingKeys := tmr.rpcTransactionSigningKeys()
+	keys, err := signingKeys(ctx, &rpcclient.RPCRequest{Params: []pldtypes.RawJSON{pldtypes.JSONString(txID)}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sender1"}, keys)
+
+	_, err = signingKeys(ctx, &rpcclient.RPCRequest{Params: []pldtypes.RawJSON{pldtypes.JSONString(uuid.New())}})
+	assert.Regexp(t, "PD012244", err)
+}
+
+func TestRPCSigningAddressKeys(t *testing.T) {
+
+	managedAddr := pldtypes.RandAddress()
+	unmanagedAddr := pldtypes.RandAddress()
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.keyManager.On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, managedAddr.String()).
+			Return(&pldapi.KeyMappingAndVerifier{
+				KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{Identifier: "sender1"}},
+			}, nil)
+		mc.keyManager.On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, unmanagedAddr.String()).
+			Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+
+	signingKeys := txm.rpcSigningAddressKeys()
+	keys, err := signingKeys(ctx, &rpcclient.RPCRequest{Params: []pldtypes.RawJSON{pldtypes.JSONString(managedAddr), pldtypes.JSONString("0x1")}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sender1"}, keys)
+
+	_, err = signingKeys(ctx, &rpcclient.RPCRequest{Params: []pldtypes.RawJSON{pldtypes.JSONString(unmanagedAddr)}})
+	assert.Regexp(t, "PD011944.*pop", err)
+}
+
+func TestCancelTransactionRPCAuthorizesSender(t *testing.T) {
+
+	senderAddr := pldtypes.RandAddress()
+	var publicTxns map[uuid.UUID][]*pldapi.PublicTx
+	ctx, txm, txmDone := newTestTransactionManager(t, true,
+		mockSubmitPublicTxOk(t, senderAddr),
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return publicTxns, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.publicTxMgr.On("CancelTransaction", mock.Anything, mock.Anything, uint64(12345), senderAddr).Return(nil).Once()
+		})
+	defer txmDone()
+
+	rpcServer, err := rpcserver.NewRPCServer(ctx, &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{
+				Port:            confutil.P(0),
+				ShutdownTimeout: confutil.P("0"),
+			},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+		Auth: pldconf.RPCAuthConfig{
+			Enabled: confutil.P(true),
+			StaticTokens: []pldconf.RPCAuthStaticToken{
+				{Principal: "app1", Token: "token1"},
+				{Principal: "app2", Token: "token2"},
+			},
+			Policies: []pldconf.RPCAuthorizationPolicy{
+				{Principals: []string{"app1"}, Methods: []string{"ptx_*"}, Keys: []string{"app1.*"}},
+				{Principals: []string{"app2"}, Methods: []string{"ptx_*"}, Keys: []string{"sender*"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	rpcServer.Register(txm.rpcModule)
+	require.NoError(t, rpcServer.Start())
+	defer rpcServer.Stop()
+
+	txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			From: "sender1",
+			Type: pldapi.TransactionTypePublic.Enum(),
+			To:   pldtypes.RandAddress(),
+			Data: pldtypes.RawJSON(`[]`),
+		},
+		ABI: abi.ABI{{Type: abi.Function, Name: "doIt"}},
+	})
+	require.NoError(t, err)
+	publicTxns = map[uuid.UUID][]*pldapi.PublicTx{
+		*txID: {{LocalID: confutil.P(uint64(12345)), From: *senderAddr}},
+	}
+
+	rpcClient := func(token string) rpcclient.Client {
+		c, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{
+			URL:         fmt.Sprintf("http://%s", rpcServer.HTTPAddr()),
+			HTTPHeaders: map[string]interface{}{"Authorization": "Bearer " + token},
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	// Another principal cannot cancel a transaction from a key it is not authorized to use
+	var cancelledID uuid.UUID
+	err = rpcClient("token1").CallRPC(ctx, &cancelledID, "ptx_cancelTransaction", txID)
+	assert.Regexp(t, "PD020709.*sender1", err)
+
+	err = rpcClient("token2").CallRPC(ctx, &cancelledID, "ptx_cancelTransaction", txID)
+	require.NoError(t, err)
+	assert.Equal(t, *txID, cancelledID)
+}
+
+func TestStuckPublicTransactionsAndNonceGaps(t *testing.T) {
+
+	from := pldtypes.EthAddress(pldtypes.RandBytes(20))
+	stuckTx := &pldapi.PublicTxWithBinding{
+		PublicTx: &pldapi.PublicTx{
+			From:  from,
+			Nonce: confutil.P(pldtypes.HexUint64(10)),
+			Stuck: true,
+		},
+		PublicTxBinding: pldapi.PublicTxBinding{Transaction: uuid.New(), TransactionType: pldapi.TransactionTypePublic.Enum()},
+	}
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.publicTxMgr.On("QueryStuckPublicTransactions", mock.Anything, mock.Anything, mock.Anything).
+			Return([]*pldapi.PublicTxWithBinding{stuckTx}, nil)
+		mc.publicTxMgr.On("GetNonceGaps", mock.Anything, from).
+			Return([]*pldapi.PublicTxNonceGap{{From: from, FirstNonce: 8, LastNonce: 9}}, nil)
+		mc.publicTxMgr.On("FillNonceGap", mock.Anything, from, uint64(8)).
+			Return(&pldapi.PublicTx{From: from, Nonce: confutil.P(pldtypes.HexUint64(8))}, nil)
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var txns []*pldapi.PublicTxWithBinding
+	err = rpcClient.CallRPC(ctx, &txns, "ptx_queryStuckPublicTransactions", query.NewQueryBuilder().Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	assert.True(t, txns[0].Stuck)
+
+	err = rpcClient.CallRPC(ctx, &txns, "ptx_queryStuckPublicTransactions", query.NewQueryBuilder().Query())
+	require.Regexp(t, "PD010721", err)
+
+	var gaps []*pldapi.PublicTxNonceGap
+	err = rpcClient.CallRPC(ctx, &gaps, "ptx_getNonceGaps", from)
+	require.NoError(t, err)
+	require.Len(t, gaps, 1)
+	assert.Equal(t, uint64(8), gaps[0].FirstNonce.Uint64())
+	assert.Equal(t, uint64(9), gaps[0].LastNonce.Uint64())
+
+	var filled *pldapi.PublicTx
+	err = rpcClient.CallRPC(ctx, &filled, "ptx_fillNonceGap", from, pldtypes.HexUint64(8))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), filled.Nonce.Uint64())
+}
+
 func TestDetailedReceiptRPCsNotFound(t *testing.T) {
 
 	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
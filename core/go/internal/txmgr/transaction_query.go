@@ -339,6 +339,13 @@ func (tm *txManager) queryPublicTransactions(ctx context.Context, jq *query.Quer
 	return tm.publicTxMgr.QueryPublicTxWithBindings(ctx, tm.p.NOTX(), jq)
 }
 
+func (tm *txManager) queryStuckPublicTransactions(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error) {
+	if err := filters.CheckLimitSet(ctx, jq); err != nil {
+		return nil, err
+	}
+	return tm.publicTxMgr.QueryStuckPublicTransactions(ctx, tm.p.NOTX(), jq)
+}
+
 func (tm *txManager) GetPublicTransactionByNonce(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (*pldapi.PublicTxWithBinding, error) {
 	prs, err := tm.publicTxMgr.QueryPublicTxWithBindings(ctx, tm.p.NOTX(),
 		query.NewQueryBuilder().Limit(1).
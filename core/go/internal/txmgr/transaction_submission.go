@@ -34,6 +34,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"gorm.io/gorm/clause"
 )
@@ -485,7 +486,10 @@ func (tm *txManager) processNewTransactions(ctx context.Context, dbTX persistenc
 	// same pattern as public transactions above
 	for _, txi := range txis {
 		if txi.Transaction.Type.V() == pldapi.TransactionTypePrivate {
-			if err := tm.privateTxMgr.HandleNewTx(ctx, dbTX, txi); err != nil {
+			txCtx, span := tracing.StartTransactionSpan(ctx, "txmgr submit private transaction", *txi.Transaction.ID)
+			err := tm.privateTxMgr.HandleNewTx(txCtx, dbTX, txi)
+			tracing.EndSpan(span, err)
+			if err != nil {
 				return nil, err
 			}
 		}
@@ -540,8 +544,10 @@ func (tm *txManager) resolvePrivateDomain(ctx context.Context, dbTX persistence.
 	return nil
 }
 
-func (tm *txManager) resolveNewTransaction(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput, submitMode pldapi.SubmitMode) (*components.ValidatedTransaction, error) {
+func (tm *txManager) resolveNewTransaction(ctx context.Context, dbTX persistence.DBTX, tx *pldapi.TransactionInput, submitMode pldapi.SubmitMode) (_ *components.ValidatedTransaction, err error) {
 	txID := uuid.New()
+	ctx, span := tracing.StartTransactionSpan(ctx, "txmgr resolve transaction", txID)
+	defer func() { tracing.EndSpan(span, err) }()
 	// Useful to have a correlation from transactionID to idempotencyKey in the logs
 	log.L(ctx).Debugf("Resolving new transaction TransactionID: %s, idempotencyKey: %s ", txID, tx.IdempotencyKey)
 
@@ -769,17 +775,7 @@ func (tm *txManager) UpdateTransaction(ctx context.Context, id uuid.UUID, tx *pl
 			publicTxData = validatedTransaction.PublicTxData
 		}
 
-		from, err = pldtypes.ParseEthAddress(oldTX.From)
-		if err != nil {
-			identifier := strings.Split(oldTX.From, "@")[0]
-			kr := tm.keyManager.KeyResolverForDBTX(dbTX)
-			var resolvedKey *pldapi.KeyMappingAndVerifier
-			resolvedKey, err = kr.ResolveKey(ctx, identifier, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
-			if err == nil {
-				// this failure should be impossible if key manager is working correctly
-				from, err = pldtypes.ParseEthAddress(resolvedKey.Verifier.Verifier)
-			}
-		}
+		from, err = tm.resolvePublicTxFrom(ctx, dbTX, oldTX.From)
 		return err
 	})
 
@@ -794,6 +790,60 @@ func (tm *txManager) UpdateTransaction(ctx context.Context, id uuid.UUID, tx *pl
 	return id, err
 }
 
+func (tm *txManager) resolvePublicTxFrom(ctx context.Context, dbTX persistence.DBTX, fromIdentifier string) (*pldtypes.EthAddress, error) {
+	from, err := pldtypes.ParseEthAddress(fromIdentifier)
+	if err != nil {
+		identifier := strings.Split(fromIdentifier, "@")[0]
+		kr := tm.keyManager.KeyResolverForDBTX(dbTX)
+		var resolvedKey *pldapi.KeyMappingAndVerifier
+		resolvedKey, err = kr.ResolveKey(ctx, identifier, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+		if err == nil {
+			// this failure should be impossible if key manager is working correctly
+			from, err = pldtypes.ParseEthAddress(resolvedKey.Verifier.Verifier)
+		}
+	}
+	return from, err
+}
+
+// CancelTransaction abandons a transaction that does not yet have a receipt, writing a failure receipt.
+//
+// A public transaction is replaced on-chain with a zero-value transaction on the same nonce. The failure
+// receipt is written when that replacement is mined. If the original is mined first, it gets its
+// normal receipt instead.
+//
+// A private transaction is cancelled in the private transaction manager, which releases any states it
+// has locked and notifies the coordinator if it was delegated. The failure receipt is written asynchronously.
+// Private transactions cannot be cancelled once they have been dispatched to the base ledger.
+func (tm *txManager) CancelTransaction(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	tx, err := tm.GetTransactionByIDFull(ctx, id)
+	if err != nil {
+		return id, err
+	}
+	if tx == nil {
+		return id, i18n.NewError(ctx, msgs.MsgTxMgrTransactionNotFound, id)
+	}
+	if tx.Receipt != nil {
+		return id, i18n.NewError(ctx, msgs.MsgTxMgrCancelAlreadyComplete, id)
+	}
+
+	if tx.Type.V() == pldapi.TransactionTypePrivate {
+		if tx.To == nil {
+			return id, i18n.NewError(ctx, msgs.MsgTxMgrCancelPrivateDeploy)
+		}
+		return id, tm.privateTxMgr.CancelTransaction(ctx, tx.Domain, *tx.To, id)
+	}
+
+	if len(tx.Public) == 0 {
+		return id, i18n.NewError(ctx, msgs.MsgPublicTransactionNotFound, id)
+	}
+	from, err := tm.resolvePublicTxFrom(ctx, tm.p.NOTX(), tx.From)
+	if err != nil {
+		return id, err
+	}
+
+	return id, tm.publicTxMgr.CancelTransaction(ctx, id, *tx.Public[0].LocalID, from)
+}
+
 func (tm *txManager) processUpdatedTransaction(ctx context.Context, dbTX persistence.DBTX, id *uuid.UUID, validatedTransaction *components.ValidatedTransaction) error {
 	// only update the fields which might have changed with this request
 	err := dbTX.DB().
@@ -29,6 +29,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 
@@ -1495,3 +1496,137 @@ func TestResolveUpdatedTransactionSuccess(t *testing.T) {
 	assert.Equal(t, `{"value":"46"}`, validatedTransaction.Transaction.Data.String())
 	assert.Equal(t, "60fe47b1000000000000000000000000000000000000000000000000000000000000002e", hex.EncodeToString(validatedTransaction.PublicTxData))
 }
+
+func TestCancelTransactionNotFound(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true)
+	defer done()
+
+	_, err := txm.CancelTransaction(ctx, uuid.New())
+	assert.Regexp(t, "PD012244", err)
+}
+
+func TestCancelTransactionPrivate(t *testing.T) {
+	var txID uuid.UUID
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockDomainContractResolve(t, "domain1"),
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return nil, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.privateTxMgr.On("CancelTransaction", mock.Anything, "domain1", mock.Anything, mock.MatchedBy(func(id uuid.UUID) bool {
+				return id == txID
+			})).Return(nil)
+		})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		tx, err := txm.PrepareInternalPrivateTransaction(ctx, dbTX, newTestInternalTransaction("tx1"), pldapi.SubmitModeAuto)
+		require.NoError(t, err)
+		txID = *tx.Transaction.ID
+		return txm.UpsertInternalPrivateTxsFinalizeIDs(ctx, dbTX, []*components.ValidatedTransaction{tx})
+	})
+	require.NoError(t, err)
+
+	cancelledID, err := txm.CancelTransaction(ctx, txID)
+	require.NoError(t, err)
+	assert.Equal(t, txID, cancelledID)
+}
+
+func TestCancelTransactionPrivateDeploy(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return nil, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.privateTxMgr.On("HandleNewTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		})
+	defer done()
+
+	exampleABI := abi.ABI{{Type: abi.Constructor}}
+	txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			From:   "me",
+			Type:   pldapi.TransactionTypePrivate.Enum(),
+			Domain: "domain1",
+			Data:   pldtypes.RawJSON(`[]`),
+		},
+		ABI: exampleABI,
+	})
+	require.NoError(t, err)
+
+	_, err = txm.CancelTransaction(ctx, *txID)
+	assert.Regexp(t, "PD012255", err)
+}
+
+func TestCancelTransactionPublic(t *testing.T) {
+	senderAddr := pldtypes.RandAddress()
+	var publicTxns map[uuid.UUID][]*pldapi.PublicTx
+	var txID *uuid.UUID
+	txi := newTestConfirm()
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockSubmitPublicTxOk(t, senderAddr),
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return publicTxns, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.publicTxMgr.On("CancelTransaction", mock.Anything, mock.Anything, uint64(12345), senderAddr).Return(nil)
+
+			mut := mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, []*blockindexer.IndexedTransactionNotify{txi})
+			mut.Run(func(args mock.Arguments) {
+				mut.Return([]*components.PublicTxMatch{
+					{
+						PaladinTXReference: components.PaladinTXReference{
+							TransactionID:   *txID,
+							TransactionType: pldapi.TransactionTypePublic.Enum(),
+						},
+						IndexedTransactionNotify: txi,
+						Cancellation:             true,
+					},
+				}, nil)
+			})
+			mc.publicTxMgr.On("NotifyConfirmPersisted", mock.Anything, mock.Anything)
+		})
+	defer done()
+
+	txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			From: "sender1",
+			Type: pldapi.TransactionTypePublic.Enum(),
+			To:   pldtypes.RandAddress(),
+			Data: pldtypes.RawJSON(`[]`),
+		},
+		ABI: abi.ABI{{Type: abi.Function, Name: "doIt"}},
+	})
+	require.NoError(t, err)
+
+	// No public transaction yet
+	_, err = txm.CancelTransaction(ctx, *txID)
+	assert.Regexp(t, "PD011911", err)
+
+	publicTxns = map[uuid.UUID][]*pldapi.PublicTx{
+		*txID: {{LocalID: confutil.P(uint64(12345)), From: *senderAddr}},
+	}
+	_, err = txm.CancelTransaction(ctx, *txID)
+	require.NoError(t, err)
+
+	// There is no receipt until the replacement is mined
+	receipt, err := txm.GetTransactionReceiptByID(ctx, *txID)
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{}, []*blockindexer.IndexedTransactionNotify{txi})
+	})
+	require.NoError(t, err)
+
+	receipt, err = txm.GetTransactionReceiptByID(ctx, *txID)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.False(t, receipt.Success)
+	assert.Regexp(t, "PD012253", receipt.FailureMessage)
+	assert.Equal(t, txi.Hash, *receipt.TransactionHash)
+
+	// Cannot cancel again
+	_, err = txm.CancelTransaction(ctx, *txID)
+	assert.Regexp(t, "PD012254", err)
+}
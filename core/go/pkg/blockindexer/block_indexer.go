@@ -36,6 +36,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -44,6 +45,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/inflight"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type BlockIndexer interface {
@@ -68,7 +70,9 @@ type BlockIndexer interface {
 	WaitForTransactionAnyResult(ctx context.Context, hash pldtypes.Bytes32) (*pldapi.IndexedTransaction, error)
 	GetBlockListenerHeight(ctx context.Context) (highest uint64, err error)
 	GetConfirmedBlockHeight(ctx context.Context) (confirmed pldtypes.HexUint64, err error)
+	GetBlockchainStatus(ctx context.Context) *pldapi.BlockchainStatus
 	RPCModule() *rpcserver.RPCModule
+	MetricsCollector() prometheus.Collector
 }
 
 // Processes blocks from a configure baseline block (0 for example), up until it
@@ -85,7 +89,7 @@ type blockIndexer struct {
 	cancelFunc                 func()
 	persistence                persistence.Persistence
 	blockListener              *blockListener
-	wsConn                     rpcclient.WSClient
+	rpcConn                    rpcclient.Client
 	stateLock                  sync.Mutex
 	fromBlock                  *ethtypes.HexUint64
 	nextBlock                  *ethtypes.HexUint64 // nil in the special case of "latest" and no block received yet
@@ -103,11 +107,14 @@ type blockIndexer struct {
 	eventStreamsLock           sync.Mutex
 	esBlockDispatchQueueLength int
 	esCatchUpQueryPageSize     int
+	receiptFetchStrategy       string
+	receiptFetchSlots          chan struct{}
 	started                    bool
 	dispatcherTap              chan struct{}
 	processorDone              chan struct{}
 	dispatcherDone             chan struct{}
 	rpcModule                  *rpcserver.RPCModule
+	blockchainStatus           func() *pldapi.BlockchainStatus // nil if not using an ethclient factory
 }
 
 func NewBlockIndexer(ctx context.Context, config *pldconf.BlockIndexerConfig, wsConfig *pldconf.WSClientConfig, persistence persistence.Persistence) (_ BlockIndexer, err error) {
@@ -120,11 +127,49 @@ func NewBlockIndexer(ctx context.Context, config *pldconf.BlockIndexerConfig, ws
 	return newBlockIndexer(ctx, config, persistence, blockListener)
 }
 
+// NewBlockIndexerHTTP makes all calls over HTTP, polling for new blocks. If a WebSocket is configured
+// it is only used to subscribe to newHeads, and the listener does not wait for it to connect.
+func NewBlockIndexerHTTP(ctx context.Context, config *pldconf.BlockIndexerConfig, httpConfig *pldconf.HTTPClientConfig, wsConfig *pldconf.WSClientConfig, persistence persistence.Persistence) (_ BlockIndexer, err error) {
+
+	blockListener, err := newHTTPBlockListener(ctx, config, httpConfig, wsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlockIndexer(ctx, config, persistence, blockListener)
+}
+
+// NewBlockIndexerForEthClientFactory listens for blocks over connections from the factory, which move
+// to another blockchain endpoint if the active one becomes unhealthy. The configured transport decides
+// if calls are made over a WebSocket, or over HTTP with a WebSocket only used for newHeads.
+func NewBlockIndexerForEthClientFactory(ctx context.Context, config *pldconf.BlockIndexerConfig, ecf ethclient.EthClientFactoryBase, persistence persistence.Persistence) (_ BlockIndexer, err error) {
+	var bl *blockListener
+	switch transport := confutil.StringNotEmpty(&config.BlockListener.Transport, pldconf.BlockIndexerDefaults.BlockListener.Transport); transport {
+	case pldconf.BlockListenerTransportWebSocket:
+		wsConn := ecf.NewWSRPC()
+		bl = newBlockListenerForClient(ctx, config, wsConn, wsConn)
+	case pldconf.BlockListenerTransportHTTP:
+		var wsConn rpcclient.WSClient
+		if confutil.Bool(config.BlockListener.NewHeads, *pldconf.BlockIndexerDefaults.BlockListener.NewHeads) {
+			wsConn = ecf.NewWSRPC()
+		}
+		bl = newBlockListenerForClient(ctx, config, ecf.HTTPRPC(), wsConn)
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerInvalidTransport, transport)
+	}
+	bi, err := newBlockIndexer(ctx, config, persistence, bl)
+	if err != nil {
+		return nil, err
+	}
+	bi.blockchainStatus = ecf.BlockchainStatus
+	return bi, nil
+}
+
 func newBlockIndexer(ctx context.Context, conf *pldconf.BlockIndexerConfig, persistence persistence.Persistence, blockListener *blockListener) (bi *blockIndexer, err error) {
 	bi = &blockIndexer{
 		parentCtxForReset:          ctx, // stored for startOrResetProcessing
 		persistence:                persistence,
-		wsConn:                     blockListener.wsConn,
+		rpcConn:                    blockListener.rpcConn,
 		blockListener:              blockListener,
 		requiredConfirmations:      confutil.IntMin(conf.RequiredConfirmations, 0, *pldconf.BlockIndexerDefaults.RequiredConfirmations),
 		retry:                      blockListener.retry,
@@ -136,8 +181,15 @@ func newBlockIndexer(ctx context.Context, conf *pldconf.BlockIndexerConfig, pers
 		esBlockDispatchQueueLength: confutil.IntMin(conf.EventStreams.BlockDispatchQueueLength, 0, *pldconf.EventStreamDefaults.BlockDispatchQueueLength),
 		esCatchUpQueryPageSize:     confutil.IntMin(conf.EventStreams.CatchUpQueryPageSize, 0, *pldconf.EventStreamDefaults.CatchUpQueryPageSize),
 		dispatcherTap:              make(chan struct{}, 1),
+		receiptFetchStrategy:       confutil.StringNotEmpty(&conf.ReceiptFetch.Strategy, pldconf.BlockIndexerDefaults.ReceiptFetch.Strategy),
+		receiptFetchSlots:          make(chan struct{}, confutil.IntMin(conf.ReceiptFetch.Concurrency, 1, *pldconf.BlockIndexerDefaults.ReceiptFetch.Concurrency)),
 	}
 	bi.highestConfirmedBlock.Store(-1)
+	switch bi.receiptFetchStrategy {
+	case pldconf.ReceiptFetchStrategyBlockReceipts, pldconf.ReceiptFetchStrategyPerTransaction, pldconf.ReceiptFetchStrategyLogsOnly:
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerInvalidReceiptFetch, bi.receiptFetchStrategy)
+	}
 	if err := bi.setFromBlock(ctx, conf); err != nil {
 		return nil, err
 	}
@@ -251,6 +303,13 @@ func (bi *blockIndexer) GetConfirmedBlockHeight(ctx context.Context) (highest pl
 	return pldtypes.HexUint64(highestConfirmedBlock), nil
 }
 
+func (bi *blockIndexer) GetBlockchainStatus(ctx context.Context) *pldapi.BlockchainStatus {
+	if bi.blockchainStatus == nil {
+		return nil
+	}
+	return bi.blockchainStatus()
+}
+
 func (bi *blockIndexer) GetBlockListenerHeight(ctx context.Context) (confirmed uint64, err error) {
 	return bi.blockListener.getHighestBlock(ctx)
 }
@@ -512,16 +571,15 @@ func (bi *blockIndexer) dispatcher(ctx context.Context) {
 func (bi *blockIndexer) hydrateBlock(ctx context.Context, batch *blockWriterBatch, blockIndex int) {
 	defer batch.wg.Done()
 	err := bi.retry.Do(ctx, func(attempt int) (bool, error) {
-		// We use eth_getBlockReceipts, which takes either a number or a hash (supported by Besu and go-ethereum)
-		rpcErr := bi.wsConn.CallRPC(ctx, &batch.receipts[blockIndex], "eth_getBlockReceipts", batch.blocks[blockIndex].Hash)
-		if rpcErr != nil || batch.receipts[blockIndex] == nil {
+		receipts, rpcErr := bi.fetchReceipts(ctx, batch.blocks[blockIndex])
+		if rpcErr != nil || receipts == nil {
 			var err error = rpcErr
 			retry := true
 			log.L(ctx).Errorf("Failed to query block %s: %v", batch.summaries[blockIndex], rpcErr)
 			if err == nil {
 				// TODO: We've seen this with Besu instead of an error, and need to diagnose
 				// Convert to a not found, but DO retry here.
-				log.L(ctx).Warnf("Blockchain node returned null from eth_getBlockReceipts")
+				log.L(ctx).Warnf("Blockchain node returned null receipts (strategy=%s)", bi.receiptFetchStrategy)
 				err = i18n.NewError(ctx, msgs.MsgBlockIndexerConfirmedBlockNotFound, batch.blocks[blockIndex].Hash, batch.blocks[blockIndex].Number)
 			} else if isNotFound(err) {
 				// If we get a not-found, that's an indication the confirmations are not set correctly,
@@ -533,6 +591,7 @@ func (bi *blockIndexer) hydrateBlock(ctx context.Context, batch *blockWriterBatc
 			}
 			return retry, err
 		}
+		batch.receipts[blockIndex] = receipts
 		return false, nil
 	})
 	batch.receiptResults[blockIndex] = err
@@ -924,7 +983,7 @@ func (bi *blockIndexer) DecodeTransactionEvents(ctx context.Context, hash pldtyp
 
 func (bi *blockIndexer) getConfirmedTransactionReceipt(ctx context.Context, tx ethtypes.HexBytes0xPrefix) (*TXReceiptJSONRPC, error) {
 	var receipt *TXReceiptJSONRPC
-	rpcErr := bi.wsConn.CallRPC(ctx, &receipt, "eth_getTransactionReceipt", tx)
+	rpcErr := bi.rpcConn.CallRPC(ctx, &receipt, "eth_getTransactionReceipt", tx)
 	if rpcErr != nil {
 		return nil, rpcErr
 	}
@@ -41,6 +41,7 @@ func (bi *blockIndexer) initRPC() {
 		Add("bidx_queryIndexedTransactions", bi.rpcQueryIndexedTransactions()).
 		Add("bidx_queryIndexedEvents", bi.rpcQueryIndexedEvents()).
 		Add("bidx_getConfirmedBlockHeight", bi.rpcGetConfirmedBlockHeight()).
+		Add("bidx_getBlockchainStatus", bi.rpcGetBlockchainStatus()).
 		Add("bidx_decodeTransactionEvents", bi.rpcDecodeTransactionEvents())
 }
 
@@ -92,6 +93,13 @@ func (bi *blockIndexer) rpcGetConfirmedBlockHeight() rpcserver.RPCHandler {
 	})
 }
 
+func (bi *blockIndexer) rpcGetBlockchainStatus() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context,
+	) (*pldapi.BlockchainStatus, error) {
+		return bi.GetBlockchainStatus(ctx), nil
+	})
+}
+
 func (bi *blockIndexer) rpcQueryIndexedBlocks() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		jq query.QueryJSON,
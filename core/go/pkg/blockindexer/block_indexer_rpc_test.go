@@ -97,6 +97,26 @@ func TestBlockIndexRPCCalls(t *testing.T) {
 	err = rpc.CallRPC(ctx, &blockHeight, "bidx_getConfirmedBlockHeight")
 	require.NoError(t, err)
 	assert.Equal(t, pldtypes.HexUint64(0), blockHeight)
+
+	var status *pldapi.BlockchainStatus
+	err = rpc.CallRPC(ctx, &status, "bidx_getBlockchainStatus")
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	bi.blockchainStatus = func() *pldapi.BlockchainStatus {
+		return &pldapi.BlockchainStatus{
+			ChainID: 12345,
+			Endpoints: []*pldapi.BlockchainEndpointStatus{
+				{HTTP: "http://node1:8545", WS: "ws://node1:8546", Active: true, Healthy: true, BlockNumber: 100},
+			},
+		}
+	}
+	err = rpc.CallRPC(ctx, &status, "bidx_getBlockchainStatus")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12345), status.ChainID)
+	require.Len(t, status.Endpoints, 1)
+	assert.True(t, status.Endpoints[0].Active)
+	assert.Equal(t, pldtypes.HexUint64(100), status.Endpoints[0].BlockNumber)
 }
 
 func newBlockIndexerWithOneBlock(t *testing.T) (context.Context, *BlockInfoJSONRPC, *blockIndexer, func()) {
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
 	"github.com/kaleido-io/paladin/core/mocks/rpcclientmocks"
 
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -168,6 +169,7 @@ func testBlockArray(t *testing.T, l int, knownAddress ...ethtypes.Address0xHex)
 		tx := &PartialTransactionInfo{
 			Hash:  txHash,
 			From:  ethtypes.MustNewAddress(pldtypes.RandHex(20)),
+			To:    to,
 			Nonce: ethtypes.HexUint64(i),
 		}
 		blocks[i] = &BlockInfoJSONRPC{
@@ -246,6 +248,18 @@ func mockBlocksRPCCallsDynamic(mRPC *rpcclientmocks.WSClient, dynamic func(args
 		}
 	})
 
+	blockLogs := mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", mock.Anything).Maybe()
+	blockLogs.Run(func(args mock.Arguments) {
+		_, receipts := dynamic(args)
+		logsReturn := args[1].(*[]*LogJSONRPC)
+		blockHash := args[3].(*ethGetLogsFilter).BlockHash
+		*logsReturn = []*LogJSONRPC{}
+		for _, r := range receipts[blockHash.String()] {
+			*logsReturn = append(*logsReturn, r.Logs...)
+		}
+		blockLogs.Return(nil)
+	})
+
 	txReceipt := mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).Maybe()
 	txReceipt.Run(func(args mock.Arguments) {
 		_, receipts := dynamic(args)
@@ -300,6 +314,117 @@ func TestNewBlockIndexerRestoreCheckpointFail(t *testing.T) {
 	require.NoError(t, p.Mock.ExpectationsWereMet())
 }
 
+func TestNewBlockIndexerForEthClientFactory(t *testing.T) {
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+
+	p.Mock.ExpectQuery("SELECT.*event_streams").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	mRPC := rpcclientmocks.NewWSClient(t)
+	status := &pldapi.BlockchainStatus{ChainID: 12345, ActiveEndpoint: 1}
+	mECF := ethclientmocks.NewEthClientFactory(t)
+	mECF.On("NewWSRPC").Return(mRPC)
+	mECF.On("BlockchainStatus").Return(status)
+
+	bi, err := NewBlockIndexerForEthClientFactory(context.Background(), &pldconf.BlockIndexerConfig{}, mECF, p.P)
+	require.NoError(t, err)
+	assert.Equal(t, mRPC, bi.(*blockIndexer).rpcConn)
+	assert.Equal(t, mRPC, bi.(*blockIndexer).blockListener.wsConn)
+	assert.Equal(t, status, bi.GetBlockchainStatus(context.Background()))
+
+	require.NoError(t, p.Mock.ExpectationsWereMet())
+}
+
+func TestNewBlockIndexerForEthClientFactoryFail(t *testing.T) {
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+
+	p.Mock.ExpectQuery("SELECT.*event_streams").WillReturnError(fmt.Errorf("pop"))
+
+	mECF := ethclientmocks.NewEthClientFactory(t)
+	mECF.On("NewWSRPC").Return(rpcclientmocks.NewWSClient(t))
+
+	_, err = NewBlockIndexerForEthClientFactory(context.Background(), &pldconf.BlockIndexerConfig{}, mECF, p.P)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNewBlockIndexerForEthClientFactoryHTTP(t *testing.T) {
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+
+	p.Mock.ExpectQuery("SELECT.*event_streams").WillReturnRows(sqlmock.NewRows([]string{}))
+	p.Mock.ExpectQuery("SELECT.*event_streams").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	mHTTP := rpcclientmocks.NewClient(t)
+	mWS := rpcclientmocks.NewWSClient(t)
+	mECF := ethclientmocks.NewEthClientFactory(t)
+	mECF.On("HTTPRPC").Return(mHTTP)
+	mECF.On("NewWSRPC").Return(mWS).Once()
+
+	bi, err := NewBlockIndexerForEthClientFactory(context.Background(), &pldconf.BlockIndexerConfig{
+		BlockListener: pldconf.BlockListenerConfig{
+			Transport: pldconf.BlockListenerTransportHTTP,
+		},
+	}, mECF, p.P)
+	require.NoError(t, err)
+	assert.Equal(t, mHTTP, bi.(*blockIndexer).rpcConn)
+	assert.Equal(t, mHTTP, bi.(*blockIndexer).blockListener.rpcConn)
+	assert.Equal(t, mWS, bi.(*blockIndexer).blockListener.wsConn)
+
+	// No WebSocket at all if we do not want newHeads
+	bi, err = NewBlockIndexerForEthClientFactory(context.Background(), &pldconf.BlockIndexerConfig{
+		BlockListener: pldconf.BlockListenerConfig{
+			Transport: pldconf.BlockListenerTransportHTTP,
+			NewHeads:  confutil.P(false),
+		},
+	}, mECF, p.P)
+	require.NoError(t, err)
+	assert.Equal(t, mHTTP, bi.(*blockIndexer).blockListener.rpcConn)
+	assert.Nil(t, bi.(*blockIndexer).blockListener.wsConn)
+
+	require.NoError(t, p.Mock.ExpectationsWereMet())
+}
+
+func TestNewBlockIndexerForEthClientFactoryBadTransport(t *testing.T) {
+	_, err := NewBlockIndexerForEthClientFactory(context.Background(), &pldconf.BlockIndexerConfig{
+		BlockListener: pldconf.BlockListenerConfig{
+			Transport: "wrong",
+		},
+	}, ethclientmocks.NewEthClientFactory(t), nil)
+	assert.Regexp(t, "PD011314.*wrong", err)
+}
+
+func TestNewBlockIndexerHTTP(t *testing.T) {
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+
+	p.Mock.ExpectQuery("SELECT.*event_streams").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	bi, err := NewBlockIndexerHTTP(context.Background(), &pldconf.BlockIndexerConfig{},
+		&pldconf.HTTPClientConfig{URL: "http://localhost:8545"},
+		&pldconf.WSClientConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: "ws://localhost:8546"}},
+		p.P)
+	require.NoError(t, err)
+	bl := bi.(*blockIndexer).blockListener
+	assert.NotNil(t, bl.rpcConn)
+	assert.NotNil(t, bl.wsConn)
+	assert.False(t, bl.rpcOverWS())
+
+	require.NoError(t, p.Mock.ExpectationsWereMet())
+}
+
+func TestNewBlockIndexerHTTPBadConfig(t *testing.T) {
+	_, err := NewBlockIndexerHTTP(context.Background(), &pldconf.BlockIndexerConfig{},
+		&pldconf.HTTPClientConfig{URL: "ws://localhost:8545"}, nil, nil)
+	assert.Regexp(t, "PD020501", err)
+
+	_, err = NewBlockIndexerHTTP(context.Background(), &pldconf.BlockIndexerConfig{},
+		&pldconf.HTTPClientConfig{URL: "http://localhost:8545"},
+		&pldconf.WSClientConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: "http://localhost:8546"}},
+		nil)
+	assert.Regexp(t, "PD020500", err)
+}
+
 func checkIndexedBlockEqual(t *testing.T, expected *BlockInfoJSONRPC, indexed *pldapi.IndexedBlock) {
 	assert.Equal(t, expected.Hash.String(), indexed.Hash.String())
 	assert.Equal(t, expected.Number.Uint64(), uint64(indexed.Number))
@@ -39,7 +39,8 @@ import (
 // 2) To feed new block information to any registered consumers
 type blockListener struct {
 	ctx                        context.Context
-	wsConn                     rpcclient.WSClient // if configured the getting the blockheight will not complete until WS connects, overrides backend once connected
+	rpcConn                    rpcclient.Client   // all JSON/RPC calls are made on this connection, which might be wsConn
+	wsConn                     rpcclient.WSClient // when also the rpcConn, getting the blockheight will not complete until WS connects - otherwise only used for newHeads (if at all)
 	wsConnClosed               bool
 	listenLoopDone             chan struct{}
 	initialBlockHeightObtained chan struct{}
@@ -49,6 +50,8 @@ type blockListener struct {
 	highestBlockMux            sync.RWMutex
 	wsMux                      sync.Mutex
 	blockPollingInterval       time.Duration
+	blockFilter                bool
+	newHeads                   bool
 	unstableHeadLength         int
 	canonicalChain             *list.List
 	retry                      *retry.Retry
@@ -60,6 +63,27 @@ func newBlockListener(ctx context.Context, conf *pldconf.BlockIndexerConfig, wsC
 	if err != nil {
 		return nil, err
 	}
+	wsConn := rpcclient.WrapWSConfig(wscConf)
+	return newBlockListenerForClient(ctx, conf, wsConn, wsConn), nil
+}
+
+// newHTTPBlockListener makes all calls over HTTP, with an optional WebSocket only used to
+// subscribe to newHeads so we can poll as soon as there is a new block
+func newHTTPBlockListener(ctx context.Context, conf *pldconf.BlockIndexerConfig, httpConfig *pldconf.HTTPClientConfig, wsConfig *pldconf.WSClientConfig) (bl *blockListener, err error) {
+	httpConn, err := rpcclient.NewHTTPClient(ctx, httpConfig)
+	if err != nil {
+		return nil, err
+	}
+	var wsConn rpcclient.WSClient
+	if wsConfig != nil && wsConfig.URL != "" && confutil.Bool(conf.BlockListener.NewHeads, *pldconf.BlockIndexerDefaults.BlockListener.NewHeads) {
+		if wsConn, err = rpcclient.NewWSClient(ctx, wsConfig); err != nil {
+			return nil, err
+		}
+	}
+	return newBlockListenerForClient(ctx, conf, httpConn, wsConn), nil
+}
+
+func newBlockListenerForClient(ctx context.Context, conf *pldconf.BlockIndexerConfig, rpcConn rpcclient.Client, wsConn rpcclient.WSClient) (bl *blockListener) {
 	chainHeadCacheLen := confutil.IntMin(conf.ChainHeadCacheLen, 1, *pldconf.BlockIndexerDefaults.ChainHeadCacheLen)
 	bl = &blockListener{
 		ctx:                        log.WithLogField(ctx, "role", "blocklistener"),
@@ -67,13 +91,16 @@ func newBlockListener(ctx context.Context, conf *pldconf.BlockIndexerConfig, wsC
 		newHeadsTap:                make(chan struct{}, 1),
 		highestBlock:               0,
 		blockPollingInterval:       confutil.DurationMin(conf.BlockPollingInterval, 1*time.Millisecond, *pldconf.BlockIndexerDefaults.BlockPollingInterval),
+		blockFilter:                confutil.Bool(conf.BlockListener.BlockFilter, *pldconf.BlockIndexerDefaults.BlockListener.BlockFilter),
+		newHeads:                   confutil.Bool(conf.BlockListener.NewHeads, *pldconf.BlockIndexerDefaults.BlockListener.NewHeads),
 		canonicalChain:             list.New(),
 		unstableHeadLength:         chainHeadCacheLen,
 		retry:                      retry.NewRetryIndefinite(&conf.Retry),
-		wsConn:                     rpcclient.WrapWSConfig(wscConf),
+		rpcConn:                    rpcConn,
+		wsConn:                     wsConn,
 		newBlocks:                  make(chan *BlockInfoJSONRPC, chainHeadCacheLen),
 	}
-	return bl, nil
+	return bl
 }
 
 func (bl *blockListener) start() {
@@ -111,6 +138,42 @@ func (bl *blockListener) tapNewHeads() {
 	}
 }
 
+func (bl *blockListener) rpcOverWS() bool {
+	return bl.wsConn != nil && bl.rpcConn == bl.wsConn
+}
+
+func (bl *blockListener) subscribeNewHeads() error {
+	// Once subscribed the backend will keep us subscribed over reconnect
+	sub, rpcErr := bl.wsConn.Subscribe(bl.ctx, rpcclient.EthSubscribeConfig(), "newHeads")
+	if rpcErr != nil {
+		return rpcErr
+	}
+	bl.newHeadsSub = sub
+	go bl.newHeadsSubListener()
+	return nil
+}
+
+// connectNewHeads is used when the calls are made over HTTP, to connect the WebSocket and subscribe to newHeads
+// in the background. We poll for new blocks regardless, so this does not hold up the listener.
+func (bl *blockListener) connectNewHeads() {
+	wsConnected := false
+	_ = bl.retry.Do(bl.ctx, func(attempt int) (retry bool, err error) {
+		bl.wsMux.Lock()
+		defer bl.wsMux.Unlock()
+		if bl.wsConnClosed {
+			return false, nil
+		}
+		if !wsConnected {
+			if err := bl.wsConn.Connect(bl.ctx); err != nil {
+				log.L(bl.ctx).Warnf("WebSocket connection failed, polling for new blocks until connected: %s", err)
+				return true, err
+			}
+			wsConnected = true
+		}
+		return true, bl.subscribeNewHeads()
+	})
+}
+
 // getBlockHeightWithRetry keeps retrying attempting to get the initial block height until successful
 func (bl *blockListener) establishBlockHeightWithRetry() error {
 	bl.wsMux.Lock()
@@ -119,28 +182,26 @@ func (bl *blockListener) establishBlockHeightWithRetry() error {
 	wsConnected := false
 	return bl.retry.Do(bl.ctx, func(attempt int) (retry bool, err error) {
 
-		// Connect the websocket if not yet connected on this retry iteration
-		if !wsConnected {
-			if err := bl.wsConn.Connect(bl.ctx); err != nil {
-				log.L(bl.ctx).Warnf("WebSocket connection failed, blocking startup of block listener: %s", err)
-				return true, err
+		// Connect the websocket if not yet connected on this retry iteration, when all our calls are made over it
+		if bl.rpcOverWS() {
+			if !wsConnected {
+				if err := bl.wsConn.Connect(bl.ctx); err != nil {
+					log.L(bl.ctx).Warnf("WebSocket connection failed, blocking startup of block listener: %s", err)
+					return true, err
+				}
+				// if we retry subscribe, we don't want to retry connect
+				wsConnected = true
 			}
-			// if we retry subscribe, we don't want to retry connect
-			wsConnected = true
-		}
-		if bl.newHeadsSub == nil {
-			// Once subscribed the backend will keep us subscribed over reconnect
-			sub, rpcErr := bl.wsConn.Subscribe(bl.ctx, rpcclient.EthSubscribeConfig(), "newHeads")
-			if rpcErr != nil {
-				return true, rpcErr
+			if bl.newHeads && bl.newHeadsSub == nil {
+				if err := bl.subscribeNewHeads(); err != nil {
+					return true, err
+				}
 			}
-			bl.newHeadsSub = sub
-			go bl.newHeadsSubListener()
 		}
 
 		// Now get the block height
 		var hexBlockHeight ethtypes.HexUint64
-		rpcErr := bl.wsConn.CallRPC(bl.ctx, &hexBlockHeight, "eth_blockNumber")
+		rpcErr := bl.rpcConn.CallRPC(bl.ctx, &hexBlockHeight, "eth_blockNumber")
 		if rpcErr != nil {
 			log.L(bl.ctx).Warnf("Block height could not be obtained: %s", rpcErr)
 			return true, rpcErr
@@ -167,7 +228,7 @@ func isNotFound(err error) bool {
 func (bl *blockListener) getBlockInfoByHash(ctx context.Context, blockHash string) (*BlockInfoJSONRPC, error) {
 	var info *BlockInfoJSONRPC
 	log.L(ctx).Debugf("Fetching block by hash %s", blockHash)
-	err := bl.wsConn.CallRPC(ctx, &info, "eth_getBlockByHash", blockHash, true)
+	err := bl.rpcConn.CallRPC(ctx, &info, "eth_getBlockByHash", blockHash, true)
 	if err != nil {
 		if isNotFound(err) {
 			return nil, nil
@@ -180,7 +241,7 @@ func (bl *blockListener) getBlockInfoByHash(ctx context.Context, blockHash strin
 func (bl *blockListener) getBlockInfoByNumber(ctx context.Context, blockNumber ethtypes.HexUint64) (*BlockInfoJSONRPC, error) {
 	var info *BlockInfoJSONRPC
 	log.L(ctx).Debugf("Fetching block by number %d", blockNumber)
-	err := bl.wsConn.CallRPC(ctx, &info, "eth_getBlockByNumber", blockNumber, true)
+	err := bl.rpcConn.CallRPC(ctx, &info, "eth_getBlockByNumber", blockNumber, true)
 	if err != nil {
 		if isNotFound(err) {
 			return nil, nil
@@ -201,7 +262,12 @@ func (bl *blockListener) listenLoop(listenerInitiated *chan struct{}) {
 		return
 	}
 
+	if bl.wsConn != nil && bl.newHeads && !bl.rpcOverWS() {
+		go bl.connectNewHeads()
+	}
+
 	var filter string
+	var polledHead *ethtypes.HexUint64
 	failCount := 0
 	bl.tapNewHeads() // poll once to start
 	notifyStarted := sync.Once{}
@@ -224,44 +290,19 @@ func (bl *blockListener) listenLoop(listenerInitiated *chan struct{}) {
 				return false // context cancelled, exit loop
 			}
 
-			if filter == "" {
-				err := bl.wsConn.CallRPC(bl.ctx, &filter, "eth_newBlockFilter")
-				if err != nil {
-					log.L(bl.ctx).Errorf("Failed to establish new block filter: %s", err)
-					failCount++
-					return true
-				}
+			var notifyPos *list.Element
+			var err error
+			if bl.blockFilter {
+				notifyPos, err = bl.checkBlockFilter(&filter)
 			}
-
-			var blockHashes []ethtypes.HexBytes0xPrefix
-			rpcErr := bl.wsConn.CallRPC(bl.ctx, &blockHashes, "eth_getFilterChanges", filter)
-			if rpcErr != nil {
-				if isNotFound(rpcErr) {
-					log.L(bl.ctx).Warnf("Block filter '%v' no longer valid. Recreating filter: %s", filter, rpcErr)
-					filter = ""
-				}
-				log.L(bl.ctx).Errorf("Failed to query block filter changes: %s", rpcErr)
+			// Note the block filter might have just been found to be unsupported
+			if !bl.blockFilter {
+				notifyPos, err = bl.checkBlockHead(&polledHead)
+			}
+			if err != nil {
 				failCount++
 				return true
 			}
-
-			var notifyPos *list.Element
-			for _, h := range blockHashes {
-				// Do a lookup of the block (which will then go into our cache).
-				bi, err := bl.getBlockInfoByHash(bl.ctx, h.String())
-				switch {
-				case err != nil:
-					log.L(bl.ctx).Debugf("Failed to query block '%s': %s", h, err)
-				case bi == nil:
-					log.L(bl.ctx).Debugf("Block '%s' no longer available after notification (assuming due to re-org)", h)
-				default:
-					candidate := bl.reconcileCanonicalChain(bi)
-					// Check this is the lowest position to notify from
-					if candidate != nil && (notifyPos == nil || candidate.Value.(*BlockInfoJSONRPC).Number <= notifyPos.Value.(*BlockInfoJSONRPC).Number) {
-						notifyPos = candidate
-					}
-				}
-			}
 			if notifyPos != nil {
 				// We notify for all hashes from the point of change in the chain onwards
 				for notifyPos != nil {
@@ -284,6 +325,88 @@ func (bl *blockListener) listenLoop(listenerInitiated *chan struct{}) {
 	log.L(bl.ctx).Debugf("Block listener loop stopping")
 }
 
+// isNotSupported checks for the errors nodes and RPC gateways return for methods they do not implement
+func isNotSupported(rpcErr rpcclient.ErrorRPC) bool {
+	if rpcErr.RPCError() != nil && rpcErr.RPCError().Code == int64(rpcclient.RPCCodeMethodNotFound) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(rpcErr.Error()), "not supported")
+}
+
+// checkBlockFilter creates the block filter if we do not yet have one, and processes all the new block hashes
+// reported by it since the last check
+func (bl *blockListener) checkBlockFilter(filter *string) (notifyPos *list.Element, err error) {
+	if *filter == "" {
+		rpcErr := bl.rpcConn.CallRPC(bl.ctx, filter, "eth_newBlockFilter")
+		if rpcErr != nil {
+			if isNotSupported(rpcErr) {
+				log.L(bl.ctx).Warnf("Block filters not supported by the node. Polling the block number instead: %s", rpcErr)
+				bl.blockFilter = false
+				return nil, nil
+			}
+			log.L(bl.ctx).Errorf("Failed to establish new block filter: %s", rpcErr)
+			return nil, rpcErr
+		}
+	}
+
+	var blockHashes []ethtypes.HexBytes0xPrefix
+	rpcErr := bl.rpcConn.CallRPC(bl.ctx, &blockHashes, "eth_getFilterChanges", *filter)
+	if rpcErr != nil {
+		if isNotFound(rpcErr) {
+			log.L(bl.ctx).Warnf("Block filter '%v' no longer valid. Recreating filter: %s", *filter, rpcErr)
+			*filter = ""
+		}
+		log.L(bl.ctx).Errorf("Failed to query block filter changes: %s", rpcErr)
+		return nil, rpcErr
+	}
+
+	for _, h := range blockHashes {
+		// Do a lookup of the block (which will then go into our cache).
+		bi, err := bl.getBlockInfoByHash(bl.ctx, h.String())
+		switch {
+		case err != nil:
+			log.L(bl.ctx).Debugf("Failed to query block '%s': %s", h, err)
+		case bi == nil:
+			log.L(bl.ctx).Debugf("Block '%s' no longer available after notification (assuming due to re-org)", h)
+		default:
+			candidate := bl.reconcileCanonicalChain(bi)
+			// Check this is the lowest position to notify from
+			if candidate != nil && (notifyPos == nil || candidate.Value.(*BlockInfoJSONRPC).Number <= notifyPos.Value.(*BlockInfoJSONRPC).Number) {
+				notifyPos = candidate
+			}
+		}
+	}
+	return notifyPos, nil
+}
+
+// checkBlockHead is used when we do not have a block filter, and polls the block number. When that has changed
+// we process the head block, and if that does not fit onto the end of our canonical chain then rebuilding the
+// chain fills in any blocks we missed in between polls (as well as handling re-orgs).
+// A re-org that does not change the block number is only detected once the next block arrives.
+func (bl *blockListener) checkBlockHead(polledHead **ethtypes.HexUint64) (*list.Element, error) {
+	var hexBlockHeight ethtypes.HexUint64
+	rpcErr := bl.rpcConn.CallRPC(bl.ctx, &hexBlockHeight, "eth_blockNumber")
+	if rpcErr != nil {
+		log.L(bl.ctx).Errorf("Failed to query block number: %s", rpcErr)
+		return nil, rpcErr
+	}
+	if *polledHead != nil && **polledHead == hexBlockHeight {
+		return nil, nil
+	}
+
+	bi, err := bl.getBlockInfoByNumber(bl.ctx, hexBlockHeight)
+	if err != nil {
+		log.L(bl.ctx).Errorf("Failed to query head block %d: %s", hexBlockHeight, err)
+		return nil, err
+	}
+	if bi == nil {
+		log.L(bl.ctx).Debugf("Block %d no longer available after polling block number (assuming due to re-org)", hexBlockHeight)
+		return nil, nil
+	}
+	*polledHead = &hexBlockHeight
+	return bl.reconcileCanonicalChain(bi), nil
+}
+
 func (bl *blockListener) notifyBlock(bi *BlockInfoJSONRPC) {
 	// Does not block if context is cancelled, we'll handle that in other processing
 	select {
@@ -487,6 +610,19 @@ func (bl *blockListener) getHighestBlock(ctx context.Context) (uint64, error) {
 	log.L(bl.ctx).Debugf("ChainHead=%d", highestBlock)
 	return highestBlock, nil
 }
+
+// Returns the highest block, without waiting for the initial block height to be obtained
+func (bl *blockListener) getHighestBlockNoWait() (uint64, bool) {
+	select {
+	case <-bl.initialBlockHeightObtained:
+	default:
+		return 0, false
+	}
+	bl.highestBlockMux.RLock()
+	defer bl.highestBlockMux.RUnlock()
+	return bl.highestBlock, true
+}
+
 func (bl *blockListener) waitClosed() {
 	bl.wsMux.Lock()
 	listenLoopDone := bl.listenLoopDone
@@ -69,6 +69,7 @@ func newTestBlockListenerConf(t *testing.T, ctx context.Context, config *pldconf
 	bl, err := newBlockListener(ctx, config, &pldconf.WSClientConfig{
 		HTTPClientConfig: pldconf.HTTPClientConfig{URL: "ws://localhost:0" /* unused per below re-wire to mRPC */}})
 	require.NoError(t, err)
+	bl.rpcConn = mRPC
 	bl.wsConn = mRPC
 	return bl, mRPC
 }
@@ -1435,3 +1436,180 @@ func TestBlockListenerClosedBeforeEstablishingBlockHeight(t *testing.T) {
 	bl.listenLoop(&listenerInitiated)
 
 }
+
+func newTestHTTPBlockListener(t *testing.T, config *pldconf.BlockIndexerConfig) (*blockListener, *rpcclientmocks.Client, *rpcclientmocks.WSClient, func()) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	mRPC := rpcclientmocks.NewClient(t)
+	mWS := rpcclientmocks.NewWSClient(t)
+	mWS.On("UnsubscribeAll", mock.Anything).Return(nil).Maybe()
+	mWS.On("Close", mock.Anything).Return(nil).Maybe()
+
+	bl := newBlockListenerForClient(ctx, config, mRPC, mWS)
+	return bl, mRPC, mWS, func() {
+		cancelCtx()
+		bl.waitClosed()
+	}
+}
+
+func mockBlockByNumber(mRPC *rpcclientmocks.Client, bi *BlockInfoJSONRPC) {
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", mock.MatchedBy(func(n ethtypes.HexUint64) bool {
+		return n == bi.Number
+	}), true).Return(nil).Run(func(args mock.Arguments) {
+		*args[1].(**BlockInfoJSONRPC) = bi
+	})
+}
+
+func TestBlockListenerHTTPPollingGap(t *testing.T) {
+
+	bl, mRPC, mWS, done := newTestHTTPBlockListener(t, &pldconf.BlockIndexerConfig{
+		BlockListener: pldconf.BlockListenerConfig{
+			BlockFilter: confutil.P(false),
+		},
+	})
+	bl.blockPollingInterval = 1 * time.Microsecond
+
+	// The WebSocket is only used in the background for newHeads
+	mSub := rpcclientmocks.NewSubscription(t)
+	mSub.On("Notifications").Return(make(chan rpcclient.RPCSubscriptionNotification)).Maybe()
+	mWS.On("Connect", mock.Anything).Return(nil).Maybe()
+	mWS.On("Subscribe", mock.Anything, mock.Anything, "newHeads").Return(mSub, nil).Maybe()
+
+	block1000 := &BlockInfoJSONRPC{
+		Number:     1000,
+		Hash:       ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+		ParentHash: ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+	}
+	block1001 := &BlockInfoJSONRPC{
+		Number:     1001,
+		Hash:       ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+		ParentHash: block1000.Hash,
+	}
+	block1002 := &BlockInfoJSONRPC{
+		Number:     1002,
+		Hash:       ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+		ParentHash: block1001.Hash,
+	}
+
+	// Block height, first poll, and an unchanged poll, before we skip a block
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexUint64)
+		*hbh = ethtypes.HexUint64(1000)
+	}).Times(3)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexUint64)
+		*hbh = ethtypes.HexUint64(1002)
+	})
+	mockBlockByNumber(mRPC, block1000)
+	mockBlockByNumber(mRPC, block1001)
+	mockBlockByNumber(mRPC, block1002)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.HexUint64(1003), true).Return(nil)
+
+	bl.start()
+
+	assert.Equal(t, block1000.Hash, (<-bl.channel()).Hash)
+	assert.Equal(t, block1001.Hash, (<-bl.channel()).Hash)
+	assert.Equal(t, block1002.Hash, (<-bl.channel()).Hash)
+
+	done()
+	<-bl.listenLoopDone
+
+	assert.Equal(t, uint64(1002), bl.highestBlock)
+	assert.Equal(t, 3, bl.canonicalChain.Len())
+
+}
+
+func TestBlockListenerBlockFilterNotSupported(t *testing.T) {
+
+	_, bl, mRPC, done := newTestBlockListener(t)
+	bl.blockPollingInterval = 1 * time.Microsecond
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexUint64)
+		*hbh = ethtypes.HexUint64(1000)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_newBlockFilter").Return(&rpcclient.RPCError{
+		Code:    int64(rpcclient.RPCCodeMethodNotFound),
+		Message: "the method eth_newBlockFilter does not exist/is not available",
+	}).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.HexUint64(1000), true).Return(nil).Run(func(args mock.Arguments) {
+		go done() // Close once we have polled the head block
+	})
+
+	bl.start()
+	bl.waitClosed()
+
+	assert.False(t, bl.blockFilter)
+
+}
+
+func TestBlockListenerIsNotSupported(t *testing.T) {
+	assert.True(t, isNotSupported(rpcclient.WrapRPCError(rpcclient.RPCCodeMethodNotFound, fmt.Errorf("not found"))))
+	assert.True(t, isNotSupported(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("Method Not Supported"))))
+	assert.False(t, isNotSupported(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop"))))
+}
+
+func TestBlockListenerCheckBlockHeadFailures(t *testing.T) {
+
+	bl, mRPC, _, done := newTestHTTPBlockListener(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").
+		Return(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop"))).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil).Run(func(args mock.Arguments) {
+		hbh := args[1].(*ethtypes.HexUint64)
+		*hbh = ethtypes.HexUint64(1000)
+	})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.HexUint64(1000), true).
+		Return(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop"))).Once()
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockByNumber", ethtypes.HexUint64(1000), true).
+		Return(nil)
+
+	var polledHead *ethtypes.HexUint64
+	_, err := bl.checkBlockHead(&polledHead)
+	assert.Regexp(t, "pop", err)
+
+	_, err = bl.checkBlockHead(&polledHead)
+	assert.Regexp(t, "pop", err)
+
+	// Not available - so we try again on the next poll
+	notifyPos, err := bl.checkBlockHead(&polledHead)
+	require.NoError(t, err)
+	assert.Nil(t, notifyPos)
+	assert.Nil(t, polledHead)
+
+}
+
+func TestBlockListenerHTTPConnectNewHeadsRetry(t *testing.T) {
+
+	bl, _, mWS, done := newTestHTTPBlockListener(t, &pldconf.BlockIndexerConfig{
+		Retry: pldconf.RetryConfig{
+			InitialDelay: confutil.P("0"),
+		},
+	})
+	defer done()
+
+	subsChan := make(chan rpcclient.RPCSubscriptionNotification)
+	mSub := rpcclientmocks.NewSubscription(t)
+	mSub.On("Notifications").Return(subsChan).Maybe()
+	mWS.On("Connect", mock.Anything).Return(fmt.Errorf("pop")).Once()
+	mWS.On("Connect", mock.Anything).Return(nil).Once()
+	mWS.On("Subscribe", mock.Anything, mock.Anything, "newHeads").
+		Return(nil, rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop"))).Once()
+	mWS.On("Subscribe", mock.Anything, mock.Anything, "newHeads").Return(mSub, nil).Once()
+
+	bl.connectNewHeads()
+	assert.Equal(t, mSub, bl.newHeadsSub)
+
+}
+
+func TestBlockListenerHTTPConnectNewHeadsClosed(t *testing.T) {
+
+	bl, _, _, done := newTestHTTPBlockListener(t, &pldconf.BlockIndexerConfig{})
+	done()
+
+	// Returns without attempting to connect
+	bl.connectNewHeads()
+	assert.Nil(t, bl.newHeadsSub)
+
+}
@@ -34,6 +34,8 @@ import (
 type EventStreamConfig struct {
 	BatchSize    *int    `json:"batchSize,omitempty"`
 	BatchTimeout *string `json:"batchTimeout,omitempty"`
+	// Not used by the block indexer - stored on behalf of PTX blockchain event listeners that deliver to a webhook
+	Webhook *pldapi.ListenerWebhook `json:"webhook,omitempty"`
 }
 
 var EventStreamDefaults = &EventStreamConfig{
@@ -126,7 +128,10 @@ type PreCommitHandler func(ctx context.Context, dbTX persistence.DBTX, blocks []
 
 type InternalStreamCallbackDBTX func(ctx context.Context, dbTX persistence.DBTX, batch *EventDeliveryBatch) error
 
-type InternalStreamCallbackNOTX func(ctx context.Context, batch *EventDeliveryBatch) error
+// Can return a handler to be run within the DB transaction that updates the checkpoint after the batch
+type InternalStreamCallbackNOTX func(ctx context.Context, batch *EventDeliveryBatch) (PreCheckpointHandler, error)
+
+type PreCheckpointHandler func(ctx context.Context, dbTX persistence.DBTX) error
 
 type IESType int
 
@@ -137,6 +142,7 @@ const (
 
 	// An event stream with its own checkpoint, and goroutine with its own DB transactions for checkpoint update, that can fall behind the head if necessary.
 	// The event stream has a handler that is called without a DB transaction so can block waiting for an external response.
+	// The handler can return a pre-checkpoint handler, to write state in the DB transaction that moves the checkpoint past the batch.
 	IESTypeEventStreamNOTX
 
 	// An in-line callback that is fired with the raw block information, WITHIN the database transaction the block indexer uses to commit that information.
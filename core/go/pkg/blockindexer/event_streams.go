@@ -634,9 +634,18 @@ func (es *eventStream) updateCheckpoint(ctx context.Context, dbTX persistence.DB
 func (es *eventStream) runBatch(batch *eventBatch) error {
 	return es.bi.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
 		if es.useNOTXHandler {
-			err = es.handlerNOTX(es.ctx, &batch.EventDeliveryBatch)
+			var preCheckpoint PreCheckpointHandler
+			preCheckpoint, err = es.handlerNOTX(es.ctx, &batch.EventDeliveryBatch)
 			if err == nil {
-				err = es.updateCheckpoint(es.ctx, es.bi.persistence.NOTX(), int64(batch.checkpointAfterBatch))
+				err = es.bi.persistence.Transaction(es.ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+					if preCheckpoint != nil {
+						err = preCheckpoint(ctx, dbTX)
+					}
+					if err == nil {
+						err = es.updateCheckpoint(ctx, dbTX, int64(batch.checkpointAfterBatch))
+					}
+					return err
+				})
 			}
 			return true, err
 		}
@@ -1091,8 +1091,8 @@ func TestNOTXHandler(t *testing.T) {
 
 	p.Mock.ExpectQuery("SELECT.*event_streams").WillReturnRows(p.Mock.NewRows([]string{}))
 	p.Mock.ExpectExec("INSERT.*event_streams").WillReturnResult(sqlmock.NewResult(1, 1))
-	p.Mock.ExpectExec("INSERT.*event_stream_checkpoints").WillReturnResult(driver.ResultNoRows)
 	returnErr := true
+	preCheckpointCalled := false
 
 	definition, err := bi.AddEventStream(ctx, bi.persistence.NOTX(), &InternalEventStream{
 		Type: IESTypeEventStreamNOTX,
@@ -1103,12 +1103,15 @@ func TestNOTXHandler(t *testing.T) {
 				ABI: testABI,
 			}},
 		},
-		HandlerNOTX: func(_ context.Context, _ *EventDeliveryBatch) error {
+		HandlerNOTX: func(_ context.Context, _ *EventDeliveryBatch) (PreCheckpointHandler, error) {
 			if returnErr {
 				returnErr = false
-				return errors.New("pop")
+				return nil, errors.New("pop")
 			}
-			return nil
+			return func(ctx context.Context, dbTX persistence.DBTX) error {
+				preCheckpointCalled = true
+				return nil
+			}, nil
 		},
 	})
 
@@ -1117,7 +1120,12 @@ func TestNOTXHandler(t *testing.T) {
 	es := bi.eventStreams[definition.ID]
 	es.ctx = ctx
 
+	p.Mock.ExpectBegin()
+	p.Mock.ExpectExec("INSERT.*event_stream_checkpoints").WillReturnResult(driver.ResultNoRows)
+	p.Mock.ExpectCommit()
+
 	err = es.runBatch(&eventBatch{})
 	assert.NoError(t, err)
 	assert.False(t, returnErr)
+	assert.True(t, preCheckpointCalled)
 }
@@ -32,6 +32,7 @@ type BlockInfoJSONRPC struct {
 type PartialTransactionInfo struct {
 	Hash  ethtypes.HexBytes0xPrefix `json:"hash"`
 	From  *ethtypes.Address0xHex    `json:"from"`
+	To    *ethtypes.Address0xHex    `json:"to"`
 	Nonce ethtypes.HexUint64        `json:"nonce"`
 }
 
@@ -50,6 +51,12 @@ type TXReceiptJSONRPC struct {
 	RevertReason      ethtypes.HexBytes0xPrefix `json:"revertReason"`
 }
 
+// We only query logs for a single block, by hash, so that the results are consistent
+// with the block even if there is a re-org
+type ethGetLogsFilter struct {
+	BlockHash ethtypes.HexBytes0xPrefix `json:"blockHash"`
+}
+
 type LogJSONRPC struct {
 	Removed          bool                        `json:"removed"`
 	LogIndex         ethtypes.HexUint64          `json:"logIndex"`
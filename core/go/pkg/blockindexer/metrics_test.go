@@ -0,0 +1,67 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	_, bi, _, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+
+	collector := bi.MetricsCollector()
+
+	// Nothing known about the chain yet
+	err := testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP paladin_blockindexer_event_streams Number of event streams loaded into the block indexer
+# TYPE paladin_blockindexer_event_streams gauge
+paladin_blockindexer_event_streams 0
+`))
+	require.NoError(t, err)
+
+	bi.blockListener.highestBlock = 5
+	close(bi.blockListener.initialBlockHeightObtained)
+	bi.highestConfirmedBlock.Store(7) // confirmed can briefly be ahead of our view of the head
+	assert.Equal(t, 4, testutil.CollectAndCount(collector))
+	err = testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP paladin_blockindexer_lag_blocks Number of blocks the indexer is behind the head of the chain
+# TYPE paladin_blockindexer_lag_blocks gauge
+paladin_blockindexer_lag_blocks 0
+`), "paladin_blockindexer_lag_blocks")
+	require.NoError(t, err)
+
+	bi.blockListener.highestBlock = 10
+	err = testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP paladin_blockindexer_chain_head_block Highest block number notified by the blockchain node
+# TYPE paladin_blockindexer_chain_head_block gauge
+paladin_blockindexer_chain_head_block 10
+# HELP paladin_blockindexer_confirmed_block Highest block number indexed with the required number of confirmations
+# TYPE paladin_blockindexer_confirmed_block gauge
+paladin_blockindexer_confirmed_block 7
+# HELP paladin_blockindexer_lag_blocks Number of blocks the indexer is behind the head of the chain
+# TYPE paladin_blockindexer_lag_blocks gauge
+paladin_blockindexer_lag_blocks 3
+`), "paladin_blockindexer_chain_head_block", "paladin_blockindexer_confirmed_block", "paladin_blockindexer_lag_blocks")
+	require.NoError(t, err)
+}
@@ -0,0 +1,124 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// fetchReceipts returns the receipts for all the transactions in a block, in the order of the
+// transactions in the block, using the configured strategy. Whichever strategy is used, the
+// receipts contain everything needed to build the indexed transactions and events.
+//
+// A nil list with no error is returned if the node returned null for any of the receipts.
+func (bi *blockIndexer) fetchReceipts(ctx context.Context, block *BlockInfoJSONRPC) (receipts []*TXReceiptJSONRPC, rpcErr rpcclient.ErrorRPC) {
+	switch bi.receiptFetchStrategy {
+	case pldconf.ReceiptFetchStrategyPerTransaction:
+		receipts = make([]*TXReceiptJSONRPC, len(block.Transactions))
+		txIndexes := make([]int, len(block.Transactions))
+		for i := range txIndexes {
+			txIndexes[i] = i
+		}
+		rpcErr = bi.fetchTransactionReceipts(ctx, block, receipts, txIndexes)
+	case pldconf.ReceiptFetchStrategyLogsOnly:
+		receipts, rpcErr = bi.fetchReceiptsFromLogs(ctx, block)
+	default:
+		// We use eth_getBlockReceipts, which takes either a number or a hash (supported by Besu and go-ethereum)
+		rpcErr = bi.rpcConn.CallRPC(ctx, &receipts, "eth_getBlockReceipts", block.Hash)
+	}
+	if rpcErr != nil || slices.Contains(receipts, nil) {
+		return nil, rpcErr
+	}
+	return receipts, nil
+}
+
+// fetchTransactionReceipts queries the receipts for the specified transactions in a block in parallel,
+// with the number in flight across all blocks limited by the configured concurrency
+func (bi *blockIndexer) fetchTransactionReceipts(ctx context.Context, block *BlockInfoJSONRPC, receipts []*TXReceiptJSONRPC, txIndexes []int) rpcclient.ErrorRPC {
+	var wg sync.WaitGroup
+	rpcErrs := make([]rpcclient.ErrorRPC, len(txIndexes))
+	for i, txIndex := range txIndexes {
+		select {
+		case bi.receiptFetchSlots <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, ctx.Err())
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-bi.receiptFetchSlots
+				wg.Done()
+			}()
+			rpcErrs[i] = bi.rpcConn.CallRPC(ctx, &receipts[txIndex], "eth_getTransactionReceipt", block.Transactions[txIndex].Hash)
+		}()
+	}
+	wg.Wait()
+	for _, rpcErr := range rpcErrs {
+		if rpcErr != nil {
+			return rpcErr
+		}
+	}
+	return nil
+}
+
+// fetchReceiptsFromLogs builds the receipts from the block, and the logs queried for the block.
+// A transaction that emitted logs cannot have reverted, but we still need to query the receipts
+// of transactions that emitted no logs to know if they succeeded, and of contract deployments
+// to know the address of the contract.
+func (bi *blockIndexer) fetchReceiptsFromLogs(ctx context.Context, block *BlockInfoJSONRPC) ([]*TXReceiptJSONRPC, rpcclient.ErrorRPC) {
+	var logs []*LogJSONRPC
+	rpcErr := bi.rpcConn.CallRPC(ctx, &logs, "eth_getLogs", &ethGetLogsFilter{BlockHash: block.Hash})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	txLogs := make(map[string][]*LogJSONRPC)
+	for _, l := range logs {
+		txHash := l.TransactionHash.String()
+		txLogs[txHash] = append(txLogs[txHash], l)
+	}
+
+	receipts := make([]*TXReceiptJSONRPC, len(block.Transactions))
+	var needReceipts []int
+	for i, tx := range block.Transactions {
+		emitted := txLogs[tx.Hash.String()]
+		if len(emitted) == 0 || tx.To == nil {
+			needReceipts = append(needReceipts, i)
+			continue
+		}
+		receipts[i] = &TXReceiptJSONRPC{
+			BlockHash:        block.Hash,
+			BlockNumber:      block.Number,
+			From:             tx.From,
+			To:               tx.To,
+			Logs:             emitted,
+			Status:           ethtypes.NewHexInteger64(1),
+			TransactionHash:  tx.Hash,
+			TransactionIndex: ethtypes.NewHexInteger64(int64(i)),
+		}
+	}
+	log.L(ctx).Debugf("Block %d/%s has %d logs, querying %d of %d receipts", block.Number, block.Hash, len(logs), len(needReceipts), len(block.Transactions))
+	return receipts, bi.fetchTransactionReceipts(ctx, block, receipts, needReceipts)
+}
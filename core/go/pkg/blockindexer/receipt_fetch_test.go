@@ -0,0 +1,234 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func indexWithReceiptFetchStrategy(t *testing.T, strategy string, blocks []*BlockInfoJSONRPC, receipts map[string][]*TXReceiptJSONRPC) ([]*IndexedTransactionNotify, []*pldapi.IndexedEvent) {
+	ctx, bi, mRPC, blDone := newTestBlockIndexerConf(t, &pldconf.BlockIndexerConfig{
+		CommitBatchSize: confutil.P(1),
+		FromBlock:       json.RawMessage(`0`),
+		ReceiptFetch: pldconf.ReceiptFetchConfig{
+			Strategy:    strategy,
+			Concurrency: confutil.P(1),
+		},
+	})
+	defer blDone()
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+	bi.requiredConfirmations = 0
+
+	utBatchNotify := make(chan []*IndexedTransactionNotify)
+	bi.preCommitHandlers = append(bi.preCommitHandlers, func(ctx context.Context, dbTX persistence.DBTX, blocks []*pldapi.IndexedBlock, transactions []*IndexedTransactionNotify) error {
+		dbTX.AddPostCommit(func(txCtx context.Context) { utBatchNotify <- transactions })
+		return nil
+	})
+
+	bi.startOrReset() // do not start block listener
+
+	var transactions []*IndexedTransactionNotify
+	for range blocks {
+		transactions = append(transactions, <-utBatchNotify...)
+	}
+	events, err := bi.QueryIndexedEvents(ctx, query.NewQueryBuilder().Limit(100).Sort("blockNumber", "transactionIndex", "logIndex").Query())
+	require.NoError(t, err)
+	return transactions, events
+}
+
+func TestReceiptFetchStrategiesIndexTheSame(t *testing.T) {
+	blocks, receipts := testBlockArray(t, 5)
+
+	// Add a transaction to one block that reverted, so has no logs
+	failedTxHash := ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32))
+	failedTx := &PartialTransactionInfo{
+		Hash:  failedTxHash,
+		From:  ethtypes.MustNewAddress(pldtypes.RandHex(20)),
+		To:    ethtypes.MustNewAddress(pldtypes.RandHex(20)),
+		Nonce: 12345,
+	}
+	blocks[2].Transactions = append(blocks[2].Transactions, failedTx)
+	receipts[blocks[2].Hash.String()] = append(receipts[blocks[2].Hash.String()], &TXReceiptJSONRPC{
+		TransactionHash: failedTxHash,
+		From:            failedTx.From,
+		To:              failedTx.To,
+		BlockNumber:     blocks[2].Number,
+		BlockHash:       blocks[2].Hash,
+		Status:          ethtypes.NewHexInteger64(0),
+		RevertReason:    ethtypes.MustNewHexBytes0xPrefix("0x08c379a0"),
+	})
+
+	expectedTxns, expectedEvents := indexWithReceiptFetchStrategy(t, pldconf.ReceiptFetchStrategyBlockReceipts, blocks, receipts)
+	require.Len(t, expectedTxns, 6)
+	require.Len(t, expectedEvents, 15)
+	assert.Equal(t, pldapi.TXResult_FAILURE, expectedTxns[3].Result.V())
+	assert.NotNil(t, expectedTxns[0].ContractAddress)
+
+	for _, strategy := range []string{
+		pldconf.ReceiptFetchStrategyPerTransaction,
+		pldconf.ReceiptFetchStrategyLogsOnly,
+	} {
+		t.Run(strategy, func(t *testing.T) {
+			txns, events := indexWithReceiptFetchStrategy(t, strategy, blocks, receipts)
+			assert.Equal(t, expectedTxns, txns)
+			assert.Equal(t, expectedEvents, events)
+		})
+	}
+}
+
+func TestReceiptFetchInvalidStrategy(t *testing.T) {
+	ctx, bl, _, done := newTestBlockListener(t)
+	defer done()
+	_, err := newBlockIndexer(ctx, &pldconf.BlockIndexerConfig{
+		ReceiptFetch: pldconf.ReceiptFetchConfig{Strategy: "wrong"},
+	}, nil, bl)
+	assert.Regexp(t, "PD011313.*wrong", err)
+}
+
+func newTestReceiptFetchBlock(txCount int) *BlockInfoJSONRPC {
+	block := &BlockInfoJSONRPC{
+		Number: 1,
+		Hash:   ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+	}
+	for i := 0; i < txCount; i++ {
+		block.Transactions = append(block.Transactions, &PartialTransactionInfo{
+			Hash: ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+			To:   ethtypes.MustNewAddress(pldtypes.RandHex(20)),
+		})
+	}
+	return block
+}
+
+func TestFetchTransactionReceiptsConcurrent(t *testing.T) {
+	ctx, bi, mRPC, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{
+		ReceiptFetch: pldconf.ReceiptFetchConfig{
+			Strategy:    pldconf.ReceiptFetchStrategyPerTransaction,
+			Concurrency: confutil.P(2),
+		},
+	})
+	defer done()
+	assert.Equal(t, 2, cap(bi.receiptFetchSlots))
+
+	block := newTestReceiptFetchBlock(5)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**TXReceiptJSONRPC)) = &TXReceiptJSONRPC{TransactionHash: args[3].(ethtypes.HexBytes0xPrefix)}
+		})
+
+	receipts, rpcErr := bi.fetchReceipts(ctx, block)
+	require.NoError(t, rpcErr)
+	require.Len(t, receipts, 5)
+	for i, r := range receipts {
+		assert.Equal(t, block.Transactions[i].Hash, r.TransactionHash)
+	}
+	assert.Empty(t, bi.receiptFetchSlots)
+}
+
+func TestFetchTransactionReceiptsErrors(t *testing.T) {
+	ctx, bi, mRPC, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{
+		ReceiptFetch: pldconf.ReceiptFetchConfig{
+			Strategy: pldconf.ReceiptFetchStrategyPerTransaction,
+		},
+	})
+	defer done()
+
+	block := newTestReceiptFetchBlock(2)
+
+	// A null receipt results in a nil list, so the block is retried
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", block.Transactions[0].Hash).Return(nil)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", block.Transactions[1].Hash).
+		Return(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop"))).Once()
+	receipts, rpcErr := bi.fetchReceipts(ctx, block)
+	assert.Regexp(t, "pop", rpcErr)
+	assert.Nil(t, receipts)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", block.Transactions[1].Hash).Return(nil)
+	receipts, rpcErr = bi.fetchReceipts(ctx, block)
+	assert.Nil(t, rpcErr)
+	assert.Nil(t, receipts)
+
+	// Cancelled while waiting for a slot
+	bi.receiptFetchSlots = make(chan struct{})
+	cancelledCtx, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+	_, rpcErr = bi.fetchReceipts(cancelledCtx, block)
+	assert.Regexp(t, "context canceled", rpcErr)
+}
+
+func TestFetchReceiptsFromLogs(t *testing.T) {
+	ctx, bi, mRPC, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{
+		ReceiptFetch: pldconf.ReceiptFetchConfig{
+			Strategy: pldconf.ReceiptFetchStrategyLogsOnly,
+		},
+	})
+	defer done()
+
+	block := newTestReceiptFetchBlock(3)
+	block.Transactions[2].To = nil // deploy
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", &ethGetLogsFilter{BlockHash: block.Hash}).
+		Return(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop"))).Once()
+	_, rpcErr := bi.fetchReceipts(ctx, block)
+	assert.Regexp(t, "pop", rpcErr)
+
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getLogs", &ethGetLogsFilter{BlockHash: block.Hash}).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(*[]*LogJSONRPC)) = []*LogJSONRPC{
+				{TransactionHash: block.Transactions[0].Hash, LogIndex: 0},
+				{TransactionHash: block.Transactions[0].Hash, LogIndex: 1},
+				{TransactionHash: block.Transactions[2].Hash, LogIndex: 2},
+			}
+		})
+	// Only the transaction with no logs, and the deploy, need their receipts
+	contractAddress := ethtypes.MustNewAddress(pldtypes.RandHex(20))
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", block.Transactions[1].Hash).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**TXReceiptJSONRPC)) = &TXReceiptJSONRPC{Status: ethtypes.NewHexInteger64(0)}
+		})
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getTransactionReceipt", block.Transactions[2].Hash).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			*(args[1].(**TXReceiptJSONRPC)) = &TXReceiptJSONRPC{Status: ethtypes.NewHexInteger64(1), ContractAddress: contractAddress}
+		})
+
+	receipts, rpcErr := bi.fetchReceipts(ctx, block)
+	require.NoError(t, rpcErr)
+	require.Len(t, receipts, 3)
+	assert.Len(t, receipts[0].Logs, 2)
+	assert.Equal(t, int64(1), receipts[0].Status.BigInt().Int64())
+	assert.Equal(t, block.Transactions[0].To, receipts[0].To)
+	assert.Equal(t, int64(0), receipts[1].Status.BigInt().Int64())
+	assert.Equal(t, contractAddress, receipts[2].ContractAddress)
+}
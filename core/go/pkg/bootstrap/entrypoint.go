@@ -15,7 +15,11 @@
 
 package bootstrap
 
-import "sync/atomic"
+import (
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
 
 var running atomic.Pointer[instance]
 
@@ -27,6 +31,18 @@ func Run(grpcTarget, loaderUUID, configFile, runMode string) RC {
 	return inst.run()
 }
 
+// RunStandalone runs Paladin as a self contained Go process, using the built-in Go plugin loader
+// rather than relying on the JVM to host Paladin and load the plugins.
+// If grpcTarget is empty a socket file is allocated in the configured tempDir.
+func RunStandalone(grpcTarget, configFile, runMode string) RC {
+	inst := newInstance(grpcTarget, uuid.New().String(), configFile, runMode)
+	inst.goPluginLoader = true
+	if !running.CompareAndSwap(nil, inst) {
+		panic("double started")
+	}
+	return inst.run()
+}
+
 func Stop() {
 	inst := running.Load()
 	if inst != nil {
@@ -17,6 +17,7 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path"
 	"testing"
@@ -25,6 +26,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/componentmgr"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/plugins"
 	"github.com/kaleido-io/paladin/core/mocks/componentmocks"
 
 	"github.com/stretchr/testify/assert"
@@ -97,3 +99,91 @@ func TestEntrypointBadMode(t *testing.T) {
 	require.Equal(t, RC_FAIL, rc)
 
 }
+
+type testGoPluginLoader struct {
+	startErr error
+	stopped  chan struct{}
+}
+
+func (tl *testGoPluginLoader) Start() error {
+	return tl.startErr
+}
+
+func (tl *testGoPluginLoader) Stop() {
+	close(tl.stopped)
+}
+
+func setupStandaloneTestConfig(t *testing.T, loader *testGoPluginLoader, mockers ...func(mockCM *componentmocks.ComponentManager)) (configFile string, done func()) {
+	origCMFactory := componentManagerFactory
+	origLoaderFactory := goPluginLoaderFactory
+	mockCM := componentmocks.NewComponentManager(t)
+	mockPM := componentmocks.NewPluginManager(t)
+	tempDir := t.TempDir()
+	componentManagerFactory = func(bgCtx context.Context, grpcTarget string, instanceUUID uuid.UUID, conf *pldconf.PaladinConfig, additionalManagers ...components.AdditionalManager) componentmgr.ComponentManager {
+		assert.Equal(t, fmt.Sprintf("unix:%s/p.%d.sock", tempDir, os.Getpid()), grpcTarget)
+		assert.NotEqual(t, uuid.Nil, instanceUUID)
+		return mockCM
+	}
+	goPluginLoaderFactory = func(bgCtx context.Context, pm components.PluginManager) plugins.GoPluginLoader {
+		assert.Equal(t, mockPM, pm)
+		return loader
+	}
+	mockCM.On("PluginManager").Return(mockPM).Maybe()
+	for _, mocker := range mockers {
+		mocker(mockCM)
+	}
+	configFile = path.Join(t.TempDir(), "paladin.conf.yaml")
+	err := os.WriteFile(configFile, []byte(fmt.Sprintf(`{
+	  "tempDir": %q,
+	  "blockchain": { "http": { "url": "http://localhost:8545" } }
+	}`, tempDir)), 0664)
+	require.NoError(t, err)
+	return configFile, func() {
+		componentManagerFactory = origCMFactory
+		goPluginLoaderFactory = origLoaderFactory
+	}
+}
+
+func TestRunStandaloneOK(t *testing.T) {
+
+	cmStarted := make(chan struct{})
+	loader := &testGoPluginLoader{stopped: make(chan struct{})}
+	configFile, done := setupStandaloneTestConfig(t, loader, func(mockCM *componentmocks.ComponentManager) {
+		mockCM.On("Init").Return(nil)
+		mockCM.On("StartManagers").Return(nil)
+		mockCM.On("CompleteStart").Return(nil).Run(func(args mock.Arguments) {
+			close(cmStarted)
+		})
+		mockCM.On("Stop").Return().Run(func(args mock.Arguments) {
+			// the plugins are stopped before the component manager
+			<-loader.stopped
+		})
+	})
+	defer done()
+
+	completed := make(chan RC)
+	go func() {
+		completed <- RunStandalone("", configFile, "engine")
+	}()
+
+	<-cmStarted
+
+	Stop()
+	assert.Equal(t, RC_OK, <-completed)
+
+}
+
+func TestRunStandaloneLoaderStartFail(t *testing.T) {
+
+	loader := &testGoPluginLoader{startErr: fmt.Errorf("pop")}
+	configFile, done := setupStandaloneTestConfig(t, loader, func(mockCM *componentmocks.ComponentManager) {
+		mockCM.On("Init").Return(nil)
+		mockCM.On("StartManagers").Return(nil)
+		mockCM.On("Stop").Return()
+	})
+	defer done()
+
+	rc := RunStandalone("", configFile, "engine")
+	assert.Equal(t, RC_FAIL, rc)
+
+}
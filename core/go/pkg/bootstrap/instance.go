@@ -17,18 +17,22 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
 	"sync/atomic"
 	"syscall"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/componentmgr"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/internal/plugins"
 	"github.com/kaleido-io/paladin/core/pkg/config"
 	"github.com/kaleido-io/paladin/core/pkg/testbed"
 
@@ -36,6 +40,7 @@ import (
 )
 
 var componentManagerFactory = componentmgr.NewComponentManager
+var goPluginLoaderFactory = plugins.NewGoPluginLoader
 
 type instance struct {
 	grpcTarget string
@@ -43,6 +48,8 @@ type instance struct {
 	configFile string
 	runMode    string
 
+	goPluginLoader bool
+
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 	signals   chan os.Signal
@@ -98,6 +105,12 @@ func (i *instance) run() RC {
 		return RC_FAIL
 	}
 
+	if i.grpcTarget == "" {
+		// Same naming as the JVM loader uses, to make the socket file unique to this process
+		tempDir := confutil.StringNotEmpty(conf.TempDir, os.TempDir())
+		i.grpcTarget = "unix:" + filepath.Join(tempDir, fmt.Sprintf("p.%d.sock", os.Getpid()))
+	}
+
 	var additionalManagers []components.AdditionalManager
 	switch i.runMode {
 	case "testbed":
@@ -118,6 +131,15 @@ func (i *instance) run() RC {
 		// Managers start first - so they are ready to process
 		err = cm.StartManagers()
 	}
+	if err == nil && i.goPluginLoader {
+		// The plugin manager is now listening, so the loader can connect to receive load instructions
+		loader := goPluginLoaderFactory(i.ctx, cm.PluginManager())
+		err = loader.Start()
+		if err == nil {
+			// Stops the plugins before the component manager stops
+			defer loader.Stop()
+		}
+	}
 	if err == nil {
 		// Then finally the active processing is started:
 		// - The block indexer starts indexing
@@ -49,6 +49,7 @@ type EthClient interface {
 	ChainID() int64
 
 	GasPrice(ctx context.Context) (gasPrice *pldtypes.HexUint256, err error)
+	FeeHistory(ctx context.Context, blockCount int, newestBlock string, rewardPercentiles []float64) (*FeeHistoryResult, error)
 	GetBalance(ctx context.Context, address pldtypes.EthAddress, block string) (balance *pldtypes.HexUint256, err error)
 	GetTransactionReceipt(ctx context.Context, txHash string) (*TransactionReceiptResponse, error)
 
@@ -115,6 +116,13 @@ type EstimateGasResult struct {
 	RevertData pldtypes.HexBytes
 }
 
+type FeeHistoryResult struct {
+	OldestBlock   pldtypes.HexUint64       `json:"oldestBlock"`
+	BaseFeePerGas []*pldtypes.HexUint256   `json:"baseFeePerGas"` // includes the base fee of the block after the newest block
+	GasUsedRatio  []float64                `json:"gasUsedRatio"`
+	Reward        [][]*pldtypes.HexUint256 `json:"reward"` // for each block, the priority fee at each of the requested percentiles
+}
+
 type CallResult struct {
 	serializer    *abi.Serializer
 	Data          pldtypes.HexBytes
@@ -138,7 +146,7 @@ func (cr CallResult) JSON() (s string) {
 }
 
 type KeyManager interface {
-	AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) // should only be called on initialization routine
+	AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error // should only be called on initialization routine
 	ResolveKey(ctx context.Context, identifier, algorithm, verifierType string) (keyHandle, verifier string, err error)
 	Sign(ctx context.Context, req *signerapi.SignRequest) (*signerapi.SignResponse, error)
 	Close()
@@ -297,6 +305,16 @@ func (ec *ethClient) GasPrice(ctx context.Context) (*pldtypes.HexUint256, error)
 	return &gasPrice, nil
 }
 
+func (ec *ethClient) FeeHistory(ctx context.Context, blockCount int, newestBlock string, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	var feeHistory FeeHistoryResult
+
+	if rpcErr := ec.rpc.CallRPC(ctx, &feeHistory, "eth_feeHistory", pldtypes.HexUint64(blockCount), newestBlock, rewardPercentiles); rpcErr != nil {
+		log.L(ctx).Errorf("eth_feeHistory failed: %+v", rpcErr)
+		return nil, rpcErr
+	}
+	return &feeHistory, nil
+}
+
 func (ec *ethClient) GetTransactionReceipt(ctx context.Context, txHash string) (*TransactionReceiptResponse, error) {
 
 	// Get the receipt in the back-end JSON/RPC format
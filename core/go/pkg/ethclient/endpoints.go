@@ -0,0 +1,537 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// endpoint is one of the blockchain nodes the factory can connect to
+type endpoint struct {
+	index   int
+	httpURL string
+	wsURL   string
+	httpRPC rpcclient.Client
+	wsConf  *wsclient.WSConfig
+
+	healthy         bool
+	blockNumber     uint64
+	lastBlockChange time.Time
+	lastChecked     *pldtypes.Timestamp
+	lastError       error
+}
+
+// endpointSet tracks the health of an ordered list of endpoints, and which one is active.
+//
+// All HTTP and WebSocket traffic goes to the active endpoint, and we only move away from it
+// when it becomes unhealthy - we do not fail back to an earlier endpoint in the list when it
+// recovers. This keeps transaction submission sticky to one node, so the nonces we assign are
+// consistent with the transaction pool we submit into.
+type endpointSet struct {
+	endpoints     []*endpoint
+	interval      time.Duration
+	timeout       time.Duration
+	maxBlockLag   uint64
+	staleHead     time.Duration
+	newWSRPC      func(*wsclient.WSConfig) rpcclient.WSClient
+	mux           sync.Mutex
+	active        int
+	chainID       int64
+	listeners     []func()
+	healthLoopCtx context.Context
+	cancelCtx     context.CancelFunc
+	loopDone      chan struct{}
+}
+
+func newEndpointSet(ctx context.Context, conf *pldconf.EthClientConfig) (es *endpointSet, err error) {
+	hcConf := &conf.HealthCheck
+	es = &endpointSet{
+		interval:    confutil.DurationMin(hcConf.Interval, 100*time.Millisecond, *pldconf.EthClientDefaults.HealthCheck.Interval),
+		timeout:     confutil.DurationMin(hcConf.Timeout, 1*time.Millisecond, *pldconf.EthClientDefaults.HealthCheck.Timeout),
+		maxBlockLag: uint64(confutil.IntMin(hcConf.MaxBlockLag, 0, *pldconf.EthClientDefaults.HealthCheck.MaxBlockLag)),
+		staleHead:   confutil.DurationMin(hcConf.StaleHead, 0, *pldconf.EthClientDefaults.HealthCheck.StaleHead),
+		newWSRPC:    func(wsConf *wsclient.WSConfig) rpcclient.WSClient { return rpcclient.WrapWSConfig(wsConf) },
+		chainID:     -1,
+	}
+
+	// The single ws/http pair is used when there is no list of endpoints
+	if len(conf.Endpoints) == 0 {
+		ep, err := newEndpoint(ctx, 0, &conf.HTTP, &conf.WS)
+		if err != nil {
+			return nil, err
+		}
+		es.endpoints = []*endpoint{ep}
+		return es, nil
+	}
+	for i := range conf.Endpoints {
+		ep, err := newEndpoint(ctx, i, &conf.Endpoints[i].HTTP, &conf.Endpoints[i].WS)
+		if err != nil {
+			return nil, err
+		}
+		es.endpoints = append(es.endpoints, ep)
+	}
+	return es, nil
+}
+
+func newEndpoint(ctx context.Context, index int, httpConf *pldconf.HTTPClientConfig, wsConf *pldconf.WSClientConfig) (ep *endpoint, err error) {
+	ep = &endpoint{
+		index:   index,
+		httpURL: httpConf.URL,
+		healthy: true, // until we find otherwise
+	}
+	// Parse the HTTP and build the HTTP client - we only have one of these per endpoint
+	// as within the HTTP client there are as many connections as required for parallelism
+	if httpConf.URL == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgEthClientHTTPURLMissing)
+	}
+	if ep.httpRPC, err = rpcclient.NewHTTPClient(ctx, httpConf); err != nil {
+		return nil, err
+	}
+
+	// Move onto WS, which can re-use the HTTP URL if required
+	if wsConf.URL == "" {
+		noHTTPPrefix, trimmed := strings.CutPrefix(httpConf.URL, "http")
+		if trimmed {
+			wsConf.URL = "ws" + noHTTPPrefix
+		}
+	}
+	ep.wsURL = wsConf.URL
+	if ep.wsConf, err = rpcclient.ParseWSConfig(ctx, wsConf); err != nil {
+		return nil, err
+	}
+	return ep, nil
+}
+
+func (es *endpointSet) activeEndpoint() *endpoint {
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	return es.endpoints[es.active]
+}
+
+func (es *endpointSet) setActive(index int) {
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	es.active = index
+}
+
+// addListener registers a function that is called after each health check, so that
+// long lived connections can follow the active endpoint
+func (es *endpointSet) addListener(fn func()) {
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	es.listeners = append(es.listeners, fn)
+}
+
+func (es *endpointSet) start(ctx context.Context, chainID int64) {
+	es.mux.Lock()
+	es.chainID = chainID
+	es.mux.Unlock()
+
+	if es.loopDone == nil {
+		es.healthLoopCtx, es.cancelCtx = context.WithCancel(log.WithLogField(ctx, "role", "ethclient-healthcheck"))
+		es.loopDone = make(chan struct{})
+		go es.healthCheckLoop()
+	}
+}
+
+func (es *endpointSet) stop() {
+	if es.loopDone != nil {
+		es.cancelCtx()
+		<-es.loopDone
+		es.loopDone = nil
+	}
+}
+
+func (es *endpointSet) healthCheckLoop() {
+	defer close(es.loopDone)
+
+	ticker := time.NewTicker(es.interval)
+	defer ticker.Stop()
+	for {
+		es.checkHealth(es.healthLoopCtx)
+		select {
+		case <-ticker.C:
+		case <-es.healthLoopCtx.Done():
+			log.L(es.healthLoopCtx).Debugf("Health check loop exiting")
+			return
+		}
+	}
+}
+
+func (es *endpointSet) queryEndpoint(ctx context.Context, ep *endpoint, chainID int64) (uint64, error) {
+	ctx, cancelCtx := context.WithTimeout(ctx, es.timeout)
+	defer cancelCtx()
+
+	var epChainID pldtypes.HexUint64
+	if rpcErr := ep.httpRPC.CallRPC(ctx, &epChainID, "eth_chainId"); rpcErr != nil {
+		return 0, rpcErr
+	}
+	if int64(epChainID) != chainID {
+		return 0, i18n.NewError(ctx, msgs.MsgEthClientEndpointChainID, ep.httpURL, epChainID.Uint64(), chainID)
+	}
+	var blockNumber pldtypes.HexUint64
+	if rpcErr := ep.httpRPC.CallRPC(ctx, &blockNumber, "eth_blockNumber"); rpcErr != nil {
+		return 0, rpcErr
+	}
+	return blockNumber.Uint64(), nil
+}
+
+func (es *endpointSet) checkHealth(ctx context.Context) {
+	es.mux.Lock()
+	chainID := es.chainID
+	es.mux.Unlock()
+
+	// Query all the endpoints in parallel before taking the lock, so one that is slow to respond
+	// (up to the timeout) does not hold up checking the others
+	blockNumbers := make([]uint64, len(es.endpoints))
+	errors := make([]error, len(es.endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range es.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			blockNumbers[i], errors[i] = es.queryEndpoint(ctx, ep, chainID)
+		}()
+	}
+	wg.Wait()
+	var highest uint64
+	for i := range es.endpoints {
+		if errors[i] == nil && blockNumbers[i] > highest {
+			highest = blockNumbers[i]
+		}
+	}
+
+	es.mux.Lock()
+	now := time.Now()
+	checked := pldtypes.TimestampNow()
+	for i, ep := range es.endpoints {
+		err := errors[i]
+		if err == nil {
+			if blockNumbers[i] != ep.blockNumber || ep.lastBlockChange.IsZero() {
+				ep.blockNumber = blockNumbers[i]
+				ep.lastBlockChange = now
+			}
+			if highest-ep.blockNumber > es.maxBlockLag {
+				err = i18n.NewError(ctx, msgs.MsgEthClientEndpointBehind, ep.httpURL, ep.blockNumber, es.maxBlockLag, highest)
+			} else if es.staleHead > 0 && now.Sub(ep.lastBlockChange) > es.staleHead {
+				err = i18n.NewError(ctx, msgs.MsgEthClientEndpointStaleHead, ep.httpURL, ep.blockNumber, es.staleHead)
+			}
+		}
+		if err != nil && ep.healthy {
+			log.L(ctx).Warnf("Endpoint %d (%s) is unhealthy: %s", ep.index, ep.httpURL, err)
+		} else if err == nil && !ep.healthy {
+			log.L(ctx).Infof("Endpoint %d (%s) is healthy at block %d", ep.index, ep.httpURL, ep.blockNumber)
+		}
+		ep.healthy = err == nil
+		ep.lastError = err
+		ep.lastChecked = &checked
+	}
+	if current := es.endpoints[es.active]; !current.healthy {
+		for _, ep := range es.endpoints {
+			if ep.healthy {
+				log.L(ctx).Warnf("Failing over from endpoint %d (%s) to endpoint %d (%s)", current.index, current.httpURL, ep.index, ep.httpURL)
+				es.active = ep.index
+				break
+			}
+		}
+	}
+	listeners := es.listeners
+	es.mux.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+func (es *endpointSet) status() *pldapi.BlockchainStatus {
+	es.mux.Lock()
+	defer es.mux.Unlock()
+	status := &pldapi.BlockchainStatus{
+		ChainID:        es.chainID,
+		ActiveEndpoint: es.active,
+		Endpoints:      make([]*pldapi.BlockchainEndpointStatus, len(es.endpoints)),
+	}
+	for i, ep := range es.endpoints {
+		epStatus := &pldapi.BlockchainEndpointStatus{
+			HTTP:        ep.httpURL,
+			WS:          ep.wsURL,
+			Active:      i == es.active,
+			Healthy:     ep.healthy,
+			BlockNumber: pldtypes.HexUint64(ep.blockNumber),
+			LastChecked: ep.lastChecked,
+		}
+		if ep.lastError != nil {
+			epStatus.Error = ep.lastError.Error()
+		}
+		status.Endpoints[i] = epStatus
+	}
+	return status
+}
+
+// failoverRPC sends each HTTP JSON/RPC call to whichever endpoint is active
+type failoverRPC struct {
+	es *endpointSet
+}
+
+func (fr *failoverRPC) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) rpcclient.ErrorRPC {
+	return fr.es.activeEndpoint().httpRPC.CallRPC(ctx, result, method, params...)
+}
+
+// failoverWS is a WebSocket client that follows the active endpoint. The underlying client
+// reconnects to the same node by itself, and re-establishes the subscriptions - but when the
+// active endpoint changes we connect to the new one, move the subscriptions across to it,
+// and close the old connection.
+type failoverWS struct {
+	es         *endpointSet
+	mux        sync.Mutex
+	ctx        context.Context
+	current    rpcclient.WSClient
+	endpoint   int
+	subs       []*failoverSub
+	registered bool
+	closed     bool
+}
+
+type failoverSub struct {
+	fw            *failoverWS
+	localID       uuid.UUID
+	conf          rpcclient.SubscriptionConfig
+	params        []interface{}
+	underlying    rpcclient.Subscription
+	stopForward   chan struct{}
+	forwardDone   chan struct{}
+	notifications chan rpcclient.RPCSubscriptionNotification
+}
+
+func newFailoverWS(es *endpointSet) *failoverWS {
+	return &failoverWS{es: es}
+}
+
+func (fw *failoverWS) Connect(ctx context.Context) error {
+	ep := fw.es.activeEndpoint()
+	wsRPC := fw.es.newWSRPC(ep.wsConf)
+	if err := wsRPC.Connect(ctx); err != nil {
+		return err
+	}
+
+	fw.mux.Lock()
+	defer fw.mux.Unlock()
+	if fw.current != nil {
+		fw.current.Close()
+	}
+	fw.ctx = ctx
+	fw.current = wsRPC
+	fw.endpoint = ep.index
+	fw.closed = false
+	if !fw.registered {
+		fw.es.addListener(fw.followActiveEndpoint)
+		fw.registered = true
+	}
+	return nil
+}
+
+func (fw *failoverWS) getCurrent(ctx context.Context) (rpcclient.WSClient, rpcclient.ErrorRPC) {
+	fw.mux.Lock()
+	defer fw.mux.Unlock()
+	if fw.current == nil || fw.closed {
+		return nil, rpcclient.NewRPCError(ctx, rpcclient.RPCCodeInternalError, msgs.MsgEthClientNoConnection)
+	}
+	return fw.current, nil
+}
+
+func (fw *failoverWS) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) rpcclient.ErrorRPC {
+	wsRPC, rpcErr := fw.getCurrent(ctx)
+	if rpcErr != nil {
+		return rpcErr
+	}
+	return wsRPC.CallRPC(ctx, result, method, params...)
+}
+
+func (fw *failoverWS) Subscribe(ctx context.Context, conf rpcclient.SubscriptionConfig, params ...interface{}) (rpcclient.Subscription, rpcclient.ErrorRPC) {
+	wsRPC, rpcErr := fw.getCurrent(ctx)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	underlying, rpcErr := wsRPC.Subscribe(ctx, conf, params...)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fs := &failoverSub{
+		fw:            fw,
+		localID:       underlying.LocalID(),
+		conf:          conf,
+		params:        params,
+		notifications: make(chan rpcclient.RPCSubscriptionNotification),
+	}
+	fs.forward(underlying)
+
+	fw.mux.Lock()
+	defer fw.mux.Unlock()
+	fw.subs = append(fw.subs, fs)
+	return fs, nil
+}
+
+func (fw *failoverWS) Subscriptions() []rpcclient.Subscription {
+	fw.mux.Lock()
+	defer fw.mux.Unlock()
+	subs := make([]rpcclient.Subscription, len(fw.subs))
+	for i, fs := range fw.subs {
+		subs[i] = fs
+	}
+	return subs
+}
+
+func (fw *failoverWS) UnsubscribeAll(ctx context.Context) rpcclient.ErrorRPC {
+	for _, sub := range fw.Subscriptions() {
+		if rpcErr := sub.Unsubscribe(ctx); rpcErr != nil {
+			return rpcErr
+		}
+	}
+	return nil
+}
+
+func (fw *failoverWS) Close() {
+	fw.mux.Lock()
+	defer fw.mux.Unlock()
+	fw.closed = true
+	for _, fs := range fw.subs {
+		fs.stopForwarding()
+	}
+	if fw.current != nil {
+		fw.current.Close()
+	}
+}
+
+// followActiveEndpoint is called after each health check, and moves the connection and all the
+// subscriptions to the active endpoint if it has changed. If we fail to connect or subscribe we
+// stay where we are, and try again after the next health check.
+func (fw *failoverWS) followActiveEndpoint() {
+	fw.mux.Lock()
+	ep := fw.es.activeEndpoint()
+	if fw.closed || fw.current == nil || ep.index == fw.endpoint {
+		fw.mux.Unlock()
+		return
+	}
+	ctx := fw.ctx
+	subs := append([]*failoverSub{}, fw.subs...)
+	fw.mux.Unlock()
+
+	log.L(ctx).Infof("Moving WebSocket connection from endpoint %d to endpoint %d (%s)", fw.endpoint, ep.index, ep.wsURL)
+	wsRPC := fw.es.newWSRPC(ep.wsConf)
+	if err := wsRPC.Connect(ctx); err != nil {
+		log.L(ctx).Errorf("WebSocket connection to endpoint %d failed: %s", ep.index, err)
+		return
+	}
+	newSubs := make([]rpcclient.Subscription, len(subs))
+	for i, fs := range subs {
+		underlying, rpcErr := wsRPC.Subscribe(ctx, fs.conf, fs.params...)
+		if rpcErr != nil {
+			log.L(ctx).Errorf("Subscription on endpoint %d failed: %s", ep.index, rpcErr)
+			wsRPC.Close()
+			return
+		}
+		newSubs[i] = underlying
+	}
+
+	fw.mux.Lock()
+	defer fw.mux.Unlock()
+	if fw.closed {
+		wsRPC.Close()
+		return
+	}
+	for i, fs := range subs {
+		fs.stopForwarding()
+		fs.forward(newSubs[i])
+	}
+	fw.current.Close()
+	fw.current = wsRPC
+	fw.endpoint = ep.index
+}
+
+// forward passes the notifications from the subscription on the current connection, through
+// to the channel the caller holds for the lifetime of the subscription
+func (fs *failoverSub) forward(underlying rpcclient.Subscription) {
+	fs.underlying = underlying
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	fs.stopForward = stop
+	fs.forwardDone = done
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case n, ok := <-underlying.Notifications():
+				if !ok {
+					return
+				}
+				select {
+				case fs.notifications <- n:
+				case <-stop:
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (fs *failoverSub) stopForwarding() {
+	select {
+	case <-fs.stopForward:
+	default:
+		close(fs.stopForward)
+	}
+	<-fs.forwardDone
+}
+
+func (fs *failoverSub) LocalID() uuid.UUID {
+	return fs.localID
+}
+
+func (fs *failoverSub) Notifications() chan rpcclient.RPCSubscriptionNotification {
+	return fs.notifications
+}
+
+func (fs *failoverSub) Unsubscribe(ctx context.Context) rpcclient.ErrorRPC {
+	fw := fs.fw
+	fw.mux.Lock()
+	defer fw.mux.Unlock()
+	for i, existing := range fw.subs {
+		if existing == fs {
+			fw.subs = append(fw.subs[:i], fw.subs[i+1:]...)
+			break
+		}
+	}
+	fs.stopForwarding()
+	rpcErr := fs.underlying.Unsubscribe(ctx)
+	close(fs.notifications)
+	return rpcErr
+}
@@ -0,0 +1,446 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/rpcclientmocks"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testEndpointRPC struct {
+	chainID     uint64
+	blockNumber uint64
+	err         error
+	hang        bool
+}
+
+func (r *testEndpointRPC) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) rpcclient.ErrorRPC {
+	if r.hang {
+		<-ctx.Done()
+		return rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, ctx.Err())
+	}
+	if r.err != nil {
+		return rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, r.err)
+	}
+	switch method {
+	case "eth_chainId":
+		*(result.(*pldtypes.HexUint64)) = pldtypes.HexUint64(r.chainID)
+	case "eth_blockNumber":
+		*(result.(*pldtypes.HexUint64)) = pldtypes.HexUint64(r.blockNumber)
+	}
+	return nil
+}
+
+func newTestEndpointSet(t *testing.T, hcConf pldconf.EthClientHealthCheckConfig, count int) (*endpointSet, []*testEndpointRPC) {
+	conf := &pldconf.EthClientConfig{HealthCheck: hcConf}
+	for i := 0; i < count; i++ {
+		conf.Endpoints = append(conf.Endpoints, pldconf.EthClientEndpointConfig{
+			HTTP: pldconf.HTTPClientConfig{URL: fmt.Sprintf("http://node%d:8545", i)},
+		})
+	}
+	es, err := newEndpointSet(context.Background(), conf)
+	require.NoError(t, err)
+	es.chainID = 12345
+
+	rpcs := make([]*testEndpointRPC, count)
+	for i, ep := range es.endpoints {
+		rpcs[i] = &testEndpointRPC{chainID: 12345, blockNumber: 100}
+		ep.httpRPC = rpcs[i]
+	}
+	return es, rpcs
+}
+
+func TestNewEndpointSetSingle(t *testing.T) {
+	conf := &pldconf.EthClientConfig{
+		HTTP: pldconf.HTTPClientConfig{URL: "http://node0:8545"},
+	}
+	es, err := newEndpointSet(context.Background(), conf)
+	require.NoError(t, err)
+	require.Len(t, es.endpoints, 1)
+	assert.Equal(t, "ws://node0:8545", conf.WS.URL)
+	assert.Equal(t, 10*time.Second, es.interval)
+	assert.Equal(t, 5*time.Second, es.timeout)
+	assert.Equal(t, uint64(10), es.maxBlockLag)
+	assert.Zero(t, es.staleHead)
+
+	status := es.status()
+	assert.Equal(t, int64(-1), status.ChainID)
+	assert.Equal(t, 0, status.ActiveEndpoint)
+	require.Len(t, status.Endpoints, 1)
+	assert.Equal(t, "http://node0:8545", status.Endpoints[0].HTTP)
+	assert.Equal(t, "ws://node0:8545", status.Endpoints[0].WS)
+	assert.True(t, status.Endpoints[0].Active)
+	assert.True(t, status.Endpoints[0].Healthy)
+	assert.Nil(t, status.Endpoints[0].LastChecked)
+}
+
+func TestNewEndpointSetList(t *testing.T) {
+	conf := &pldconf.EthClientConfig{
+		Endpoints: []pldconf.EthClientEndpointConfig{
+			{HTTP: pldconf.HTTPClientConfig{URL: "https://node0:8545"}},
+			{
+				HTTP: pldconf.HTTPClientConfig{URL: "http://node1:8545"},
+				WS:   pldconf.WSClientConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: "ws://node1:8546"}},
+			},
+		},
+	}
+	es, err := newEndpointSet(context.Background(), conf)
+	require.NoError(t, err)
+	require.Len(t, es.endpoints, 2)
+	assert.Equal(t, "wss://node0:8545", es.endpoints[0].wsURL)
+	assert.Equal(t, "ws://node1:8546", es.endpoints[1].wsURL)
+	assert.Equal(t, 1, es.endpoints[1].index)
+}
+
+func TestNewEndpointSetBadEndpoint(t *testing.T) {
+	_, err := newEndpointSet(context.Background(), &pldconf.EthClientConfig{
+		Endpoints: []pldconf.EthClientEndpointConfig{
+			{HTTP: pldconf.HTTPClientConfig{URL: "http://node0:8545"}},
+			{WS: pldconf.WSClientConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: "ws://node1:8546"}}},
+		},
+	})
+	assert.Regexp(t, "PD011511", err)
+
+	_, err = newEndpointSet(context.Background(), &pldconf.EthClientConfig{
+		Endpoints: []pldconf.EthClientEndpointConfig{
+			{
+				HTTP: pldconf.HTTPClientConfig{URL: "http://node0:8545"},
+				WS:   pldconf.WSClientConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: "wrong://node0:8546"}},
+			},
+		},
+	})
+	assert.Regexp(t, "PD020500", err)
+}
+
+func TestHealthCheckFailoverIsSticky(t *testing.T) {
+	ctx := context.Background()
+	es, rpcs := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{}, 3)
+
+	listenerCalls := 0
+	es.addListener(func() { listenerCalls++ })
+
+	es.checkHealth(ctx)
+	status := es.status()
+	assert.Equal(t, 0, status.ActiveEndpoint)
+	for _, ep := range status.Endpoints {
+		assert.True(t, ep.Healthy)
+		assert.Equal(t, pldtypes.HexUint64(100), ep.BlockNumber)
+		assert.NotNil(t, ep.LastChecked)
+	}
+	assert.Equal(t, 1, listenerCalls)
+
+	// The active endpoint fails, so we move to the next healthy one in the list
+	rpcs[0].err = fmt.Errorf("pop")
+	rpcs[1].chainID = 11111
+	es.checkHealth(ctx)
+	status = es.status()
+	assert.Equal(t, 2, status.ActiveEndpoint)
+	assert.False(t, status.Endpoints[0].Healthy)
+	assert.Regexp(t, "pop", status.Endpoints[0].Error)
+	assert.False(t, status.Endpoints[1].Healthy)
+	assert.Regexp(t, "PD011518", status.Endpoints[1].Error)
+	assert.True(t, status.Endpoints[2].Active)
+	assert.Equal(t, 2, listenerCalls)
+
+	// Recovery of the earlier endpoints does not move us back
+	rpcs[0].err = nil
+	rpcs[1].chainID = 12345
+	es.checkHealth(ctx)
+	status = es.status()
+	assert.Equal(t, 2, status.ActiveEndpoint)
+	assert.True(t, status.Endpoints[0].Healthy)
+	assert.Empty(t, status.Endpoints[0].Error)
+
+	// When nothing is healthy we stay where we are
+	for _, rpc := range rpcs {
+		rpc.err = fmt.Errorf("pop")
+	}
+	es.checkHealth(ctx)
+	assert.Equal(t, 2, es.status().ActiveEndpoint)
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+	ctx := context.Background()
+	es, rpcs := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{
+		Timeout: confutil.P("50ms"),
+	}, 3)
+
+	// Two hung endpoints are checked in parallel, so both time out together
+	rpcs[0].hang = true
+	rpcs[1].hang = true
+	start := time.Now()
+	es.checkHealth(ctx)
+	assert.Less(t, time.Since(start), 1*time.Second)
+
+	status := es.status()
+	assert.Equal(t, 2, status.ActiveEndpoint)
+	assert.False(t, status.Endpoints[0].Healthy)
+	assert.Regexp(t, "deadline exceeded", status.Endpoints[0].Error)
+	assert.False(t, status.Endpoints[1].Healthy)
+	assert.True(t, status.Endpoints[2].Healthy)
+}
+
+func TestHealthCheckBlockLag(t *testing.T) {
+	ctx := context.Background()
+	es, rpcs := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{
+		MaxBlockLag: confutil.P(5),
+	}, 2)
+
+	rpcs[0].blockNumber = 100
+	rpcs[1].blockNumber = 106
+	es.checkHealth(ctx)
+	status := es.status()
+	assert.Equal(t, 1, status.ActiveEndpoint)
+	assert.Regexp(t, "PD011519", status.Endpoints[0].Error)
+	assert.True(t, status.Endpoints[1].Healthy)
+
+	rpcs[0].blockNumber = 105
+	es.checkHealth(ctx)
+	status = es.status()
+	assert.True(t, status.Endpoints[0].Healthy)
+	assert.Equal(t, 1, status.ActiveEndpoint)
+}
+
+func TestHealthCheckStaleHead(t *testing.T) {
+	ctx := context.Background()
+	es, rpcs := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{
+		StaleHead: confutil.P("1ms"),
+	}, 2)
+
+	es.checkHealth(ctx)
+	assert.True(t, es.status().Endpoints[0].Healthy)
+
+	time.Sleep(5 * time.Millisecond)
+	rpcs[1].blockNumber = 101
+	es.checkHealth(ctx)
+	status := es.status()
+	assert.Equal(t, 1, status.ActiveEndpoint)
+	assert.Regexp(t, "PD011520", status.Endpoints[0].Error)
+}
+
+func TestHealthCheckLoop(t *testing.T) {
+	es, _ := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{
+		Interval: confutil.P("1ms"),
+	}, 1)
+
+	checked := make(chan struct{}, 1)
+	es.addListener(func() {
+		select {
+		case checked <- struct{}{}:
+		default:
+		}
+	})
+
+	es.start(context.Background(), 12345)
+	<-checked
+	<-checked
+	es.stop()
+	es.stop() // no-op
+	assert.Equal(t, int64(12345), es.status().ChainID)
+}
+
+func TestFailoverRPC(t *testing.T) {
+	es, rpcs := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{}, 2)
+	rpcs[1].blockNumber = 200
+
+	fr := &failoverRPC{es: es}
+	var blockNumber pldtypes.HexUint64
+	rpcErr := fr.CallRPC(context.Background(), &blockNumber, "eth_blockNumber")
+	require.NoError(t, rpcErr)
+	assert.Equal(t, pldtypes.HexUint64(100), blockNumber)
+
+	es.setActive(1)
+	rpcErr = fr.CallRPC(context.Background(), &blockNumber, "eth_blockNumber")
+	require.NoError(t, rpcErr)
+	assert.Equal(t, pldtypes.HexUint64(200), blockNumber)
+}
+
+func newTestSubscription(t *testing.T) (*rpcclientmocks.Subscription, chan rpcclient.RPCSubscriptionNotification) {
+	notifications := make(chan rpcclient.RPCSubscriptionNotification)
+	mSub := rpcclientmocks.NewSubscription(t)
+	mSub.On("LocalID").Return(uuid.New()).Maybe()
+	mSub.On("Notifications").Return(notifications).Maybe()
+	return mSub, notifications
+}
+
+func TestFailoverWSMovesSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	es, _ := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{}, 2)
+
+	mWS0 := rpcclientmocks.NewWSClient(t)
+	mWS1 := rpcclientmocks.NewWSClient(t)
+	wsClients := map[*wsclient.WSConfig]rpcclient.WSClient{
+		es.endpoints[0].wsConf: mWS0,
+		es.endpoints[1].wsConf: mWS1,
+	}
+	es.newWSRPC = func(wsConf *wsclient.WSConfig) rpcclient.WSClient { return wsClients[wsConf] }
+
+	fw := newFailoverWS(es)
+
+	// Nothing works until we connect
+	var err error = fw.CallRPC(ctx, nil, "eth_blockNumber")
+	assert.Regexp(t, "PD011517", err)
+	_, err = fw.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "newHeads")
+	assert.Regexp(t, "PD011517", err)
+
+	mWS0.On("Connect", mock.Anything).Return(nil)
+	mWS0.On("CallRPC", mock.Anything, mock.Anything, "eth_blockNumber").Return(nil)
+	mSub0, notifications0 := newTestSubscription(t)
+	mWS0.On("Subscribe", mock.Anything, mock.Anything, "newHeads").Return(mSub0, nil)
+	err = fw.Connect(ctx)
+	require.NoError(t, err)
+	err = fw.CallRPC(ctx, nil, "eth_blockNumber")
+	require.NoError(t, err)
+
+	sub, err := fw.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "newHeads")
+	require.NoError(t, err)
+	assert.Equal(t, []rpcclient.Subscription{sub}, fw.Subscriptions())
+	localID := sub.LocalID()
+
+	n0 := rpcclientmocks.NewRPCSubscriptionNotification(t)
+	notifications0 <- n0
+	assert.Equal(t, n0, <-sub.Notifications())
+
+	// No change of endpoint is a no-op
+	fw.followActiveEndpoint()
+
+	// Move over to the next endpoint
+	mWS1.On("Connect", mock.Anything).Return(nil)
+	mSub1, notifications1 := newTestSubscription(t)
+	mWS1.On("Subscribe", mock.Anything, mock.Anything, "newHeads").Return(mSub1, nil)
+	mWS0.On("Close").Return()
+	es.setActive(1)
+	fw.followActiveEndpoint()
+	assert.Equal(t, 1, fw.endpoint)
+	assert.Equal(t, localID, sub.LocalID())
+
+	n1 := rpcclientmocks.NewRPCSubscriptionNotification(t)
+	notifications1 <- n1
+	assert.Equal(t, n1, <-sub.Notifications())
+
+	// Unsubscribe goes to the current connection, and closes the channel
+	mSub1.On("Unsubscribe", mock.Anything).Return(nil)
+	err = fw.UnsubscribeAll(ctx)
+	require.NoError(t, err)
+	_, open := <-sub.Notifications()
+	assert.False(t, open)
+	assert.Empty(t, fw.Subscriptions())
+
+	mWS1.On("Close").Return()
+	fw.Close()
+	fw.followActiveEndpoint() // no-op once closed
+}
+
+func TestFailoverWSStaysOnFailure(t *testing.T) {
+	ctx := context.Background()
+	es, _ := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{}, 2)
+
+	mWS0 := rpcclientmocks.NewWSClient(t)
+	mWS1 := rpcclientmocks.NewWSClient(t)
+	wsClients := map[*wsclient.WSConfig]rpcclient.WSClient{
+		es.endpoints[0].wsConf: mWS0,
+		es.endpoints[1].wsConf: mWS1,
+	}
+	es.newWSRPC = func(wsConf *wsclient.WSConfig) rpcclient.WSClient { return wsClients[wsConf] }
+
+	fw := newFailoverWS(es)
+	mWS0.On("Connect", mock.Anything).Return(nil)
+	mSub0, _ := newTestSubscription(t)
+	mWS0.On("Subscribe", mock.Anything, mock.Anything, "newHeads").Return(mSub0, nil)
+	err := fw.Connect(ctx)
+	require.NoError(t, err)
+	_, err = fw.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "newHeads")
+	require.NoError(t, err)
+
+	es.setActive(1)
+
+	// Connect fails
+	mWS1.On("Connect", mock.Anything).Return(fmt.Errorf("pop")).Once()
+	fw.followActiveEndpoint()
+	assert.Equal(t, 0, fw.endpoint)
+
+	// Subscribe fails
+	mWS1.On("Connect", mock.Anything).Return(nil).Once()
+	mWS1.On("Subscribe", mock.Anything, mock.Anything, "newHeads").Return(nil, rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop")))
+	mWS1.On("Close").Return()
+	fw.followActiveEndpoint()
+	assert.Equal(t, 0, fw.endpoint)
+
+	mWS0.On("Close").Return()
+	fw.Close()
+}
+
+func TestFailoverWSConnectFail(t *testing.T) {
+	es, _ := newTestEndpointSet(t, pldconf.EthClientHealthCheckConfig{}, 1)
+	mWS := rpcclientmocks.NewWSClient(t)
+	es.newWSRPC = func(wsConf *wsclient.WSConfig) rpcclient.WSClient { return mWS }
+	mWS.On("Connect", mock.Anything).Return(fmt.Errorf("pop"))
+
+	fw := newFailoverWS(es)
+	err := fw.Connect(context.Background())
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, es.listeners)
+}
+
+func TestFactoryStartsOnFirstAvailableEndpoint(t *testing.T) {
+	ctx := context.Background()
+	mEth := &mockEth{
+		eth_blockNumber: func(ctx context.Context) (pldtypes.HexUint64, error) { return 100, nil },
+	}
+	httpRPCServer, httpServerDone := newTestServer(t, ctx, false, mEth)
+	defer httpServerDone()
+	wsRPCServer, wsServerDone := newTestServer(t, ctx, true, mEth)
+	defer wsServerDone()
+
+	ecf, err := NewEthClientFactory(ctx, &pldconf.EthClientConfig{
+		Endpoints: []pldconf.EthClientEndpointConfig{
+			{HTTP: pldconf.HTTPClientConfig{URL: "http://127.0.0.1:1"}},
+			{
+				HTTP: pldconf.HTTPClientConfig{URL: fmt.Sprintf("http://%s", httpRPCServer.HTTPAddr().String())},
+				WS:   pldconf.WSClientConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: fmt.Sprintf("ws://%s", wsRPCServer.WSAddr().String())}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	err = ecf.Start()
+	require.NoError(t, err)
+	defer ecf.Stop()
+
+	status := ecf.BlockchainStatus()
+	assert.Equal(t, int64(12345), status.ChainID)
+	assert.Equal(t, 1, status.ActiveEndpoint)
+	assert.True(t, status.Endpoints[1].Active)
+	assert.NotNil(t, ecf.NewWSRPC())
+
+	// The raw HTTP client follows the active endpoint
+	var blockNumber pldtypes.HexUint64
+	rpcErr := ecf.HTTPRPC().CallRPC(ctx, &blockNumber, "eth_blockNumber")
+	require.NoError(t, rpcErr)
+	assert.Equal(t, uint64(100), blockNumber.Uint64())
+}
@@ -17,34 +17,37 @@ package ethclient
 
 import (
 	"context"
-	"strings"
 
-	"github.com/hyperledger/firefly-common/pkg/wsclient"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 )
 
 // Allows separate components to maintain separate connections/connection-pools to the
 // blockchain, all using a common set of configuration pointing at the same blockchain.
 type EthClientFactoryBase interface {
-	Start() error   // connects the shared websocket and queries the chainID
-	Stop()          // closes HTTP client and shared WS client
-	ChainID() int64 // available after start
+	Start() error                               // connects the shared websocket and queries the chainID
+	Stop()                                      // closes HTTP client and shared WS client
+	ChainID() int64                             // available after start
+	NewWSRPC() rpcclient.WSClient               // unconnected raw JSON/RPC WS client, that follows the active endpoint - which the caller is responsible for closing
+	HTTPRPC() rpcclient.Client                  // raw JSON/RPC HTTP client, that follows the active endpoint
+	BlockchainStatus() *pldapi.BlockchainStatus // health of each endpoint, and which is active
 }
 
 type EthClientFactory interface {
 	EthClientFactoryBase
 	HTTPClient() EthClient     // HTTP client
-	SharedWS() EthClient       // WS client with a single long lived socket shared across multiple components
+	SharedWS() EthClient       // WS client with a single long lived socket shared across multiple components (the HTTP client if no WebSocket is available)
 	NewWS() (EthClient, error) // created a dedicated socket - which the caller responsible for closing
 }
 
 type EthClientFactoryWithKeyManager interface {
 	EthClientFactoryBase
 	HTTPClient() EthClientWithKeyManager     // HTTP client
-	SharedWS() EthClientWithKeyManager       // WS client with a single long lived socket shared across multiple components
+	SharedWS() EthClientWithKeyManager       // WS client with a single long lived socket shared across multiple components (the HTTP client if no WebSocket is available)
 	NewWS() (EthClientWithKeyManager, error) // created a dedicated socket - which the caller responsible for closing
 }
 
@@ -54,13 +57,12 @@ type ethClientFactory struct {
 	conf   *pldconf.EthClientConfig
 	keymgr KeyManager
 
+	endpoints  *endpointSet
 	httpRPC    rpcclient.Client
 	httpClient *ethClient
 
 	sharedWSClient *ethClient
 
-	wsConf *wsclient.WSConfig
-
 	chainID int64
 }
 
@@ -91,39 +93,32 @@ func newEthClientFactory(bgCtx context.Context, keymgr KeyManager, conf *pldconf
 		keymgr:  keymgr,
 		chainID: -1,
 	}
-	// Parse the HTTP and build the HTTP client - we only have one of these across the factory
-	// as within the HTTP client there are as many connections as required for parallelism
-	if conf.HTTP.URL == "" {
-		return nil, i18n.NewError(bgCtx, msgs.MsgEthClientHTTPURLMissing)
-	}
-	if ecf.httpRPC, err = rpcclient.NewHTTPClient(bgCtx, &conf.HTTP); err != nil {
-		return nil, err
-	}
-
-	// Move onto WS, which can re-use the HTTP URL if required
-	if conf.WS.URL == "" {
-		noHTTPPrefix, trimmed := strings.CutPrefix(conf.HTTP.URL, "http")
-		if trimmed {
-			conf.WS.URL = "ws" + noHTTPPrefix
-		}
-	}
-	ecf.wsConf, err = rpcclient.ParseWSConfig(bgCtx, &conf.WS)
-	if err != nil {
+	if ecf.endpoints, err = newEndpointSet(bgCtx, conf); err != nil {
 		return nil, err
 	}
+	ecf.httpRPC = &failoverRPC{es: ecf.endpoints}
 	return ecf, nil
 }
 
 func (ecf *ethClientFactory) Start() (err error) {
-	// Connect and check the two connections are to the same network
-	var sharedWSClient EthClient
-	httpClient, err := WrapRPCClient(ecf.bgCtx, ecf.keymgr, ecf.httpRPC, ecf.conf)
-	if err == nil {
-		sharedWSClient, err = ecf.NewWS()
+	// Start on the first endpoint in the list that is available
+	var httpClient, sharedWSClient EthClient
+	for _, ep := range ecf.endpoints.endpoints {
+		ecf.endpoints.setActive(ep.index)
+		if httpClient, err = WrapRPCClient(ecf.bgCtx, ecf.keymgr, ecf.httpRPC, ecf.conf); err == nil {
+			break
+		}
+		log.L(ecf.bgCtx).Warnf("Endpoint %d (%s) unavailable: %s", ep.index, ep.httpURL, err)
 	}
 	if err != nil {
 		return err
 	}
+	// Connect and check the two connections are to the same network. Some RPC gateways only
+	// expose HTTPS, so if the WebSocket is not reachable the shared client falls back to HTTP.
+	if sharedWSClient, err = ecf.NewWS(); err != nil {
+		log.L(ecf.bgCtx).Warnf("WebSocket connection to endpoint %d (%s) failed, using HTTP for shared client: %s", ecf.endpoints.activeEndpoint().index, ecf.endpoints.activeEndpoint().wsURL, err)
+		sharedWSClient = httpClient
+	}
 	ecf.httpClient = httpClient.(*ethClient)
 	ecf.sharedWSClient = sharedWSClient.(*ethClient)
 	httpChainID := ecf.httpClient.ChainID()
@@ -132,14 +127,25 @@ func (ecf *ethClientFactory) Start() (err error) {
 		return i18n.NewError(ecf.bgCtx, msgs.MsgEthClientChainIDMismatch, httpChainID, wsChainID)
 	}
 	ecf.chainID = httpChainID
-	return err
+	ecf.endpoints.start(ecf.bgCtx, httpChainID)
+	return nil
+}
+
+func (ecf *ethClientFactory) NewWSRPC() rpcclient.WSClient {
+	return newFailoverWS(ecf.endpoints)
+}
+
+func (ecf *ethClientFactory) HTTPRPC() rpcclient.Client {
+	return ecf.httpRPC
 }
 
 func (ecf *ethClientFactory) NewWS() (ec EthClient, err error) {
-	wsRPC := rpcclient.WrapWSConfig(ecf.wsConf)
+	wsRPC := ecf.NewWSRPC()
 	err = wsRPC.Connect(ecf.bgCtx)
 	if err == nil {
-		ec, err = WrapRPCClient(ecf.bgCtx, ecf.keymgr, wsRPC, ecf.conf)
+		if ec, err = WrapRPCClient(ecf.bgCtx, ecf.keymgr, wsRPC, ecf.conf); err != nil {
+			wsRPC.Close()
+		}
 	}
 	return ec, err
 }
@@ -156,6 +162,7 @@ func (ecf *ethClientFactory) SharedWS() EthClient {
 }
 
 func (ecf *ethClientFactory) Stop() {
+	ecf.endpoints.stop()
 	ecf.httpClient.Close()
 	ecf.sharedWSClient.Close()
 }
@@ -164,6 +171,10 @@ func (ecf *ethClientFactory) ChainID() int64 {
 	return ecf.chainID
 }
 
+func (ecf *ethClientFactory) BlockchainStatus() *pldapi.BlockchainStatus {
+	return ecf.endpoints.status()
+}
+
 // Wrapper for key manager support in environments using this directly to access the blockchain rather than in Paladin
 
 func (w *ethClientFactoryKeyManagerWrapper) Start() error {
@@ -193,3 +204,15 @@ func (w *ethClientFactoryKeyManagerWrapper) Stop() {
 func (w *ethClientFactoryKeyManagerWrapper) ChainID() int64 {
 	return w.ecf.ChainID()
 }
+
+func (w *ethClientFactoryKeyManagerWrapper) NewWSRPC() rpcclient.WSClient {
+	return w.ecf.NewWSRPC()
+}
+
+func (w *ethClientFactoryKeyManagerWrapper) HTTPRPC() rpcclient.Client {
+	return w.ecf.HTTPRPC()
+}
+
+func (w *ethClientFactoryKeyManagerWrapper) BlockchainStatus() *pldapi.BlockchainStatus {
+	return w.ecf.BlockchainStatus()
+}
@@ -35,8 +35,10 @@ import (
 type mockEth struct {
 	eth_getBalance            func(context.Context, pldtypes.EthAddress, string) (*pldtypes.HexUint256, error)
 	eth_gasPrice              func(context.Context) (*pldtypes.HexUint256, error)
+	eth_feeHistory            func(context.Context, pldtypes.HexUint64, string, []float64) (*FeeHistoryResult, error)
 	eth_gasLimit              func(context.Context, ethsigner.Transaction) (*pldtypes.HexUint256, error)
 	eth_chainId               func(context.Context) (pldtypes.HexUint64, error)
+	eth_blockNumber           func(context.Context) (pldtypes.HexUint64, error)
 	eth_getTransactionCount   func(context.Context, pldtypes.EthAddress, string) (pldtypes.HexUint64, error)
 	eth_getTransactionReceipt func(context.Context, pldtypes.Bytes32) (*txReceiptJSONRPC, error)
 	eth_estimateGas           func(context.Context, ethsigner.Transaction) (pldtypes.HexUint64, error)
@@ -82,6 +84,7 @@ func newTestServer(t *testing.T, ctx context.Context, isWS bool, mEth *mockEth)
 
 	rpcServer.Register(rpcserver.NewRPCModule("eth").
 		Add("eth_chainId", checkNil(mEth.eth_chainId, rpcserver.RPCMethod0)).
+		Add("eth_blockNumber", checkNil(mEth.eth_blockNumber, rpcserver.RPCMethod0)).
 		Add("eth_getTransactionCount", checkNil(mEth.eth_getTransactionCount, rpcserver.RPCMethod2)).
 		Add("eth_getTransactionReceipt", checkNil(mEth.eth_getTransactionReceipt, rpcserver.RPCMethod1)).
 		Add("eth_estimateGas", checkNil(mEth.eth_estimateGas, rpcserver.RPCMethod1)).
@@ -89,6 +92,7 @@ func newTestServer(t *testing.T, ctx context.Context, isWS bool, mEth *mockEth)
 		Add("eth_call", primarySecondary(mEth.eth_callErr, checkNil(mEth.eth_call, rpcserver.RPCMethod2))).
 		Add("eth_getBalance", checkNil(mEth.eth_getBalance, rpcserver.RPCMethod2)).
 		Add("eth_gasPrice", checkNil(mEth.eth_gasPrice, rpcserver.RPCMethod0)).
+		Add("eth_feeHistory", checkNil(mEth.eth_feeHistory, rpcserver.RPCMethod3)).
 		Add("eth_gasLimit", checkNil(mEth.eth_gasLimit, rpcserver.RPCMethod1)),
 	)
 
@@ -149,6 +153,7 @@ func newTestClientAndServer(t *testing.T, mEth *mockEth) (ctx context.Context, _
 	err = ecf.Start()
 	require.NoError(t, err)
 	assert.Equal(t, int64(12345), ecf.ChainID())
+	assert.NotNil(t, ecf.HTTPRPC())
 
 	return ctx, ecf.(*ethClientFactoryKeyManagerWrapper), func() {
 		httpServerDone()
@@ -249,6 +254,36 @@ func TestMismatchedChainID(t *testing.T) {
 
 }
 
+func TestFactoryStartsHTTPOnly(t *testing.T) {
+	ctx := context.Background()
+	mEth := &mockEth{
+		eth_getTransactionCount: func(ctx context.Context, addr pldtypes.EthAddress, block string) (pldtypes.HexUint64, error) {
+			return 10, nil
+		},
+	}
+	// The server only supports HTTP, so the WebSocket URL we derive from the HTTP URL fails to connect
+	httpRPCServer, httpServerDone := newTestServer(t, ctx, false, mEth)
+	defer httpServerDone()
+
+	ecf, err := NewEthClientFactory(ctx, &pldconf.EthClientConfig{
+		HTTP: pldconf.HTTPClientConfig{
+			URL: fmt.Sprintf("http://%s", httpRPCServer.HTTPAddr().String()),
+		},
+	})
+	require.NoError(t, err)
+	err = ecf.Start()
+	require.NoError(t, err)
+	defer ecf.Stop()
+
+	assert.Equal(t, int64(12345), ecf.ChainID())
+	assert.Same(t, ecf.HTTPClient(), ecf.SharedWS())
+
+	// The shared client works over HTTP
+	txCount, err := ecf.SharedWS().GetTransactionCount(ctx, *pldtypes.RandAddress())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), txCount.Uint64())
+}
+
 func TestSharedWSBeforeStart(t *testing.T) {
 	assert.PanicsWithValue(t, "call to SharedWS() before Start", func() {
 		_ = (&ethClientFactory{}).SharedWS()
@@ -62,8 +62,8 @@ func NewSimpleTestKeyManager(ctx context.Context, signerConfig *signerapi.Config
 	}, nil
 }
 
-func (km *simpleKeyManager) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) {
-	km.signer.AddInMemorySigner(prefix, signer)
+func (km *simpleKeyManager) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error {
+	return km.signer.AddInMemorySigner(prefix, signer)
 }
 
 func (km *simpleKeyManager) ResolveKey(ctx context.Context, identifier, algorithm, verifierType string) (keyHandle, verifier string, err error) {
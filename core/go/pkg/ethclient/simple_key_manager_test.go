@@ -37,7 +37,9 @@ type mockKeyManager struct {
 }
 
 // AddInMemorySigner implements KeyManager.
-func (mkm *mockKeyManager) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) {}
+func (mkm *mockKeyManager) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error {
+	return nil
+}
 
 func (mkm *mockKeyManager) ResolveKey(ctx context.Context, identifier, algorithm, verifierType string) (keyHandle, verifier string, err error) {
 	return mkm.resolveKey(ctx, identifier, algorithm, verifierType)
@@ -121,11 +123,12 @@ func TestSimpleKeyManagerPassThoroughInMemSigner(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	sm.AddInMemorySigner("bad", &mockSigner{
+	err = sm.AddInMemorySigner("bad", &mockSigner{
 		getVerifier: func(ctx context.Context, algorithm, verifierType string, privateKey []byte) (string, error) {
 			return "", fmt.Errorf("pop")
 		},
 	})
+	require.NoError(t, err)
 	_, _, err = sm.ResolveKey(context.Background(), "any", "bad:test", verifiers.ETH_ADDRESS)
 	assert.Regexp(t, "pop", err)
 }
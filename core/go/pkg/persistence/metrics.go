@@ -0,0 +1,91 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+const metricsStartTimeKey = "paladin:metrics_start"
+
+type dbMetrics struct {
+	operationDuration *prometheus.HistogramVec
+}
+
+// RegisterMetrics installs GORM callbacks that record the duration of every database
+// operation, labelled by the type of operation, the table, and whether it succeeded.
+func RegisterMetrics(ctx context.Context, p Persistence, registry prometheus.Registerer) error {
+	m := &dbMetrics{
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "db",
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of database operations",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 16), // 0.5ms to ~16s
+		}, []string{"operation", "table", "result"}),
+	}
+	if err := registry.Register(m.operationDuration); err != nil {
+		return err
+	}
+
+	cb := p.DB().Callback()
+	errs := []error{
+		cb.Create().Before("gorm:create").Register("paladin:metrics_before_create", m.start),
+		cb.Create().After("gorm:create").Register("paladin:metrics_after_create", m.record("create")),
+		cb.Query().Before("gorm:query").Register("paladin:metrics_before_query", m.start),
+		cb.Query().After("gorm:query").Register("paladin:metrics_after_query", m.record("query")),
+		cb.Update().Before("gorm:update").Register("paladin:metrics_before_update", m.start),
+		cb.Update().After("gorm:update").Register("paladin:metrics_after_update", m.record("update")),
+		cb.Delete().Before("gorm:delete").Register("paladin:metrics_before_delete", m.start),
+		cb.Delete().After("gorm:delete").Register("paladin:metrics_after_delete", m.record("delete")),
+		cb.Row().Before("gorm:row").Register("paladin:metrics_before_row", m.start),
+		cb.Row().After("gorm:row").Register("paladin:metrics_after_row", m.record("row")),
+		cb.Raw().Before("gorm:raw").Register("paladin:metrics_before_raw", m.start),
+		cb.Raw().After("gorm:raw").Register("paladin:metrics_after_raw", m.record("raw")),
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	log.L(ctx).Debugf("Database metrics enabled")
+	return nil
+}
+
+func (m *dbMetrics) start(db *gorm.DB) {
+	db.InstanceSet(metricsStartTimeKey, time.Now())
+}
+
+func (m *dbMetrics) record(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		startTime, ok := db.InstanceGet(metricsStartTimeKey)
+		if !ok {
+			return
+		}
+		result := "success"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			result = "error"
+		}
+		m.operationDuration.WithLabelValues(operation, db.Statement.Table, result).
+			Observe(time.Since(startTime.(time.Time)).Seconds())
+	}
+}
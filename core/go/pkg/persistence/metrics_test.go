@@ -0,0 +1,64 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	p, done, err := NewUnitTestPersistence(ctx, "persistence")
+	require.NoError(t, err)
+	defer done()
+
+	registry := prometheus.NewRegistry()
+	err = RegisterMetrics(ctx, p, registry)
+	require.NoError(t, err)
+
+	var count int64
+	err = p.DB().Table("schema_migrations").Count(&count).Error
+	require.NoError(t, err)
+	err = p.DB().Table("does_not_exist").Count(&count).Error
+	assert.Error(t, err)
+
+	mfs, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, mfs, 1)
+	assert.Equal(t, "paladin_db_operation_duration_seconds", mfs[0].GetName())
+	results := map[string]uint64{}
+	for _, m := range mfs[0].GetMetric() {
+		labels := map[string]string{}
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		assert.Equal(t, "query", labels["operation"])
+		results[labels["table"]+":"+labels["result"]] = m.GetHistogram().GetSampleCount()
+	}
+	assert.Equal(t, uint64(1), results["schema_migrations:success"])
+	assert.Equal(t, uint64(1), results["does_not_exist:error"])
+
+	// Cannot register twice into the same registry
+	err = RegisterMetrics(ctx, p, registry)
+	assert.Regexp(t, "duplicate", err)
+}
@@ -114,20 +114,25 @@ func (tb *testbed) ResolveKey(ctx context.Context, fqLookup, algorithm, verifier
 	return resolvedKey, err
 }
 
-func (tb *testbed) gatherSignatures(ctx context.Context, tx *testbedTransaction) error {
-	tx.ptx.PostAssembly.Signatures = []*prototk.AttestationResult{}
-	for _, ar := range tx.ptx.PostAssembly.AttestationPlan {
+func (tb *testbed) gatherSignatures(ctx context.Context, tx *testbedTransaction) (err error) {
+	tx.ptx.PostAssembly.Signatures, err = tb.signAttestations(ctx, tx.ptx.PostAssembly.AttestationPlan)
+	return err
+}
+
+func (tb *testbed) signAttestations(ctx context.Context, attestationPlan []*prototk.AttestationRequest) ([]*prototk.AttestationResult, error) {
+	signatures := []*prototk.AttestationResult{}
+	for _, ar := range attestationPlan {
 		if ar.AttestationType == prototk.AttestationType_SIGN {
 			for _, partyName := range ar.Parties {
 				resolvedKey, err := tb.ResolveKey(ctx, partyName, ar.Algorithm, ar.VerifierType)
 				if err != nil {
-					return fmt.Errorf("failed to resolve local signer for %s (algorithm=%s): %s", partyName, ar.Algorithm, err)
+					return nil, fmt.Errorf("failed to resolve local signer for %s (algorithm=%s): %s", partyName, ar.Algorithm, err)
 				}
 				signaturePayload, err := tb.c.KeyManager().Sign(ctx, resolvedKey, ar.PayloadType, ar.Payload)
 				if err != nil {
-					return fmt.Errorf("failed to sign for party %s (verifier=%s,algorithm=%s): %s", partyName, resolvedKey.Verifier.Verifier, ar.Algorithm, err)
+					return nil, fmt.Errorf("failed to sign for party %s (verifier=%s,algorithm=%s): %s", partyName, resolvedKey.Verifier.Verifier, ar.Algorithm, err)
 				}
-				tx.ptx.PostAssembly.Signatures = append(tx.ptx.PostAssembly.Signatures, &prototk.AttestationResult{
+				signatures = append(signatures, &prototk.AttestationResult{
 					Name:            ar.Name,
 					AttestationType: ar.AttestationType,
 					Verifier: &prototk.ResolvedVerifier{
@@ -142,7 +147,7 @@ func (tb *testbed) gatherSignatures(ctx context.Context, tx *testbedTransaction)
 			}
 		}
 	}
-	return nil
+	return signatures, nil
 }
 
 func (tb *testbed) writeNullifiersToContext(dCtx components.DomainContext, tx *components.PrivateTransaction) error {
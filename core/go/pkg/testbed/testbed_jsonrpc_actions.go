@@ -503,20 +503,25 @@ func (tb *testbed) rpcTestbedCall() rpcserver.RPCHandler {
 			return nil, err
 		}
 
-		requiredVerifiers, err := tx.psc.InitCall(ctx, tx.localTx)
+		dCtx := tb.c.StateManager().NewDomainContext(ctx, tx.psc.Domain(), tx.psc.Address())
+		defer dCtx.Close()
+
+		initRes, err := tx.psc.InitCall(dCtx, tb.c.Persistence().NOTX(), tx.localTx)
 		if err != nil {
 			return nil, err
 		}
 
-		resolvedVerifiers, err := tb.resolveVerifiers(ctx, requiredVerifiers)
+		resolvedVerifiers, err := tb.resolveVerifiers(ctx, initRes.RequiredVerifiers)
 		if err != nil {
 			return nil, err
 		}
 
-		dCtx := tb.c.StateManager().NewDomainContext(ctx, tx.psc.Domain(), tx.psc.Address())
-		defer dCtx.Close()
+		signatures, err := tb.signAttestations(ctx, initRes.AttestationPlan)
+		if err != nil {
+			return nil, err
+		}
 
-		cv, err := tx.psc.ExecCall(dCtx, tb.c.Persistence().NOTX(), tx.localTx, resolvedVerifiers)
+		cv, err := tx.psc.ExecCall(dCtx, tb.c.Persistence().NOTX(), tx.localTx, resolvedVerifiers, signatures)
 		if err != nil {
 			return nil, err
 		}
@@ -30,7 +30,7 @@ type ethClientKeyMgrShim struct {
 	resolved map[string]*pldapi.KeyMappingAndVerifier
 }
 
-func (e *ethClientKeyMgrShim) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) {
+func (e *ethClientKeyMgrShim) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error {
 	panic("unimplemented")
 }
 
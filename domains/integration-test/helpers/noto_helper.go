@@ -22,6 +22,7 @@ import (
 
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/solutils"
@@ -85,6 +86,16 @@ func (n *NotoHelper) Transfer(ctx context.Context, to string, amount int64) *Dom
 	}))
 }
 
+func (n *NotoHelper) TransferBatch(ctx context.Context, params *types.TransferBatchParams) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["transferBatch"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
+}
+
+func (n *NotoHelper) Consolidate(ctx context.Context) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["consolidate"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, &types.ConsolidateParams{}))
+}
+
 func (n *NotoHelper) ApproveTransfer(ctx context.Context, params *types.ApproveParams) *DomainTransactionHelper {
 	fn := types.NotoABI.Functions()["approveTransfer"]
 	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
@@ -109,3 +120,65 @@ func (n *NotoHelper) DelegateLock(ctx context.Context, params *types.DelegateLoc
 	fn := types.NotoABI.Functions()["delegateLock"]
 	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
 }
+
+func (n *NotoHelper) ReclaimLock(ctx context.Context, params *types.ReclaimLockParams) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["reclaimLock"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
+}
+
+func (n *NotoHelper) ChangeNotary(ctx context.Context, params *types.ChangeNotaryParams) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["changeNotary"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
+}
+
+func (n *NotoHelper) Pause(ctx context.Context, params *types.PauseParams) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["pause"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
+}
+
+func (n *NotoHelper) Unpause(ctx context.Context, params *types.PauseParams) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["unpause"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
+}
+
+func (n *NotoHelper) Freeze(ctx context.Context, params *types.FreezeParams) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["freeze"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
+}
+
+func (n *NotoHelper) Unfreeze(ctx context.Context, params *types.FreezeParams) *DomainTransactionHelper {
+	fn := types.NotoABI.Functions()["unfreeze"]
+	return NewDomainTransactionHelper(ctx, n.t, n.rpc, n.Address, fn, toJSON(n.t, params))
+}
+
+func (n *NotoHelper) BalanceOf(ctx context.Context, account string) *types.BalanceOfResult {
+	var result types.BalanceOfResult
+	n.call(ctx, &result, "balanceOf", &types.BalanceOfParams{Account: account})
+	return &result
+}
+
+func (n *NotoHelper) LockedBalanceOf(ctx context.Context, account string, lockID pldtypes.Bytes32) *types.BalanceOfResult {
+	var result types.BalanceOfResult
+	n.call(ctx, &result, "lockedBalanceOf", &types.LockedBalanceOfParams{Account: account, LockID: lockID})
+	return &result
+}
+
+func (n *NotoHelper) GetLock(ctx context.Context, lockID pldtypes.Bytes32) *types.GetLockResult {
+	var result types.GetLockResult
+	n.call(ctx, &result, "getLock", &types.GetLockParams{LockID: lockID})
+	return &result
+}
+
+func (n *NotoHelper) call(ctx context.Context, result any, function string, params any) {
+	rpcerr := n.rpc.CallRPC(ctx, result, "testbed_call", &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			To:       n.Address,
+			Function: function,
+			Data:     toJSON(n.t, params),
+		},
+		ABI: types.NotoABI,
+	}, pldtypes.DefaultJSONFormatOptions)
+	if rpcerr != nil {
+		assert.NoError(n.t, rpcerr)
+	}
+}
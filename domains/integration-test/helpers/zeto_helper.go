@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/core/pkg/testbed"
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -225,6 +226,32 @@ func (z *ZetoHelper) Withdraw(ctx context.Context, amount int64) *DomainTransact
 	return NewDomainTransactionHelper(ctx, z.t, z.rpc, z.Address, fn, toJSON(z.t, &params))
 }
 
+func (z *ZetoHelperFungible) BalanceOf(ctx context.Context, account string) *types.BalanceOfResult {
+	var result types.BalanceOfResult
+	z.call(ctx, &result, types.ZetoFungibleABI, types.METHOD_BALANCE_OF, &types.BalanceOfParams{Account: account})
+	return &result
+}
+
+func (z *ZetoHelperFungible) GetMerkleProof(ctx context.Context, utxo *pldtypes.HexUint256) *types.GetMerkleProofResult {
+	var result types.GetMerkleProofResult
+	z.call(ctx, &result, types.ZetoFungibleABI, types.METHOD_GET_MERKLE_PROOF, &types.GetMerkleProofParams{UTXO: utxo})
+	return &result
+}
+
+func (z *ZetoHelper) call(ctx context.Context, result any, a abi.ABI, function string, params any) {
+	rpcerr := z.rpc.CallRPC(ctx, result, "testbed_call", &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			To:       z.Address,
+			Function: function,
+			Data:     toJSON(z.t, params),
+		},
+		ABI: a,
+	}, pldtypes.DefaultJSONFormatOptions)
+	if rpcerr != nil {
+		assert.NoError(z.t, rpcerr)
+	}
+}
+
 // =============================================================================
 //
 //	NonFungible
@@ -276,3 +303,15 @@ func (n *ZetoHelperNonFungible) Transfer(ctx context.Context, to string, tokenID
 		},
 	}))
 }
+
+func (n *ZetoHelperNonFungible) OwnerOf(ctx context.Context, tokenID *pldtypes.HexUint256) pldtypes.HexBytes {
+	var result types.OwnerOfResult
+	n.call(ctx, &result, types.ZetoNonFungibleABI, types.METHOD_OWNER_OF, &types.OwnerOfParams{TokenID: tokenID})
+	return result.Owner
+}
+
+func (n *ZetoHelperNonFungible) TokensOf(ctx context.Context, account string) []*types.TokensOfEntry {
+	var result types.TokensOfResult
+	n.call(ctx, &result, types.ZetoNonFungibleABI, types.METHOD_TOKENS_OF, &types.TokensOfParams{Account: account})
+	return result.Tokens
+}
@@ -207,6 +207,12 @@ func (s *notoTestSuite) TestNoto() {
 	assert.Equal(t, int64(50), coins[1].Data.Amount.Int().Int64())
 	assert.Equal(t, recipient2Key.Verifier.Verifier, coins[1].Data.Owner.String())
 
+	balance := noto.BalanceOf(ctx, recipient2Name)
+	assert.Equal(t, int64(1), balance.TotalStates.Int().Int64())
+	assert.Equal(t, int64(50), balance.TotalBalance.Int().Int64())
+	balance = noto.BalanceOf(ctx, recipient1Name)
+	assert.Zero(t, balance.TotalStates.Int().Int64())
+
 	log.L(ctx).Infof("Burn 25 from recipient2")
 	rpcerr = rpc.CallRPC(ctx, &invokeResult, "testbed_invoke", &pldapi.TransactionInput{
 		TransactionBase: pldapi.TransactionBase{
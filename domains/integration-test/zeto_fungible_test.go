@@ -114,6 +114,17 @@ func (s *fungibleTestSuiteHelper) testZeto(t *testing.T, tokenName string, useBa
 	assert.Equal(t, int64(20), coins[1].Data.Amount.Int().Int64())
 	assert.Equal(t, controllerAddr.String(), coins[1].Data.Owner.String())
 
+	balance := zeto.BalanceOf(ctx, controllerName)
+	assert.Equal(t, int64(2), balance.TotalStates.Int().Int64())
+	assert.Equal(t, int64(30), balance.TotalBalance.Int().Int64())
+	assert.Zero(t, balance.LockedStates.Int().Int64())
+
+	if isNullifiersToken {
+		proof := zeto.GetMerkleProof(ctx, &coins[0].ID)
+		assert.False(t, proof.Root.NilOrZero())
+		assert.NotEmpty(t, proof.Siblings)
+	}
+
 	// for testing the batch circuits, we mint the 3rd UTXO
 	if useBatch {
 		log.L(ctx).Info("*************************************")
@@ -58,6 +58,8 @@ func (s *nonFungibleTestSuiteHelper) testZeto(t *testing.T, tokenName string, is
 	controllerNFTs := findAvailableNFTs(t, ctx, s.rpc, s.domain.Name(), s.domain.NFTSchemaID(), zetoAddress, nil, isNullifiersToken, &controllerAddr)
 	controllerNFTs = filterNFTs(controllerNFTs, &controllerAddr)
 	require.Len(t, controllerNFTs, len(uris))
+	assert.Len(t, zeto.TokensOf(ctx, controllerName), len(uris))
+	assert.Equal(t, controllerAddr.String(), zeto.OwnerOf(ctx, controllerNFTs[0].Data.TokenID).String())
 	for i := range controllerNFTs {
 		assert.Equal(t, controllerAddr.String(), controllerNFTs[i].Data.Owner.String())
 		assert.Equal(t, uris[i], controllerNFTs[i].Data.URI)
@@ -69,4 +69,28 @@ var (
 	MsgMissingStateData            = pde("PD200029", "Missing state data for one or more states: %s")
 	MsgLockNotAllowed              = pde("PD200030", "Lock is not enabled")
 	MsgUnlockOnlyCreator           = pde("PD200031", "Only the lock creator can perform unlock: expected=%s actual=%s")
+	MsgNotCallFunction             = pde("PD200032", "Function '%s' modifies state, and must be submitted as a transaction")
+	MsgUnknownCoinSelection        = pde("PD200033", "Unknown coin selection strategy '%s'")
+	MsgTooManyInputs               = pde("PD200034", "Amount %s cannot be covered by at most %d input states (selected=%s)")
+	MsgNothingToConsolidate        = pde("PD200035", "At least 2 states are required for consolidation (available=%d)")
+	MsgInvalidConsolidation        = pde("PD200036", "Consolidation must produce a single output state (outputs=%d)")
+	MsgChangeNotaryOnlyNotary      = pde("PD200037", "Notary can only be changed by the current notary: expected=%s actual=%s")
+	MsgChangeNotaryNotAllowed      = pde("PD200038", "Notary cannot be changed in notary mode '%s'")
+	MsgRestrictionNotAllowed       = pde("PD200039", "Function '%s' is not enabled for this contract")
+	MsgRestrictionOnlyNotary       = pde("PD200040", "Function '%s' can only be initiated by notary: expected=%s actual=%s")
+	MsgContractPaused              = pde("PD200041", "Contract is paused")
+	MsgAccountFrozen               = pde("PD200042", "Account %s is frozen")
+	MsgMaxSupplyExceeded           = pde("PD200043", "Mint of %s would exceed the max supply of %s (minted=%s)")
+	MsgAlreadyPaused               = pde("PD200044", "Contract is already paused")
+	MsgNotPaused                   = pde("PD200045", "Contract is not paused")
+	MsgAlreadyFrozen               = pde("PD200046", "Account %s is already frozen")
+	MsgNotFrozen                   = pde("PD200047", "Account %s is not frozen")
+	MsgInvalidRestriction          = pde("PD200048", "Invalid restriction states for '%s'")
+	MsgNotSupportedByNotaryMode    = pde("PD200049", "Function '%s' is not supported in notary mode '%s'")
+	MsgLockExpiryInPast            = pde("PD200050", "Lock expiry timestamp %d is in the past")
+	MsgNoLockedStatesToReclaim     = pde("PD200051", "No locked states owned by '%s' were found for lock %s")
+	MsgMaxSupplyNeedsRestrictMint  = pde("PD200052", "Option 'maxSupply' requires 'restrictMint', as the supply is tracked by the notary")
+	MsgInvalidSupply               = pde("PD200053", "Mint must create a single supply state with minted=%s")
+	MsgMultipleSupplyStates        = pde("PD200054", "Found %d available supply states, but the notary must hold exactly one")
+	MsgSupplyNotSpent              = pde("PD200055", "Mint does not spend the current supply state %s")
 )
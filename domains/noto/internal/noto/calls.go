@@ -0,0 +1,246 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+const defaultQueryPageSize = 100
+
+// Read-only calls are answered from the available (confirmed and unspent) states
+// known to the local node, so they only reflect the states distributed to this node.
+func (n *Noto) InitCall(ctx context.Context, req *prototk.InitCallRequest) (*prototk.InitCallResponse, error) {
+	params, err := n.validateCall(ctx, req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &prototk.InitCallResponse{}
+	switch p := params.(type) {
+	case *types.BalanceOfParams:
+		res.RequiredVerifiers = n.ethAddressVerifiers(p.Account)
+	case *types.LockedBalanceOfParams:
+		res.RequiredVerifiers = n.ethAddressVerifiers(p.Account)
+	}
+	return res, nil
+}
+
+func (n *Noto) ExecCall(ctx context.Context, req *prototk.ExecCallRequest) (*prototk.ExecCallResponse, error) {
+	params, err := n.validateCall(ctx, req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	switch p := params.(type) {
+	case *types.BalanceOfParams:
+		result, err = n.balanceOf(ctx, req.StateQueryContext, p, req.ResolvedVerifiers)
+	case *types.LockedBalanceOfParams:
+		result, err = n.lockedBalanceOf(ctx, req.StateQueryContext, p, req.ResolvedVerifiers)
+	case *types.GetLockParams:
+		result, err = n.getLock(ctx, req.StateQueryContext, p)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.ExecCallResponse{
+		ResultJson: string(resultJSON),
+	}, nil
+}
+
+func (n *Noto) validateCall(ctx context.Context, tx *prototk.TransactionSpecification) (any, error) {
+	var functionABI abi.Entry
+	err := json.Unmarshal([]byte(tx.FunctionAbiJson), &functionABI)
+	if err != nil {
+		return nil, err
+	}
+
+	abi := types.NotoABI.Functions()[functionABI.Name]
+	if abi == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgUnknownFunction, functionABI.Name)
+	}
+
+	var params any
+	switch functionABI.Name {
+	case "balanceOf":
+		var balanceParams types.BalanceOfParams
+		if err := json.Unmarshal([]byte(tx.FunctionParamsJson), &balanceParams); err != nil {
+			return nil, err
+		}
+		if balanceParams.Account == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "account")
+		}
+		params = &balanceParams
+	case "lockedBalanceOf":
+		var balanceParams types.LockedBalanceOfParams
+		if err := json.Unmarshal([]byte(tx.FunctionParamsJson), &balanceParams); err != nil {
+			return nil, err
+		}
+		if balanceParams.Account == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "account")
+		}
+		if balanceParams.LockID.IsZero() {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "lockId")
+		}
+		params = &balanceParams
+	case "getLock":
+		var lockParams types.GetLockParams
+		if err := json.Unmarshal([]byte(tx.FunctionParamsJson), &lockParams); err != nil {
+			return nil, err
+		}
+		if lockParams.LockID.IsZero() {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "lockId")
+		}
+		params = &lockParams
+	default:
+		// Functions that modify state must be submitted as transactions
+		return nil, i18n.NewError(ctx, msgs.MsgNotCallFunction, functionABI.Name)
+	}
+
+	signature, err := abi.SolidityStringCtx(ctx)
+	if err == nil && tx.FunctionSignature != signature {
+		err = i18n.NewError(ctx, msgs.MsgUnexpectedFunctionSignature, functionABI.Name, signature, tx.FunctionSignature)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (n *Noto) balanceOf(ctx context.Context, stateQueryContext string, params *types.BalanceOfParams, verifiers []*prototk.ResolvedVerifier) (*types.BalanceOfResult, error) {
+	owner, err := n.findEthAddressVerifier(ctx, "account", params.Account, verifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	totalStates, totalBalance := big.NewInt(0), big.NewInt(0)
+	err = n.forEachAvailableState(ctx, stateQueryContext, n.coinSchema.Id,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder().Equal("owner", owner.String())
+		},
+		func(state *prototk.StoredState) error {
+			coin, err := n.unmarshalCoin(state.DataJson)
+			if err != nil {
+				return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+			}
+			totalStates.Add(totalStates, big.NewInt(1))
+			totalBalance.Add(totalBalance, coin.Amount.Int())
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &types.BalanceOfResult{
+		TotalStates:  (*pldtypes.HexUint256)(totalStates),
+		TotalBalance: (*pldtypes.HexUint256)(totalBalance),
+	}, nil
+}
+
+func (n *Noto) lockedBalanceOf(ctx context.Context, stateQueryContext string, params *types.LockedBalanceOfParams, verifiers []*prototk.ResolvedVerifier) (*types.BalanceOfResult, error) {
+	owner, err := n.findEthAddressVerifier(ctx, "account", params.Account, verifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	totalStates, totalBalance := big.NewInt(0), big.NewInt(0)
+	err = n.forEachAvailableState(ctx, stateQueryContext, n.lockedCoinSchema.Id,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder().Equal("lockId", params.LockID).Equal("owner", owner.String())
+		},
+		func(state *prototk.StoredState) error {
+			coin, err := n.unmarshalLockedCoin(state.DataJson)
+			if err != nil {
+				return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+			}
+			totalStates.Add(totalStates, big.NewInt(1))
+			totalBalance.Add(totalBalance, coin.Amount.Int())
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &types.BalanceOfResult{
+		TotalStates:  (*pldtypes.HexUint256)(totalStates),
+		TotalBalance: (*pldtypes.HexUint256)(totalBalance),
+	}, nil
+}
+
+// The lock info is recorded as an info state by every transaction that creates or updates a lock. The lock info
+// schema has no indexed fields, so all of the lock info states are paged through to find the latest for the lock.
+func (n *Noto) getLock(ctx context.Context, stateQueryContext string, params *types.GetLockParams) (*types.GetLockResult, error) {
+	var lock *types.NotoLockInfo
+	err := domain.ForEachState(ctx, n.queryPageSize(), query.NewQueryBuilder,
+		func(query string) ([]*prototk.StoredState, error) {
+			return n.findInfoStates(ctx, stateQueryContext, n.lockInfoSchema.Id, query)
+		},
+		func(state *prototk.StoredState) error {
+			lockInfo, err := n.unmarshalLock(state.DataJson)
+			if err != nil {
+				return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+			}
+			if lockInfo.LockID == params.LockID {
+				lock = lockInfo
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgLockIDNotFound)
+	}
+	result := &types.GetLockResult{Lock: lock}
+	expiry, err := n.findLockExpiry(ctx, stateQueryContext, params.LockID)
+	if err != nil {
+		return nil, err
+	}
+	if expiry != nil {
+		result.Expiry = *expiry
+	}
+	return result, nil
+}
+
+func (n *Noto) queryPageSize() int {
+	if n.config.QueryPageSize != nil {
+		return *n.config.QueryPageSize
+	}
+	return defaultQueryPageSize
+}
+
+// Pages through all available states matching the filter, in the order they were created
+func (n *Noto) forEachAvailableState(ctx context.Context, stateQueryContext, schemaId string, newQuery func() query.QueryBuilder, fn func(state *prototk.StoredState) error) error {
+	return domain.ForEachState(ctx, n.queryPageSize(), newQuery,
+		func(query string) ([]*prototk.StoredState, error) {
+			return n.findAvailableStates(ctx, stateQueryContext, schemaId, query)
+		}, fn)
+}
@@ -0,0 +1,267 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCallNoto() *Noto {
+	return &Noto{
+		Callbacks:        mockCallbacks,
+		coinSchema:       &prototk.StateSchema{Id: "coin"},
+		lockedCoinSchema: &prototk.StateSchema{Id: "lockedCoin"},
+		lockInfoSchema:   &prototk.StateSchema{Id: "lockInfo"},
+		lockExpirySchema: &prototk.StateSchema{Id: "lockExpiry"},
+		dataSchema:       &prototk.StateSchema{Id: "data"},
+	}
+}
+
+func newTestCall(fnName, params string) *prototk.TransactionSpecification {
+	fn := types.NotoABI.Functions()[fnName]
+	return &prototk.TransactionSpecification{
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(notoBasicConfig),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: params,
+	}
+}
+
+// Returns the supplied pages of states, followed by an empty page
+func mockAvailableStatePages(pages ...[]*prototk.StoredState) *int {
+	calls := 0
+	mockCallbacks.MockFindAvailableStates = func() (*prototk.FindAvailableStatesResponse, error) {
+		res := &prototk.FindAvailableStatesResponse{}
+		if calls < len(pages) {
+			res.States = pages[calls]
+		}
+		calls++
+		return res, nil
+	}
+	return &calls
+}
+
+func TestBalanceOf(t *testing.T) {
+	n := newTestCallNoto()
+	ctx := context.Background()
+	owner := pldtypes.RandAddress()
+
+	tx := newTestCall("balanceOf", `{"account": "owner@node1"}`)
+	initRes, err := n.InitCall(ctx, &prototk.InitCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 1)
+	assert.Equal(t, "owner@node1", initRes.RequiredVerifiers[0].Lookup)
+
+	calls := mockAvailableStatePages(
+		[]*prototk.StoredState{
+			{Id: "0x01", SchemaId: "coin", CreatedAt: 1, DataJson: mustParseJSON(&types.NotoCoin{Owner: owner, Amount: pldtypes.Int64ToInt256(10)})},
+			{Id: "0x02", SchemaId: "coin", CreatedAt: 2, DataJson: mustParseJSON(&types.NotoCoin{Owner: owner, Amount: pldtypes.Int64ToInt256(20)})},
+		},
+		[]*prototk.StoredState{
+			{Id: "0x03", SchemaId: "coin", CreatedAt: 3, DataJson: mustParseJSON(&types.NotoCoin{Owner: owner, Amount: pldtypes.Int64ToInt256(30)})},
+		},
+	)
+
+	execRes, err := n.ExecCall(ctx, &prototk.ExecCallRequest{
+		Transaction: tx,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{{
+			Lookup:       "owner@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     owner.String(),
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, *calls)
+	assert.JSONEq(t, `{"totalStates":"0x3","totalBalance":"0x3c"}`, execRes.ResultJson)
+
+	// The result must decode against the outputs of the private ABI
+	_, err = types.NotoABI.Functions()["balanceOf"].Outputs.ParseJSON([]byte(execRes.ResultJson))
+	require.NoError(t, err)
+}
+
+func TestBalanceOfBadState(t *testing.T) {
+	n := newTestCallNoto()
+	owner := pldtypes.RandAddress()
+	mockAvailableStatePages([]*prototk.StoredState{
+		{Id: "0x01", SchemaId: "coin", DataJson: "!!wrong"},
+	})
+
+	_, err := n.ExecCall(context.Background(), &prototk.ExecCallRequest{
+		Transaction: newTestCall("balanceOf", `{"account": "owner@node1"}`),
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{{
+			Lookup:       "owner@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     owner.String(),
+		}},
+	})
+	assert.ErrorContains(t, err, "PD200006")
+}
+
+func TestBalanceOfMissingVerifier(t *testing.T) {
+	n := newTestCallNoto()
+	_, err := n.ExecCall(context.Background(), &prototk.ExecCallRequest{
+		Transaction: newTestCall("balanceOf", `{"account": "owner@node1"}`),
+	})
+	assert.ErrorContains(t, err, "PD200011")
+}
+
+func TestLockedBalanceOf(t *testing.T) {
+	n := newTestCallNoto()
+	ctx := context.Background()
+	owner := pldtypes.RandAddress()
+	lockID := pldtypes.RandBytes32()
+
+	tx := newTestCall("lockedBalanceOf", fmt.Sprintf(`{"account": "owner@node1", "lockId": "%s"}`, lockID))
+	initRes, err := n.InitCall(ctx, &prototk.InitCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 1)
+	assert.Equal(t, "owner@node1", initRes.RequiredVerifiers[0].Lookup)
+
+	mockAvailableStatePages([]*prototk.StoredState{
+		{Id: "0x01", SchemaId: "lockedCoin", CreatedAt: 1, DataJson: mustParseJSON(&types.NotoLockedCoin{LockID: lockID, Owner: owner, Amount: pldtypes.Int64ToInt256(15)})},
+	})
+
+	execRes, err := n.ExecCall(ctx, &prototk.ExecCallRequest{
+		Transaction: tx,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{{
+			Lookup:       "owner@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     owner.String(),
+		}},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"totalStates":"0x1","totalBalance":"0xf"}`, execRes.ResultJson)
+}
+
+func TestGetLock(t *testing.T) {
+	n := newTestCallNoto()
+	ctx := context.Background()
+	owner := pldtypes.RandAddress()
+	delegate := pldtypes.RandAddress()
+	lockID := pldtypes.RandBytes32()
+
+	tx := newTestCall("getLock", fmt.Sprintf(`{"lockId": "%s"}`, lockID))
+	initRes, err := n.InitCall(ctx, &prototk.InitCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	assert.Empty(t, initRes.RequiredVerifiers)
+
+	salt := pldtypes.RandBytes32()
+	calls := mockAvailableStatePages(
+		[]*prototk.StoredState{
+			{Id: "0x01", SchemaId: "lockInfo", CreatedAt: 1, DataJson: mustParseJSON(&types.NotoLockInfo{LockID: lockID, Owner: owner, Delegate: &pldtypes.EthAddress{}})},
+			{Id: "0x02", SchemaId: "lockInfo", CreatedAt: 2, DataJson: mustParseJSON(&types.NotoLockInfo{LockID: pldtypes.RandBytes32(), Owner: owner, Delegate: &pldtypes.EthAddress{}})},
+		},
+		[]*prototk.StoredState{
+			{Id: "0x03", SchemaId: "lockInfo", CreatedAt: 3, DataJson: mustParseJSON(&types.NotoLockInfo{Salt: salt, LockID: lockID, Owner: owner, Delegate: delegate})},
+		},
+		nil,
+		[]*prototk.StoredState{
+			{Id: "0x04", SchemaId: "lockExpiry", CreatedAt: 1, DataJson: mustParseJSON(&types.NotoLockExpiry{LockID: lockID, Expiry: types.LockExpiry{BlockNumber: 500}})},
+		},
+	)
+
+	execRes, err := n.ExecCall(ctx, &prototk.ExecCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	assert.Equal(t, 4, *calls)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"lock": {"salt":"%s","lockId":"%s","owner":"%s","delegate":"%s"},
+		"expiry": {"blockNumber":"0x1f4","timestamp":"0x0"}
+	}`, salt, lockID, owner, delegate), execRes.ResultJson)
+
+	_, err = types.NotoABI.Functions()["getLock"].Outputs.ParseJSON([]byte(execRes.ResultJson))
+	require.NoError(t, err)
+}
+
+func TestGetLockNotFound(t *testing.T) {
+	n := newTestCallNoto()
+	mockAvailableStatePages()
+
+	_, err := n.ExecCall(context.Background(), &prototk.ExecCallRequest{
+		Transaction: newTestCall("getLock", fmt.Sprintf(`{"lockId": "%s"}`, pldtypes.RandBytes32())),
+	})
+	assert.ErrorContains(t, err, "PD200028")
+}
+
+func TestGetLockBadState(t *testing.T) {
+	n := newTestCallNoto()
+	mockAvailableStatePages([]*prototk.StoredState{{Id: "0x01", DataJson: "!!wrong"}})
+
+	_, err := n.ExecCall(context.Background(), &prototk.ExecCallRequest{
+		Transaction: newTestCall("getLock", fmt.Sprintf(`{"lockId": "%s"}`, pldtypes.RandBytes32())),
+	})
+	assert.ErrorContains(t, err, "PD200006")
+}
+
+func TestCallBadFunctions(t *testing.T) {
+	n := newTestCallNoto()
+	ctx := context.Background()
+
+	_, err := n.InitCall(ctx, &prototk.InitCallRequest{Transaction: &prototk.TransactionSpecification{
+		FunctionAbiJson: "!!wrong",
+	}})
+	assert.ErrorContains(t, err, "invalid character")
+
+	_, err = n.InitCall(ctx, &prototk.InitCallRequest{Transaction: &prototk.TransactionSpecification{
+		FunctionAbiJson: `{"name": "does-not-exist"}`,
+	}})
+	assert.ErrorContains(t, err, "PD200001")
+
+	_, err = n.InitCall(ctx, &prototk.InitCallRequest{Transaction: newTestCall("transfer", `{}`)})
+	assert.ErrorContains(t, err, "PD200032")
+
+	tx := newTestCall("balanceOf", `{"account": "owner@node1"}`)
+	tx.FunctionSignature = "wrong"
+	_, err = n.ExecCall(ctx, &prototk.ExecCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD200002")
+}
+
+func TestCallBadParams(t *testing.T) {
+	n := newTestCallNoto()
+	ctx := context.Background()
+
+	for _, fnName := range []string{"balanceOf", "lockedBalanceOf", "getLock"} {
+		_, err := n.InitCall(ctx, &prototk.InitCallRequest{Transaction: newTestCall(fnName, "!!wrong")})
+		assert.ErrorContains(t, err, "invalid character")
+	}
+
+	_, err := n.InitCall(ctx, &prototk.InitCallRequest{Transaction: newTestCall("balanceOf", `{}`)})
+	assert.Regexp(t, "PD200007.*account", err)
+
+	_, err = n.InitCall(ctx, &prototk.InitCallRequest{Transaction: newTestCall("lockedBalanceOf", `{}`)})
+	assert.Regexp(t, "PD200007.*account", err)
+
+	_, err = n.InitCall(ctx, &prototk.InitCallRequest{Transaction: newTestCall("lockedBalanceOf", `{"account": "owner@node1"}`)})
+	assert.Regexp(t, "PD200007.*lockId", err)
+
+	_, err = n.InitCall(ctx, &prototk.InitCallRequest{Transaction: newTestCall("getLock", `{}`)})
+	assert.Regexp(t, "PD200007.*lockId", err)
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"math/big"
+	"slices"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// Maximum number of states merged by a consolidation, when no maxInputs is configured
+const defaultConsolidateInputs = 10
+
+type availableCoin struct {
+	state *prototk.StoredState
+	coin  *types.NotoCoin
+}
+
+func validateCoinSelectionConfig(ctx context.Context, config *types.CoinSelectionConfig) error {
+	switch config.Strategy {
+	case "", types.CoinSelectionOldestFirst, types.CoinSelectionLargestFirst, types.CoinSelectionBestFit:
+	default:
+		return i18n.NewError(ctx, msgs.MsgUnknownCoinSelection, config.Strategy)
+	}
+	if config.MaxInputs != nil && *config.MaxInputs < 1 {
+		return i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, "coinSelection.maxInputs")
+	}
+	if config.ConsolidateThreshold != nil && *config.ConsolidateThreshold < 1 {
+		return i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, "coinSelection.consolidateThreshold")
+	}
+	return nil
+}
+
+func (n *Noto) maxInputs() int {
+	if n.config.CoinSelection.MaxInputs != nil {
+		return *n.config.CoinSelection.MaxInputs
+	}
+	return 0
+}
+
+func (n *Noto) consolidateLimit() int {
+	if maxInputs := n.maxInputs(); maxInputs > 0 {
+		return maxInputs
+	}
+	return defaultConsolidateInputs
+}
+
+// Loads every available coin of the owner, oldest first
+func (n *Noto) loadAvailableCoins(ctx context.Context, stateQueryContext string, owner *pldtypes.EthAddress) ([]*availableCoin, error) {
+	var coins []*availableCoin
+	err := n.forEachAvailableState(ctx, stateQueryContext, n.coinSchema.Id,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder().Equal("owner", owner.String())
+		},
+		func(state *prototk.StoredState) error {
+			coin, err := n.unmarshalCoin(state.DataJson)
+			if err != nil {
+				return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+			}
+			coins = append(coins, &availableCoin{state: state, coin: coin})
+			return nil
+		})
+	return coins, err
+}
+
+// Selects inputs to cover the amount from the full set of available coins, using the configured strategy.
+// When the owner holds more coins than the consolidation threshold, the smallest remaining coins are
+// also spent, so that they are merged into the change returned to the owner.
+func (n *Noto) selectInputs(ctx context.Context, stateQueryContext string, owner *pldtypes.EthAddress, amount *pldtypes.HexUint256) (inputs *preparedInputs, revert bool, err error) {
+	coins, err := n.loadAvailableCoins(ctx, stateQueryContext, owner)
+	if err != nil {
+		return nil, false, err
+	}
+
+	maxInputs := n.maxInputs()
+	selected, total, covered := selectCoins(n.config.CoinSelection.Strategy, coins, amount.Int(), maxInputs)
+	if !covered {
+		available := sumCoins(coins)
+		if available.Cmp(amount.Int()) < 0 {
+			return nil, true, i18n.NewError(ctx, msgs.MsgInsufficientFunds, available.Text(10))
+		}
+		return nil, true, i18n.NewError(ctx, msgs.MsgTooManyInputs, amount.Int().Text(10), maxInputs, total.Text(10))
+	}
+
+	threshold := n.config.CoinSelection.ConsolidateThreshold
+	if threshold != nil && len(coins) > *threshold {
+		limit := n.consolidateLimit()
+		for _, c := range smallestFirst(coins) {
+			if len(selected) >= limit {
+				break
+			}
+			if !slices.Contains(selected, c) {
+				log.L(ctx).Debugf("Consolidating coin %s value=%s", c.state.Id, c.coin.Amount.Int().Text(10))
+				selected = append(selected, c)
+			}
+		}
+	}
+
+	return newPreparedInputs(selected), false, nil
+}
+
+// Selects the smallest available coins of the owner, to be merged into a single coin
+func (n *Noto) prepareConsolidateInputs(ctx context.Context, stateQueryContext string, owner *pldtypes.EthAddress) (inputs *preparedInputs, revert bool, err error) {
+	coins, err := n.loadAvailableCoins(ctx, stateQueryContext, owner)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(coins) < 2 {
+		return nil, true, i18n.NewError(ctx, msgs.MsgNothingToConsolidate, len(coins))
+	}
+	selected := smallestFirst(coins)
+	if limit := n.consolidateLimit(); len(selected) > limit {
+		selected = selected[:limit]
+	}
+	return newPreparedInputs(selected), false, nil
+}
+
+func selectCoins(strategy types.CoinSelectionStrategy, coins []*availableCoin, amount *big.Int, maxInputs int) (selected []*availableCoin, total *big.Int, covered bool) {
+	ordered := coins
+	switch strategy {
+	case types.CoinSelectionLargestFirst:
+		ordered = largestFirst(coins)
+	case types.CoinSelectionBestFit:
+		// A single coin is the best fit if it exactly matches, or is the smallest coin that covers the amount
+		var bestFit *availableCoin
+		for _, c := range coins {
+			cmp := c.coin.Amount.Int().Cmp(amount)
+			if cmp == 0 {
+				bestFit = c
+				break
+			}
+			if cmp > 0 && (bestFit == nil || c.coin.Amount.Int().Cmp(bestFit.coin.Amount.Int()) < 0) {
+				bestFit = c
+			}
+		}
+		if bestFit != nil {
+			return []*availableCoin{bestFit}, new(big.Int).Set(bestFit.coin.Amount.Int()), true
+		}
+		ordered = largestFirst(coins)
+	}
+
+	total = big.NewInt(0)
+	for _, c := range ordered {
+		if maxInputs > 0 && len(selected) >= maxInputs {
+			break
+		}
+		selected = append(selected, c)
+		total.Add(total, c.coin.Amount.Int())
+		if total.Cmp(amount) >= 0 {
+			return selected, total, true
+		}
+	}
+	return selected, total, false
+}
+
+func largestFirst(coins []*availableCoin) []*availableCoin {
+	sorted := slices.Clone(coins)
+	slices.SortStableFunc(sorted, func(a, b *availableCoin) int {
+		return b.coin.Amount.Int().Cmp(a.coin.Amount.Int())
+	})
+	return sorted
+}
+
+func smallestFirst(coins []*availableCoin) []*availableCoin {
+	sorted := slices.Clone(coins)
+	slices.SortStableFunc(sorted, func(a, b *availableCoin) int {
+		return a.coin.Amount.Int().Cmp(b.coin.Amount.Int())
+	})
+	return sorted
+}
+
+func sumCoins(coins []*availableCoin) *big.Int {
+	total := big.NewInt(0)
+	for _, c := range coins {
+		total.Add(total, c.coin.Amount.Int())
+	}
+	return total
+}
+
+func newPreparedInputs(selected []*availableCoin) *preparedInputs {
+	inputs := &preparedInputs{
+		coins:  make([]*types.NotoCoin, len(selected)),
+		states: make([]*prototk.StateRef, len(selected)),
+		total:  sumCoins(selected),
+	}
+	for i, c := range selected {
+		inputs.coins[i] = c.coin
+		inputs.states[i] = &prototk.StateRef{
+			SchemaId: c.state.SchemaId,
+			Id:       c.state.Id,
+		}
+	}
+	return inputs
+}
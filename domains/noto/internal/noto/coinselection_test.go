@@ -0,0 +1,284 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCoinStates(owner *pldtypes.EthAddress, amounts ...int64) []*prototk.StoredState {
+	states := make([]*prototk.StoredState, len(amounts))
+	for i, amount := range amounts {
+		states[i] = &prototk.StoredState{
+			Id:        fmt.Sprintf("0x%02x", i+1),
+			SchemaId:  "coin",
+			CreatedAt: int64(i + 1),
+			DataJson:  mustParseJSON(&types.NotoCoin{Owner: owner, Amount: pldtypes.Int64ToInt256(amount)}),
+		}
+	}
+	return states
+}
+
+func testAvailableCoins(amounts ...int64) []*availableCoin {
+	coins := make([]*availableCoin, len(amounts))
+	for i, amount := range amounts {
+		coins[i] = &availableCoin{
+			state: &prototk.StoredState{Id: fmt.Sprintf("0x%02x", i+1), SchemaId: "coin"},
+			coin:  &types.NotoCoin{Amount: pldtypes.Int64ToInt256(amount)},
+		}
+	}
+	return coins
+}
+
+func selectedIDs(selected []*availableCoin) []string {
+	ids := make([]string, len(selected))
+	for i, c := range selected {
+		ids[i] = c.state.Id
+	}
+	return ids
+}
+
+func TestSelectCoins(t *testing.T) {
+	coins := testAvailableCoins(5, 30, 10, 20)
+
+	selected, total, covered := selectCoins(types.CoinSelectionOldestFirst, coins, big.NewInt(15), 0)
+	assert.True(t, covered)
+	assert.Equal(t, int64(35), total.Int64())
+	assert.Equal(t, []string{"0x01", "0x02"}, selectedIDs(selected))
+
+	selected, total, covered = selectCoins(types.CoinSelectionLargestFirst, coins, big.NewInt(45), 0)
+	assert.True(t, covered)
+	assert.Equal(t, int64(50), total.Int64())
+	assert.Equal(t, []string{"0x02", "0x04"}, selectedIDs(selected))
+
+	// Exact match
+	selected, total, covered = selectCoins(types.CoinSelectionBestFit, coins, big.NewInt(10), 0)
+	assert.True(t, covered)
+	assert.Equal(t, int64(10), total.Int64())
+	assert.Equal(t, []string{"0x03"}, selectedIDs(selected))
+
+	// Smallest single coin that covers the amount
+	selected, total, covered = selectCoins(types.CoinSelectionBestFit, coins, big.NewInt(15), 0)
+	assert.True(t, covered)
+	assert.Equal(t, int64(20), total.Int64())
+	assert.Equal(t, []string{"0x04"}, selectedIDs(selected))
+
+	// No single coin is enough, so falls back to largest first
+	selected, total, covered = selectCoins(types.CoinSelectionBestFit, coins, big.NewInt(55), 0)
+	assert.True(t, covered)
+	assert.Equal(t, int64(60), total.Int64())
+	assert.Equal(t, []string{"0x02", "0x04", "0x03"}, selectedIDs(selected))
+
+	// Limited by max inputs
+	selected, total, covered = selectCoins(types.CoinSelectionOldestFirst, coins, big.NewInt(60), 2)
+	assert.False(t, covered)
+	assert.Equal(t, int64(35), total.Int64())
+	assert.Len(t, selected, 2)
+
+	_, _, covered = selectCoins(types.CoinSelectionLargestFirst, coins, big.NewInt(60), 3)
+	assert.True(t, covered)
+}
+
+func TestPrepareInputsOldestFirstPaging(t *testing.T) {
+	pageSize := 2
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		config:     types.DomainConfig{QueryPageSize: &pageSize},
+	}
+	ctx := context.Background()
+	owner := pldtypes.RandAddress()
+
+	// Stops reading pages once the amount is covered
+	states := testCoinStates(owner, 1, 2, 3, 4)
+	calls := mockAvailableStatePages(states[0:2], states[2:4])
+	inputs, revert, err := n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(6))
+	require.NoError(t, err)
+	assert.False(t, revert)
+	assert.Equal(t, int64(6), inputs.total.Int64())
+	require.Len(t, inputs.states, 3)
+	assert.Equal(t, "0x03", inputs.states[2].Id)
+	assert.Equal(t, 2, *calls)
+
+	calls = mockAvailableStatePages(states[0:2], states[2:4])
+	_, revert, err = n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(11))
+	assert.True(t, revert)
+	assert.Regexp(t, "PD200005.*available=10", err)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestPrepareInputsLargestFirst(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		config: types.DomainConfig{
+			CoinSelection: types.CoinSelectionConfig{Strategy: types.CoinSelectionLargestFirst},
+		},
+	}
+	ctx := context.Background()
+	owner := pldtypes.RandAddress()
+
+	states := testCoinStates(owner, 1, 2, 3, 4)
+	mockAvailableStatePages(states[0:2], states[2:4])
+	inputs, revert, err := n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(6))
+	require.NoError(t, err)
+	assert.False(t, revert)
+	assert.Equal(t, int64(7), inputs.total.Int64())
+	require.Len(t, inputs.states, 2)
+	assert.Equal(t, "0x04", inputs.states[0].Id)
+	assert.Equal(t, "0x03", inputs.states[1].Id)
+
+	mockAvailableStatePages(states)
+	_, revert, err = n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(11))
+	assert.True(t, revert)
+	assert.Regexp(t, "PD200005.*available=10", err)
+}
+
+func TestPrepareInputsMaxInputs(t *testing.T) {
+	maxInputs := 2
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		config: types.DomainConfig{
+			CoinSelection: types.CoinSelectionConfig{MaxInputs: &maxInputs},
+		},
+	}
+	ctx := context.Background()
+	owner := pldtypes.RandAddress()
+
+	// Oldest first streams the available states
+	states := testCoinStates(owner, 1, 2, 3, 4)
+	mockAvailableStatePages(states)
+	_, revert, err := n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(5))
+	assert.True(t, revert)
+	assert.Regexp(t, "PD200034.*5.*2.*selected=3", err)
+
+	n.config.CoinSelection.Strategy = types.CoinSelectionLargestFirst
+	mockAvailableStatePages(states)
+	inputs, revert, err := n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(5))
+	require.NoError(t, err)
+	assert.False(t, revert)
+	assert.Equal(t, int64(7), inputs.total.Int64())
+
+	mockAvailableStatePages(states)
+	_, revert, err = n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(8))
+	assert.True(t, revert)
+	assert.Regexp(t, "PD200034.*8.*2.*selected=7", err)
+}
+
+func TestPrepareInputsAutoConsolidate(t *testing.T) {
+	threshold := 3
+	maxInputs := 4
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		config: types.DomainConfig{
+			CoinSelection: types.CoinSelectionConfig{
+				ConsolidateThreshold: &threshold,
+				MaxInputs:            &maxInputs,
+			},
+		},
+	}
+	ctx := context.Background()
+	owner := pldtypes.RandAddress()
+
+	// Below the threshold, only the required coins are spent
+	mockAvailableStatePages(testCoinStates(owner, 10, 1, 2))
+	inputs, _, err := n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(5))
+	require.NoError(t, err)
+	require.Len(t, inputs.states, 1)
+
+	// Above the threshold, the smallest coins are merged into the change
+	mockAvailableStatePages(testCoinStates(owner, 10, 1, 3, 2, 5, 4))
+	inputs, _, err = n.prepareInputs(ctx, "sqc1", owner, pldtypes.Int64ToInt256(5))
+	require.NoError(t, err)
+	require.Len(t, inputs.states, 4)
+	assert.Equal(t, "0x01", inputs.states[0].Id)
+	assert.Equal(t, "0x02", inputs.states[1].Id)
+	assert.Equal(t, "0x04", inputs.states[2].Id)
+	assert.Equal(t, "0x03", inputs.states[3].Id)
+	assert.Equal(t, int64(16), inputs.total.Int64())
+}
+
+func TestPrepareInputsBadState(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		config: types.DomainConfig{
+			CoinSelection: types.CoinSelectionConfig{Strategy: types.CoinSelectionBestFit},
+		},
+	}
+	mockAvailableStatePages([]*prototk.StoredState{{Id: "0x01", DataJson: "!!wrong"}})
+	_, revert, err := n.prepareInputs(context.Background(), "sqc1", pldtypes.RandAddress(), pldtypes.Int64ToInt256(5))
+	assert.False(t, revert)
+	assert.Regexp(t, "PD200006", err)
+}
+
+func TestConfigureDomainCoinSelection(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	_, err := n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		ConfigJson: `{"coinSelection": {"strategy": "best_fit", "maxInputs": 5, "consolidateThreshold": 20}}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.CoinSelectionBestFit, n.config.CoinSelection.Strategy)
+	assert.Equal(t, 5, n.maxInputs())
+
+	n = &Noto{Callbacks: mockCallbacks}
+	_, err = n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		ConfigJson: `{"coinSelection": {"strategy": "random"}}`,
+	})
+	assert.Regexp(t, "PD200033.*random", err)
+
+	n = &Noto{Callbacks: mockCallbacks}
+	_, err = n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		ConfigJson: `{"coinSelection": {"maxInputs": 0}}`,
+	})
+	assert.Regexp(t, "PD200008.*coinSelection.maxInputs", err)
+
+	n = &Noto{Callbacks: mockCallbacks}
+	_, err = n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		ConfigJson: `{"coinSelection": {"consolidateThreshold": -1}}`,
+	})
+	assert.Regexp(t, "PD200008.*coinSelection.consolidateThreshold", err)
+}
+
+func TestConfigureDomainQueryPageSize(t *testing.T) {
+	ctx := context.Background()
+
+	n := &Noto{Callbacks: mockCallbacks}
+	_, err := n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		ConfigJson: `{"queryPageSize": 5}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, n.queryPageSize())
+
+	n = &Noto{Callbacks: mockCallbacks}
+	_, err = n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		ConfigJson: `{"queryPageSize": 0}`,
+	})
+	assert.Regexp(t, "PD200008.*queryPageSize", err)
+}
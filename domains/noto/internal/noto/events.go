@@ -134,6 +134,26 @@ func (n *Noto) HandleEventBatch(ctx context.Context, req *prototk.HandleEventBat
 			} else {
 				log.L(ctx).Warnf("Ignoring malformed NotoLockDelegated event in batch %s: %s", req.BatchId, err)
 			}
+
+		case eventSignatures[NotoNotaryChanged]:
+			log.L(ctx).Infof("Processing '%s' event in batch %s", ev.SoliditySignature, req.BatchId)
+			var notaryChanged NotoNotaryChanged_Event
+			if err := json.Unmarshal([]byte(ev.DataJson), &notaryChanged); err == nil {
+				txData, err := n.decodeTransactionData(ctx, notaryChanged.Data)
+				if err != nil {
+					return nil, err
+				}
+				n.recordTransactionInfo(ev, txData, &res)
+
+				// Hand the new configuration back to Paladin, so the contract is re-initialized with the new notary
+				if _, _, err := n.decodeConfig(ctx, notaryChanged.Config); err == nil {
+					res.ContractConfig = notaryChanged.Config
+				} else {
+					log.L(ctx).Warnf("Ignoring invalid config in NotoNotaryChanged event in batch %s: %s", req.BatchId, err)
+				}
+			} else {
+				log.L(ctx).Warnf("Ignoring malformed NotoNotaryChanged event in batch %s: %s", req.BatchId, err)
+			}
 		}
 	}
 	return &res, nil
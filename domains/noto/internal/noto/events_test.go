@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
@@ -301,3 +302,75 @@ func TestHandleEventBatch_NotoUnlockBadTransactionData(t *testing.T) {
 	_, err = n.HandleEventBatch(ctx, req)
 	require.ErrorContains(t, err, "FF22047")
 }
+
+func TestHandleEventBatch_NotoNotaryChanged(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	_, err := n.ConfigureDomain(context.Background(), &prototk.ConfigureDomainRequest{
+		ConfigJson: `{}`,
+	})
+	require.NoError(t, err)
+
+	config := encodedConfig(&types.NotoConfigData_V0{
+		NotaryLookup: "notary2@node2",
+	})
+	event := &NotoNotaryChanged_Event{
+		Notary:    pldtypes.RandAddress(),
+		Config:    config,
+		Signature: pldtypes.MustParseHexBytes("0x1234"),
+		Data:      pldtypes.MustParseHexBytes("0x"),
+	}
+	notoEventJson, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	req := &prototk.HandleEventBatchRequest{
+		Events: []*prototk.OnChainEvent{
+			{
+				SoliditySignature: eventSignatures[NotoNotaryChanged],
+				DataJson:          string(notoEventJson),
+			},
+		},
+	}
+
+	res, err := n.HandleEventBatch(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, res.TransactionsComplete, 1)
+	assert.Equal(t, config, res.ContractConfig)
+}
+
+func TestHandleEventBatch_NotoNotaryChangedBadConfig(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	_, err := n.ConfigureDomain(context.Background(), &prototk.ConfigureDomainRequest{
+		ConfigJson: `{}`,
+	})
+	require.NoError(t, err)
+
+	event := &NotoNotaryChanged_Event{
+		Notary: pldtypes.RandAddress(),
+		Config: pldtypes.MustParseHexBytes("0x1234"),
+		Data:   pldtypes.MustParseHexBytes("0x"),
+	}
+	notoEventJson, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	req := &prototk.HandleEventBatchRequest{
+		Events: []*prototk.OnChainEvent{
+			{
+				SoliditySignature: eventSignatures[NotoNotaryChanged],
+				DataJson:          string(notoEventJson),
+			},
+			{
+				SoliditySignature: eventSignatures[NotoNotaryChanged],
+				DataJson:          "!!wrong",
+			},
+		},
+	}
+
+	res, err := n.HandleEventBatch(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, res.TransactionsComplete, 1)
+	assert.Empty(t, res.ContractConfig)
+}
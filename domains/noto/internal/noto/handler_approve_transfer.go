@@ -125,6 +125,9 @@ func (h *approveHandler) Endorse(ctx context.Context, tx *types.ParsedTransactio
 	if err := h.noto.validateOwners(ctx, tx.Transaction.From, req, inputs.coins, inputs.states); err != nil {
 		return nil, err
 	}
+	if err := h.checkRestrictions(ctx, tx, req, outputs); err != nil {
+		return nil, err
+	}
 
 	// Notary checks the signature from the sender, then submits the transaction
 	transferHash, err := h.noto.encodeTransferMasked(ctx, tx.ContractAddress, params.Inputs, params.Outputs, params.Data)
@@ -139,6 +142,22 @@ func (h *approveHandler) Endorse(ctx context.Context, tx *types.ParsedTransactio
 	}, nil
 }
 
+// Check that the contract is not paused, and neither the sender nor any of the recipients is frozen
+func (h *approveHandler) checkRestrictions(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest, outputs *parsedCoins) error {
+	if !pauseAllowed(tx.DomainConfig) && !freezeAllowed(tx.DomainConfig) {
+		return nil
+	}
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	accounts := []*pldtypes.EthAddress{fromAddress}
+	for _, coin := range outputs.coins {
+		accounts = append(accounts, coin.Owner)
+	}
+	return h.noto.checkRestrictions(ctx, tx.DomainConfig, req.StateQueryContext, accounts...)
+}
+
 func (h *approveHandler) baseLedgerInvoke(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*TransactionWrapper, error) {
 	inParams := tx.Params.(*types.ApproveParams)
 	transferHash, err := h.noto.encodeTransferMasked(ctx, tx.ContractAddress, inParams.Inputs, inParams.Outputs, inParams.Data)
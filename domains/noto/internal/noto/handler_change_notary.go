@@ -0,0 +1,198 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// Hands over a contract to a new notary. The resulting NotoNotaryChanged event carries the new
+// contract configuration, which every node picks up when indexing the event.
+type changeNotaryHandler struct {
+	noto *Noto
+}
+
+func (h *changeNotaryHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var changeNotaryParams types.ChangeNotaryParams
+	if err := json.Unmarshal([]byte(params), &changeNotaryParams); err != nil {
+		return nil, err
+	}
+	if changeNotaryParams.Notary == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "notary")
+	}
+	return &changeNotaryParams, nil
+}
+
+func (h *changeNotaryHandler) checkAllowed(ctx context.Context, tx *types.ParsedTransaction, from string) error {
+	// With hooks, the base ledger notary is the hooks contract, and it cannot be handed over
+	if tx.DomainConfig.NotaryMode != types.NotaryModeBasic.Enum() {
+		return i18n.NewError(ctx, msgs.MsgChangeNotaryNotAllowed, tx.DomainConfig.NotaryMode)
+	}
+	if from != tx.DomainConfig.NotaryLookup {
+		return i18n.NewError(ctx, msgs.MsgChangeNotaryOnlyNotary, tx.DomainConfig.NotaryLookup, from)
+	}
+	return nil
+}
+
+func (h *changeNotaryHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	params := tx.Params.(*types.ChangeNotaryParams)
+	if err := h.checkAllowed(ctx, tx, req.Transaction.From); err != nil {
+		return nil, err
+	}
+
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(tx.DomainConfig.NotaryLookup, params.Notary),
+	}, nil
+}
+
+func (h *changeNotaryHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.ChangeNotaryParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	newNotaryAddress, err := h.noto.findEthAddressVerifier(ctx, "notary", params.Notary, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	infoStates, err := h.noto.prepareInfo(params.Data, []string{notary, params.Notary})
+	if err != nil {
+		return nil, err
+	}
+
+	encodedChange, err := h.noto.encodeChangeNotary(ctx, tx.ContractAddress, newNotaryAddress, params.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InfoStates: infoStates,
+		},
+		AttestationPlan: []*prototk.AttestationRequest{
+			// Sender (the current notary) confirms the initial request with a signature
+			{
+				Name:            "sender",
+				AttestationType: prototk.AttestationType_SIGN,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+				Payload:         encodedChange,
+				Parties:         []string{req.Transaction.From},
+			},
+			// Notary will endorse the assembled transaction (by submitting to the ledger)
+			{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Parties:         []string{notary},
+			},
+		},
+	}, nil
+}
+
+func (h *changeNotaryHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	params := tx.Params.(*types.ChangeNotaryParams)
+	if err := h.checkAllowed(ctx, tx, req.Transaction.From); err != nil {
+		return nil, err
+	}
+
+	newNotaryAddress, err := h.noto.findEthAddressVerifier(ctx, "notary", params.Notary, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedChange, err := h.noto.encodeChangeNotary(ctx, tx.ContractAddress, newNotaryAddress, params.Data)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedChange); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+// Builds the configuration data for the new notary, retaining all other options of the contract
+func (h *changeNotaryHandler) newConfigData(ctx context.Context, tx *types.ParsedTransaction, notary string) (pldtypes.HexBytes, error) {
+	localNodeName, _ := h.noto.Callbacks.LocalNodeName(ctx, &prototk.LocalNodeNameRequest{})
+	notaryQualified, err := pldtypes.PrivateIdentityLocator(notary).FullyQualified(ctx, localNodeName.Name)
+	if err != nil {
+		return nil, err
+	}
+	basic := tx.DomainConfig.Options.Basic
+	return json.Marshal(&types.NotoConfigData_V0{
+		NotaryLookup: notaryQualified.String(),
+		NotaryMode:   types.NotaryModeIntBasic,
+		RestrictMint: *basic.RestrictMint,
+		AllowBurn:    *basic.AllowBurn,
+		AllowLock:    *basic.AllowLock,
+		AllowPause:   pauseAllowed(tx.DomainConfig),
+		AllowFreeze:  freezeAllowed(tx.DomainConfig),
+		MaxSupply:    basic.MaxSupply,
+	})
+}
+
+func (h *changeNotaryHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	inParams := tx.Params.(*types.ChangeNotaryParams)
+
+	sender := domain.FindAttestation("sender", req.AttestationResult)
+	if sender == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "sender")
+	}
+
+	newNotaryAddress, err := h.noto.findEthAddressVerifier(ctx, "notary", inParams.Notary, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	config, err := h.newConfigData(ctx, tx, inParams.Notary)
+	if err != nil {
+		return nil, err
+	}
+	data, err := h.noto.encodeTransactionData(ctx, req.Transaction, req.InfoStates)
+	if err != nil {
+		return nil, err
+	}
+	params := &NotoChangeNotaryParams{
+		Notary:    newNotaryAddress,
+		Config:    config,
+		Signature: sender.Payload,
+		Data:      data,
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	baseTransaction := &TransactionWrapper{
+		functionABI: interfaceBuild.ABI.Functions()["changeNotary"],
+		paramsJSON:  paramsJSON,
+	}
+	return baseTransaction.prepare(nil)
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeNotary(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["changeNotary"]
+
+	notaryKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	newNotaryAddress := pldtypes.RandAddress()
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "notary@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    contractAddress,
+			ContractConfigJson: mustParseJSON(notoBasicConfig),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: `{
+			"notary": "notary2@node2",
+			"data": "0x1234"
+		}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 2)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "notary2@node2", initRes.RequiredVerifiers[1].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryKey.Address.String(),
+		},
+		{
+			Lookup:       "notary2@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     newNotaryAddress.String(),
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	assert.Len(t, assembleRes.AssembledTransaction.InputStates, 0)
+	assert.Len(t, assembleRes.AssembledTransaction.OutputStates, 0)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 1)
+	assert.Equal(t, []string{"notary@node1", "notary2@node2"}, assembleRes.AssembledTransaction.InfoStates[0].DistributionList)
+	require.Len(t, assembleRes.AttestationPlan, 2)
+
+	encodedChange, err := n.encodeChangeNotary(ctx, ethtypes.MustNewAddress(contractAddress), newNotaryAddress, pldtypes.MustParseHexBytes("0x1234"))
+	require.NoError(t, err)
+	assert.Equal(t, encodedChange, ethtypes.HexBytes0xPrefix(assembleRes.AttestationPlan[0].Payload))
+	signature, err := notaryKey.SignDirect(encodedChange)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000001",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+	}
+	signatures := []*prototk.AttestationResult{
+		{
+			Name:     "sender",
+			Verifier: &prototk.ResolvedVerifier{Verifier: notaryKey.Address.String()},
+			Payload:  signatureBytes,
+		},
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InfoStates:        infoStates,
+		AttestationResult: signatures,
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["changeNotary"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+
+	var params NotoChangeNotaryParams
+	err = json.Unmarshal([]byte(prepareRes.Transaction.ParamsJson), &params)
+	require.NoError(t, err)
+	assert.Equal(t, newNotaryAddress, params.Notary)
+	assert.Equal(t, signatureBytes, params.Signature)
+	assert.JSONEq(t, `{
+		"notaryLookup": "notary2@node2",
+		"notaryMode": "0x0",
+		"privateAddress": null,
+		"privateGroup": null,
+		"restrictMint": true,
+		"allowBurn": true,
+		"allowLock": true
+	}`, string(params.Config))
+}
+
+func TestChangeNotaryNotAllowed(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["changeNotary"]
+
+	tx := &prototk.TransactionSpecification{
+		From: "other@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(notoBasicConfig),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: `{"notary": "notary2@node2"}`,
+	}
+	_, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD200037")
+
+	tx.From = "notary@node1"
+	tx.ContractInfo.ContractConfigJson = mustParseJSON(&types.NotoParsedConfig{
+		NotaryMode:   types.NotaryModeHooks.Enum(),
+		NotaryLookup: "notary@node1",
+		Options: types.NotoOptions{
+			Hooks: &types.NotoHooksOptions{},
+		},
+	})
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD200038")
+
+	tx.FunctionParamsJson = `{}`
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD200007")
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// A consolidation is a transfer from the sender back to themselves, which merges
+// the smallest available coins of the sender into a single coin.
+type consolidateHandler struct {
+	noto *Noto
+}
+
+func (h *consolidateHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var consolidateParams types.ConsolidateParams
+	if err := json.Unmarshal([]byte(params), &consolidateParams); err != nil {
+		return nil, err
+	}
+	return &consolidateParams, nil
+}
+
+func (h *consolidateHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	notary := tx.DomainConfig.NotaryLookup
+
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(notary, tx.Transaction.From),
+	}, nil
+}
+
+func (h *consolidateHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.ConsolidateParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	inputStates, revert, err := h.noto.prepareConsolidateInputs(ctx, req.StateQueryContext, fromAddress)
+	if err != nil {
+		if revert {
+			message := err.Error()
+			return &prototk.AssembleTransactionResponse{
+				AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+				RevertReason:   &message,
+			}, nil
+		}
+		return nil, err
+	}
+	outputStates, err := h.noto.prepareOutputs(fromAddress, (*pldtypes.HexUint256)(inputStates.total), []string{notary, tx.Transaction.From})
+	if err != nil {
+		return nil, err
+	}
+	infoStates, err := h.noto.prepareInfo(params.Data, []string{notary, tx.Transaction.From})
+	if err != nil {
+		return nil, err
+	}
+
+	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputStates.coins, outputStates.coins)
+	if err != nil {
+		return nil, err
+	}
+	attestation := []*prototk.AttestationRequest{
+		// Sender confirms the initial request with a signature
+		{
+			Name:            "sender",
+			AttestationType: prototk.AttestationType_SIGN,
+			Algorithm:       algorithms.ECDSA_SECP256K1,
+			VerifierType:    verifiers.ETH_ADDRESS,
+			Payload:         encodedTransfer,
+			PayloadType:     signpayloads.OPAQUE_TO_RSV,
+			Parties:         []string{req.Transaction.From},
+		},
+		// Notary will endorse the assembled transaction (by submitting to the ledger)
+		{
+			Name:            "notary",
+			AttestationType: prototk.AttestationType_ENDORSE,
+			Algorithm:       algorithms.ECDSA_SECP256K1,
+			VerifierType:    verifiers.ETH_ADDRESS,
+			Parties:         []string{notary},
+		},
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InputStates:  inputStates.states,
+			OutputStates: outputStates.states,
+			InfoStates:   infoStates,
+		},
+		AttestationPlan: attestation,
+	}, nil
+}
+
+func (h *consolidateHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	inputs, err := h.noto.parseCoinList(ctx, "input", req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "output", req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the amounts, and sender's ownership of the inputs and the single output
+	if err := h.noto.validateTransferAmounts(ctx, inputs, outputs); err != nil {
+		return nil, err
+	}
+	if len(outputs.coins) != 1 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidConsolidation, len(outputs.coins))
+	}
+	if err := h.noto.validateOwners(ctx, tx.Transaction.From, req, inputs.coins, inputs.states); err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateOwners(ctx, tx.Transaction.From, req, outputs.coins, outputs.states); err != nil {
+		return nil, err
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputs.coins, outputs.coins)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedTransfer); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+func (h *consolidateHandler) hookInvoke(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest, baseTransaction *TransactionWrapper) (*TransactionWrapper, error) {
+	inParams := tx.Params.(*types.ConsolidateParams)
+
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "output", req.OutputStates)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedCall, err := baseTransaction.encode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := &TransferHookParams{
+		Sender: fromAddress,
+		From:   fromAddress,
+		To:     fromAddress,
+		Amount: (*pldtypes.HexUint256)(outputs.total),
+		Data:   inParams.Data,
+		Prepared: PreparedTransaction{
+			ContractAddress: (*pldtypes.EthAddress)(tx.ContractAddress),
+			EncodedCall:     encodedCall,
+		},
+	}
+
+	transactionType, functionABI, paramsJSON, err := h.noto.wrapHookTransaction(
+		tx.DomainConfig,
+		hooksBuild.ABI.Functions()["onTransfer"],
+		params,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionWrapper{
+		transactionType: mapPrepareTransactionType(transactionType),
+		functionABI:     functionABI,
+		paramsJSON:      paramsJSON,
+		contractAddress: tx.DomainConfig.Options.Hooks.PublicAddress,
+	}, nil
+}
+
+func (h *consolidateHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	endorsement := domain.FindAttestation("notary", req.AttestationResult)
+	if endorsement == nil || endorsement.Verifier.Lookup != tx.DomainConfig.NotaryLookup {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
+	}
+
+	// The base ledger sees an ordinary transfer
+	transfer := &transferHandler{noto: h.noto}
+	baseTransaction, err := transfer.baseLedgerInvoke(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+	if tx.DomainConfig.NotaryMode == types.NotaryModeHooks.Enum() {
+		hookTransaction, err := h.hookInvoke(ctx, tx, req, baseTransaction)
+		if err != nil {
+			return nil, err
+		}
+		return hookTransaction.prepare(nil)
+	}
+	return baseTransaction.prepare(nil)
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsolidate(t *testing.T) {
+	maxInputs := 2
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+		config: types.DomainConfig{
+			CoinSelection: types.CoinSelectionConfig{MaxInputs: &maxInputs},
+		},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["consolidate"]
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	senderKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	owner := (*pldtypes.EthAddress)(&senderKey.Address)
+
+	coins := []*types.NotoCoinState{
+		{ID: pldtypes.RandBytes32(), Data: types.NotoCoin{Owner: owner, Amount: pldtypes.Int64ToInt256(50)}},
+		{ID: pldtypes.RandBytes32(), Data: types.NotoCoin{Owner: owner, Amount: pldtypes.Int64ToInt256(2)}},
+		{ID: pldtypes.RandBytes32(), Data: types.NotoCoin{Owner: owner, Amount: pldtypes.Int64ToInt256(3)}},
+	}
+	availableStates := make([]*prototk.StoredState, len(coins))
+	for i, coin := range coins {
+		availableStates[i] = &prototk.StoredState{
+			Id:        coin.ID.String(),
+			SchemaId:  "coin",
+			CreatedAt: int64(i + 1),
+			DataJson:  mustParseJSON(coin.Data),
+		}
+	}
+	mockAvailableStatePages(availableStates)
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    contractAddress,
+			ContractConfigJson: mustParseJSON(notoBasicConfig),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: `{"data": "0x1234"}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 2)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "sender@node1", initRes.RequiredVerifiers[1].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderKey.Address.String(),
+		},
+	}
+
+	// The two smallest coins are merged
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 2)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 1)
+	assert.Equal(t, coins[1].ID.String(), assembleRes.AssembledTransaction.InputStates[0].Id)
+	assert.Equal(t, coins[2].ID.String(), assembleRes.AssembledTransaction.InputStates[1].Id)
+
+	outputCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.OutputStates[0].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, senderKey.Address.String(), outputCoin.Owner.String())
+	assert.Equal(t, "5", outputCoin.Amount.Int().String())
+	assert.Equal(t, []string{"notary@node1", "sender@node1"}, assembleRes.AssembledTransaction.OutputStates[0].DistributionList)
+
+	encodedTransfer, err := n.encodeTransferUnmasked(ctx, ethtypes.MustNewAddress(contractAddress),
+		[]*types.NotoCoin{&coins[1].Data, &coins[2].Data},
+		[]*types.NotoCoin{outputCoin},
+	)
+	require.NoError(t, err)
+	signature, err := senderKey.SignDirect(encodedTransfer)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	inputStates := []*prototk.EndorsableState{
+		{SchemaId: "coin", Id: coins[1].ID.String(), StateDataJson: mustParseJSON(coins[1].Data)},
+		{SchemaId: "coin", Id: coins[2].ID.String(), StateDataJson: mustParseJSON(coins[2].Data)},
+	}
+	outputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "coin",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000001",
+			StateDataJson: assembleRes.AssembledTransaction.OutputStates[0].StateDataJson,
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000002",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+	}
+	signatures := []*prototk.AttestationResult{
+		{
+			Name:     "sender",
+			Verifier: &prototk.ResolvedVerifier{Verifier: senderKey.Address.String()},
+			Payload:  signatureBytes,
+		},
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	// The notary will not endorse a consolidation that sends value to anyone else
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		Inputs:            inputStates,
+		Outputs: []*prototk.EndorsableState{
+			{
+				SchemaId:      "coin",
+				Id:            "0x0000000000000000000000000000000000000000000000000000000000000001",
+				StateDataJson: mustParseJSON(&types.NotoCoin{Owner: pldtypes.RandAddress(), Amount: pldtypes.Int64ToInt256(5)}),
+			},
+		},
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	assert.ErrorContains(t, err, "PD200018")
+
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: append(signatures, &prototk.AttestationResult{
+			Name:     "notary",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+		}),
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["transfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"inputs": ["%s","%s"],
+		"outputs": ["0x0000000000000000000000000000000000000000000000000000000000000001"],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002"
+	}`, coins[1].ID, coins[2].ID, signatureBytes), prepareRes.Transaction.ParamsJson)
+}
+
+func TestConsolidateNothingToDo(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["consolidate"]
+	senderAddress := pldtypes.RandAddress()
+
+	mockAvailableStatePages([]*prototk.StoredState{
+		{Id: "0x01", SchemaId: "coin", CreatedAt: 1, DataJson: mustParseJSON(&types.NotoCoin{Owner: senderAddress, Amount: pldtypes.Int64ToInt256(10)})},
+	})
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction: &prototk.TransactionSpecification{
+			From: "sender@node1",
+			ContractInfo: &prototk.ContractInfo{
+				ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+				ContractConfigJson: mustParseJSON(notoBasicConfig),
+			},
+			FunctionAbiJson:    mustParseJSON(fn),
+			FunctionSignature:  fn.SolString(),
+			FunctionParamsJson: `{}`,
+		},
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderAddress.String(),
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200035.*available=1", *assembleRes.RevertReason)
+}
@@ -85,11 +85,15 @@ func (h *delegateLockHandler) Assemble(ctx context.Context, tx *types.ParsedTran
 	if err != nil {
 		return nil, err
 	}
-	lockState, err := h.noto.prepareLockInfo(params.LockID, fromAddress, params.Delegate, []string{notary, tx.Transaction.From})
+	expiry, err := h.noto.findLockExpiry(ctx, req.StateQueryContext, params.LockID)
 	if err != nil {
 		return nil, err
 	}
-	infoStates = append(infoStates, lockState)
+	lockStates, err := h.noto.prepareLockInfo(params.LockID, fromAddress, params.Delegate, expiry, []string{notary, tx.Transaction.From})
+	if err != nil {
+		return nil, err
+	}
+	infoStates = append(infoStates, lockStates...)
 
 	// This approval may leak the requesting signature on-chain, as all the inputs are visible on-chain
 	// TODO: possibly we should be signing a different payload here
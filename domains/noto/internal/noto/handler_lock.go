@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"math/big"
+	"time"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
@@ -43,6 +44,10 @@ func (h *lockHandler) ValidateParams(ctx context.Context, config *types.NotoPars
 	if lockParams.Amount == nil || lockParams.Amount.Int().Sign() != 1 {
 		return nil, i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, "amount")
 	}
+	// Block numbers are checked only by the base ledger, but a timestamp can be sanity checked here
+	if lockParams.Expiry.Timestamp != 0 && lockParams.Expiry.Timestamp.Uint64() <= uint64(time.Now().Unix()) {
+		return nil, i18n.NewError(ctx, msgs.MsgLockExpiryInPast, lockParams.Expiry.Timestamp.Uint64())
+	}
 	return &lockParams, nil
 }
 
@@ -57,10 +62,15 @@ func (h *lockHandler) checkAllowed(ctx context.Context, tx *types.ParsedTransact
 }
 
 func (h *lockHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	params := tx.Params.(*types.LockParams)
 	notary := tx.DomainConfig.NotaryLookup
 	if err := h.checkAllowed(ctx, tx); err != nil {
 		return nil, err
 	}
+	// An expired lock can only be reclaimed in basic mode
+	if params.Expiry.IsSet() && tx.DomainConfig.NotaryMode != types.NotaryModeBasic.Enum() {
+		return nil, i18n.NewError(ctx, msgs.MsgNotSupportedByNotaryMode, "lockWithExpiry", tx.DomainConfig.NotaryMode)
+	}
 
 	return &prototk.InitTransactionResponse{
 		RequiredVerifiers: h.noto.ethAddressVerifiers(notary, tx.Transaction.From),
@@ -113,11 +123,11 @@ func (h *lockHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction,
 	if err != nil {
 		return nil, err
 	}
-	lockState, err := h.noto.prepareLockInfo(lockID, fromAddress, nil, []string{notary, tx.Transaction.From})
+	lockStates, err := h.noto.prepareLockInfo(lockID, fromAddress, nil, &params.Expiry, []string{notary, tx.Transaction.From})
 	if err != nil {
 		return nil, err
 	}
-	infoStates = append(infoStates, lockState)
+	infoStates = append(infoStates, lockStates...)
 
 	encodedLock, err := h.noto.encodeLock(ctx, tx.ContractAddress, inputStates.coins, unlockedOutputStates.coins, lockedOutputStates.coins)
 	if err != nil {
@@ -184,6 +194,15 @@ func (h *lockHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction,
 	if err := h.noto.validateLockOwners(ctx, tx.Transaction.From, req.ResolvedVerifiers, outputs.lockedCoins, outputs.lockedStates); err != nil {
 		return nil, err
 	}
+	if pauseAllowed(tx.DomainConfig) || freezeAllowed(tx.DomainConfig) {
+		fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.noto.checkRestrictions(ctx, tx.DomainConfig, req.StateQueryContext, fromAddress); err != nil {
+			return nil, err
+		}
+	}
 
 	// Notary checks the signature from the sender, then submits the transaction
 	encodedLock, err := h.noto.encodeLock(ctx, tx.ContractAddress, inputs.coins, outputs.coins, outputs.lockedCoins)
@@ -198,7 +217,8 @@ func (h *lockHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction,
 	}, nil
 }
 
-func (h *lockHandler) baseLedgerInvoke(ctx context.Context, req *prototk.PrepareTransactionRequest) (*TransactionWrapper, error) {
+func (h *lockHandler) baseLedgerInvoke(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*TransactionWrapper, error) {
+	inParams := tx.Params.(*types.LockParams)
 	inputs := req.InputStates
 	outputs, lockedOutputs := h.noto.splitStates(req.OutputStates)
 
@@ -220,12 +240,19 @@ func (h *lockHandler) baseLedgerInvoke(ctx context.Context, req *prototk.Prepare
 		Signature:     lockSignature.Payload,
 		Data:          data,
 	}
+
+	// Locks with an expiry are recorded on the base ledger, which enforces the expiry when reclaiming
+	function := "lock"
+	if inParams.Expiry.IsSet() {
+		function = "lockWithExpiry"
+		params.Expiry = &inParams.Expiry
+	}
 	paramsJSON, err := json.Marshal(params)
 	if err != nil {
 		return nil, err
 	}
 	return &TransactionWrapper{
-		functionABI: interfaceBuild.ABI.Functions()["lock"],
+		functionABI: interfaceBuild.ABI.Functions()[function],
 		paramsJSON:  paramsJSON,
 	}, nil
 }
@@ -294,7 +321,7 @@ func (h *lockHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction,
 		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
 	}
 
-	baseTransaction, err := h.baseLedgerInvoke(ctx, req)
+	baseTransaction, err := h.baseLedgerInvoke(ctx, tx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
@@ -39,6 +40,7 @@ func TestLock(t *testing.T) {
 		coinSchema:       &prototk.StateSchema{Id: "coin"},
 		lockedCoinSchema: &prototk.StateSchema{Id: "lockedCoin"},
 		lockInfoSchema:   &prototk.StateSchema{Id: "lockInfo"},
+		lockExpirySchema: &prototk.StateSchema{Id: "lockExpiry"},
 		dataSchema:       &prototk.StateSchema{Id: "data"},
 	}
 	ctx := context.Background()
@@ -271,3 +273,110 @@ func TestLock(t *testing.T) {
 		}
 	}`, senderKey.Address, lockInfo.LockID, senderKey.Address, contractAddress, pldtypes.HexBytes(encodedCall)), prepareRes.Transaction.ParamsJson)
 }
+
+func TestLockWithExpiry(t *testing.T) {
+	n := &Noto{
+		Callbacks:        mockCallbacks,
+		coinSchema:       &prototk.StateSchema{Id: "coin"},
+		lockedCoinSchema: &prototk.StateSchema{Id: "lockedCoin"},
+		lockInfoSchema:   &prototk.StateSchema{Id: "lockInfo"},
+		lockExpirySchema: &prototk.StateSchema{Id: "lockExpiry"},
+		dataSchema:       &prototk.StateSchema{Id: "data"},
+	}
+	h := lockHandler{noto: n}
+	ctx := context.Background()
+
+	_, err := h.ValidateParams(ctx, notoBasicConfig, `{"amount": 100, "expiry": {"timestamp": 1000}, "data": "0x"}`)
+	assert.Regexp(t, "PD200050.*1000", err)
+
+	expiry := time.Now().Add(1 * time.Hour).Unix()
+	params, err := h.ValidateParams(ctx, notoBasicConfig, fmt.Sprintf(`{"amount": 100, "expiry": {"blockNumber": 500, "timestamp": %d}, "data": "0x"}`, expiry))
+	require.NoError(t, err)
+	lockParams := params.(*types.LockParams)
+	assert.Equal(t, uint64(500), lockParams.Expiry.BlockNumber.Uint64())
+	assert.Equal(t, uint64(expiry), lockParams.Expiry.Timestamp.Uint64())
+
+	hooksConfig := &types.NotoParsedConfig{
+		NotaryLookup: "notary@node1",
+		NotaryMode:   types.NotaryModeHooks.Enum(),
+		Options: types.NotoOptions{
+			Hooks: &types.NotoHooksOptions{
+				PublicAddress:     pldtypes.RandAddress(),
+				DevUsePublicHooks: true,
+			},
+		},
+	}
+	_, err = h.Init(ctx, &types.ParsedTransaction{
+		Transaction:  &prototk.TransactionSpecification{From: "sender@node1"},
+		DomainConfig: hooksConfig,
+		Params:       lockParams,
+	}, &prototk.InitTransactionRequest{})
+	assert.Regexp(t, "PD200049.*lockWithExpiry.*hooks", err)
+
+	// The expiry is passed through to the base ledger
+	txID := "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d"
+	baseTransaction, err := h.baseLedgerInvoke(ctx, &types.ParsedTransaction{
+		Transaction:  &prototk.TransactionSpecification{TransactionId: txID, From: "sender@node1"},
+		DomainConfig: notoBasicConfig,
+		Params:       lockParams,
+	}, &prototk.PrepareTransactionRequest{
+		Transaction: &prototk.TransactionSpecification{TransactionId: txID},
+		AttestationResult: []*prototk.AttestationResult{
+			{Name: "sender", Payload: pldtypes.MustParseHexBytes("0x1234")},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "lockWithExpiry", baseTransaction.functionABI.Name)
+	var invokeParams NotoLockParams
+	err = json.Unmarshal(baseTransaction.paramsJSON, &invokeParams)
+	require.NoError(t, err)
+	assert.Equal(t, &lockParams.Expiry, invokeParams.Expiry)
+
+	_, err = baseTransaction.functionABI.EncodeCallDataJSONCtx(ctx, baseTransaction.paramsJSON)
+	require.NoError(t, err)
+}
+
+func TestLockExpiryCarriedForward(t *testing.T) {
+	n := &Noto{
+		Callbacks:        mockCallbacks,
+		lockInfoSchema:   &prototk.StateSchema{Id: "lockInfo"},
+		lockExpirySchema: &prototk.StateSchema{Id: "lockExpiry"},
+	}
+	ctx := context.Background()
+	lockID := pldtypes.RandBytes32()
+	owner := pldtypes.RandAddress()
+
+	mockAvailableStatePages()
+	expiry, err := n.findLockExpiry(ctx, "sqc1", lockID)
+	require.NoError(t, err)
+	assert.Nil(t, expiry)
+	lockStates, err := n.prepareLockInfo(lockID, owner, nil, expiry, []string{"notary@node1"})
+	require.NoError(t, err)
+	require.Len(t, lockStates, 1)
+	assert.Equal(t, "lockInfo", lockStates[0].SchemaId)
+
+	mockAvailableStatePages([]*prototk.StoredState{{
+		Id:       "0x01",
+		SchemaId: "lockExpiry",
+		DataJson: mustParseJSON(&types.NotoLockExpiry{
+			LockID: lockID,
+			Expiry: types.LockExpiry{BlockNumber: 500},
+		}),
+	}})
+	expiry, err = n.findLockExpiry(ctx, "sqc1", lockID)
+	require.NoError(t, err)
+	require.NotNil(t, expiry)
+	assert.Equal(t, uint64(500), expiry.BlockNumber.Uint64())
+	lockStates, err = n.prepareLockInfo(lockID, owner, nil, expiry, []string{"notary@node1"})
+	require.NoError(t, err)
+	require.Len(t, lockStates, 2)
+	assert.Equal(t, "lockExpiry", lockStates[1].SchemaId)
+	lockExpiry, err := n.unmarshalLockExpiry(lockStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, lockID, lockExpiry.LockID)
+	assert.Equal(t, *expiry, lockExpiry.Expiry)
+
+	mockAvailableStatePages([]*prototk.StoredState{{Id: "0x01", DataJson: "!!wrong"}})
+	_, err = n.findLockExpiry(ctx, "sqc1", lockID)
+	assert.Regexp(t, "PD200", err)
+}
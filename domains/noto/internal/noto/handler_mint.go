@@ -90,6 +90,20 @@ func (h *mintHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction,
 	if err != nil {
 		return nil, err
 	}
+	supplyInputs, supplyOutput, revert, err := h.noto.prepareSupply(ctx, tx.DomainConfig, req.StateQueryContext, params.Amount.Int())
+	if err != nil {
+		if revert {
+			message := err.Error()
+			return &prototk.AssembleTransactionResponse{
+				AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+				RevertReason:   &message,
+			}, nil
+		}
+		return nil, err
+	}
+	if supplyOutput != nil {
+		outputStates.states = append(outputStates.states, supplyOutput)
+	}
 
 	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, nil, outputStates.coins)
 	if err != nil {
@@ -99,6 +113,7 @@ func (h *mintHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction,
 	return &prototk.AssembleTransactionResponse{
 		AssemblyResult: prototk.AssembleTransactionResponse_OK,
 		AssembledTransaction: &prototk.AssembledTransaction{
+			InputStates:  supplyInputs,
 			OutputStates: outputStates.states,
 			InfoStates:   infoStates,
 		},
@@ -131,11 +146,13 @@ func (h *mintHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction,
 		return nil, err
 	}
 
-	inputs, err := h.noto.parseCoinList(ctx, "input", req.Inputs)
+	coinInputs, supplyInputs := h.noto.splitSupplyStates(req.Inputs)
+	coinOutputs, supplyOutputs := h.noto.splitSupplyStates(req.Outputs)
+	inputs, err := h.noto.parseCoinList(ctx, "input", coinInputs)
 	if err != nil {
 		return nil, err
 	}
-	outputs, err := h.noto.parseCoinList(ctx, "output", req.Outputs)
+	outputs, err := h.noto.parseCoinList(ctx, "output", coinOutputs)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +161,12 @@ func (h *mintHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction,
 	if err := h.noto.validateMintAmounts(ctx, params, inputs, outputs); err != nil {
 		return nil, err
 	}
+	if err := h.checkRestrictions(ctx, tx, req); err != nil {
+		return nil, err
+	}
+	if err := h.noto.checkSupply(ctx, tx.DomainConfig, req.StateQueryContext, params.Amount.Int(), supplyInputs, supplyOutputs); err != nil {
+		return nil, err
+	}
 
 	// Notary checks the signature from the sender, then submits the transaction
 	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, nil, outputs.coins)
@@ -158,6 +181,19 @@ func (h *mintHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction,
 	}, nil
 }
 
+// Check that the contract is not paused, and the recipient is not frozen
+func (h *mintHandler) checkRestrictions(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) error {
+	if !pauseAllowed(tx.DomainConfig) && !freezeAllowed(tx.DomainConfig) {
+		return nil
+	}
+	params := tx.Params.(*types.MintParams)
+	toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", params.To, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	return h.noto.checkRestrictions(ctx, tx.DomainConfig, req.StateQueryContext, toAddress)
+}
+
 func (h *mintHandler) baseLedgerInvoke(ctx context.Context, req *prototk.PrepareTransactionRequest) (*TransactionWrapper, error) {
 	// Include the signature from the sender/notary
 	// This is not verified on the base ledger, but can be verified by anyone with the unmasked state data
@@ -170,10 +206,24 @@ func (h *mintHandler) baseLedgerInvoke(ctx context.Context, req *prototk.Prepare
 	if err != nil {
 		return nil, err
 	}
-	params := &NotoMintParams{
-		Outputs:   endorsableStateIDs(req.OutputStates),
-		Signature: sender.Payload,
-		Data:      data,
+
+	// Once there is a supply state to spend, the mint is submitted as a transfer that spends it
+	var params any
+	fn := "mint"
+	if len(req.InputStates) > 0 {
+		fn = "transfer"
+		params = &NotoTransferParams{
+			Inputs:    endorsableStateIDs(req.InputStates),
+			Outputs:   endorsableStateIDs(req.OutputStates),
+			Signature: sender.Payload,
+			Data:      data,
+		}
+	} else {
+		params = &NotoMintParams{
+			Outputs:   endorsableStateIDs(req.OutputStates),
+			Signature: sender.Payload,
+			Data:      data,
+		}
 	}
 	paramsJSON, err := json.Marshal(params)
 	if err != nil {
@@ -181,7 +231,7 @@ func (h *mintHandler) baseLedgerInvoke(ctx context.Context, req *prototk.Prepare
 	}
 	return &TransactionWrapper{
 		transactionType: prototk.PreparedTransaction_PUBLIC,
-		functionABI:     interfaceBuild.ABI.Functions()["mint"],
+		functionABI:     interfaceBuild.ABI.Functions()[fn],
 		paramsJSON:      paramsJSON,
 	}, nil
 }
@@ -35,9 +35,10 @@ import (
 
 func TestMint(t *testing.T) {
 	n := &Noto{
-		Callbacks:  mockCallbacks,
-		coinSchema: &prototk.StateSchema{Id: "coin"},
-		dataSchema: &prototk.StateSchema{Id: "data"},
+		Callbacks:    mockCallbacks,
+		coinSchema:   &prototk.StateSchema{Id: "coin"},
+		dataSchema:   &prototk.StateSchema{Id: "data"},
+		supplySchema: &prototk.StateSchema{Id: "supply"},
 	}
 	ctx := context.Background()
 	fn := types.NotoABI.Functions()["mint"]
@@ -39,6 +39,7 @@ func TestPrepareUnlock(t *testing.T) {
 		coinSchema:       &prototk.StateSchema{Id: "coin"},
 		lockedCoinSchema: &prototk.StateSchema{Id: "lockedCoin"},
 		lockInfoSchema:   &prototk.StateSchema{Id: "lockInfo"},
+		lockExpirySchema: &prototk.StateSchema{Id: "lockExpiry"},
 		dataSchema:       &prototk.StateSchema{Id: "data"},
 	}
 	ctx := context.Background()
@@ -0,0 +1,241 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// Returns all of the value from an expired lock to the lock owner, without the involvement of any
+// delegate. The expiry is enforced by the base ledger, which rejects the reclaim if any of the
+// locked states have not yet expired.
+type reclaimLockHandler struct {
+	noto *Noto
+}
+
+func (h *reclaimLockHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var reclaimParams types.ReclaimLockParams
+	if err := json.Unmarshal([]byte(params), &reclaimParams); err != nil {
+		return nil, err
+	}
+	if reclaimParams.LockID.IsZero() {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "lockId")
+	}
+	return &reclaimParams, nil
+}
+
+func (h *reclaimLockHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	// Hooks have no callback for a reclaim, so cannot approve it
+	if tx.DomainConfig.NotaryMode != types.NotaryModeBasic.Enum() {
+		return nil, i18n.NewError(ctx, msgs.MsgNotSupportedByNotaryMode, "reclaimLock", tx.DomainConfig.NotaryMode)
+	}
+
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(tx.DomainConfig.NotaryLookup, tx.Transaction.From),
+	}, nil
+}
+
+// Select every locked state remaining for the lock that is owned by the sender
+func (h *reclaimLockHandler) prepareLockedInputs(ctx context.Context, stateQueryContext string, lockID pldtypes.Bytes32, owner *pldtypes.EthAddress) (*preparedLockedInputs, error) {
+	inputs := &preparedLockedInputs{total: big.NewInt(0)}
+	err := h.noto.forEachAvailableState(ctx, stateQueryContext, h.noto.lockedCoinSchema.Id,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder().Equal("lockId", lockID).Equal("owner", owner.String())
+		},
+		func(state *prototk.StoredState) error {
+			coin, err := h.noto.unmarshalLockedCoin(state.DataJson)
+			if err != nil {
+				return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+			}
+			inputs.coins = append(inputs.coins, coin)
+			inputs.states = append(inputs.states, &prototk.StateRef{SchemaId: state.SchemaId, Id: state.Id})
+			inputs.total.Add(inputs.total, coin.Amount.Int())
+			return nil
+		})
+	return inputs, err
+}
+
+func (h *reclaimLockHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.ReclaimLockParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	lockedInputStates, err := h.prepareLockedInputs(ctx, req.StateQueryContext, params.LockID, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(lockedInputStates.states) == 0 {
+		message := i18n.NewError(ctx, msgs.MsgNoLockedStatesToReclaim, tx.Transaction.From, params.LockID).Error()
+		return &prototk.AssembleTransactionResponse{
+			AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+			RevertReason:   &message,
+		}, nil
+	}
+
+	outputStates, err := h.noto.prepareOutputs(fromAddress, (*pldtypes.HexUint256)(lockedInputStates.total), []string{notary, tx.Transaction.From})
+	if err != nil {
+		return nil, err
+	}
+
+	infoStates, err := h.noto.prepareInfo(params.Data, []string{notary, tx.Transaction.From})
+	if err != nil {
+		return nil, err
+	}
+	lockStates, err := h.noto.prepareLockInfo(params.LockID, fromAddress, nil, nil, []string{notary, tx.Transaction.From})
+	if err != nil {
+		return nil, err
+	}
+	infoStates = append(infoStates, lockStates...)
+
+	encodedUnlock, err := h.noto.encodeUnlock(ctx, tx.ContractAddress, lockedInputStates.coins, []*types.NotoLockedCoin{}, outputStates.coins)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InputStates:  lockedInputStates.states,
+			OutputStates: outputStates.states,
+			InfoStates:   infoStates,
+		},
+		AttestationPlan: []*prototk.AttestationRequest{
+			// Sender confirms the initial request with a signature
+			{
+				Name:            "sender",
+				AttestationType: prototk.AttestationType_SIGN,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Payload:         encodedUnlock,
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+				Parties:         []string{req.Transaction.From},
+			},
+			// Notary will endorse the assembled transaction (by submitting to the ledger)
+			{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Parties:         []string{notary},
+			},
+		},
+	}, nil
+}
+
+func (h *reclaimLockHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	params := tx.Params.(*types.ReclaimLockParams)
+
+	inputs, err := h.noto.parseCoinList(ctx, "input", req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "output", req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only locked states from the requested lock may be spent, and all value must be returned unlocked to the owner
+	if len(inputs.coins) > 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidInputs, "reclaimLock", inputs.coins)
+	}
+	if len(outputs.lockedCoins) > 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidAmount, "reclaimLock", "0", outputs.lockedTotal.Text(10))
+	}
+	for i, coin := range inputs.lockedCoins {
+		if coin.LockID != params.LockID {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidInputs, "reclaimLock", inputs.lockedStates[i].Id)
+		}
+	}
+	if err := h.noto.validateUnlockAmounts(ctx, inputs, outputs); err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateLockOwners(ctx, tx.Transaction.From, req.ResolvedVerifiers, inputs.lockedCoins, inputs.lockedStates); err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateOwners(ctx, tx.Transaction.From, req, outputs.coins, outputs.states); err != nil {
+		return nil, err
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedUnlock, err := h.noto.encodeUnlock(ctx, tx.ContractAddress, inputs.lockedCoins, outputs.lockedCoins, outputs.coins)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedUnlock); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+func (h *reclaimLockHandler) baseLedgerInvoke(ctx context.Context, req *prototk.PrepareTransactionRequest) (*TransactionWrapper, error) {
+	// Include the signature from the sender
+	// This is not verified on the base ledger, but can be verified by anyone with the unmasked state data
+	reclaimSignature := domain.FindAttestation("sender", req.AttestationResult)
+	if reclaimSignature == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "sender")
+	}
+
+	data, err := h.noto.encodeTransactionData(ctx, req.Transaction, req.InfoStates)
+	if err != nil {
+		return nil, err
+	}
+	params := &NotoReclaimLockParams{
+		LockedInputs: endorsableStateIDs(req.InputStates),
+		Outputs:      endorsableStateIDs(req.OutputStates),
+		Signature:    reclaimSignature.Payload,
+		Data:         data,
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionWrapper{
+		functionABI: interfaceBuild.ABI.Functions()["reclaimLock"],
+		paramsJSON:  paramsJSON,
+	}, nil
+}
+
+func (h *reclaimLockHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	endorsement := domain.FindAttestation("notary", req.AttestationResult)
+	if endorsement == nil || endorsement.Verifier.Lookup != tx.DomainConfig.NotaryLookup {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
+	}
+
+	baseTransaction, err := h.baseLedgerInvoke(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return baseTransaction.prepare(nil)
+}
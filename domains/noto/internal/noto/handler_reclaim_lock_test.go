@@ -0,0 +1,313 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReclaimLockTestNoto() *Noto {
+	return &Noto{
+		Callbacks:        mockCallbacks,
+		coinSchema:       &prototk.StateSchema{Id: "coin"},
+		lockedCoinSchema: &prototk.StateSchema{Id: "lockedCoin"},
+		lockInfoSchema:   &prototk.StateSchema{Id: "lockInfo"},
+		lockExpirySchema: &prototk.StateSchema{Id: "lockExpiry"},
+		dataSchema:       &prototk.StateSchema{Id: "data"},
+	}
+}
+
+func newReclaimLockTestTransaction(config *types.NotoParsedConfig, params string) *prototk.TransactionSpecification {
+	fn := types.NotoABI.Functions()["reclaimLock"]
+	return &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(config),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: params,
+	}
+}
+
+func TestReclaimLock(t *testing.T) {
+	n := newReclaimLockTestNoto()
+	ctx := context.Background()
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	senderKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	lockID := pldtypes.RandBytes32()
+	inputCoins := []*types.NotoLockedCoinState{
+		{
+			ID: pldtypes.RandBytes32(),
+			Data: types.NotoLockedCoin{
+				LockID: lockID,
+				Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+				Amount: pldtypes.Int64ToInt256(60),
+			},
+		},
+		{
+			ID: pldtypes.RandBytes32(),
+			Data: types.NotoLockedCoin{
+				LockID: lockID,
+				Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+				Amount: pldtypes.Int64ToInt256(40),
+			},
+		},
+	}
+	var storedStates []*prototk.StoredState
+	for _, coin := range inputCoins {
+		storedStates = append(storedStates, &prototk.StoredState{
+			Id:       coin.ID.String(),
+			SchemaId: "lockedCoin",
+			DataJson: mustParseJSON(coin.Data),
+		})
+	}
+	mockAvailableStatePages(storedStates)
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := newReclaimLockTestTransaction(notoBasicConfig, fmt.Sprintf(`{
+		"lockId": "%s",
+		"data": "0x1234"
+	}`, lockID))
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 2)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "sender@node1", initRes.RequiredVerifiers[1].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderKey.Address.String(),
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 2)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 2)
+	assert.Equal(t, inputCoins[0].ID.String(), assembleRes.AssembledTransaction.InputStates[0].Id)
+	assert.Equal(t, inputCoins[1].ID.String(), assembleRes.AssembledTransaction.InputStates[1].Id)
+
+	outputCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.OutputStates[0].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, senderKey.Address.String(), outputCoin.Owner.String())
+	assert.Equal(t, "100", outputCoin.Amount.Int().String())
+	assert.Equal(t, []string{"notary@node1", "sender@node1"}, assembleRes.AssembledTransaction.OutputStates[0].DistributionList)
+
+	lockInfo, err := n.unmarshalLock(assembleRes.AssembledTransaction.InfoStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, lockID, lockInfo.LockID)
+	assert.Equal(t, senderKey.Address.String(), lockInfo.Owner.String())
+	assert.Nil(t, lockInfo.Delegate)
+
+	encodedUnlock, err := n.encodeUnlock(ctx, ethtypes.MustNewAddress(contractAddress),
+		[]*types.NotoLockedCoin{&inputCoins[0].Data, &inputCoins[1].Data}, []*types.NotoLockedCoin{}, []*types.NotoCoin{outputCoin})
+	require.NoError(t, err)
+	signature, err := senderKey.SignDirect(encodedUnlock)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	inputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "lockedCoin",
+			Id:            inputCoins[0].ID.String(),
+			StateDataJson: mustParseJSON(inputCoins[0].Data),
+		},
+		{
+			SchemaId:      "lockedCoin",
+			Id:            inputCoins[1].ID.String(),
+			StateDataJson: mustParseJSON(inputCoins[1].Data),
+		},
+	}
+	outputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "coin",
+			Id:            "0x26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945",
+			StateDataJson: assembleRes.AssembledTransaction.OutputStates[0].StateDataJson,
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x4cc7840e186de23c4127b4853c878708d2642f1942959692885e098f1944547d",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+		{
+			SchemaId:      "lockInfo",
+			Id:            "0x69101A0740EC8096B83653600FA7553D676FC92BCC6E203C3572D2CAC4F1DB2F",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[1].StateDataJson,
+		},
+	}
+	senderSignature := &prototk.AttestationResult{
+		Name:     "sender",
+		Verifier: &prototk.ResolvedVerifier{Verifier: senderKey.Address.String()},
+		Payload:  signatureBytes,
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         []*prototk.AttestationResult{senderSignature},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: []*prototk.AttestationResult{
+			senderSignature,
+			{
+				Name:     "notary",
+				Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["reclaimLock"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.Nil(t, prepareRes.Transaction.ContractAddress)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"lockedInputs": ["%s", "%s"],
+		"outputs": ["0x26b394af655bdc794a6d7cd7f8004eec20bffb374e4ddd24cdaefe554878d945"],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000024cc7840e186de23c4127b4853c878708d2642f1942959692885e098f1944547d69101a0740ec8096b83653600fa7553d676fc92bcc6e203c3572d2cac4f1db2f"
+	}`, inputCoins[0].ID, inputCoins[1].ID, signatureBytes), prepareRes.Transaction.ParamsJson)
+
+	// Locked states from another lock cannot be reclaimed
+	otherCoin := &types.NotoLockedCoin{
+		LockID: pldtypes.RandBytes32(),
+		Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+		Amount: pldtypes.Int64ToInt256(100),
+	}
+	otherStateID := pldtypes.RandBytes32()
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		Inputs: []*prototk.EndorsableState{
+			{SchemaId: "lockedCoin", Id: otherStateID.String(), StateDataJson: mustParseJSON(otherCoin)},
+		},
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         []*prototk.AttestationResult{senderSignature},
+	})
+	assert.Regexp(t, "PD200012.*"+otherStateID.String(), err)
+
+	// Value cannot be left locked
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		Inputs:            inputStates,
+		Outputs: []*prototk.EndorsableState{
+			{SchemaId: "lockedCoin", Id: pldtypes.RandBytes32().String(), StateDataJson: mustParseJSON(&inputCoins[0].Data)},
+		},
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         []*prototk.AttestationResult{senderSignature},
+	})
+	assert.Regexp(t, "PD200013.*reclaimLock", err)
+}
+
+func TestReclaimLockNoStates(t *testing.T) {
+	n := newReclaimLockTestNoto()
+	ctx := context.Background()
+	mockAvailableStatePages()
+
+	lockID := pldtypes.RandBytes32()
+	tx := newReclaimLockTestTransaction(notoBasicConfig, fmt.Sprintf(`{"lockId": "%s", "data": "0x"}`, lockID))
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction: tx,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "sender@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     pldtypes.RandAddress().String(),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	require.NotNil(t, assembleRes.RevertReason)
+	assert.Regexp(t, "PD200051.*"+lockID.String(), *assembleRes.RevertReason)
+}
+
+func TestReclaimLockBadParams(t *testing.T) {
+	n := newReclaimLockTestNoto()
+	ctx := context.Background()
+
+	_, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: newReclaimLockTestTransaction(notoBasicConfig, `{"data": "0x"}`),
+	})
+	assert.Regexp(t, "PD200007.*lockId", err)
+
+	hooksConfig := &types.NotoParsedConfig{
+		NotaryLookup: "notary@node1",
+		NotaryMode:   types.NotaryModeHooks.Enum(),
+		Options: types.NotoOptions{
+			Hooks: &types.NotoHooksOptions{
+				PublicAddress:     pldtypes.RandAddress(),
+				DevUsePublicHooks: true,
+			},
+		},
+	}
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: newReclaimLockTestTransaction(hooksConfig, fmt.Sprintf(`{"lockId": "%s", "data": "0x"}`, pldtypes.RandBytes32())),
+	})
+	assert.Regexp(t, "PD200049.*reclaimLock.*hooks", err)
+}
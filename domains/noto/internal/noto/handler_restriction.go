@@ -0,0 +1,255 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// Handles the notary administration functions pause/unpause (which restrict the whole contract)
+// and freeze/unfreeze (which restrict a single account).
+// Applying or removing a restriction records a NotoRestriction info state, which takes effect once the
+// transaction is confirmed on the base ledger.
+type restrictionHandler struct {
+	noto        *Noto
+	action      string
+	restriction string
+	apply       bool
+}
+
+func (h *restrictionHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	if h.restriction == types.NotoRestrictionFreeze {
+		var freezeParams types.FreezeParams
+		if err := json.Unmarshal([]byte(params), &freezeParams); err != nil {
+			return nil, err
+		}
+		if freezeParams.Account == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "account")
+		}
+		return &freezeParams, nil
+	}
+	var pauseParams types.PauseParams
+	if err := json.Unmarshal([]byte(params), &pauseParams); err != nil {
+		return nil, err
+	}
+	return &pauseParams, nil
+}
+
+func (h *restrictionHandler) params(tx *types.ParsedTransaction) (account string, data pldtypes.HexBytes) {
+	switch params := tx.Params.(type) {
+	case *types.FreezeParams:
+		return params.Account, params.Data
+	case *types.PauseParams:
+		return "", params.Data
+	}
+	return "", nil
+}
+
+func (h *restrictionHandler) checkAllowed(ctx context.Context, tx *types.ParsedTransaction, from string) error {
+	allowed := pauseAllowed(tx.DomainConfig)
+	if h.restriction == types.NotoRestrictionFreeze {
+		allowed = freezeAllowed(tx.DomainConfig)
+	}
+	if !allowed {
+		return i18n.NewError(ctx, msgs.MsgRestrictionNotAllowed, h.action)
+	}
+	if from != tx.DomainConfig.NotaryLookup {
+		return i18n.NewError(ctx, msgs.MsgRestrictionOnlyNotary, h.action, tx.DomainConfig.NotaryLookup, from)
+	}
+	return nil
+}
+
+// Restrictions on the whole contract are recorded against the zero address
+func (h *restrictionHandler) accountAddress(ctx context.Context, account string, verifiers []*prototk.ResolvedVerifier) (*pldtypes.EthAddress, error) {
+	if account == "" {
+		return &pldtypes.EthAddress{}, nil
+	}
+	return h.noto.findEthAddressVerifier(ctx, "account", account, verifiers)
+}
+
+func (h *restrictionHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	account, _ := h.params(tx)
+	notary := tx.DomainConfig.NotaryLookup
+	if err := h.checkAllowed(ctx, tx, req.Transaction.From); err != nil {
+		return nil, err
+	}
+
+	lookups := []string{notary}
+	if account != "" {
+		lookups = append(lookups, account)
+	}
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(lookups...),
+	}, nil
+}
+
+func (h *restrictionHandler) revert(ctx context.Context, account *pldtypes.EthAddress) *prototk.AssembleTransactionResponse {
+	var err error
+	switch {
+	case h.restriction == types.NotoRestrictionPause && h.apply:
+		err = i18n.NewError(ctx, msgs.MsgAlreadyPaused)
+	case h.restriction == types.NotoRestrictionPause:
+		err = i18n.NewError(ctx, msgs.MsgNotPaused)
+	case h.apply:
+		err = i18n.NewError(ctx, msgs.MsgAlreadyFrozen, account)
+	default:
+		err = i18n.NewError(ctx, msgs.MsgNotFrozen, account)
+	}
+	message := err.Error()
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+		RevertReason:   &message,
+	}
+}
+
+func (h *restrictionHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	account, data := h.params(tx)
+	notary := tx.DomainConfig.NotaryLookup
+
+	accountAddress, err := h.accountAddress(ctx, account, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+	restricted, err := h.noto.isRestricted(ctx, req.StateQueryContext, h.restriction, accountAddress)
+	if err != nil {
+		return nil, err
+	}
+	if restricted == h.apply {
+		return h.revert(ctx, accountAddress), nil
+	}
+
+	distributionList := []string{notary}
+	if account != "" {
+		distributionList = append(distributionList, account)
+	}
+	infoStates, err := h.noto.prepareInfo(data, distributionList)
+	if err != nil {
+		return nil, err
+	}
+	restrictionState, err := h.noto.makeNewRestrictionState(&types.NotoRestriction{
+		Salt:        pldtypes.RandBytes32(),
+		Restriction: h.restriction,
+		Account:     accountAddress,
+		Active:      h.apply,
+	}, distributionList)
+	if err != nil {
+		return nil, err
+	}
+	infoStates = append(infoStates, restrictionState)
+
+	encodedRestriction, err := h.noto.encodeRestriction(ctx, tx.ContractAddress, h.action, accountAddress, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InfoStates: infoStates,
+		},
+		AttestationPlan: []*prototk.AttestationRequest{
+			// Sender (the notary) confirms the initial request with a signature
+			{
+				Name:            "sender",
+				AttestationType: prototk.AttestationType_SIGN,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Payload:         encodedRestriction,
+				PayloadType:     signpayloads.OPAQUE_TO_RSV,
+				Parties:         []string{req.Transaction.From},
+			},
+			// Notary will endorse the assembled transaction (by submitting to the ledger)
+			{
+				Name:            "notary",
+				AttestationType: prototk.AttestationType_ENDORSE,
+				Algorithm:       algorithms.ECDSA_SECP256K1,
+				VerifierType:    verifiers.ETH_ADDRESS,
+				Parties:         []string{notary},
+			},
+		},
+	}, nil
+}
+
+// Check that the transaction records a single restriction of the expected type, for the expected account,
+// and that it applies or removes the restriction as requested
+func (h *restrictionHandler) validateRestrictionStates(ctx context.Context, account *pldtypes.EthAddress, req *prototk.EndorseTransactionRequest) error {
+	states := h.noto.filterSchema(req.Info, []string{h.noto.restrictionSchema.Id})
+	if len(req.Inputs) != 0 || len(req.Outputs) != 0 || len(states) != 1 {
+		return i18n.NewError(ctx, msgs.MsgInvalidRestriction, h.action)
+	}
+	restriction, err := h.noto.unmarshalRestriction(states[0].StateDataJson)
+	if err != nil {
+		return i18n.NewError(ctx, msgs.MsgInvalidStateData, states[0].Id, err)
+	}
+	if restriction.Restriction != h.restriction || !restriction.Account.Equals(account) || restriction.Active != h.apply {
+		return i18n.NewError(ctx, msgs.MsgInvalidRestriction, h.action)
+	}
+	return nil
+}
+
+func (h *restrictionHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	account, data := h.params(tx)
+	if err := h.checkAllowed(ctx, tx, req.Transaction.From); err != nil {
+		return nil, err
+	}
+
+	accountAddress, err := h.accountAddress(ctx, account, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.validateRestrictionStates(ctx, accountAddress, req); err != nil {
+		return nil, err
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedRestriction, err := h.noto.encodeRestriction(ctx, tx.ContractAddress, h.action, accountAddress, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedRestriction); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+func (h *restrictionHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	endorsement := domain.FindAttestation("notary", req.AttestationResult)
+	if endorsement == nil || endorsement.Verifier.Lookup != tx.DomainConfig.NotaryLookup {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
+	}
+
+	// The base ledger sees an empty transfer, which records the restriction info state
+	transfer := &transferHandler{noto: h.noto}
+	baseTransaction, err := transfer.baseLedgerInvoke(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+	return baseTransaction.prepare(nil)
+}
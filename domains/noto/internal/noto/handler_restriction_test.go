@@ -0,0 +1,645 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var notoRestrictedConfig = &types.NotoParsedConfig{
+	NotaryMode:   types.NotaryModeBasic.Enum(),
+	NotaryLookup: "notary@node1",
+	Options: types.NotoOptions{
+		Basic: &types.NotoBasicOptions{
+			RestrictMint: &pTrue,
+			AllowBurn:    &pTrue,
+			AllowLock:    &pTrue,
+			AllowPause:   &pTrue,
+			AllowFreeze:  &pTrue,
+			MaxSupply:    pldtypes.Int64ToInt256(100),
+		},
+	},
+}
+
+func newRestrictionTestNoto() *Noto {
+	return &Noto{
+		Callbacks:         mockCallbacks,
+		coinSchema:        &prototk.StateSchema{Id: "coin"},
+		lockedCoinSchema:  &prototk.StateSchema{Id: "lockedCoin"},
+		dataSchema:        &prototk.StateSchema{Id: "data"},
+		restrictionSchema: &prototk.StateSchema{Id: "restriction"},
+		supplySchema:      &prototk.StateSchema{Id: "supply"},
+	}
+}
+
+func newRestrictionTestTransaction(function, params string) *prototk.TransactionSpecification {
+	fn := types.NotoABI.Functions()[function]
+	return &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "notary@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(notoRestrictedConfig),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: params,
+	}
+}
+
+func restrictionState(id string, restriction string, account *pldtypes.EthAddress, active bool) *prototk.StoredState {
+	return &prototk.StoredState{
+		Id:       id,
+		SchemaId: "restriction",
+		DataJson: mustParseJSON(&types.NotoRestriction{
+			Salt:        pldtypes.RandBytes32(),
+			Restriction: restriction,
+			Account:     account,
+			Active:      active,
+		}),
+	}
+}
+
+func supplyState(id string, minted int64) *prototk.StoredState {
+	return &prototk.StoredState{
+		Id:       id,
+		SchemaId: "supply",
+		DataJson: mustParseJSON(&types.NotoSupply{
+			Salt:   pldtypes.RandBytes32(),
+			Minted: pldtypes.Int64ToInt256(minted),
+		}),
+	}
+}
+
+func TestFreezeAndUnfreeze(t *testing.T) {
+	n := newRestrictionTestNoto()
+	ctx := context.Background()
+
+	notaryKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	holderAddress := pldtypes.RandAddress()
+	verifierList := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryKey.Address.String(),
+		},
+		{
+			Lookup:       "holder@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     holderAddress.String(),
+		},
+	}
+
+	tx := newRestrictionTestTransaction("freeze", `{"account": "holder@node2", "data": "0x1234"}`)
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 2)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "holder@node2", initRes.RequiredVerifiers[1].Lookup)
+
+	mockAvailableStatePages()
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	assert.Len(t, assembleRes.AssembledTransaction.InputStates, 0)
+	assert.Len(t, assembleRes.AssembledTransaction.OutputStates, 0)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 2)
+	assert.Equal(t, "data", assembleRes.AssembledTransaction.InfoStates[0].SchemaId)
+	assert.Equal(t, "restriction", assembleRes.AssembledTransaction.InfoStates[1].SchemaId)
+	assert.Equal(t, []string{"notary@node1", "holder@node2"}, assembleRes.AssembledTransaction.InfoStates[0].DistributionList)
+	assert.Equal(t, []string{"notary@node1", "holder@node2"}, assembleRes.AssembledTransaction.InfoStates[1].DistributionList)
+	restriction, err := n.unmarshalRestriction(assembleRes.AssembledTransaction.InfoStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, types.NotoRestrictionFreeze, restriction.Restriction)
+	assert.Equal(t, holderAddress, restriction.Account)
+	assert.True(t, restriction.Active)
+
+	encodedRestriction, err := n.encodeRestriction(ctx, ethtypes.MustNewAddress(tx.ContractInfo.ContractAddress), "freeze", holderAddress, pldtypes.MustParseHexBytes("0x1234"))
+	require.NoError(t, err)
+	assert.Equal(t, encodedRestriction, ethtypes.HexBytes0xPrefix(assembleRes.AttestationPlan[0].Payload))
+	signature, err := notaryKey.SignDirect(encodedRestriction)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000002",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+		{
+			SchemaId:      "restriction",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000003",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[1].StateDataJson,
+		},
+	}
+	signatures := []*prototk.AttestationResult{
+		{
+			Name:     "sender",
+			Verifier: &prototk.ResolvedVerifier{Verifier: notaryKey.Address.String()},
+			Payload:  signatureBytes,
+		},
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifierList,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	// The notary will not endorse a restriction on a different account, or one that removes the restriction
+	for _, wrongRestriction := range []*prototk.StoredState{
+		restrictionState("0x03", types.NotoRestrictionFreeze, pldtypes.RandAddress(), true),
+		restrictionState("0x03", types.NotoRestrictionFreeze, holderAddress, false),
+	} {
+		_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+			Transaction:       tx,
+			ResolvedVerifiers: verifierList,
+			Info: []*prototk.EndorsableState{infoStates[0], {
+				SchemaId:      "restriction",
+				Id:            wrongRestriction.Id,
+				StateDataJson: wrongRestriction.DataJson,
+			}},
+			EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+			Signatures:         signatures,
+		})
+		assert.ErrorContains(t, err, "PD200048")
+	}
+
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+		InfoStates:        infoStates,
+		AttestationResult: append(signatures, &prototk.AttestationResult{
+			Name:     "notary",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+		}),
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["transfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"inputs": [],
+		"outputs": [],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d0000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000003"
+	}`, signatureBytes), prepareRes.Transaction.ParamsJson)
+
+	// Freezing again is reverted once the freeze is confirmed
+	mockAvailableStatePages([]*prototk.StoredState{restrictionState("0x03", types.NotoRestrictionFreeze, holderAddress, true)})
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200046", *assembleRes.RevertReason)
+
+	// Unfreeze records that the restriction is removed
+	tx = newRestrictionTestTransaction("unfreeze", `{"account": "holder@node2"}`)
+	mockAvailableStatePages([]*prototk.StoredState{restrictionState("0x03", types.NotoRestrictionFreeze, holderAddress, true)})
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	assert.Len(t, assembleRes.AssembledTransaction.InputStates, 0)
+	assert.Len(t, assembleRes.AssembledTransaction.OutputStates, 0)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 2)
+	restriction, err = n.unmarshalRestriction(assembleRes.AssembledTransaction.InfoStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, holderAddress, restriction.Account)
+	assert.False(t, restriction.Active)
+
+	// Unfreezing an account that was never frozen, or has already been unfrozen, is reverted
+	mockAvailableStatePages()
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200047", *assembleRes.RevertReason)
+
+	mockAvailableStatePages([]*prototk.StoredState{restrictionState("0x04", types.NotoRestrictionFreeze, holderAddress, false)})
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200047", *assembleRes.RevertReason)
+}
+
+func TestPauseAndUnpause(t *testing.T) {
+	n := newRestrictionTestNoto()
+	ctx := context.Background()
+	verifierList := []*prototk.ResolvedVerifier{{
+		Lookup:       "notary@node1",
+		Algorithm:    algorithms.ECDSA_SECP256K1,
+		VerifierType: verifiers.ETH_ADDRESS,
+		Verifier:     pldtypes.RandAddress().String(),
+	}}
+
+	tx := newRestrictionTestTransaction("pause", `{}`)
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 1)
+
+	mockAvailableStatePages()
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 2)
+	assert.Equal(t, []string{"notary@node1"}, assembleRes.AssembledTransaction.InfoStates[1].DistributionList)
+	restriction, err := n.unmarshalRestriction(assembleRes.AssembledTransaction.InfoStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, types.NotoRestrictionPause, restriction.Restriction)
+	assert.True(t, restriction.Account.IsZero())
+	assert.True(t, restriction.Active)
+
+	mockAvailableStatePages([]*prototk.StoredState{restrictionState("0x01", types.NotoRestrictionPause, &pldtypes.EthAddress{}, true)})
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200044", *assembleRes.RevertReason)
+
+	tx = newRestrictionTestTransaction("unpause", `{}`)
+	mockAvailableStatePages()
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200045", *assembleRes.RevertReason)
+
+	// Unpause must record the removed restriction
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifierList,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+	})
+	assert.ErrorContains(t, err, "PD200048")
+}
+
+func TestRestrictionNotAllowed(t *testing.T) {
+	n := newRestrictionTestNoto()
+	ctx := context.Background()
+
+	tx := newRestrictionTestTransaction("freeze", `{}`)
+	_, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD200007")
+
+	tx = newRestrictionTestTransaction("pause", `{}`)
+	tx.From = "holder@node2"
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD200040")
+
+	tx = newRestrictionTestTransaction("unfreeze", `{"account": "holder@node2"}`)
+	tx.ContractInfo.ContractConfigJson = mustParseJSON(notoBasicConfig)
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD200039")
+}
+
+func TestTransferRestricted(t *testing.T) {
+	n := newRestrictionTestNoto()
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["transfer"]
+
+	senderAddress := pldtypes.RandAddress()
+	receiverAddress := pldtypes.RandAddress()
+	req := &prototk.EndorseTransactionRequest{
+		Transaction: &prototk.TransactionSpecification{
+			From: "sender@node1",
+			ContractInfo: &prototk.ContractInfo{
+				ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+				ContractConfigJson: mustParseJSON(notoRestrictedConfig),
+			},
+			FunctionAbiJson:    mustParseJSON(fn),
+			FunctionSignature:  fn.SolString(),
+			FunctionParamsJson: `{"to": "receiver@node2", "amount": 10}`,
+		},
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "sender@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     senderAddress.String(),
+			},
+			{
+				Lookup:       "receiver@node2",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     receiverAddress.String(),
+			},
+		},
+		Inputs: []*prototk.EndorsableState{{
+			SchemaId:      "coin",
+			Id:            "0x01",
+			StateDataJson: mustParseJSON(&types.NotoCoin{Owner: senderAddress, Amount: pldtypes.Int64ToInt256(10)}),
+		}},
+		Outputs: []*prototk.EndorsableState{{
+			SchemaId:      "coin",
+			Id:            "0x02",
+			StateDataJson: mustParseJSON(&types.NotoCoin{Owner: receiverAddress, Amount: pldtypes.Int64ToInt256(10)}),
+		}},
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+	}
+
+	mockAvailableStatePages([]*prototk.StoredState{restrictionState("0x03", types.NotoRestrictionPause, &pldtypes.EthAddress{}, true)})
+	_, err := n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200041", err)
+
+	// pause check, then the freeze check for the sender and the receiver
+	mockAvailableStatePages(nil, nil, []*prototk.StoredState{restrictionState("0x03", types.NotoRestrictionFreeze, receiverAddress, true)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200042.*"+receiverAddress.String(), err)
+
+	// With no restrictions in force, the transfer proceeds to the signature check
+	mockAvailableStatePages(
+		[]*prototk.StoredState{restrictionState("0x04", types.NotoRestrictionPause, &pldtypes.EthAddress{}, false)},
+		nil,
+		[]*prototk.StoredState{restrictionState("0x05", types.NotoRestrictionFreeze, receiverAddress, false)},
+	)
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200015", err)
+}
+
+func TestApproveTransferRestricted(t *testing.T) {
+	n := newRestrictionTestNoto()
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["approveTransfer"]
+
+	senderAddress := pldtypes.RandAddress()
+	receiverAddress := pldtypes.RandAddress()
+	params := &types.ApproveParams{
+		Inputs: []*pldapi.StateEncoded{{
+			ID:     pldtypes.MustParseHexBytes("0x01"),
+			Schema: pldtypes.MustParseBytes32("0x0000000000000000000000000000000000000000000000000000000000000001"),
+			Data:   pldtypes.HexBytes(mustParseJSON(&types.NotoCoin{Owner: senderAddress, Amount: pldtypes.Int64ToInt256(10)})),
+		}},
+		Outputs: []*pldapi.StateEncoded{{
+			ID:     pldtypes.MustParseHexBytes("0x02"),
+			Schema: pldtypes.MustParseBytes32("0x0000000000000000000000000000000000000000000000000000000000000001"),
+			Data:   pldtypes.HexBytes(mustParseJSON(&types.NotoCoin{Owner: receiverAddress, Amount: pldtypes.Int64ToInt256(10)})),
+		}},
+		Delegate: pldtypes.RandAddress(),
+	}
+	n.coinSchema.Id = params.Inputs[0].Schema.String()
+	req := &prototk.EndorseTransactionRequest{
+		Transaction: &prototk.TransactionSpecification{
+			From: "sender@node1",
+			ContractInfo: &prototk.ContractInfo{
+				ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+				ContractConfigJson: mustParseJSON(notoRestrictedConfig),
+			},
+			FunctionAbiJson:    mustParseJSON(fn),
+			FunctionSignature:  fn.SolString(),
+			FunctionParamsJson: mustParseJSON(params),
+		},
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderAddress.String(),
+		}},
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+	}
+
+	mockAvailableStatePages([]*prototk.StoredState{restrictionState("0x03", types.NotoRestrictionPause, &pldtypes.EthAddress{}, true)})
+	_, err := n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200041", err)
+
+	mockAvailableStatePages(nil, nil, []*prototk.StoredState{restrictionState("0x03", types.NotoRestrictionFreeze, receiverAddress, true)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200042.*"+receiverAddress.String(), err)
+
+	mockAvailableStatePages()
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200015", err)
+}
+
+func TestMintMaxSupply(t *testing.T) {
+	n := newRestrictionTestNoto()
+	ctx := context.Background()
+
+	notaryKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	holderAddress := pldtypes.RandAddress()
+	verifierList := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryKey.Address.String(),
+		},
+		{
+			Lookup:       "holder@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     holderAddress.String(),
+		},
+	}
+
+	// The first mint creates the supply state
+	tx := newRestrictionTestTransaction("mint", `{"to": "holder@node2", "amount": 30}`)
+	mockAvailableStatePages()
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	assert.Len(t, assembleRes.AssembledTransaction.InputStates, 0)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 2)
+	assert.Equal(t, "supply", assembleRes.AssembledTransaction.OutputStates[1].SchemaId)
+	assert.Equal(t, []string{"notary@node1"}, assembleRes.AssembledTransaction.OutputStates[1].DistributionList)
+	supply, err := n.unmarshalSupply(assembleRes.AssembledTransaction.OutputStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), supply.Minted.Int().Int64())
+
+	// Later mints spend the supply state, and replace it with the new total
+	mockAvailableStatePages([]*prototk.StoredState{supplyState("0x01", 60)})
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 1)
+	assert.Equal(t, "0x01", assembleRes.AssembledTransaction.InputStates[0].Id)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 2)
+	supply, err = n.unmarshalSupply(assembleRes.AssembledTransaction.OutputStates[1].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, int64(90), supply.Minted.Int().Int64())
+
+	// Burns do not reduce the total minted
+	mockAvailableStatePages([]*prototk.StoredState{supplyState("0x01", 80)})
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200043.*30.*100.*minted=80", *assembleRes.RevertReason)
+
+	// The notary must only ever hold a single supply state
+	mockAvailableStatePages([]*prototk.StoredState{supplyState("0x01", 30), supplyState("0x02", 60)})
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200054.*2", *assembleRes.RevertReason)
+
+	mintOutput := &prototk.EndorsableState{
+		SchemaId:      "coin",
+		Id:            "0x02",
+		StateDataJson: mustParseJSON(&types.NotoCoin{Owner: holderAddress, Amount: pldtypes.Int64ToInt256(30)}),
+	}
+	supplyEndorsable := func(state *prototk.StoredState) *prototk.EndorsableState {
+		return &prototk.EndorsableState{SchemaId: state.SchemaId, Id: state.Id, StateDataJson: state.DataJson}
+	}
+	req := &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifierList,
+		Inputs:             []*prototk.EndorsableState{supplyEndorsable(supplyState("0x01", 60))},
+		Outputs:            []*prototk.EndorsableState{mintOutput, supplyEndorsable(supplyState("0x03", 90))},
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+	}
+
+	// pause check and freeze check, then the signature check
+	mockAvailableStatePages()
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200015", err)
+
+	req.Outputs[1] = supplyEndorsable(supplyState("0x03", 80))
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200053.*90", err)
+
+	req.Outputs = req.Outputs[:1]
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200053", err)
+
+	req.Inputs = []*prototk.EndorsableState{supplyEndorsable(supplyState("0x01", 80))}
+	req.Outputs = []*prototk.EndorsableState{mintOutput, supplyEndorsable(supplyState("0x03", 110))}
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200043.*30.*100.*minted=80", err)
+
+	mockAvailableStatePages(nil, []*prototk.StoredState{restrictionState("0x04", types.NotoRestrictionFreeze, holderAddress, true)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200042", err)
+
+	req.Inputs = []*prototk.EndorsableState{supplyEndorsable(supplyState("0x01", 60)), supplyEndorsable(supplyState("0x04", 60))}
+	req.Outputs = []*prototk.EndorsableState{mintOutput, supplyEndorsable(supplyState("0x03", 90))}
+	mockAvailableStatePages()
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200054.*2", err)
+
+	// pause check and freeze check, then the notary's own supply states
+	req.Inputs = req.Inputs[:1]
+	mockAvailableStatePages(nil, nil, []*prototk.StoredState{supplyState("0x03", 90)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200015", err)
+
+	// A later mint has already spent the output, and only its own output is available
+	mockAvailableStatePages(nil, nil, []*prototk.StoredState{supplyState("0x05", 100)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200015", err)
+
+	// A mint that leaves the current supply state unspent is rejected
+	mockAvailableStatePages(nil, nil, []*prototk.StoredState{supplyState("0x05", 100), supplyState("0x03", 90)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200055.*0x05", err)
+
+	req.Inputs = nil
+	req.Outputs = []*prototk.EndorsableState{mintOutput, supplyEndorsable(supplyState("0x03", 30))}
+	mockAvailableStatePages(nil, nil, []*prototk.StoredState{supplyState("0x01", 60), supplyState("0x03", 30)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200055.*0x01", err)
+
+	mockAvailableStatePages(nil, nil, []*prototk.StoredState{supplyState("0x01", 60), supplyState("0x05", 60)})
+	_, err = n.EndorseTransaction(ctx, req)
+	assert.Regexp(t, "PD200055", err)
+
+	// A mint that spends a supply state is submitted as a transfer
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifierList,
+		InputStates: []*prototk.EndorsableState{
+			{SchemaId: "supply", Id: "0x0000000000000000000000000000000000000000000000000000000000000001"},
+		},
+		OutputStates: []*prototk.EndorsableState{
+			{SchemaId: "coin", Id: "0x0000000000000000000000000000000000000000000000000000000000000002"},
+			{SchemaId: "supply", Id: "0x0000000000000000000000000000000000000000000000000000000000000003"},
+		},
+		AttestationResult: []*prototk.AttestationResult{
+			{
+				Name:     "sender",
+				Verifier: &prototk.ResolvedVerifier{Verifier: notaryKey.Address.String()},
+				Payload:  pldtypes.MustParseHexBytes("0x1234"),
+			},
+			{
+				Name:     "notary",
+				Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["transfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.JSONEq(t, `{
+		"inputs": ["0x0000000000000000000000000000000000000000000000000000000000000001"],
+		"outputs": [
+			"0x0000000000000000000000000000000000000000000000000000000000000002",
+			"0x0000000000000000000000000000000000000000000000000000000000000003"
+		],
+		"signature": "0x1234",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d00000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000000"
+	}`, prepareRes.Transaction.ParamsJson)
+}
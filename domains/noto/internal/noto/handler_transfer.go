@@ -154,6 +154,9 @@ func (h *transferHandler) Endorse(ctx context.Context, tx *types.ParsedTransacti
 	if err := h.noto.validateOwners(ctx, tx.Transaction.From, req, inputs.coins, inputs.states); err != nil {
 		return nil, err
 	}
+	if err := h.checkRestrictions(ctx, tx, req); err != nil {
+		return nil, err
+	}
 
 	// Notary checks the signature from the sender, then submits the transaction
 	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputs.coins, outputs.coins)
@@ -168,6 +171,23 @@ func (h *transferHandler) Endorse(ctx context.Context, tx *types.ParsedTransacti
 	}, nil
 }
 
+// Check that the contract is not paused, and neither the sender nor the recipient is frozen
+func (h *transferHandler) checkRestrictions(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) error {
+	if !pauseAllowed(tx.DomainConfig) && !freezeAllowed(tx.DomainConfig) {
+		return nil
+	}
+	params := tx.Params.(*types.TransferParams)
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", params.To, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	return h.noto.checkRestrictions(ctx, tx.DomainConfig, req.StateQueryContext, fromAddress, toAddress)
+}
+
 func (h *transferHandler) baseLedgerInvoke(ctx context.Context, req *prototk.PrepareTransactionRequest, withApproval bool) (*TransactionWrapper, error) {
 	// Include the signature from the sender
 	// This is not verified on the base ledger, but can be verified by anyone with the unmasked state data
@@ -0,0 +1,240 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// A batch transfer pays multiple recipients from a single set of inputs, with one output per recipient
+// (plus any remainder returned to the sender). On the base ledger it is an ordinary transfer.
+type transferBatchHandler struct {
+	noto *Noto
+}
+
+func (h *transferBatchHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var batchParams types.TransferBatchParams
+	if err := json.Unmarshal([]byte(params), &batchParams); err != nil {
+		return nil, err
+	}
+	if len(batchParams.Transfers) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "transfers")
+	}
+	for i, entry := range batchParams.Transfers {
+		if entry == nil || entry.To == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, fmt.Sprintf("transfers[%d].to", i))
+		}
+		if entry.Amount == nil || entry.Amount.Int().Sign() != 1 {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, fmt.Sprintf("transfers[%d].amount", i))
+		}
+	}
+	return &batchParams, nil
+}
+
+// Every party to the batch (without duplicates, as the same recipient may appear more than once)
+func (h *transferBatchHandler) parties(tx *types.ParsedTransaction) []string {
+	params := tx.Params.(*types.TransferBatchParams)
+	parties := []string{tx.DomainConfig.NotaryLookup, tx.Transaction.From}
+	seen := map[string]bool{parties[0]: true, parties[1]: true}
+	for _, entry := range params.Transfers {
+		if !seen[entry.To] {
+			seen[entry.To] = true
+			parties = append(parties, entry.To)
+		}
+	}
+	return parties
+}
+
+func (h *transferBatchHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	// Hooks receive a single transfer at a time, so cannot be invoked for a batch
+	if tx.DomainConfig.NotaryMode != types.NotaryModeBasic.Enum() {
+		return nil, i18n.NewError(ctx, msgs.MsgNotSupportedByNotaryMode, "transferBatch", tx.DomainConfig.NotaryMode)
+	}
+
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(h.parties(tx)...),
+	}, nil
+}
+
+func (h *transferBatchHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.TransferBatchParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	total := big.NewInt(0)
+	for _, entry := range params.Transfers {
+		total.Add(total, entry.Amount.Int())
+	}
+
+	inputStates, revert, err := h.noto.prepareInputs(ctx, req.StateQueryContext, fromAddress, (*pldtypes.HexUint256)(total))
+	if err != nil {
+		if revert {
+			message := err.Error()
+			return &prototk.AssembleTransactionResponse{
+				AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+				RevertReason:   &message,
+			}, nil
+		}
+		return nil, err
+	}
+
+	outputStates := &preparedOutputs{}
+	for _, entry := range params.Transfers {
+		toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", entry.To, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := h.noto.prepareOutputs(toAddress, entry.Amount, []string{notary, tx.Transaction.From, entry.To})
+		if err != nil {
+			return nil, err
+		}
+		outputStates.coins = append(outputStates.coins, outputs.coins...)
+		outputStates.states = append(outputStates.states, outputs.states...)
+	}
+
+	if inputStates.total.Cmp(total) == 1 {
+		remainder := big.NewInt(0).Sub(inputStates.total, total)
+		returnedStates, err := h.noto.prepareOutputs(fromAddress, (*pldtypes.HexUint256)(remainder), []string{notary, tx.Transaction.From})
+		if err != nil {
+			return nil, err
+		}
+		outputStates.coins = append(outputStates.coins, returnedStates.coins...)
+		outputStates.states = append(outputStates.states, returnedStates.states...)
+	}
+
+	infoStates, err := h.noto.prepareInfo(params.Data, h.parties(tx))
+	if err != nil {
+		return nil, err
+	}
+
+	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputStates.coins, outputStates.coins)
+	if err != nil {
+		return nil, err
+	}
+	attestation := []*prototk.AttestationRequest{
+		// Sender confirms the initial request with a signature
+		{
+			Name:            "sender",
+			AttestationType: prototk.AttestationType_SIGN,
+			Algorithm:       algorithms.ECDSA_SECP256K1,
+			VerifierType:    verifiers.ETH_ADDRESS,
+			Payload:         encodedTransfer,
+			PayloadType:     signpayloads.OPAQUE_TO_RSV,
+			Parties:         []string{req.Transaction.From},
+		},
+		// Notary will endorse the assembled transaction (by submitting to the ledger)
+		{
+			Name:            "notary",
+			AttestationType: prototk.AttestationType_ENDORSE,
+			Algorithm:       algorithms.ECDSA_SECP256K1,
+			VerifierType:    verifiers.ETH_ADDRESS,
+			Parties:         []string{notary},
+		},
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InputStates:  inputStates.states,
+			OutputStates: outputStates.states,
+			InfoStates:   infoStates,
+		},
+		AttestationPlan: attestation,
+	}, nil
+}
+
+func (h *transferBatchHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	params := tx.Params.(*types.TransferBatchParams)
+
+	inputs, err := h.noto.parseCoinList(ctx, "input", req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "output", req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the amounts, and sender's ownership of the inputs
+	if err := h.noto.validateTransferAmounts(ctx, inputs, outputs); err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateOwners(ctx, tx.Transaction.From, req, inputs.coins, inputs.states); err != nil {
+		return nil, err
+	}
+
+	// Check the contract is not paused, and neither the sender nor any recipient is frozen
+	if pauseAllowed(tx.DomainConfig) || freezeAllowed(tx.DomainConfig) {
+		fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", tx.Transaction.From, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+		accounts := []*pldtypes.EthAddress{fromAddress}
+		for _, entry := range params.Transfers {
+			toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", entry.To, req.ResolvedVerifiers)
+			if err != nil {
+				return nil, err
+			}
+			accounts = append(accounts, toAddress)
+		}
+		if err := h.noto.checkRestrictions(ctx, tx.DomainConfig, req.StateQueryContext, accounts...); err != nil {
+			return nil, err
+		}
+	}
+
+	// Notary checks the signature from the sender, then submits the transaction
+	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputs.coins, outputs.coins)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.noto.validateSignature(ctx, "sender", req.Signatures, encodedTransfer); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+func (h *transferBatchHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	endorsement := domain.FindAttestation("notary", req.AttestationResult)
+	if endorsement == nil || endorsement.Verifier.Lookup != tx.DomainConfig.NotaryLookup {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
+	}
+
+	transfer := &transferHandler{noto: h.noto}
+	baseTransaction, err := transfer.baseLedgerInvoke(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+	return baseTransaction.prepare(nil)
+}
@@ -0,0 +1,339 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransferBatchTestTransaction(config *types.NotoParsedConfig, params string) *prototk.TransactionSpecification {
+	fn := types.NotoABI.Functions()["transferBatch"]
+	return &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(config),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: params,
+	}
+}
+
+func TestTransferBatch(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	receiver1Address := "0x2000000000000000000000000000000000000000"
+	receiver2Address := "0x3000000000000000000000000000000000000000"
+	senderKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	inputCoin := &types.NotoCoinState{
+		ID: pldtypes.RandBytes32(),
+		Data: types.NotoCoin{
+			Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+			Amount: pldtypes.Int64ToInt256(100),
+		},
+	}
+	mockCallbacks.MockFindAvailableStates = func() (*prototk.FindAvailableStatesResponse, error) {
+		return &prototk.FindAvailableStatesResponse{
+			States: []*prototk.StoredState{
+				{
+					Id:       inputCoin.ID.String(),
+					SchemaId: "coin",
+					DataJson: mustParseJSON(inputCoin.Data),
+				},
+			},
+		}, nil
+	}
+
+	tx := newTransferBatchTestTransaction(notoBasicConfig, `{
+		"transfers": [
+			{"to": "receiver1@node2", "amount": 30},
+			{"to": "receiver2@node3", "amount": 40},
+			{"to": "receiver1@node2", "amount": 5}
+		],
+		"data": "0x1234"
+	}`)
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 4)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "sender@node1", initRes.RequiredVerifiers[1].Lookup)
+	assert.Equal(t, "receiver1@node2", initRes.RequiredVerifiers[2].Lookup)
+	assert.Equal(t, "receiver2@node3", initRes.RequiredVerifiers[3].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderKey.Address.String(),
+		},
+		{
+			Lookup:       "receiver1@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiver1Address,
+		},
+		{
+			Lookup:       "receiver2@node3",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiver2Address,
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 4)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 1)
+	assert.Equal(t, inputCoin.ID.String(), assembleRes.AssembledTransaction.InputStates[0].Id)
+
+	expectedOutputs := []struct {
+		owner            string
+		amount           string
+		distributionList []string
+	}{
+		{receiver1Address, "30", []string{"notary@node1", "sender@node1", "receiver1@node2"}},
+		{receiver2Address, "40", []string{"notary@node1", "sender@node1", "receiver2@node3"}},
+		{receiver1Address, "5", []string{"notary@node1", "sender@node1", "receiver1@node2"}},
+		{senderKey.Address.String(), "25", []string{"notary@node1", "sender@node1"}},
+	}
+	outputCoins := make([]*types.NotoCoin, len(expectedOutputs))
+	outputStates := make([]*prototk.EndorsableState, len(expectedOutputs))
+	for i, expected := range expectedOutputs {
+		state := assembleRes.AssembledTransaction.OutputStates[i]
+		outputCoins[i], err = n.unmarshalCoin(state.StateDataJson)
+		require.NoError(t, err)
+		assert.Equal(t, expected.owner, outputCoins[i].Owner.String())
+		assert.Equal(t, expected.amount, outputCoins[i].Amount.Int().String())
+		assert.Equal(t, expected.distributionList, state.DistributionList)
+		outputStates[i] = &prototk.EndorsableState{
+			SchemaId:      "coin",
+			Id:            fmt.Sprintf("0x%064x", i+1),
+			StateDataJson: state.StateDataJson,
+		}
+	}
+
+	outputInfo, err := n.unmarshalInfo(assembleRes.AssembledTransaction.InfoStates[0].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, "0x1234", outputInfo.Data.String())
+	assert.Equal(t, []string{"notary@node1", "sender@node1", "receiver1@node2", "receiver2@node3"}, assembleRes.AssembledTransaction.InfoStates[0].DistributionList)
+
+	encodedTransfer, err := n.encodeTransferUnmasked(ctx, ethtypes.MustNewAddress(tx.ContractInfo.ContractAddress),
+		[]*types.NotoCoin{&inputCoin.Data}, outputCoins)
+	require.NoError(t, err)
+	signature, err := senderKey.SignDirect(encodedTransfer)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	inputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "coin",
+			Id:            inputCoin.ID.String(),
+			StateDataJson: mustParseJSON(inputCoin.Data),
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000005",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+	}
+	senderSignature := &prototk.AttestationResult{
+		Name:     "sender",
+		Verifier: &prototk.ResolvedVerifier{Verifier: senderKey.Address.String()},
+		Payload:  signatureBytes,
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         []*prototk.AttestationResult{senderSignature},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	// Outputs that do not balance the inputs are rejected
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates[0:3],
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         []*prototk.AttestationResult{senderSignature},
+	})
+	assert.Regexp(t, "PD200013", err)
+
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: []*prototk.AttestationResult{
+			senderSignature,
+			{
+				Name:     "notary",
+				Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["transfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.Nil(t, prepareRes.Transaction.ContractAddress)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"inputs": ["%s"],
+		"outputs": [
+			"0x0000000000000000000000000000000000000000000000000000000000000001",
+			"0x0000000000000000000000000000000000000000000000000000000000000002",
+			"0x0000000000000000000000000000000000000000000000000000000000000003",
+			"0x0000000000000000000000000000000000000000000000000000000000000004"
+		],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000005"
+	}`, inputCoin.ID, signatureBytes), prepareRes.Transaction.ParamsJson)
+
+	_, err = n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: []*prototk.AttestationResult{senderSignature},
+	})
+	assert.Regexp(t, "PD200015", err)
+}
+
+func TestTransferBatchBadParams(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+
+	_, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: newTransferBatchTestTransaction(notoBasicConfig, `{"transfers": []}`),
+	})
+	assert.Regexp(t, "PD200007.*transfers", err)
+
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: newTransferBatchTestTransaction(notoBasicConfig, `{"transfers": [{"amount": 1}]}`),
+	})
+	assert.Regexp(t, `PD200007.*transfers\[0\].to`, err)
+
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: newTransferBatchTestTransaction(notoBasicConfig, `{"transfers": [{"to": "a@node1", "amount": 1}, {"to": "b@node1", "amount": 0}]}`),
+	})
+	assert.Regexp(t, `PD200008.*transfers\[1\].amount`, err)
+
+	hooksConfig := &types.NotoParsedConfig{
+		NotaryLookup: "notary@node1",
+		NotaryMode:   types.NotaryModeHooks.Enum(),
+		Options: types.NotoOptions{
+			Hooks: &types.NotoHooksOptions{
+				PublicAddress:     pldtypes.RandAddress(),
+				DevUsePublicHooks: true,
+			},
+		},
+	}
+	_, err = n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: newTransferBatchTestTransaction(hooksConfig, `{"transfers": [{"to": "a@node1", "amount": 1}]}`),
+	})
+	assert.Regexp(t, "PD200049.*transferBatch.*hooks", err)
+}
+
+func TestTransferBatchInsufficientFunds(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+	sender := pldtypes.RandAddress()
+
+	mockAvailableStatePages([]*prototk.StoredState{{
+		Id:       pldtypes.RandBytes32().String(),
+		SchemaId: "coin",
+		DataJson: mustParseJSON(&types.NotoCoin{
+			Owner:  sender,
+			Amount: pldtypes.Int64ToInt256(50),
+		}),
+	}})
+
+	tx := newTransferBatchTestTransaction(notoBasicConfig, `{
+		"transfers": [
+			{"to": "receiver1@node2", "amount": 30},
+			{"to": "receiver2@node3", "amount": 30}
+		]
+	}`)
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction: tx,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "sender@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     sender.String(),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200005.*available=50", *assembleRes.RevertReason)
+}
@@ -130,11 +130,15 @@ func (h *unlockCommon) assembleStates(ctx context.Context, tx *types.ParsedTrans
 	if err != nil {
 		return nil, nil, err
 	}
-	lockState, err := h.noto.prepareLockInfo(params.LockID, fromAddress, nil, []string{notary, params.From})
+	expiry, err := h.noto.findLockExpiry(ctx, req.StateQueryContext, params.LockID)
 	if err != nil {
 		return nil, nil, err
 	}
-	infoStates = append(infoStates, lockState)
+	lockStates, err := h.noto.prepareLockInfo(params.LockID, fromAddress, nil, expiry, []string{notary, params.From})
+	if err != nil {
+		return nil, nil, err
+	}
+	infoStates = append(infoStates, lockStates...)
 
 	return &prototk.AssembleTransactionResponse{
 			AssemblyResult: prototk.AssembleTransactionResponse_OK,
@@ -196,6 +200,9 @@ func (h *unlockCommon) endorse(
 	if err := h.noto.validateLockOwners(ctx, params.From, req.ResolvedVerifiers, outputs.lockedCoins, outputs.lockedStates); err != nil {
 		return nil, err
 	}
+	if err := h.checkRestrictions(ctx, tx, params, req, outputs); err != nil {
+		return nil, err
+	}
 
 	// Notary checks the signatures from the sender, then submits the transaction
 	encodedUnlock, err := h.noto.encodeUnlock(ctx, tx.ContractAddress, inputs.lockedCoins, outputs.lockedCoins, outputs.coins)
@@ -210,6 +217,22 @@ func (h *unlockCommon) endorse(
 	}, nil
 }
 
+// Check that the contract is not paused, and neither the lock owner nor any of the recipients is frozen
+func (h *unlockCommon) checkRestrictions(ctx context.Context, tx *types.ParsedTransaction, params *types.UnlockParams, req *prototk.EndorseTransactionRequest, outputs *parsedCoins) error {
+	if !pauseAllowed(tx.DomainConfig) && !freezeAllowed(tx.DomainConfig) {
+		return nil
+	}
+	fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", params.From, req.ResolvedVerifiers)
+	if err != nil {
+		return err
+	}
+	accounts := []*pldtypes.EthAddress{fromAddress}
+	for _, coin := range outputs.coins {
+		accounts = append(accounts, coin.Owner)
+	}
+	return h.noto.checkRestrictions(ctx, tx.DomainConfig, req.StateQueryContext, accounts...)
+}
+
 func (h *unlockHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
 	var unlockParams types.UnlockParams
 	err := json.Unmarshal([]byte(params), &unlockParams)
@@ -38,8 +38,12 @@ func (n *Noto) GetHandler(method string) types.DomainHandler {
 		return &mintHandler{noto: n}
 	case "transfer":
 		return &transferHandler{noto: n}
+	case "transferBatch":
+		return &transferBatchHandler{noto: n}
 	case "burn":
 		return &burnHandler{noto: n}
+	case "consolidate":
+		return &consolidateHandler{noto: n}
 	case "approveTransfer":
 		return &approveHandler{noto: n}
 	case "lock":
@@ -54,6 +58,14 @@ func (n *Noto) GetHandler(method string) types.DomainHandler {
 		}
 	case "delegateLock":
 		return &delegateLockHandler{noto: n}
+	case "reclaimLock":
+		return &reclaimLockHandler{noto: n}
+	case "changeNotary":
+		return &changeNotaryHandler{noto: n}
+	case "pause", "unpause":
+		return &restrictionHandler{noto: n, action: method, restriction: types.NotoRestrictionPause, apply: method == "pause"}
+	case "freeze", "unfreeze":
+		return &restrictionHandler{noto: n, action: method, restriction: types.NotoRestrictionFreeze, apply: method == "freeze"}
 	default:
 		return nil
 	}
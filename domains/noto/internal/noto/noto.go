@@ -64,6 +64,7 @@ var (
 	NotoUnlock         = "NotoUnlock"
 	NotoUnlockPrepared = "NotoUnlockPrepared"
 	NotoLockDelegated  = "NotoLockDelegated"
+	NotoNotaryChanged  = "NotoNotaryChanged"
 )
 
 var allEvents = []string{
@@ -73,6 +74,7 @@ var allEvents = []string{
 	NotoUnlock,
 	NotoUnlockPrepared,
 	NotoLockDelegated,
+	NotoNotaryChanged,
 }
 
 var eventsJSON = mustBuildEventsJSON(interfaceBuild.ABI, errorsBuild.ABI)
@@ -83,6 +85,9 @@ var allSchemas = []*abi.Parameter{
 	types.NotoLockInfoABI,
 	types.NotoLockedCoinABI,
 	types.TransactionDataABI,
+	types.NotoRestrictionABI,
+	types.NotoSupplyABI,
+	types.NotoLockExpiryABI,
 }
 
 var schemasJSON = mustParseSchemas(allSchemas)
@@ -90,13 +95,16 @@ var schemasJSON = mustParseSchemas(allSchemas)
 type Noto struct {
 	Callbacks plugintk.DomainCallbacks
 
-	name             string
-	config           types.DomainConfig
-	chainID          int64
-	coinSchema       *prototk.StateSchema
-	lockedCoinSchema *prototk.StateSchema
-	dataSchema       *prototk.StateSchema
-	lockInfoSchema   *prototk.StateSchema
+	name              string
+	config            types.DomainConfig
+	chainID           int64
+	coinSchema        *prototk.StateSchema
+	lockedCoinSchema  *prototk.StateSchema
+	dataSchema        *prototk.StateSchema
+	lockInfoSchema    *prototk.StateSchema
+	restrictionSchema *prototk.StateSchema
+	supplySchema      *prototk.StateSchema
+	lockExpirySchema  *prototk.StateSchema
 }
 
 type NotoDeployParams struct {
@@ -137,10 +145,18 @@ type NotoLockParams struct {
 	Inputs        []string          `json:"inputs"`
 	Outputs       []string          `json:"outputs"`
 	LockedOutputs []string          `json:"lockedOutputs"`
+	Expiry        *types.LockExpiry `json:"expiry,omitempty"` // only for lockWithExpiry
 	Signature     pldtypes.HexBytes `json:"signature"`
 	Data          pldtypes.HexBytes `json:"data"`
 }
 
+type NotoReclaimLockParams struct {
+	LockedInputs []string          `json:"lockedInputs"`
+	Outputs      []string          `json:"outputs"`
+	Signature    pldtypes.HexBytes `json:"signature"`
+	Data         pldtypes.HexBytes `json:"data"`
+}
+
 type NotoPrepareUnlockParams struct {
 	LockedInputs []string          `json:"lockedInputs"`
 	UnlockHash   pldtypes.Bytes32  `json:"unlockHash"`
@@ -155,6 +171,13 @@ type NotoDelegateLockParams struct {
 	Data       pldtypes.HexBytes    `json:"data"`
 }
 
+type NotoChangeNotaryParams struct {
+	Notary    *pldtypes.EthAddress `json:"notary"`
+	Config    pldtypes.HexBytes    `json:"config"`
+	Signature pldtypes.HexBytes    `json:"signature"`
+	Data      pldtypes.HexBytes    `json:"data"`
+}
+
 type NotoTransfer_Event struct {
 	Inputs    []pldtypes.Bytes32 `json:"inputs"`
 	Outputs   []pldtypes.Bytes32 `json:"outputs"`
@@ -200,6 +223,13 @@ type NotoLockDelegated_Event struct {
 	Data       pldtypes.HexBytes    `json:"data"`
 }
 
+type NotoNotaryChanged_Event struct {
+	Notary    *pldtypes.EthAddress `json:"notary"`
+	Config    pldtypes.HexBytes    `json:"config"`
+	Signature pldtypes.HexBytes    `json:"signature"`
+	Data      pldtypes.HexBytes    `json:"data"`
+}
+
 type parsedCoins struct {
 	coins        []*types.NotoCoin
 	states       []*prototk.StateRef
@@ -271,11 +301,29 @@ func (n *Noto) DataSchemaID() string {
 	return n.dataSchema.Id
 }
 
+func (n *Noto) RestrictionSchemaID() string {
+	return n.restrictionSchema.Id
+}
+
+func (n *Noto) SupplySchemaID() string {
+	return n.supplySchema.Id
+}
+
+func (n *Noto) LockExpirySchemaID() string {
+	return n.lockExpirySchema.Id
+}
+
 func (n *Noto) ConfigureDomain(ctx context.Context, req *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
 	err := json.Unmarshal([]byte(req.ConfigJson), &n.config)
 	if err != nil {
 		return nil, err
 	}
+	if err := validateCoinSelectionConfig(ctx, &n.config.CoinSelection); err != nil {
+		return nil, err
+	}
+	if n.config.QueryPageSize != nil && *n.config.QueryPageSize < 1 {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, "queryPageSize")
+	}
 
 	n.name = req.Name
 	n.chainID = req.ChainId
@@ -299,6 +347,12 @@ func (n *Noto) InitDomain(ctx context.Context, req *prototk.InitDomainRequest) (
 			n.dataSchema = req.AbiStateSchemas[i]
 		case types.NotoLockInfoABI.Name:
 			n.lockInfoSchema = req.AbiStateSchemas[i]
+		case types.NotoRestrictionABI.Name:
+			n.restrictionSchema = req.AbiStateSchemas[i]
+		case types.NotoSupplyABI.Name:
+			n.supplySchema = req.AbiStateSchemas[i]
+		case types.NotoLockExpiryABI.Name:
+			n.lockExpirySchema = req.AbiStateSchemas[i]
 		}
 	}
 	return &prototk.InitDomainResponse{}, nil
@@ -312,7 +366,11 @@ func (n *Noto) InitDeploy(ctx context.Context, req *prototk.InitDeployRequest) (
 
 	switch params.NotaryMode {
 	case types.NotaryModeBasic:
-		// no required params
+		// The supply is tracked by the notary, so only the notary can mint when there is a max supply
+		basic := params.Options.Basic
+		if basic != nil && basic.MaxSupply != nil && basic.RestrictMint != nil && !*basic.RestrictMint {
+			return nil, i18n.NewError(ctx, msgs.MsgMaxSupplyNeedsRestrictMint)
+		}
 	case types.NotaryModeHooks:
 		if params.Options.Hooks == nil {
 			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "options.hooks")
@@ -377,6 +435,13 @@ func (n *Noto) PrepareDeploy(ctx context.Context, req *prototk.PrepareDeployRequ
 			if params.Options.Basic.AllowLock != nil {
 				deployData.AllowLock = *params.Options.Basic.AllowLock
 			}
+			if params.Options.Basic.AllowPause != nil {
+				deployData.AllowPause = *params.Options.Basic.AllowPause
+			}
+			if params.Options.Basic.AllowFreeze != nil {
+				deployData.AllowFreeze = *params.Options.Basic.AllowFreeze
+			}
+			deployData.MaxSupply = params.Options.Basic.MaxSupply
 		}
 	case types.NotaryModeHooks:
 		deployData.NotaryMode = types.NotaryModeIntHooks
@@ -452,6 +517,9 @@ func (n *Noto) InitContract(ctx context.Context, req *prototk.InitContractReques
 			RestrictMint: &decodedData.RestrictMint,
 			AllowBurn:    &decodedData.AllowBurn,
 			AllowLock:    &decodedData.AllowLock,
+			AllowPause:   &decodedData.AllowPause,
+			AllowFreeze:  &decodedData.AllowFreeze,
+			MaxSupply:    decodedData.MaxSupply,
 		}
 	}
 
@@ -752,14 +820,6 @@ func (n *Noto) ValidateStateHashes(ctx context.Context, req *prototk.ValidateSta
 	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
 }
 
-func (n *Noto) InitCall(ctx context.Context, req *prototk.InitCallRequest) (*prototk.InitCallResponse, error) {
-	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
-}
-
-func (n *Noto) ExecCall(ctx context.Context, req *prototk.ExecCallRequest) (*prototk.ExecCallResponse, error) {
-	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
-}
-
 func (n *Noto) ConfigurePrivacyGroup(ctx context.Context, req *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error) {
 	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
 }
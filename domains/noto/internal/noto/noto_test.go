@@ -77,7 +77,7 @@ func TestNotoDomainInit(t *testing.T) {
 		ConfigJson: "{}",
 	})
 	require.NoError(t, err)
-	assert.Len(t, configureRes.DomainConfig.AbiStateSchemasJson, 4)
+	assert.Len(t, configureRes.DomainConfig.AbiStateSchemasJson, 7)
 
 	initRes, err := n.InitDomain(ctx, &prototk.InitDomainRequest{
 		AbiStateSchemas: []*prototk.StateSchema{
@@ -85,6 +85,9 @@ func TestNotoDomainInit(t *testing.T) {
 			{Id: "schema2"},
 			{Id: "schema3"},
 			{Id: "schema4"},
+			{Id: "schema5"},
+			{Id: "schema6"},
+			{Id: "schema7"},
 		},
 	})
 	require.NoError(t, err)
@@ -95,6 +98,9 @@ func TestNotoDomainInit(t *testing.T) {
 	assert.Equal(t, "schema2", n.LockInfoSchemaID())
 	assert.Equal(t, "schema3", n.LockedCoinSchemaID())
 	assert.Equal(t, "schema4", n.DataSchemaID())
+	assert.Equal(t, "schema5", n.RestrictionSchemaID())
+	assert.Equal(t, "schema6", n.SupplySchemaID())
+	assert.Equal(t, "schema7", n.LockExpirySchemaID())
 }
 
 func TestNotoDomainDeployDefaults(t *testing.T) {
@@ -216,6 +222,71 @@ func TestNotoDomainDeployBasicConfig(t *testing.T) {
 	assert.True(t, initContractRes.Valid)
 }
 
+func TestNotoDomainDeployComplianceConfig(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	deployTransaction := &prototk.DeployTransactionSpecification{
+		TransactionId: "tx1",
+		ConstructorParamsJson: `{
+			"notary": "notary@node1",
+			"notaryMode": "basic",
+			"options": {
+				"basic": {
+					"allowPause": true,
+					"allowFreeze": true,
+					"maxSupply": 1000
+				}
+			}
+		}`,
+	}
+
+	prepareDeployRes, err := n.PrepareDeploy(ctx, &prototk.PrepareDeployRequest{
+		Transaction: deployTransaction,
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "notary@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x6e2430d15301a7ee28ceaaee0dff9781f8f82f71",
+			},
+		},
+	})
+	require.NoError(t, err)
+	var deployParams map[string]any
+	err = json.Unmarshal([]byte(prepareDeployRes.Transaction.ParamsJson), &deployParams)
+	require.NoError(t, err)
+	deployData := pldtypes.MustParseHexBytes(deployParams["data"].(string))
+	assert.JSONEq(t, `{
+		"notaryLookup": "notary@node1",
+		"notaryMode": "0x0",
+		"privateAddress": null,
+		"privateGroup": null,
+		"restrictMint": true,
+		"allowBurn": true,
+		"allowLock": true,
+		"allowPause": true,
+		"allowFreeze": true,
+		"maxSupply": "0x3e8"
+	}`, string(deployData))
+
+	var deployedConfig types.NotoConfigData_V0
+	err = json.Unmarshal(deployData, &deployedConfig)
+	require.NoError(t, err)
+	initContractRes, err := n.InitContract(ctx, &prototk.InitContractRequest{
+		ContractAddress: "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+		ContractConfig:  encodedConfig(&deployedConfig),
+	})
+	require.NoError(t, err)
+	assert.True(t, initContractRes.Valid)
+	var parsedConfig types.NotoParsedConfig
+	err = json.Unmarshal([]byte(initContractRes.ContractConfig.ContractConfigJson), &parsedConfig)
+	require.NoError(t, err)
+	assert.True(t, pauseAllowed(&parsedConfig))
+	assert.True(t, freezeAllowed(&parsedConfig))
+	assert.Equal(t, int64(1000), maxSupply(&parsedConfig).Int64())
+}
+
 func TestNotoDomainDeployHooksConfig(t *testing.T) {
 	n := &Noto{Callbacks: mockCallbacks}
 	ctx := context.Background()
@@ -320,6 +391,25 @@ func TestInitDeployBadMode(t *testing.T) {
 	assert.ErrorContains(t, err, "PD200007")
 }
 
+func TestInitDeployMaxSupplyUnrestrictedMint(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	_, err := n.InitDeploy(context.Background(), &prototk.InitDeployRequest{
+		Transaction: &prototk.DeployTransactionSpecification{
+			ConstructorParamsJson: `{
+				"notary": "notary@node1",
+				"notaryMode": "basic",
+				"options": {
+					"basic": {
+						"restrictMint": false,
+						"maxSupply": 1000
+					}
+				}
+			}`,
+		},
+	})
+	assert.ErrorContains(t, err, "PD200052")
+}
+
 func TestInitDeployMissingNotary(t *testing.T) {
 	n := &Noto{Callbacks: mockCallbacks}
 	_, err := n.InitDeploy(context.Background(), &prototk.InitDeployRequest{
@@ -673,12 +763,6 @@ func TestUnimplementedMethods(t *testing.T) {
 
 	_, err = n.ValidateStateHashes(ctx, nil)
 	assert.ErrorContains(t, err, "PD200022")
-
-	_, err = n.InitCall(ctx, nil)
-	assert.ErrorContains(t, err, "PD200022")
-
-	_, err = n.ExecCall(ctx, nil)
-	assert.ErrorContains(t, err, "PD200022")
 }
 
 func TestDecodeConfigInvalid(t *testing.T) {
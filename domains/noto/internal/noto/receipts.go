@@ -49,6 +49,15 @@ func (n *Noto) BuildReceipt(ctx context.Context, req *prototk.BuildReceiptReques
 		}
 	}
 
+	lockExpiryStates := n.filterSchema(req.InfoStates, []string{n.lockExpirySchema.Id})
+	if receipt.LockInfo != nil && len(lockExpiryStates) == 1 {
+		expiry, err := n.unmarshalLockExpiry(lockExpiryStates[0].StateDataJson)
+		if err != nil {
+			return nil, err
+		}
+		receipt.LockInfo.Expiry = &expiry.Expiry
+	}
+
 	receipt.States.Inputs, err = n.receiptStates(ctx, n.filterSchema(req.InputStates, []string{n.coinSchema.Id}))
 	if err == nil {
 		receipt.States.LockedInputs, err = n.receiptStates(ctx, n.filterSchema(req.InputStates, []string{n.lockedCoinSchema.Id}))
@@ -139,6 +148,7 @@ func (n *Noto) receiptTransfers(ctx context.Context, req *prototk.BuildReceiptRe
 	var from *pldtypes.EthAddress
 	fromAmount := big.NewInt(0)
 	to := make(map[pldtypes.EthAddress]*big.Int)
+	var recipients []pldtypes.EthAddress // preserves output order, for batch transfers
 
 	parseInput := func(owner *pldtypes.EthAddress, amount *big.Int) bool {
 		if from == nil {
@@ -156,7 +166,8 @@ func (n *Noto) receiptTransfers(ctx context.Context, req *prototk.BuildReceiptRe
 		} else if toAmount, ok := to[owner]; ok {
 			toAmount.Add(toAmount, amount)
 		} else {
-			to[owner] = amount
+			to[owner] = new(big.Int).Set(amount)
+			recipients = append(recipients, owner)
 		}
 		return true
 	}
@@ -191,8 +202,8 @@ func (n *Noto) receiptTransfers(ctx context.Context, req *prototk.BuildReceiptRe
 	}
 
 	transfers := make([]*types.ReceiptTransfer, 0, len(to))
-	for owner, amount := range to {
-		if amount.BitLen() > 0 {
+	for _, owner := range recipients {
+		if amount := to[owner]; amount.BitLen() > 0 {
 			transfers = append(transfers, &types.ReceiptTransfer{
 				From:   from,
 				To:     &owner,
@@ -185,4 +185,48 @@ func TestReceiptTransfers(t *testing.T) {
 		To:     owner3,
 		Amount: pldtypes.Int64ToInt256(2),
 	}}, transfers)
+
+	// Batch transfer to multiple recipients, with change (reported in output order)
+	transfers, err = n.receiptTransfers(ctx, &prototk.BuildReceiptRequest{
+		InputStates: []*prototk.EndorsableState{{
+			Id:       "1",
+			SchemaId: "coin",
+			StateDataJson: fmt.Sprintf(`{
+				"amount": 10,
+				"owner": "%s"
+			}`, owner1),
+		}},
+		OutputStates: []*prototk.EndorsableState{{
+			Id:       "2",
+			SchemaId: "coin",
+			StateDataJson: fmt.Sprintf(`{
+				"amount": 3,
+				"owner": "%s"
+			}`, owner3),
+		}, {
+			Id:       "3",
+			SchemaId: "coin",
+			StateDataJson: fmt.Sprintf(`{
+				"amount": 2,
+				"owner": "%s"
+			}`, owner2),
+		}, {
+			Id:       "4",
+			SchemaId: "coin",
+			StateDataJson: fmt.Sprintf(`{
+				"amount": 5,
+				"owner": "%s"
+			}`, owner1),
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*types.ReceiptTransfer{{
+		From:   owner1,
+		To:     owner3,
+		Amount: pldtypes.Int64ToInt256(3),
+	}, {
+		From:   owner1,
+		To:     owner2,
+		Amount: pldtypes.Int64ToInt256(2),
+	}}, transfers)
 }
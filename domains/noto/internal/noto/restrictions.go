@@ -0,0 +1,231 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// Compliance controls are only available in basic mode (in hooks mode, the same checks can be
+// implemented by the hooks contract). Each pause/unpause and freeze/unfreeze records a NotoRestriction
+// info state, and the latest one confirmed on the base ledger determines whether the restriction applies.
+// The max supply is enforced against a NotoSupply state held by the notary, which tracks the total minted.
+
+func pauseAllowed(config *types.NotoParsedConfig) bool {
+	basic := config.Options.Basic
+	return config.NotaryMode == types.NotaryModeBasic.Enum() && basic != nil && basic.AllowPause != nil && *basic.AllowPause
+}
+
+func freezeAllowed(config *types.NotoParsedConfig) bool {
+	basic := config.Options.Basic
+	return config.NotaryMode == types.NotaryModeBasic.Enum() && basic != nil && basic.AllowFreeze != nil && *basic.AllowFreeze
+}
+
+func maxSupply(config *types.NotoParsedConfig) *big.Int {
+	basic := config.Options.Basic
+	if config.NotaryMode != types.NotaryModeBasic.Enum() || basic == nil || basic.MaxSupply == nil {
+		return nil
+	}
+	return basic.MaxSupply.Int()
+}
+
+// Find the latest confirmed record of a restriction for an account, if there is one
+func (n *Noto) findRestriction(ctx context.Context, stateQueryContext, restriction string, account *pldtypes.EthAddress) (*types.NotoRestriction, error) {
+	queryBuilder := query.NewQueryBuilder().
+		Limit(1).
+		Sort("-.created").
+		Equal("restriction", restriction).
+		Equal("account", account.String())
+	states, err := n.findInfoStates(ctx, stateQueryContext, n.restrictionSchema.Id, queryBuilder.Query().String())
+	if err != nil || len(states) == 0 {
+		return nil, err
+	}
+	record, err := n.unmarshalRestriction(states[0].DataJson)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidStateData, states[0].Id, err)
+	}
+	return record, nil
+}
+
+// Check whether a restriction is currently in force for an account
+func (n *Noto) isRestricted(ctx context.Context, stateQueryContext, restriction string, account *pldtypes.EthAddress) (bool, error) {
+	record, err := n.findRestriction(ctx, stateQueryContext, restriction, account)
+	if err != nil {
+		return false, err
+	}
+	return record != nil && record.Active, nil
+}
+
+// Check that the contract is not paused, and that none of the accounts are frozen
+func (n *Noto) checkRestrictions(ctx context.Context, config *types.NotoParsedConfig, stateQueryContext string, accounts ...*pldtypes.EthAddress) error {
+	if pauseAllowed(config) {
+		paused, err := n.isRestricted(ctx, stateQueryContext, types.NotoRestrictionPause, &pldtypes.EthAddress{})
+		if err != nil {
+			return err
+		}
+		if paused {
+			return i18n.NewError(ctx, msgs.MsgContractPaused)
+		}
+	}
+	if freezeAllowed(config) {
+		for _, account := range accounts {
+			frozen, err := n.isRestricted(ctx, stateQueryContext, types.NotoRestrictionFreeze, account)
+			if err != nil {
+				return err
+			}
+			if frozen {
+				return i18n.NewError(ctx, msgs.MsgAccountFrozen, account)
+			}
+		}
+	}
+	return nil
+}
+
+// Separate the supply states from the coins in a list of inputs or outputs
+func (n *Noto) splitSupplyStates(states []*prototk.EndorsableState) (coins, supply []*prototk.EndorsableState) {
+	for _, state := range states {
+		if state.SchemaId == n.supplySchema.Id {
+			supply = append(supply, state)
+		} else {
+			coins = append(coins, state)
+		}
+	}
+	return coins, supply
+}
+
+// Find the supply states available to the notary, of which there should be exactly one once anything has been minted
+func (n *Noto) findSupplyStates(ctx context.Context, stateQueryContext string) ([]*prototk.StoredState, error) {
+	var states []*prototk.StoredState
+	err := n.forEachAvailableState(ctx, stateQueryContext, n.supplySchema.Id,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder()
+		},
+		func(state *prototk.StoredState) error {
+			states = append(states, state)
+			return nil
+		})
+	return states, err
+}
+
+// Prepare the supply states for a mint when there is a max supply. The single live supply state is spent,
+// and replaced with a new one that adds the minted amount. Burns do not reduce the total minted, as they are
+// assembled by the owner of the coins, who does not hold the supply state - so burning never frees up capacity.
+func (n *Noto) prepareSupply(ctx context.Context, config *types.NotoParsedConfig, stateQueryContext string, amount *big.Int) (inputs []*prototk.StateRef, output *prototk.NewState, revert bool, err error) {
+	limit := maxSupply(config)
+	if limit == nil {
+		return nil, nil, false, nil
+	}
+
+	states, err := n.findSupplyStates(ctx, stateQueryContext)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(states) > 1 {
+		return nil, nil, true, i18n.NewError(ctx, msgs.MsgMultipleSupplyStates, len(states))
+	}
+	minted := big.NewInt(0)
+	for _, state := range states {
+		supply, err := n.unmarshalSupply(state.DataJson)
+		if err != nil {
+			return nil, nil, false, i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+		}
+		minted.Set(supply.Minted.Int())
+		inputs = append(inputs, &prototk.StateRef{SchemaId: state.SchemaId, Id: state.Id})
+	}
+
+	if new(big.Int).Add(minted, amount).Cmp(limit) > 0 {
+		return nil, nil, true, i18n.NewError(ctx, msgs.MsgMaxSupplyExceeded, amount.Text(10), limit.Text(10), minted.Text(10))
+	}
+	output, err = n.makeNewSupplyState(&types.NotoSupply{
+		Salt:   pldtypes.RandBytes32(),
+		Minted: (*pldtypes.HexUint256)(minted.Add(minted, amount)),
+	}, []string{config.NotaryLookup})
+	return inputs, output, false, err
+}
+
+// Check that a mint replaces the current supply state with a single new one, which adds the minted amount
+// and stays within the max supply.
+//
+// The inputs are chosen by the assembler, so they are checked against the supply states the notary holds.
+// In the notary's view the inputs of this transaction are already spent, and its output is available.
+// If the output is still available, no other supply state may be - otherwise the mint skipped the current
+// one (for example by spending nothing, and starting again from zero). If the output has already been spent
+// by a later mint in the same chain, only the tip of that chain may be available.
+func (n *Noto) checkSupply(ctx context.Context, config *types.NotoParsedConfig, stateQueryContext string, amount *big.Int, inputs, outputs []*prototk.EndorsableState) error {
+	limit := maxSupply(config)
+	if limit == nil {
+		if len(inputs) > 0 || len(outputs) > 0 {
+			return i18n.NewError(ctx, msgs.MsgUnexpectedSchema, n.supplySchema.Id)
+		}
+		return nil
+	}
+
+	if len(inputs) > 1 {
+		return i18n.NewError(ctx, msgs.MsgMultipleSupplyStates, len(inputs))
+	}
+	minted := big.NewInt(0)
+	for _, state := range inputs {
+		supply, err := n.unmarshalSupply(state.StateDataJson)
+		if err != nil {
+			return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+		}
+		minted.Set(supply.Minted.Int())
+	}
+	if new(big.Int).Add(minted, amount).Cmp(limit) > 0 {
+		return i18n.NewError(ctx, msgs.MsgMaxSupplyExceeded, amount.Text(10), limit.Text(10), minted.Text(10))
+	}
+
+	expected := minted.Add(minted, amount)
+	if len(outputs) != 1 {
+		return i18n.NewError(ctx, msgs.MsgInvalidSupply, expected.Text(10))
+	}
+	supply, err := n.unmarshalSupply(outputs[0].StateDataJson)
+	if err != nil {
+		return i18n.NewError(ctx, msgs.MsgInvalidStateData, outputs[0].Id, err)
+	}
+	if supply.Minted == nil || supply.Minted.Int().Cmp(expected) != 0 {
+		return i18n.NewError(ctx, msgs.MsgInvalidSupply, expected.Text(10))
+	}
+
+	available, err := n.findSupplyStates(ctx, stateQueryContext)
+	if err != nil {
+		return err
+	}
+	outputAvailable := false
+	var others []string
+	for _, state := range available {
+		switch {
+		case state.Id == outputs[0].Id:
+			outputAvailable = true
+		case len(inputs) > 0 && state.Id == inputs[0].Id:
+			// The notary has not yet recorded this transaction as spending its input
+		default:
+			others = append(others, state.Id)
+		}
+	}
+	if len(others) > 1 || (len(others) == 1 && outputAvailable) {
+		return i18n.NewError(ctx, msgs.MsgSupplyNotSpent, others[0])
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 )
 
@@ -114,6 +115,23 @@ var NotoDelegateLockTypeSet = eip712.TypeSet{
 	eip712.EIP712Domain: EIP712DomainType,
 }
 
+var NotoChangeNotaryTypeSet = eip712.TypeSet{
+	"ChangeNotary": {
+		{Name: "notary", Type: "address"},
+		{Name: "data", Type: "bytes"},
+	},
+	eip712.EIP712Domain: EIP712DomainType,
+}
+
+var NotoRestrictionTypeSet = eip712.TypeSet{
+	"Restriction": {
+		{Name: "action", Type: "string"},
+		{Name: "account", Type: "address"},
+		{Name: "data", Type: "bytes"},
+	},
+	eip712.EIP712Domain: EIP712DomainType,
+}
+
 func (n *Noto) unmarshalCoin(stateData string) (*types.NotoCoin, error) {
 	var coin types.NotoCoin
 	err := json.Unmarshal([]byte(stateData), &coin)
@@ -138,6 +156,24 @@ func (n *Noto) unmarshalLock(stateData string) (*types.NotoLockInfo, error) {
 	return &lock, err
 }
 
+func (n *Noto) unmarshalLockExpiry(stateData string) (*types.NotoLockExpiry, error) {
+	var expiry types.NotoLockExpiry
+	err := json.Unmarshal([]byte(stateData), &expiry)
+	return &expiry, err
+}
+
+func (n *Noto) unmarshalRestriction(stateData string) (*types.NotoRestriction, error) {
+	var restriction types.NotoRestriction
+	err := json.Unmarshal([]byte(stateData), &restriction)
+	return &restriction, err
+}
+
+func (n *Noto) unmarshalSupply(stateData string) (*types.NotoSupply, error) {
+	var supply types.NotoSupply
+	err := json.Unmarshal([]byte(stateData), &supply)
+	return &supply, err
+}
+
 func (n *Noto) makeNewCoinState(coin *types.NotoCoin, distributionList []string) (*prototk.NewState, error) {
 	coinJSON, err := json.Marshal(coin)
 	if err != nil {
@@ -186,6 +222,42 @@ func (n *Noto) makeNewLockState(lock *types.NotoLockInfo, distributionList []str
 	}, nil
 }
 
+func (n *Noto) makeNewLockExpiryState(expiry *types.NotoLockExpiry, distributionList []string) (*prototk.NewState, error) {
+	expiryJSON, err := json.Marshal(expiry)
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.NewState{
+		SchemaId:         n.lockExpirySchema.Id,
+		StateDataJson:    string(expiryJSON),
+		DistributionList: distributionList,
+	}, nil
+}
+
+func (n *Noto) makeNewRestrictionState(restriction *types.NotoRestriction, distributionList []string) (*prototk.NewState, error) {
+	restrictionJSON, err := json.Marshal(restriction)
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.NewState{
+		SchemaId:         n.restrictionSchema.Id,
+		StateDataJson:    string(restrictionJSON),
+		DistributionList: distributionList,
+	}, nil
+}
+
+func (n *Noto) makeNewSupplyState(supply *types.NotoSupply, distributionList []string) (*prototk.NewState, error) {
+	supplyJSON, err := json.Marshal(supply)
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.NewState{
+		SchemaId:         n.supplySchema.Id,
+		StateDataJson:    string(supplyJSON),
+		DistributionList: distributionList,
+	}, nil
+}
+
 type preparedInputs struct {
 	coins  []*types.NotoCoin
 	states []*prototk.StateRef
@@ -209,34 +281,28 @@ type preparedLockedOutputs struct {
 }
 
 func (n *Noto) prepareInputs(ctx context.Context, stateQueryContext string, owner *pldtypes.EthAddress, amount *pldtypes.HexUint256) (inputs *preparedInputs, revert bool, err error) {
-	var lastStateTimestamp int64
+	coinSelection := &n.config.CoinSelection
+	if (coinSelection.Strategy == "" || coinSelection.Strategy == types.CoinSelectionOldestFirst) && coinSelection.ConsolidateThreshold == nil {
+		return n.prepareInputsOldestFirst(ctx, stateQueryContext, owner, amount)
+	}
+	return n.selectInputs(ctx, stateQueryContext, owner, amount)
+}
+
+// Selects the oldest coins first, without needing to load every available coin of the owner
+func (n *Noto) prepareInputsOldestFirst(ctx context.Context, stateQueryContext string, owner *pldtypes.EthAddress, amount *pldtypes.HexUint256) (inputs *preparedInputs, revert bool, err error) {
+	maxInputs := n.maxInputs()
 	total := big.NewInt(0)
 	stateRefs := []*prototk.StateRef{}
 	coins := []*types.NotoCoin{}
-	for {
-		// TODO: make this configurable
-		queryBuilder := query.NewQueryBuilder().
-			Limit(10).
-			Sort(".created").
-			Equal("owner", owner.String())
-
-		if lastStateTimestamp > 0 {
-			queryBuilder.GreaterThan(".created", lastStateTimestamp)
-		}
-
-		log.L(ctx).Debugf("State query: %s", queryBuilder.Query())
-		states, err := n.findAvailableStates(ctx, stateQueryContext, n.coinSchema.Id, queryBuilder.Query().String())
-		if err != nil {
-			return nil, false, err
-		}
-		if len(states) == 0 {
-			return nil, true, i18n.NewError(ctx, msgs.MsgInsufficientFunds, total.Text(10))
-		}
-		for _, state := range states {
-			lastStateTimestamp = state.CreatedAt
+	covered := false
+	err = n.forEachAvailableState(ctx, stateQueryContext, n.coinSchema.Id,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder().Equal("owner", owner.String())
+		},
+		func(state *prototk.StoredState) error {
 			coin, err := n.unmarshalCoin(state.DataJson)
 			if err != nil {
-				return nil, false, i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+				return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
 			}
 			total = total.Add(total, coin.Amount.Int())
 			stateRefs = append(stateRefs, &prototk.StateRef{
@@ -245,48 +311,43 @@ func (n *Noto) prepareInputs(ctx context.Context, stateQueryContext string, owne
 			})
 			coins = append(coins, coin)
 			log.L(ctx).Debugf("Selecting coin %s value=%s total=%s required=%s)", state.Id, coin.Amount.Int().Text(10), total.Text(10), amount.Int().Text(10))
-			if total.Cmp(amount.Int()) >= 0 {
-				return &preparedInputs{
-					coins:  coins,
-					states: stateRefs,
-					total:  total,
-				}, false, nil
+			covered = total.Cmp(amount.Int()) >= 0
+			if covered || (maxInputs > 0 && len(coins) >= maxInputs) {
+				return domain.SkipRemainingStates
 			}
+			return nil
+		})
+	if err != nil {
+		return nil, false, err
+	}
+	if !covered {
+		if maxInputs > 0 && len(coins) >= maxInputs {
+			return nil, true, i18n.NewError(ctx, msgs.MsgTooManyInputs, amount.Int().Text(10), maxInputs, total.Text(10))
 		}
+		return nil, true, i18n.NewError(ctx, msgs.MsgInsufficientFunds, total.Text(10))
 	}
+	return &preparedInputs{
+		coins:  coins,
+		states: stateRefs,
+		total:  total,
+	}, false, nil
 }
 
 func (n *Noto) prepareLockedInputs(ctx context.Context, stateQueryContext string, lockID pldtypes.Bytes32, owner *pldtypes.EthAddress, amount *big.Int) (inputs *preparedLockedInputs, revert bool, err error) {
-	var lastStateTimestamp int64
 	total := big.NewInt(0)
 	stateRefs := []*prototk.StateRef{}
 	coins := []*types.NotoLockedCoin{}
-
-	for {
-		queryBuilder := query.NewQueryBuilder().
-			Limit(10).
-			Sort(".created").
-			Equal("lockId", lockID).
-			Equal("owner", owner.String())
-
-		if lastStateTimestamp > 0 {
-			queryBuilder.GreaterThan(".created", lastStateTimestamp)
-		}
-
-		log.L(ctx).Debugf("State query: %s", queryBuilder.Query())
-		states, err := n.findAvailableStates(ctx, stateQueryContext, n.lockedCoinSchema.Id, queryBuilder.Query().String())
-
-		if err != nil {
-			return nil, false, err
-		}
-		if len(states) == 0 {
-			return nil, true, i18n.NewError(ctx, msgs.MsgInsufficientFunds, total.Text(10))
-		}
-		for _, state := range states {
-			lastStateTimestamp = state.CreatedAt
+	covered := false
+	err = n.forEachAvailableState(ctx, stateQueryContext, n.lockedCoinSchema.Id,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder().
+				Equal("lockId", lockID).
+				Equal("owner", owner.String())
+		},
+		func(state *prototk.StoredState) error {
 			coin, err := n.unmarshalLockedCoin(state.DataJson)
 			if err != nil {
-				return nil, false, i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+				return i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
 			}
 			total = total.Add(total, coin.Amount.Int())
 			stateRefs = append(stateRefs, &prototk.StateRef{
@@ -295,15 +356,22 @@ func (n *Noto) prepareLockedInputs(ctx context.Context, stateQueryContext string
 			})
 			coins = append(coins, coin)
 			log.L(ctx).Debugf("Selecting coin %s value=%s total=%s required=%s)", state.Id, coin.Amount.Int().Text(10), total.Text(10), amount.Text(10))
-			if total.Cmp(amount) >= 0 {
-				return &preparedLockedInputs{
-					coins:  coins,
-					states: stateRefs,
-					total:  total,
-				}, false, nil
+			if covered = total.Cmp(amount) >= 0; covered {
+				return domain.SkipRemainingStates
 			}
-		}
+			return nil
+		})
+	if err != nil {
+		return nil, false, err
 	}
+	if !covered {
+		return nil, true, i18n.NewError(ctx, msgs.MsgInsufficientFunds, total.Text(10))
+	}
+	return &preparedLockedInputs{
+		coins:  coins,
+		states: stateRefs,
+		total:  total,
+	}, false, nil
 }
 
 func (n *Noto) prepareOutputs(ownerAddress *pldtypes.EthAddress, amount *pldtypes.HexUint256, distributionList []string) (*preparedOutputs, error) {
@@ -346,7 +414,8 @@ func (n *Noto) prepareInfo(data pldtypes.HexBytes, distributionList []string) ([
 	return []*prototk.NewState{newState}, err
 }
 
-func (n *Noto) prepareLockInfo(lockID pldtypes.Bytes32, owner, delegate *pldtypes.EthAddress, distributionList []string) (*prototk.NewState, error) {
+// Prepare the lock info state, followed by a lock expiry state if the lock has an expiry
+func (n *Noto) prepareLockInfo(lockID pldtypes.Bytes32, owner, delegate *pldtypes.EthAddress, expiry *types.LockExpiry, distributionList []string) ([]*prototk.NewState, error) {
 	if delegate == nil {
 		delegate = &pldtypes.EthAddress{}
 	}
@@ -356,8 +425,34 @@ func (n *Noto) prepareLockInfo(lockID pldtypes.Bytes32, owner, delegate *pldtype
 		Owner:    owner,
 		Delegate: delegate,
 	}
-	return n.makeNewLockState(newData, distributionList)
-
+	lockState, err := n.makeNewLockState(newData, distributionList)
+	if err != nil || expiry == nil || !expiry.IsSet() {
+		return []*prototk.NewState{lockState}, err
+	}
+	expiryState, err := n.makeNewLockExpiryState(&types.NotoLockExpiry{
+		Salt:   pldtypes.RandBytes32(),
+		LockID: lockID,
+		Expiry: *expiry,
+	}, distributionList)
+	return []*prototk.NewState{lockState, expiryState}, err
+}
+
+// Find the expiry of a lock from the latest confirmed lock expiry state, so that it can be carried forward
+// by transactions that update the lock (returns nil if the lock has no expiry)
+func (n *Noto) findLockExpiry(ctx context.Context, stateQueryContext string, lockID pldtypes.Bytes32) (*types.LockExpiry, error) {
+	queryBuilder := query.NewQueryBuilder().
+		Limit(1).
+		Sort("-.created").
+		Equal("lockId", lockID)
+	states, err := n.findInfoStates(ctx, stateQueryContext, n.lockExpirySchema.Id, queryBuilder.Query().String())
+	if err != nil || len(states) == 0 {
+		return nil, err
+	}
+	expiry, err := n.unmarshalLockExpiry(states[0].DataJson)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidStateData, states[0].Id, err)
+	}
+	return &expiry.Expiry, nil
 }
 
 func (n *Noto) filterSchema(states []*prototk.EndorsableState, schemas []string) (filtered []*prototk.EndorsableState) {
@@ -399,6 +494,22 @@ func (n *Noto) findAvailableStates(ctx context.Context, stateQueryContext, schem
 	return res.States, nil
 }
 
+// Find the info states recorded by confirmed transactions
+func (n *Noto) findInfoStates(ctx context.Context, stateQueryContext, schemaId, query string) ([]*prototk.StoredState, error) {
+	infoStates := true
+	req := &prototk.FindAvailableStatesRequest{
+		StateQueryContext: stateQueryContext,
+		SchemaId:          schemaId,
+		QueryJson:         query,
+		InfoStates:        &infoStates,
+	}
+	res, err := n.Callbacks.FindAvailableStates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.States, nil
+}
+
 func (n *Noto) eip712Domain(contract *ethtypes.Address0xHex) map[string]any {
 	return map[string]any{
 		"name":              EIP712DomainName,
@@ -538,3 +649,28 @@ func (n *Noto) encodeDelegateLock(ctx context.Context, contract *ethtypes.Addres
 		},
 	})
 }
+
+func (n *Noto) encodeChangeNotary(ctx context.Context, contract *ethtypes.Address0xHex, notary *pldtypes.EthAddress, data pldtypes.HexBytes) (ethtypes.HexBytes0xPrefix, error) {
+	return eip712.EncodeTypedDataV4(ctx, &eip712.TypedData{
+		Types:       NotoChangeNotaryTypeSet,
+		PrimaryType: "ChangeNotary",
+		Domain:      n.eip712Domain(contract),
+		Message: map[string]any{
+			"notary": notary,
+			"data":   data,
+		},
+	})
+}
+
+func (n *Noto) encodeRestriction(ctx context.Context, contract *ethtypes.Address0xHex, action string, account *pldtypes.EthAddress, data pldtypes.HexBytes) (ethtypes.HexBytes0xPrefix, error) {
+	return eip712.EncodeTypedDataV4(ctx, &eip712.TypedData{
+		Types:       NotoRestrictionTypeSet,
+		PrimaryType: "Restriction",
+		Domain:      n.eip712Domain(contract),
+		Message: map[string]any{
+			"action":  action,
+			"account": account,
+			"data":    data,
+		},
+	})
+}
@@ -66,11 +66,25 @@ type TransferParams struct {
 	Data   pldtypes.HexBytes    `json:"data"`
 }
 
+type TransferBatchParams struct {
+	Transfers []*TransferRecipient `json:"transfers"`
+	Data      pldtypes.HexBytes    `json:"data"`
+}
+
+type TransferRecipient struct {
+	To     string               `json:"to"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
+
 type BurnParams struct {
 	Amount *pldtypes.HexUint256 `json:"amount"`
 	Data   pldtypes.HexBytes    `json:"data"`
 }
 
+type ConsolidateParams struct {
+	Data pldtypes.HexBytes `json:"data"`
+}
+
 type ApproveParams struct {
 	Inputs   []*pldapi.StateEncoded `json:"inputs"`
 	Outputs  []*pldapi.StateEncoded `json:"outputs"`
@@ -80,9 +94,27 @@ type ApproveParams struct {
 
 type LockParams struct {
 	Amount *pldtypes.HexUint256 `json:"amount"`
+	Expiry LockExpiry           `json:"expiry"`
 	Data   pldtypes.HexBytes    `json:"data"`
 }
 
+// Optional expiry for a lock, after which the lock owner may reclaim the locked value
+// without the involvement of any delegate. Zero values mean no expiry. If both are set,
+// the lock expires only once both the block number and the timestamp have been reached.
+type LockExpiry struct {
+	BlockNumber pldtypes.HexUint64 `json:"blockNumber"`
+	Timestamp   pldtypes.HexUint64 `json:"timestamp"` // unix seconds
+}
+
+func (e *LockExpiry) IsSet() bool {
+	return e.BlockNumber != 0 || e.Timestamp != 0
+}
+
+type ReclaimLockParams struct {
+	LockID pldtypes.Bytes32  `json:"lockId"`
+	Data   pldtypes.HexBytes `json:"data"`
+}
+
 type UnlockParams struct {
 	LockID     pldtypes.Bytes32   `json:"lockId"`
 	From       string             `json:"from"`
@@ -97,6 +129,20 @@ type DelegateLockParams struct {
 	Data     pldtypes.HexBytes    `json:"data"`
 }
 
+type ChangeNotaryParams struct {
+	Notary string            `json:"notary"`
+	Data   pldtypes.HexBytes `json:"data"`
+}
+
+type PauseParams struct {
+	Data pldtypes.HexBytes `json:"data"`
+}
+
+type FreezeParams struct {
+	Account string            `json:"account"`
+	Data    pldtypes.HexBytes `json:"data"`
+}
+
 type UnlockRecipient struct {
 	To     string               `json:"to"`
 	Amount *pldtypes.HexUint256 `json:"amount"`
@@ -110,6 +156,29 @@ type UnlockPublicParams struct {
 	Data          pldtypes.HexBytes `json:"data"`
 }
 
+type BalanceOfParams struct {
+	Account string `json:"account"`
+}
+
+type LockedBalanceOfParams struct {
+	Account string           `json:"account"`
+	LockID  pldtypes.Bytes32 `json:"lockId"`
+}
+
+type GetLockParams struct {
+	LockID pldtypes.Bytes32 `json:"lockId"`
+}
+
+type BalanceOfResult struct {
+	TotalStates  *pldtypes.HexUint256 `json:"totalStates"`
+	TotalBalance *pldtypes.HexUint256 `json:"totalBalance"`
+}
+
+type GetLockResult struct {
+	Lock   *NotoLockInfo `json:"lock"`
+	Expiry LockExpiry    `json:"expiry"` // zero values if the lock does not expire
+}
+
 type ApproveExtraParams struct {
 	Data pldtypes.HexBytes `json:"data"`
 }
@@ -22,7 +22,24 @@ import (
 )
 
 type DomainConfig struct {
-	FactoryAddress string `json:"factoryAddress"`
+	FactoryAddress string              `json:"factoryAddress"`
+	CoinSelection  CoinSelectionConfig `json:"coinSelection"`
+	QueryPageSize  *int                `json:"queryPageSize,omitempty"` // Number of states read in each page, when a query must read through all matching states (default: 100)
+}
+
+type CoinSelectionStrategy string
+
+const (
+	CoinSelectionOldestFirst  CoinSelectionStrategy = "oldest_first"  // Spend the oldest coins first (default)
+	CoinSelectionLargestFirst CoinSelectionStrategy = "largest_first" // Spend the largest coins first, minimizing the number of inputs
+	CoinSelectionBestFit      CoinSelectionStrategy = "best_fit"      // Prefer a single coin that exactly matches (or most closely exceeds) the amount
+)
+
+// Controls how the available coins of the sender are selected as inputs to transfer, burn and lock transactions
+type CoinSelectionConfig struct {
+	Strategy             CoinSelectionStrategy `json:"strategy,omitempty"`
+	MaxInputs            *int                  `json:"maxInputs,omitempty"`            // Maximum number of coins spent by a single transaction (default: unlimited, except for consolidate which defaults to 10)
+	ConsolidateThreshold *int                  `json:"consolidateThreshold,omitempty"` // When the sender holds more coins than this, additional small coins are merged into the change output (default: disabled)
 }
 
 var NotoConfigID_V0 = pldtypes.MustParseHexBytes("0x00010000")
@@ -43,6 +60,9 @@ type NotoConfigData_V0 struct {
 	RestrictMint   bool                 `json:"restrictMint"`
 	AllowBurn      bool                 `json:"allowBurn"`
 	AllowLock      bool                 `json:"allowLock"`
+	AllowPause     bool                 `json:"allowPause,omitempty"`
+	AllowFreeze    bool                 `json:"allowFreeze,omitempty"`
+	MaxSupply      *pldtypes.HexUint256 `json:"maxSupply,omitempty"`
 }
 
 // This is the structure we parse the config into in InitConfig and gets passed back to us on every call
@@ -60,9 +80,12 @@ type NotoOptions struct {
 }
 
 type NotoBasicOptions struct {
-	RestrictMint *bool `json:"restrictMint"` // Only allow notary to mint (default: true)
-	AllowBurn    *bool `json:"allowBurn"`    // Allow token holders to burn their tokens (default: true)
-	AllowLock    *bool `json:"allowLock"`    // Allow token holders to lock their tokens (default: true)
+	RestrictMint *bool                `json:"restrictMint"`          // Only allow notary to mint (default: true)
+	AllowBurn    *bool                `json:"allowBurn"`             // Allow token holders to burn their tokens (default: true)
+	AllowLock    *bool                `json:"allowLock"`             // Allow token holders to lock their tokens (default: true)
+	AllowPause   *bool                `json:"allowPause,omitempty"`  // Allow the notary to pause all mints, transfers and locks (default: false)
+	AllowFreeze  *bool                `json:"allowFreeze,omitempty"` // Allow the notary to freeze the accounts of individual token holders (default: false)
+	MaxSupply    *pldtypes.HexUint256 `json:"maxSupply,omitempty"`   // Maximum total supply that can be minted (default: unlimited)
 }
 
 type NotoHooksOptions struct {
@@ -41,6 +41,7 @@ type ReceiptStates struct {
 type ReceiptLockInfo struct {
 	LockID       pldtypes.Bytes32     `json:"lockId"`
 	Delegate     *pldtypes.EthAddress `json:"delegate,omitempty"`     // only set for delegateLock
+	Expiry       *LockExpiry          `json:"expiry,omitempty"`       // only set for locks with an expiry
 	UnlockParams *UnlockPublicParams  `json:"unlockParams,omitempty"` // only set for prepareUnlock
 	UnlockCall   pldtypes.HexBytes    `json:"unlockCall,omitempty"`   // only set for prepareUnlock
 }
@@ -125,6 +126,72 @@ var NotoLockInfoABI = &abi.Parameter{
 	},
 }
 
+// The expiry of a lock, recorded alongside the lock info by each transaction that creates or updates a lock
+// with an expiry. This is a separate schema, so that the lock info schema is unchanged for locks without one.
+type NotoLockExpiry struct {
+	Salt   pldtypes.Bytes32 `json:"salt"`
+	LockID pldtypes.Bytes32 `json:"lockId"`
+	Expiry LockExpiry       `json:"expiry"`
+}
+
+var NotoLockExpiryABI = &abi.Parameter{
+	Name:         "NotoLockExpiry",
+	Type:         "tuple",
+	InternalType: "struct NotoLockExpiry",
+	Components: abi.ParameterArray{
+		{Name: "salt", Type: "bytes32"},
+		{Name: "lockId", Type: "bytes32", Indexed: true},
+		{Name: "expiry", Type: "tuple", InternalType: "struct LockExpiry", Components: abi.ParameterArray{
+			{Name: "blockNumber", Type: "uint256"},
+			{Name: "timestamp", Type: "uint256"},
+		}},
+	},
+}
+
+const (
+	NotoRestrictionPause  = "pause"
+	NotoRestrictionFreeze = "freeze"
+)
+
+// A restriction applied or removed by the notary. Each change is recorded as a new info state, and the
+// latest confirmed record for the account determines whether the restriction is in force.
+// The account is the zero address for restrictions that apply to the whole contract.
+type NotoRestriction struct {
+	Salt        pldtypes.Bytes32     `json:"salt"`
+	Restriction string               `json:"restriction"`
+	Account     *pldtypes.EthAddress `json:"account"`
+	Active      bool                 `json:"active"`
+}
+
+var NotoRestrictionABI = &abi.Parameter{
+	Name:         "NotoRestriction",
+	Type:         "tuple",
+	InternalType: "struct NotoRestriction",
+	Components: abi.ParameterArray{
+		{Name: "salt", Type: "bytes32"},
+		{Name: "restriction", Type: "string", Indexed: true},
+		{Name: "account", Type: "address", Indexed: true},
+		{Name: "active", Type: "bool"},
+	},
+}
+
+// The total amount minted by a contract with a max supply. The notary keeps a single available
+// supply state, which each mint spends and replaces with the new total.
+type NotoSupply struct {
+	Salt   pldtypes.Bytes32     `json:"salt"`
+	Minted *pldtypes.HexUint256 `json:"minted"`
+}
+
+var NotoSupplyABI = &abi.Parameter{
+	Name:         "NotoSupply",
+	Type:         "tuple",
+	InternalType: "struct NotoSupply",
+	Components: abi.ParameterArray{
+		{Name: "salt", Type: "bytes32"},
+		{Name: "minted", Type: "uint256"},
+	},
+}
+
 type TransactionData struct {
 	Salt string            `json:"salt"`
 	Data pldtypes.HexBytes `json:"data"`
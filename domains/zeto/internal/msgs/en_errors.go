@@ -141,7 +141,6 @@ var (
 	MsgErrorAssembleInputs                   = pde("PD210099", "failed to assemble private inputs for witness calculation. %s")
 	MsgErrorCalcWitness                      = pde("PD210100", "failed to calculate the witness. %s")
 	MsgErrorGenerateProof                    = pde("PD210101", "failed to generate proof. %s")
-	MsgNoDomainReceipt                       = pde("PD210102", "Not implemented. See state receipt for coin transfers")
 	MsgUnknownSignPayload                    = pde("PD210103", "Sign payload type '%s' not recognized")
 	MsgNullifierGenerationFailed             = pde("PD210104", "Failed to generate nullifier for coin")
 	MsgErrorDecodeDepositCall                = pde("PD210105", "Failed to decode the deposit call. %s")
@@ -173,4 +172,17 @@ var (
 	MsgErrorDecodeDelegateExtras             = pde("PD210132", "Failed to decode delegate in extras. %s")
 	MsgErrorMissingLockDelegate              = pde("PD210133", "lock delegate is required")
 	MsgFailedToQueryStatesById               = pde("PD210134", "Failed to query states by IDs. Wanted: %d, Found: %d")
+	MsgNotCallFunction                       = pde("PD210135", "Function '%s' modifies state, and must be submitted as a transaction")
+	MsgParameterRequired                     = pde("PD210136", "Parameter '%s' is required")
+	MsgTokenNotFound                         = pde("PD210137", "Token %s was not found in the available states of this node")
+	MsgUTXONotFound                          = pde("PD210138", "UTXO %s was not found in the available states of this node")
+	MsgNoMerkleTree                          = pde("PD210139", "Token '%s' does not use nullifiers, and has no Merkle tree")
+	MsgErrorECDHSharedSecret                 = pde("PD210143", "Failed to derive the ECDH shared secret")
+	MsgNoAuditorConfigured                   = pde("PD210144", "No auditor is configured for the Zeto domain")
+	MsgErrorEncryptForAuditor                = pde("PD210145", "Failed to encrypt the transfer values for the auditor. %s")
+	MsgErrorDecryptAuditRecord               = pde("PD210146", "Failed to decrypt the audit record for transaction %s. %s")
+	MsgErrorAuditRecordMismatch              = pde("PD210147", "Decrypted values for transaction %s do not match UTXO %s on the base ledger")
+	MsgErrorDecodeAuditorData                = pde("PD210148", "Failed to decode the auditor ciphertext in the transaction data. %s")
+	MsgNotEncryptionToken                    = pde("PD210149", "Token '%s' does not encrypt transfer values")
+	MsgErrorInvalidECDHPoint                 = pde("PD210150", "Invalid ECDH public key")
 )
@@ -0,0 +1,525 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package zeto
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/hyperledger-labs/zeto/go-sdk/pkg/sparse-merkle-tree/node"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/smt"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// Number of states read in each page, when a call must read through all the matching states
+const queryPageSize = 100
+
+type parsedCall struct {
+	method          string
+	domainConfig    *types.DomainInstanceConfig
+	contractAddress *pldtypes.EthAddress
+	params          any
+}
+
+// Read-only calls are answered from the available states known to the local node,
+// so reflect only the states that have been distributed to this node.
+func (z *Zeto) InitCall(ctx context.Context, req *prototk.InitCallRequest) (*prototk.InitCallResponse, error) {
+	call, err := z.validateCall(ctx, req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &prototk.InitCallResponse{}
+	switch params := call.params.(type) {
+	case *types.BalanceOfParams:
+		res.RequiredVerifiers = z.bjjVerifiers(params.Account)
+	case *types.TokensOfParams:
+		res.RequiredVerifiers = z.bjjVerifiers(params.Account)
+	case *types.DecryptTransfersParams:
+		res.AttestationPlan, err = z.planDecryptTransfers(ctx, req.StateQueryContext)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (z *Zeto) ExecCall(ctx context.Context, req *prototk.ExecCallRequest) (*prototk.ExecCallResponse, error) {
+	call, err := z.validateCall(ctx, req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	useNullifiers := common.IsNullifiersToken(call.domainConfig.TokenName)
+	var result any
+	switch params := call.params.(type) {
+	case *types.BalanceOfParams:
+		result, err = z.balanceOf(ctx, req.StateQueryContext, useNullifiers, params, req.ResolvedVerifiers)
+	case *types.OwnerOfParams:
+		result, err = z.ownerOf(ctx, req.StateQueryContext, useNullifiers, params)
+	case *types.TokensOfParams:
+		result, err = z.tokensOf(ctx, req.StateQueryContext, useNullifiers, params, req.ResolvedVerifiers)
+	case *types.GetMerkleProofParams:
+		result, err = z.getMerkleProof(ctx, req.StateQueryContext, call, params)
+	case *types.DecryptTransfersParams:
+		result, err = z.decryptTransfers(ctx, req.StateQueryContext, req.AttestationResult)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.ExecCallResponse{
+		ResultJson: string(resultJSON),
+	}, nil
+}
+
+func (z *Zeto) validateCall(ctx context.Context, tx *prototk.TransactionSpecification) (*parsedCall, error) {
+	var functionABI abi.Entry
+	err := json.Unmarshal([]byte(tx.FunctionAbiJson), &functionABI)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorUnmarshalFuncAbi, err)
+	}
+
+	var domainConfig *types.DomainInstanceConfig
+	err = json.Unmarshal([]byte(tx.ContractInfo.ContractConfigJson), &domainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var abi *abi.Entry
+	nonFungible := common.IsNonFungibleToken(domainConfig.TokenName)
+	if nonFungible {
+		abi = types.ZetoNonFungibleABI.Functions()[functionABI.Name]
+	} else {
+		abi = types.ZetoFungibleABI.Functions()[functionABI.Name]
+	}
+	if abi == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgUnknownFunction, functionABI.Name)
+	}
+
+	var params any
+	switch functionABI.Name {
+	case types.METHOD_BALANCE_OF:
+		var balanceParams types.BalanceOfParams
+		err = json.Unmarshal([]byte(tx.FunctionParamsJson), &balanceParams)
+		if err == nil && balanceParams.Account == "" {
+			err = i18n.NewError(ctx, msgs.MsgParameterRequired, "account")
+		}
+		params = &balanceParams
+	case types.METHOD_OWNER_OF:
+		var ownerParams types.OwnerOfParams
+		err = json.Unmarshal([]byte(tx.FunctionParamsJson), &ownerParams)
+		if err == nil && ownerParams.TokenID == nil {
+			err = i18n.NewError(ctx, msgs.MsgParameterRequired, "tokenID")
+		}
+		params = &ownerParams
+	case types.METHOD_TOKENS_OF:
+		var tokensParams types.TokensOfParams
+		err = json.Unmarshal([]byte(tx.FunctionParamsJson), &tokensParams)
+		if err == nil && tokensParams.Account == "" {
+			err = i18n.NewError(ctx, msgs.MsgParameterRequired, "account")
+		}
+		params = &tokensParams
+	case types.METHOD_GET_MERKLE_PROOF:
+		var proofParams types.GetMerkleProofParams
+		err = json.Unmarshal([]byte(tx.FunctionParamsJson), &proofParams)
+		if err == nil && proofParams.UTXO.NilOrZero() {
+			err = i18n.NewError(ctx, msgs.MsgParameterRequired, "utxo")
+		}
+		params = &proofParams
+	case types.METHOD_DECRYPT_TRANSFERS:
+		if !common.IsEncryptionToken(domainConfig.TokenName) {
+			err = i18n.NewError(ctx, msgs.MsgNotEncryptionToken, domainConfig.TokenName)
+		}
+		params = &types.DecryptTransfersParams{}
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgNotCallFunction, functionABI.Name)
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorValidateFuncParams, err)
+	}
+
+	signature := abi.SolString()
+	if tx.FunctionSignature != signature {
+		return nil, i18n.NewError(ctx, msgs.MsgUnexpectedFuncSignature, functionABI.Name, signature, tx.FunctionSignature)
+	}
+
+	contractAddress, err := pldtypes.ParseEthAddress(tx.ContractInfo.ContractAddress)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecodeContractAddress, err)
+	}
+
+	return &parsedCall{
+		method:          functionABI.Name,
+		domainConfig:    domainConfig,
+		contractAddress: contractAddress,
+		params:          params,
+	}, nil
+}
+
+func (z *Zeto) bjjVerifiers(lookup string) []*prototk.ResolveVerifierRequest {
+	return []*prototk.ResolveVerifierRequest{
+		{
+			Lookup:       lookup,
+			Algorithm:    z.getAlgoZetoSnarkBJJ(),
+			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+		},
+	}
+}
+
+func (z *Zeto) findBJJVerifier(ctx context.Context, lookup string, verifiers []*prototk.ResolvedVerifier) (string, error) {
+	resolved := domain.FindVerifier(lookup, z.getAlgoZetoSnarkBJJ(), zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X, verifiers)
+	if resolved == nil {
+		return "", i18n.NewError(ctx, msgs.MsgErrorResolveVerifier, lookup)
+	}
+	return resolved.Verifier, nil
+}
+
+func (z *Zeto) balanceOf(ctx context.Context, stateQueryContext string, useNullifiers bool, params *types.BalanceOfParams, verifiers []*prototk.ResolvedVerifier) (*types.BalanceOfResult, error) {
+	ownerKey, err := z.findBJJVerifier(ctx, params.Account, verifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+	for i, locked := range []bool{false, true} {
+		states, balance := totals[i*2], totals[i*2+1]
+		err := z.forEachAvailableState(ctx, stateQueryContext, z.coinSchema, useNullifiers,
+			func() query.QueryBuilder {
+				return query.NewQueryBuilder().Equal("owner", ownerKey).Equal("locked", locked)
+			},
+			func(state *prototk.StoredState) error {
+				var coin types.ZetoCoin
+				if err := json.Unmarshal([]byte(state.DataJson), &coin); err != nil {
+					return i18n.NewError(ctx, msgs.MsgInvalidCoin, state.Id, err)
+				}
+				states.Add(states, big.NewInt(1))
+				balance.Add(balance, coin.Amount.Int())
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &types.BalanceOfResult{
+		TotalStates:   (*pldtypes.HexUint256)(totals[0]),
+		TotalBalance:  (*pldtypes.HexUint256)(totals[1]),
+		LockedStates:  (*pldtypes.HexUint256)(totals[2]),
+		LockedBalance: (*pldtypes.HexUint256)(totals[3]),
+	}, nil
+}
+
+// The owner of a token is recorded in its state only as a Baby Jubjub public key, which cannot be mapped back to the
+// identity that owns it, so the key is returned as-is. Callers can compare it to the resolved key of a known identity.
+func (z *Zeto) ownerOf(ctx context.Context, stateQueryContext string, useNullifiers bool, params *types.OwnerOfParams) (*types.OwnerOfResult, error) {
+	queryBuilder := query.NewQueryBuilder().
+		Limit(1).
+		Equal("tokenID", params.TokenID.String())
+	states, err := z.findAvailableStates(ctx, stateQueryContext, z.nftSchema, useNullifiers, queryBuilder.Query().String())
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorQueryAvailCoins, err)
+	}
+	if len(states) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgTokenNotFound, params.TokenID)
+	}
+	var token types.ZetoNFToken
+	if err := json.Unmarshal([]byte(states[0].DataJson), &token); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidCoin, states[0].Id, err)
+	}
+	return &types.OwnerOfResult{
+		Owner: token.Owner,
+	}, nil
+}
+
+func (z *Zeto) tokensOf(ctx context.Context, stateQueryContext string, useNullifiers bool, params *types.TokensOfParams, verifiers []*prototk.ResolvedVerifier) (*types.TokensOfResult, error) {
+	ownerKey, err := z.findBJJVerifier(ctx, params.Account, verifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.TokensOfResult{
+		Tokens: []*types.TokensOfEntry{},
+	}
+	err = z.forEachAvailableState(ctx, stateQueryContext, z.nftSchema, useNullifiers,
+		func() query.QueryBuilder {
+			return query.NewQueryBuilder().Equal("owner", ownerKey)
+		},
+		func(state *prototk.StoredState) error {
+			var token types.ZetoNFToken
+			if err := json.Unmarshal([]byte(state.DataJson), &token); err != nil {
+				return i18n.NewError(ctx, msgs.MsgInvalidCoin, state.Id, err)
+			}
+			result.Tokens = append(result.Tokens, &types.TokensOfEntry{
+				TokenID: token.TokenID,
+				URI:     token.URI,
+			})
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// The proof is generated in the same way as for the inputs of a transaction, so can be checked by
+// anyone who knows the root - for example against the root history of the base ledger contract.
+func (z *Zeto) getMerkleProof(ctx context.Context, stateQueryContext string, call *parsedCall, params *types.GetMerkleProofParams) (*types.GetMerkleProofResult, error) {
+	tokenName := call.domainConfig.TokenName
+	if !common.IsNullifiersToken(tokenName) {
+		return nil, i18n.NewError(ctx, msgs.MsgNoMerkleTree, tokenName)
+	}
+
+	schema := z.coinSchema
+	nonFungible := common.IsNonFungibleToken(tokenName)
+	if nonFungible {
+		schema = z.nftSchema
+	}
+	queryBuilder := query.NewQueryBuilder().
+		Limit(1).
+		Equal(".id", params.UTXO.String())
+	states, err := z.findAvailableStates(ctx, stateQueryContext, schema, true, queryBuilder.Query().String())
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorQueryAvailCoins, err)
+	}
+	if len(states) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgUTXONotFound, params.UTXO)
+	}
+
+	// The tree is indexed by the hash of each output, and locked coins are tracked in their own tree
+	var hash *pldtypes.HexUint256
+	smtName := smt.MerkleTreeName(tokenName, call.contractAddress)
+	if nonFungible {
+		var token types.ZetoNFToken
+		if err := json.Unmarshal([]byte(states[0].DataJson), &token); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidCoin, states[0].Id, err)
+		}
+		if hash, err = token.Hash(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		var coin types.ZetoCoin
+		if err := json.Unmarshal([]byte(states[0].DataJson), &coin); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgInvalidCoin, states[0].Id, err)
+		}
+		if hash, err = coin.Hash(ctx); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgErrorHashInputState, err)
+		}
+		if coin.Locked {
+			smtName = smt.MerkleTreeNameForLockedStates(tokenName, call.contractAddress)
+		}
+	}
+
+	tree, err := z.newSmtTreeSpec(ctx, smtName, stateQueryContext)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := node.NewNodeIndexFromBigInt(hash.Int())
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorNewNodeIndex, err)
+	}
+	leaf, err := node.NewLeafNode(node.NewIndexOnly(idx))
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorNewLeafNode, err)
+	}
+	n, err := tree.tree.GetNode(leaf.Ref())
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorQueryLeafNode, leaf.Ref().Hex(), err)
+	}
+
+	root := tree.tree.Root()
+	index := n.Index().BigInt()
+	proofs, _, err := tree.tree.GenerateProofs([]*big.Int{index}, root)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorGenerateMTP, err)
+	}
+	cp, err := proofs[0].ToCircomVerifierProof(index, index, root, smt.SMT_HEIGHT_UTXO)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorConvertToCircomProof, err)
+	}
+	siblings := make([]*pldtypes.HexUint256, len(cp.Siblings)-1)
+	for i, s := range cp.Siblings[0 : len(cp.Siblings)-1] {
+		siblings[i] = (*pldtypes.HexUint256)(s.BigInt())
+	}
+	return &types.GetMerkleProofResult{
+		Root:     (*pldtypes.HexUint256)(root.BigInt()),
+		Siblings: siblings,
+	}, nil
+}
+
+// Each audit record is decrypted with the ECDH shared secret of the auditor's viewing key and the ephemeral key
+// of the transfer. The viewing key never leaves the signer - instead a signature is requested for each record,
+// which is only produced when the caller is the auditor.
+func (z *Zeto) planDecryptTransfers(ctx context.Context, stateQueryContext string) ([]*prototk.AttestationRequest, error) {
+	auditor := z.auditor()
+	if auditor == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgNoAuditorConfigured)
+	}
+	viewingKey := common.AuditorViewingKey(auditor)
+	attestationPlan := []*prototk.AttestationRequest{}
+	err := z.forEachAuditRecord(ctx, stateQueryContext, func(state *prototk.StoredState, record *types.AuditRecord) error {
+		ecdhPublicKey, err := common.AuditRecordECDHPublicKey(ctx, record)
+		if err != nil {
+			log.L(ctx).Warnf("Skipping audit record %s: %s", state.Id, err)
+			return nil
+		}
+		attestationPlan = append(attestationPlan, &prototk.AttestationRequest{
+			Name:            auditAttestationName(state),
+			AttestationType: prototk.AttestationType_SIGN,
+			Algorithm:       z.getAlgoZetoSnarkBJJ(),
+			VerifierType:    zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+			PayloadType:     zetosignerapi.PAYLOAD_DOMAIN_ZETO_ECDH,
+			Payload:         ecdhPublicKey,
+			Parties:         []string{viewingKey},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attestationPlan, nil
+}
+
+func auditAttestationName(state *prototk.StoredState) string {
+	return "audit_" + state.Id
+}
+
+// An audit record is only included in the result if the decrypted values hash to the UTXOs that the base
+// ledger contract emitted for the transfer.
+func (z *Zeto) decryptTransfers(ctx context.Context, stateQueryContext string, attestations []*prototk.AttestationResult) (*types.DecryptTransfersResult, error) {
+	result := &types.DecryptTransfersResult{
+		Transfers: []*types.AuditedTransfer{},
+	}
+	err := z.forEachAuditRecord(ctx, stateQueryContext, func(state *prototk.StoredState, record *types.AuditRecord) error {
+		sharedSecret := domain.FindAttestation(auditAttestationName(state), attestations)
+		if sharedSecret == nil {
+			// the record was stored after the call was planned
+			return nil
+		}
+		transfer, err := auditTransfer(ctx, sharedSecret.Payload, record)
+		if err != nil {
+			// the auditor ciphertext is not verified by the circuit, so a sender could have attached garbage
+			log.L(ctx).Warnf("Skipping audit record %s: %s", state.Id, err)
+			return nil
+		}
+		result.Transfers = append(result.Transfers, transfer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (z *Zeto) forEachAuditRecord(ctx context.Context, stateQueryContext string, fn func(state *prototk.StoredState, record *types.AuditRecord) error) error {
+	return z.forEachAvailableState(ctx, stateQueryContext, z.auditRecordSchema, false, query.NewQueryBuilder,
+		func(state *prototk.StoredState) error {
+			var record types.AuditRecord
+			if err := json.Unmarshal([]byte(state.DataJson), &record); err != nil {
+				return i18n.NewError(ctx, msgs.MsgErrorUnmarshalStateData, err)
+			}
+			return fn(state, &record)
+		})
+}
+
+func auditTransfer(ctx context.Context, sharedSecretPayload []byte, record *types.AuditRecord) (*types.AuditedTransfer, error) {
+	sharedSecret, err := common.DecodeECDHPoint(ctx, sharedSecretPayload)
+	if err != nil {
+		return nil, err
+	}
+	values, err := common.DecryptAuditRecord(ctx, sharedSecret, record)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := auditedUTXOs(ctx, record.TransactionID, values.Inputs, record.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := auditedUTXOs(ctx, record.TransactionID, values.Outputs, record.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	return &types.AuditedTransfer{
+		TransactionID: record.TransactionID,
+		Sender:        pldtypes.MustParseHexBytes(zetosigner.EncodeBabyJubJubPublicKey(values.Sender)),
+		Inputs:        inputs,
+		Outputs:       outputs,
+	}, nil
+}
+
+func auditedUTXOs(ctx context.Context, txID pldtypes.Bytes32, coins []*common.AuditedCoin, onChain []pldtypes.HexUint256) ([]*types.AuditedUTXO, error) {
+	if len(coins) != len(onChain) {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecryptAuditRecord, txID, "unexpected number of UTXOs")
+	}
+	utxos := []*types.AuditedUTXO{}
+	for i, coin := range coins {
+		utxo := onChain[i]
+		if utxo.Int().Sign() == 0 {
+			// padding to the size of the circuit
+			continue
+		}
+		commitment, err := coin.Commitment()
+		if err != nil || commitment.Cmp(utxo.Int()) != 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgErrorAuditRecordMismatch, txID, utxo.String())
+		}
+		utxos = append(utxos, &types.AuditedUTXO{
+			ID:     &utxo,
+			Owner:  pldtypes.MustParseHexBytes(zetosigner.EncodeBabyJubJubPublicKey(coin.Owner)),
+			Amount: (*pldtypes.HexUint256)(coin.Amount),
+		})
+	}
+	return utxos, nil
+}
+
+// Pages through all available states matching the filter, in the order they were created
+func (z *Zeto) forEachAvailableState(ctx context.Context, stateQueryContext string, schema *prototk.StateSchema, useNullifiers bool, newQuery func() query.QueryBuilder, fn func(state *prototk.StoredState) error) error {
+	return domain.ForEachState(ctx, queryPageSize, newQuery,
+		func(query string) ([]*prototk.StoredState, error) {
+			states, err := z.findAvailableStates(ctx, stateQueryContext, schema, useNullifiers, query)
+			if err != nil {
+				return nil, i18n.NewError(ctx, msgs.MsgErrorQueryAvailCoins, err)
+			}
+			return states, nil
+		}, fn)
+}
+
+func (z *Zeto) findAvailableStates(ctx context.Context, stateQueryContext string, schema *prototk.StateSchema, useNullifiers bool, query string) ([]*prototk.StoredState, error) {
+	res, err := z.Callbacks.FindAvailableStates(ctx, &prototk.FindAvailableStatesRequest{
+		StateQueryContext: stateQueryContext,
+		SchemaId:          schema.Id,
+		QueryJson:         query,
+		UseNullifiers:     &useNullifiers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.States, nil
+}
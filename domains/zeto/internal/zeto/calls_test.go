@@ -0,0 +1,430 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package zeto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/constants"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOwnerKey = "0x19d2ee6b9770a4f8d7c3b7906bc7595684509166fa42d718d1d880b62bcb7922"
+
+func newTestCallZeto() (*Zeto, *domain.MockDomainCallbacks) {
+	z, testCallbacks := newTestZeto()
+	z.nftSchema = &pb.StateSchema{
+		Id: "nft",
+	}
+	return z, testCallbacks
+}
+
+func newTestCall(tokenName, fnName, params string) *pb.TransactionSpecification {
+	fn := types.ZetoFungibleABI.Functions()[fnName]
+	if tokenName == constants.TOKEN_NF_ANON || tokenName == constants.TOKEN_NF_ANON_NULLIFIER {
+		fn = types.ZetoNonFungibleABI.Functions()[fnName]
+	}
+	return &pb.TransactionSpecification{
+		ContractInfo: &pb.ContractInfo{
+			ContractAddress:    "0x1234567890123456789012345678901234567890",
+			ContractConfigJson: pldtypes.JSONString(&types.DomainInstanceConfig{TokenName: tokenName}).String(),
+		},
+		FunctionAbiJson:    pldtypes.JSONString(fn).String(),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: params,
+	}
+}
+
+// Returns the supplied pages of states in turn, with an empty page whenever a nil page is supplied
+func mockAvailableStatePages(testCallbacks *domain.MockDomainCallbacks, pages ...[]*pb.StoredState) *int {
+	calls := 0
+	testCallbacks.MockFindAvailableStates = func() (*pb.FindAvailableStatesResponse, error) {
+		res := &pb.FindAvailableStatesResponse{}
+		if calls < len(pages) {
+			res.States = pages[calls]
+		}
+		calls++
+		return res, nil
+	}
+	return &calls
+}
+
+func TestBalanceOf(t *testing.T) {
+	z, testCallbacks := newTestCallZeto()
+	ctx := context.Background()
+
+	tx := newTestCall(constants.TOKEN_ANON, "balanceOf", `{"account": "Alice"}`)
+	initRes, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 1)
+	assert.Equal(t, "Alice", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, z.getAlgoZetoSnarkBJJ(), initRes.RequiredVerifiers[0].Algorithm)
+
+	calls := mockAvailableStatePages(testCallbacks,
+		[]*pb.StoredState{
+			{Id: "0x01", CreatedAt: 1, DataJson: `{"salt":"0x01","owner":"` + testOwnerKey + `","amount":"0x0a"}`},
+			{Id: "0x02", CreatedAt: 2, DataJson: `{"salt":"0x02","owner":"` + testOwnerKey + `","amount":"0x14"}`},
+		},
+		nil,
+		[]*pb.StoredState{
+			{Id: "0x03", CreatedAt: 3, DataJson: `{"salt":"0x03","owner":"` + testOwnerKey + `","amount":"0x05","locked":true}`},
+		},
+		nil,
+	)
+
+	execRes, err := z.ExecCall(ctx, &pb.ExecCallRequest{
+		Transaction: tx,
+		ResolvedVerifiers: []*pb.ResolvedVerifier{{
+			Lookup:       "Alice",
+			Algorithm:    z.getAlgoZetoSnarkBJJ(),
+			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+			Verifier:     testOwnerKey,
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, *calls)
+	assert.JSONEq(t, `{"totalStates":"0x2","totalBalance":"0x1e","lockedStates":"0x1","lockedBalance":"0x5"}`, execRes.ResultJson)
+
+	// The result must decode against the outputs of the private ABI
+	_, err = types.ZetoFungibleABI.Functions()["balanceOf"].Outputs.ParseJSON([]byte(execRes.ResultJson))
+	require.NoError(t, err)
+}
+
+func TestBalanceOfErrors(t *testing.T) {
+	z, testCallbacks := newTestCallZeto()
+	ctx := context.Background()
+	tx := newTestCall(constants.TOKEN_ANON, "balanceOf", `{"account": "Alice"}`)
+
+	_, err := z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.EqualError(t, err, "PD210036: Failed to resolve verifier: Alice")
+
+	req := &pb.ExecCallRequest{
+		Transaction: tx,
+		ResolvedVerifiers: []*pb.ResolvedVerifier{{
+			Lookup:       "Alice",
+			Algorithm:    z.getAlgoZetoSnarkBJJ(),
+			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+			Verifier:     testOwnerKey,
+		}},
+	}
+	testCallbacks.MockFindAvailableStates = func() (*pb.FindAvailableStatesResponse, error) {
+		return nil, errors.New("test error")
+	}
+	_, err = z.ExecCall(ctx, req)
+	assert.EqualError(t, err, "PD210032: Failed to query the state store for available coins. test error")
+
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{{Id: "0x01", DataJson: "!!wrong"}})
+	_, err = z.ExecCall(ctx, req)
+	assert.ErrorContains(t, err, "PD210034: Coin 0x01 is invalid")
+}
+
+func TestOwnerOf(t *testing.T) {
+	z, testCallbacks := newTestCallZeto()
+	ctx := context.Background()
+
+	tx := newTestCall(constants.TOKEN_NF_ANON, "ownerOf", `{"tokenID": "0x1234"}`)
+	initRes, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	assert.Empty(t, initRes.RequiredVerifiers)
+
+	mockAvailableStatePages(testCallbacks)
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.EqualError(t, err, "PD210137: Token 0x1234 was not found in the available states of this node")
+
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{
+		{Id: "0x01", DataJson: `{"salt":"0x01","uri":"https://example.com/token/1","owner":"` + testOwnerKey + `","tokenID":"0x1234"}`},
+	})
+	execRes, err := z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"owner":"`+testOwnerKey+`"}`, execRes.ResultJson)
+
+	_, err = types.ZetoNonFungibleABI.Functions()["ownerOf"].Outputs.ParseJSON([]byte(execRes.ResultJson))
+	require.NoError(t, err)
+}
+
+func TestTokensOf(t *testing.T) {
+	z, testCallbacks := newTestCallZeto()
+	ctx := context.Background()
+
+	tx := newTestCall(constants.TOKEN_NF_ANON, "tokensOf", `{"account": "Alice"}`)
+	initRes, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 1)
+	assert.Equal(t, "Alice", initRes.RequiredVerifiers[0].Lookup)
+
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{
+		{Id: "0x01", CreatedAt: 1, DataJson: `{"salt":"0x01","uri":"https://example.com/token/1","owner":"` + testOwnerKey + `","tokenID":"0x01"}`},
+		{Id: "0x02", CreatedAt: 2, DataJson: `{"salt":"0x02","uri":"https://example.com/token/2","owner":"` + testOwnerKey + `","tokenID":"0x02"}`},
+	})
+	execRes, err := z.ExecCall(ctx, &pb.ExecCallRequest{
+		Transaction: tx,
+		ResolvedVerifiers: []*pb.ResolvedVerifier{{
+			Lookup:       "Alice",
+			Algorithm:    z.getAlgoZetoSnarkBJJ(),
+			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+			Verifier:     testOwnerKey,
+		}},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tokens":[
+		{"tokenID":"0x1","uri":"https://example.com/token/1"},
+		{"tokenID":"0x2","uri":"https://example.com/token/2"}
+	]}`, execRes.ResultJson)
+
+	_, err = types.ZetoNonFungibleABI.Functions()["tokensOf"].Outputs.ParseJSON([]byte(execRes.ResultJson))
+	require.NoError(t, err)
+}
+
+func TestGetMerkleProofErrors(t *testing.T) {
+	z, testCallbacks := newTestCallZeto()
+	ctx := context.Background()
+
+	_, err := z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: newTestCall(constants.TOKEN_ANON, "getMerkleProof", `{"utxo": "0x01"}`)})
+	assert.EqualError(t, err, "PD210139: Token 'Zeto_Anon' does not use nullifiers, and has no Merkle tree")
+
+	tx := newTestCall(constants.TOKEN_ANON_NULLIFIER, "getMerkleProof", `{"utxo": "0x01"}`)
+	initRes, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	assert.Empty(t, initRes.RequiredVerifiers)
+
+	mockAvailableStatePages(testCallbacks)
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.EqualError(t, err, "PD210138: UTXO 0x1 was not found in the available states of this node")
+
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{{Id: "0x01", DataJson: "!!wrong"}})
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD210034: Coin 0x01 is invalid")
+
+	// The coin is available, but has not been indexed into the (empty) Merkle tree
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{
+		{Id: "0x01", DataJson: `{"salt":"0x01","owner":"` + testOwnerKey + `","amount":"0x0a"}`},
+	})
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD210055")
+
+	tx = newTestCall(constants.TOKEN_NF_ANON_NULLIFIER, "getMerkleProof", `{"utxo": "0x01"}`)
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{
+		{Id: "0x01", DataJson: `{"salt":"0x01","uri":"https://example.com/token/1","owner":"` + testOwnerKey + `","tokenID":"0x01"}`},
+	})
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD210055")
+}
+
+func newTestAuditRecord(t *testing.T, auditor *babyjub.PublicKey) *pb.StoredState {
+	ctx := context.Background()
+	senderKey := babyjub.NewRandPrivKey()
+	receiverKey := babyjub.NewRandPrivKey()
+	newCoin := func(amount uint64, owner *babyjub.PublicKey) *types.ZetoCoin {
+		salt, err := common.CryptoRandBN254()
+		require.NoError(t, err)
+		return &types.ZetoCoin{
+			Salt:   (*pldtypes.HexUint256)(salt),
+			Owner:  pldtypes.MustParseHexBytes(zetosigner.EncodeBabyJubJubPublicKey(owner)),
+			Amount: pldtypes.Uint64ToUint256(amount),
+		}
+	}
+	inputs := []*types.ZetoCoin{newCoin(15, senderKey.Public())}
+	outputs := []*types.ZetoCoin{newCoin(10, receiverKey.Public()), newCoin(5, senderKey.Public())}
+	ciphertext, err := common.EncryptForAuditor(ctx, auditor, senderKey.Public(), inputs, outputs, 2)
+	require.NoError(t, err)
+
+	record := &types.AuditRecord{
+		TransactionID:   pldtypes.RandBytes32(),
+		Inputs:          []pldtypes.HexUint256{*pldtypes.Uint64ToUint256(0), *pldtypes.Uint64ToUint256(0)},
+		Outputs:         []pldtypes.HexUint256{*pldtypes.Uint64ToUint256(0), *pldtypes.Uint64ToUint256(0)},
+		EncryptionNonce: *ciphertext.EncryptionNonce,
+	}
+	for i, coin := range inputs {
+		hash, err := coin.Hash(ctx)
+		require.NoError(t, err)
+		record.Inputs[i] = *hash
+	}
+	for i, coin := range outputs {
+		hash, err := coin.Hash(ctx)
+		require.NoError(t, err)
+		record.Outputs[i] = *hash
+	}
+	for _, v := range ciphertext.EcdhPublicKey {
+		record.EcdhPublicKey = append(record.EcdhPublicKey, *v)
+	}
+	for _, v := range ciphertext.EncryptedValues {
+		record.EncryptedValues = append(record.EncryptedValues, *v)
+	}
+	return &pb.StoredState{
+		Id:       record.TransactionID.String(),
+		DataJson: pldtypes.JSONString(record).String(),
+	}
+}
+
+func TestDecryptTransfers(t *testing.T) {
+	z, testCallbacks := newTestCallZeto()
+	z.auditRecordSchema = &pb.StateSchema{Id: "audit_record"}
+	ctx := context.Background()
+
+	tx := newTestCall(constants.TOKEN_ANON_ENC, "decryptTransfers", `{}`)
+	_, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx})
+	assert.EqualError(t, err, "PD210144: No auditor is configured for the Zeto domain")
+
+	auditorKey := babyjub.NewRandPrivKey()
+	valid := newTestAuditRecord(t, auditorKey.Public())
+	otherKey := babyjub.NewRandPrivKey()
+	forOtherAuditor := newTestAuditRecord(t, otherKey.Public())
+	tampered := newTestAuditRecord(t, auditorKey.Public())
+	var record types.AuditRecord
+	require.NoError(t, json.Unmarshal([]byte(tampered.DataJson), &record))
+	record.Outputs[0] = *pldtypes.Uint64ToUint256(12345)
+	tampered.DataJson = pldtypes.JSONString(record).String()
+	noECDHKey := newTestAuditRecord(t, auditorKey.Public())
+	require.NoError(t, json.Unmarshal([]byte(noECDHKey.DataJson), &record))
+	record.EcdhPublicKey = nil
+	noECDHKey.DataJson = pldtypes.JSONString(record).String()
+	records := []*pb.StoredState{valid, forOtherAuditor, tampered, noECDHKey}
+
+	// A shared secret is requested from the viewing key of the auditor for each record
+	z.config = &types.DomainFactoryConfig{Auditor: "auditor@node1"}
+	mockAvailableStatePages(testCallbacks, records)
+	initRes, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx, StateQueryContext: "query1"})
+	require.NoError(t, err)
+	require.Len(t, initRes.AttestationPlan, 3)
+	var attestations []*pb.AttestationResult
+	for _, ar := range initRes.AttestationPlan {
+		assert.Equal(t, pb.AttestationType_SIGN, ar.AttestationType)
+		assert.Equal(t, zetosignerapi.PAYLOAD_DOMAIN_ZETO_ECDH, ar.PayloadType)
+		assert.Equal(t, []string{"auditor.viewing@node1"}, ar.Parties)
+		signed, err := z.Sign(ctx, &pb.SignRequest{
+			Algorithm:   ar.Algorithm,
+			PayloadType: ar.PayloadType,
+			Payload:     ar.Payload,
+			PrivateKey:  auditorKey[:],
+		})
+		require.NoError(t, err)
+		attestations = append(attestations, &pb.AttestationResult{
+			Name:            ar.Name,
+			AttestationType: ar.AttestationType,
+			Payload:         signed.Payload,
+		})
+	}
+	assert.Equal(t, "audit_"+valid.Id, initRes.AttestationPlan[0].Name)
+
+	// Records without a shared secret are skipped
+	mockAvailableStatePages(testCallbacks, records)
+	res, err := z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"transfers":[]}`, res.ResultJson)
+
+	mockAvailableStatePages(testCallbacks, records)
+	res, err = z.ExecCall(ctx, &pb.ExecCallRequest{
+		Transaction:       tx,
+		AttestationResult: attestations,
+	})
+	require.NoError(t, err)
+	var result types.DecryptTransfersResult
+	require.NoError(t, json.Unmarshal([]byte(res.ResultJson), &result))
+	require.Len(t, result.Transfers, 1)
+	transfer := result.Transfers[0]
+	assert.Equal(t, valid.Id, transfer.TransactionID.String())
+	require.Len(t, transfer.Inputs, 1)
+	assert.Equal(t, "0x0f", transfer.Inputs[0].Amount.String())
+	assert.Equal(t, transfer.Sender, transfer.Inputs[0].Owner)
+	require.Len(t, transfer.Outputs, 2)
+	assert.Equal(t, "0x0a", transfer.Outputs[0].Amount.String())
+	assert.Equal(t, "0x05", transfer.Outputs[1].Amount.String())
+	assert.Equal(t, transfer.Sender, transfer.Outputs[1].Owner)
+
+	// An invalid shared secret is skipped
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{valid})
+	res, err = z.ExecCall(ctx, &pb.ExecCallRequest{
+		Transaction:       tx,
+		AttestationResult: []*pb.AttestationResult{{Name: "audit_" + valid.Id, Payload: []byte{0x01}}},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"transfers":[]}`, res.ResultJson)
+
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{{Id: "bad", DataJson: "!!wrong"}})
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD210087")
+
+	mockAvailableStatePages(testCallbacks, []*pb.StoredState{{Id: "bad", DataJson: "!!wrong"}})
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD210087")
+
+	// only tokens that encrypt values have audit records
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_ANON, "decryptTransfers", `{}`)})
+	assert.Regexp(t, "PD210015.*PD210149", err)
+}
+
+func TestCallBadFunctions(t *testing.T) {
+	z, _ := newTestCallZeto()
+	ctx := context.Background()
+
+	_, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: &pb.TransactionSpecification{
+		FunctionAbiJson: "!!wrong",
+	}})
+	assert.ErrorContains(t, err, "PD210012")
+
+	// Calls are only available on the matching token type
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_NF_ANON, "ownerOf", `{"tokenID": "0x01"}`)})
+	require.NoError(t, err)
+	tx := newTestCall(constants.TOKEN_NF_ANON, "ownerOf", `{"tokenID": "0x01"}`)
+	tx.ContractInfo.ContractConfigJson = pldtypes.JSONString(&types.DomainInstanceConfig{TokenName: constants.TOKEN_ANON}).String()
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: tx})
+	assert.EqualError(t, err, "PD210014: Unknown function: ownerOf")
+
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_ANON, "transfer", `{}`)})
+	assert.EqualError(t, err, "PD210135: Function 'transfer' modifies state, and must be submitted as a transaction")
+
+	tx = newTestCall(constants.TOKEN_ANON, "balanceOf", `{"account": "Alice"}`)
+	tx.FunctionSignature = "wrong"
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD210016")
+
+	tx = newTestCall(constants.TOKEN_ANON, "balanceOf", `{"account": "Alice"}`)
+	tx.ContractInfo.ContractAddress = "0x1234"
+	_, err = z.ExecCall(ctx, &pb.ExecCallRequest{Transaction: tx})
+	assert.ErrorContains(t, err, "PD210017")
+}
+
+func TestCallBadParams(t *testing.T) {
+	z, _ := newTestCallZeto()
+	ctx := context.Background()
+
+	_, err := z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_ANON, "balanceOf", "!!wrong")})
+	assert.ErrorContains(t, err, "PD210015")
+
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_ANON, "balanceOf", `{}`)})
+	assert.Regexp(t, "PD210015.*PD210136.*account", err)
+
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_NF_ANON, "ownerOf", `{}`)})
+	assert.Regexp(t, "PD210015.*PD210136.*tokenID", err)
+
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_NF_ANON, "tokensOf", `{}`)})
+	assert.Regexp(t, "PD210015.*PD210136.*account", err)
+
+	_, err = z.InitCall(ctx, &pb.InitCallRequest{Transaction: newTestCall(constants.TOKEN_ANON_NULLIFIER, "getMerkleProof", `{}`)})
+	assert.Regexp(t, "PD210015.*PD210136.*utxo", err)
+}
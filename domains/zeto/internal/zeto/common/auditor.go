@@ -0,0 +1,243 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/hyperledger-labs/zeto/go-sdk/pkg/crypto"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// AuditorCiphertext is a second encryption of the values of a transfer, for the configured auditor.
+// Unlike the ciphertext for the receivers, it is not verified by the circuit - so the decrypted values
+// must be checked against the UTXO commitments on the base ledger before they are trusted.
+type AuditorCiphertext struct {
+	EcdhPublicKey   []*pldtypes.HexUint256 `json:"ecdhPublicKey"`
+	EncryptionNonce *pldtypes.HexUint256   `json:"encryptionNonce"`
+	EncryptedValues []*pldtypes.HexUint256 `json:"encryptedValues"`
+}
+
+// AuditorCiphertextABI is appended to the transaction ID in V1 of the Zeto transaction data
+var AuditorCiphertextABI = abi.ParameterArray{
+	{Name: "ecdhPublicKey", Type: "uint256[2]"},
+	{Name: "encryptionNonce", Type: "uint256"},
+	{Name: "encryptedValues", Type: "uint256[]"},
+}
+
+type AuditedCoin struct {
+	Amount *big.Int
+	Salt   *big.Int
+	Owner  *babyjub.PublicKey
+}
+
+// Commitment is the UTXO hash of the coin, as it appears on the base ledger
+func (c *AuditedCoin) Commitment() (*big.Int, error) {
+	return poseidon.Hash([]*big.Int{c.Amount, c.Salt, c.Owner.X, c.Owner.Y})
+}
+
+type AuditedValues struct {
+	Sender  *babyjub.PublicKey
+	Inputs  []*AuditedCoin
+	Outputs []*AuditedCoin
+}
+
+// The plaintext is the public key of the sender, then the amount and salt of each input
+// (all owned by the sender), then the amount, salt and owner public key of each output.
+// The inputs and outputs are padded with zeros to the size of the circuit.
+func auditorPlaintextLength(size int) int {
+	return 2 + size*2 + size*4
+}
+
+// EncryptForAuditor encrypts the values of a transfer with a shared secret derived, using ECDH,
+// from the public key of the auditor and a fresh ephemeral key pair for each transfer.
+func EncryptForAuditor(ctx context.Context, auditor, sender *babyjub.PublicKey, inputs, outputs []*types.ZetoCoin, size int) (*AuditorCiphertext, error) {
+	plaintext := make([]*big.Int, auditorPlaintextLength(size))
+	for i := range plaintext {
+		plaintext[i] = big.NewInt(0)
+	}
+	plaintext[0], plaintext[1] = sender.X, sender.Y
+	for i, coin := range inputs {
+		plaintext[2+i*2] = coin.Amount.Int()
+		plaintext[2+i*2+1] = coin.Salt.Int()
+	}
+	outputsStart := 2 + size*2
+	for i, coin := range outputs {
+		owner, err := LoadBabyJubKey([]byte(coin.Owner.String()))
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgErrorLoadOwnerPubKey, err)
+		}
+		plaintext[outputsStart+i*4] = coin.Amount.Int()
+		plaintext[outputsStart+i*4+1] = coin.Salt.Int()
+		plaintext[outputsStart+i*4+2] = owner.X
+		plaintext[outputsStart+i*4+3] = owner.Y
+	}
+
+	ephemeralKey := babyjub.NewRandPrivKey()
+	sharedSecret := babyjub.NewPoint().Mul(babyjub.SkToBigInt(&ephemeralKey), auditor.Point())
+	nonce := crypto.NewEncryptionNonce()
+	encrypted, err := crypto.PoseidonEncrypt(plaintext, []*big.Int{sharedSecret.X, sharedSecret.Y}, nonce)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorEncryptForAuditor, err)
+	}
+
+	ephemeralPublicKey := ephemeralKey.Public()
+	ciphertext := &AuditorCiphertext{
+		EcdhPublicKey: []*pldtypes.HexUint256{
+			(*pldtypes.HexUint256)(ephemeralPublicKey.X),
+			(*pldtypes.HexUint256)(ephemeralPublicKey.Y),
+		},
+		EncryptionNonce: (*pldtypes.HexUint256)(nonce),
+		EncryptedValues: make([]*pldtypes.HexUint256, len(encrypted)),
+	}
+	for i, v := range encrypted {
+		ciphertext.EncryptedValues[i] = (*pldtypes.HexUint256)(v)
+	}
+	return ciphertext, nil
+}
+
+// AuditorViewingKey is the lookup for the key the auditor decrypts with. It is a dedicated key in the
+// hierarchy of the auditor identity, rather than the key the auditor uses to own and spend tokens.
+func AuditorViewingKey(auditor string) string {
+	identity, node, qualified := strings.Cut(auditor, "@")
+	if !qualified {
+		return identity + ".viewing"
+	}
+	return identity + ".viewing@" + node
+}
+
+// EncodeECDHPoint encodes a point on the Baby Jub Jub curve for an ECDH payload or result,
+// as the X and Y coordinates in 32 byte big-endian form
+func EncodeECDHPoint(p *babyjub.Point) []byte {
+	return append(IntTo32ByteSlice(p.X), IntTo32ByteSlice(p.Y)...)
+}
+
+func DecodeECDHPoint(ctx context.Context, b []byte) (*babyjub.Point, error) {
+	if len(b) != 64 {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorInvalidECDHPoint)
+	}
+	p := &babyjub.Point{X: new(big.Int).SetBytes(b[0:32]), Y: new(big.Int).SetBytes(b[32:64])}
+	if !p.InCurve() || !p.InSubGroup() {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorInvalidECDHPoint)
+	}
+	return p, nil
+}
+
+// ECDHSharedSecret derives the shared secret for a private key, and an encoded public key of the other party
+func ECDHSharedSecret(ctx context.Context, privateKey *babyjub.PrivateKey, publicKey []byte) (*babyjub.Point, error) {
+	p, err := DecodeECDHPoint(ctx, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return babyjub.NewPoint().Mul(babyjub.SkToBigInt(privateKey), p), nil
+}
+
+// AuditRecordECDHPublicKey is the encoded ephemeral public key of a transfer, for which the auditor
+// must provide the ECDH shared secret to decrypt the record
+func AuditRecordECDHPublicKey(ctx context.Context, record *types.AuditRecord) ([]byte, error) {
+	if len(record.EcdhPublicKey) != 2 {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecryptAuditRecord, record.TransactionID, "invalid ECDH public key")
+	}
+	return EncodeECDHPoint(&babyjub.Point{X: record.EcdhPublicKey[0].Int(), Y: record.EcdhPublicKey[1].Int()}), nil
+}
+
+// DecryptAuditRecord decrypts the values of a transfer, with the ECDH shared secret of the auditor's
+// viewing key and the ephemeral public key of the transfer
+func DecryptAuditRecord(ctx context.Context, sharedSecret *babyjub.Point, record *types.AuditRecord) (*AuditedValues, error) {
+	txID := record.TransactionID.String()
+	size := len(record.Inputs)
+	encrypted := make([]*big.Int, len(record.EncryptedValues))
+	for i := range record.EncryptedValues {
+		encrypted[i] = record.EncryptedValues[i].Int()
+	}
+	plaintext, err := crypto.PoseidonDecrypt(encrypted, []*big.Int{sharedSecret.X, sharedSecret.Y}, record.EncryptionNonce.Int(), auditorPlaintextLength(size))
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecryptAuditRecord, txID, err)
+	}
+
+	sender := &babyjub.PublicKey{X: plaintext[0], Y: plaintext[1]}
+	values := &AuditedValues{
+		Sender:  sender,
+		Inputs:  make([]*AuditedCoin, size),
+		Outputs: make([]*AuditedCoin, size),
+	}
+	outputsStart := 2 + size*2
+	for i := 0; i < size; i++ {
+		values.Inputs[i] = &AuditedCoin{
+			Amount: plaintext[2+i*2],
+			Salt:   plaintext[2+i*2+1],
+			Owner:  sender,
+		}
+		values.Outputs[i] = &AuditedCoin{
+			Amount: plaintext[outputsStart+i*4],
+			Salt:   plaintext[outputsStart+i*4+1],
+			Owner:  &babyjub.PublicKey{X: plaintext[outputsStart+i*4+2], Y: plaintext[outputsStart+i*4+3]},
+		}
+	}
+	return values, nil
+}
+
+// EncodeTransactionDataWithAuditor builds V1 of the transaction data, which carries the auditor ciphertext
+// after the transaction ID, so that it is included in the event emitted by the base ledger contract
+func EncodeTransactionDataWithAuditor(ctx context.Context, transaction *prototk.TransactionSpecification, ciphertext *AuditorCiphertext) (pldtypes.HexBytes, error) {
+	txID, err := pldtypes.ParseBytes32Ctx(ctx, transaction.TransactionId)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorParseTxId, err)
+	}
+	ciphertextJSON, err := json.Marshal(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := AuditorCiphertextABI.EncodeABIDataJSONCtx(ctx, ciphertextJSON)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorEncryptForAuditor, err)
+	}
+	var data []byte
+	data = append(data, types.ZetoTransactionData_V1...)
+	data = append(data, txID[:]...)
+	data = append(data, encoded...)
+	return data, nil
+}
+
+// DecodeAuditorCiphertext returns the auditor ciphertext from V1 transaction data, or nil for any other version
+func DecodeAuditorCiphertext(ctx context.Context, data pldtypes.HexBytes) (*AuditorCiphertext, error) {
+	if len(data) < 36 || data[0:4].String() != types.ZetoTransactionData_V1.String() {
+		return nil, nil
+	}
+	values, err := AuditorCiphertextABI.DecodeABIDataCtx(ctx, data[36:], 0)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecodeAuditorData, err)
+	}
+	valuesJSON, err := pldtypes.StandardABISerializer().SerializeJSON(values)
+	if err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecodeAuditorData, err)
+	}
+	var ciphertext AuditorCiphertext
+	if err := json.Unmarshal(valuesJSON, &ciphertext); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecodeAuditorData, err)
+	}
+	return &ciphertext, nil
+}
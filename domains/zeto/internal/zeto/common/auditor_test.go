@@ -0,0 +1,148 @@
+package common
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCoin(t *testing.T, amount int64, owner *babyjub.PublicKey) *types.ZetoCoin {
+	salt, err := CryptoRandBN254()
+	require.NoError(t, err)
+	return &types.ZetoCoin{
+		Salt:   (*pldtypes.HexUint256)(salt),
+		Owner:  pldtypes.MustParseHexBytes(zetosigner.EncodeBabyJubJubPublicKey(owner)),
+		Amount: pldtypes.Uint64ToUint256(uint64(amount)),
+	}
+}
+
+func TestAuditorEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	auditorKey := babyjub.NewRandPrivKey()
+	senderKey := babyjub.NewRandPrivKey()
+	receiverKey := babyjub.NewRandPrivKey()
+
+	inputs := []*types.ZetoCoin{newTestCoin(t, 15, senderKey.Public())}
+	outputs := []*types.ZetoCoin{newTestCoin(t, 10, receiverKey.Public()), newTestCoin(t, 5, senderKey.Public())}
+	ciphertext, err := EncryptForAuditor(ctx, auditorKey.Public(), senderKey.Public(), inputs, outputs, 2)
+	require.NoError(t, err)
+	assert.Len(t, ciphertext.EcdhPublicKey, 2)
+
+	input0, err := inputs[0].Hash(ctx)
+	require.NoError(t, err)
+	record := &types.AuditRecord{
+		TransactionID:   pldtypes.RandBytes32(),
+		Inputs:          []pldtypes.HexUint256{*input0, *pldtypes.Uint64ToUint256(0)},
+		EncryptionNonce: *ciphertext.EncryptionNonce,
+	}
+	for _, v := range ciphertext.EcdhPublicKey {
+		record.EcdhPublicKey = append(record.EcdhPublicKey, *v)
+	}
+	for _, v := range ciphertext.EncryptedValues {
+		record.EncryptedValues = append(record.EncryptedValues, *v)
+	}
+
+	// the auditor derives the shared secret from the ephemeral public key in the record
+	ecdhPublicKey, err := AuditRecordECDHPublicKey(ctx, record)
+	require.NoError(t, err)
+	sharedSecret, err := ECDHSharedSecret(ctx, &auditorKey, ecdhPublicKey)
+	require.NoError(t, err)
+	values, err := DecryptAuditRecord(ctx, sharedSecret, record)
+	require.NoError(t, err)
+	assert.Equal(t, senderKey.Public().X.String(), values.Sender.X.String())
+	assert.Equal(t, senderKey.Public().Y.String(), values.Sender.Y.String())
+	require.Len(t, values.Inputs, 2)
+	commitment, err := values.Inputs[0].Commitment()
+	require.NoError(t, err)
+	assert.Equal(t, input0.Int().String(), commitment.String())
+	assert.Equal(t, int64(0), values.Inputs[1].Amount.Int64())
+	require.Len(t, values.Outputs, 2)
+	assert.Equal(t, int64(10), values.Outputs[0].Amount.Int64())
+	output0, err := outputs[0].Hash(ctx)
+	require.NoError(t, err)
+	commitment, err = values.Outputs[0].Commitment()
+	require.NoError(t, err)
+	assert.Equal(t, output0.Int().String(), commitment.String())
+
+	// a different key cannot decrypt the values
+	otherKey := babyjub.NewRandPrivKey()
+	sharedSecret, err = ECDHSharedSecret(ctx, &otherKey, ecdhPublicKey)
+	require.NoError(t, err)
+	_, err = DecryptAuditRecord(ctx, sharedSecret, record)
+	assert.ErrorContains(t, err, "PD210146")
+
+	record.EcdhPublicKey = nil
+	_, err = AuditRecordECDHPublicKey(ctx, record)
+	assert.ErrorContains(t, err, "PD210146")
+}
+
+func TestAuditorViewingKey(t *testing.T) {
+	assert.Equal(t, "auditor.viewing@node1", AuditorViewingKey("auditor@node1"))
+	assert.Equal(t, "org.auditor.viewing", AuditorViewingKey("org.auditor"))
+}
+
+func TestECDHSharedSecretInvalidPoint(t *testing.T) {
+	ctx := context.Background()
+	key := babyjub.NewRandPrivKey()
+
+	_, err := ECDHSharedSecret(ctx, &key, []byte{0x01})
+	assert.ErrorContains(t, err, "PD210150")
+
+	_, err = ECDHSharedSecret(ctx, &key, EncodeECDHPoint(&babyjub.Point{X: big.NewInt(1), Y: big.NewInt(2)}))
+	assert.ErrorContains(t, err, "PD210150")
+
+	// both parties derive the same secret
+	other := babyjub.NewRandPrivKey()
+	s1, err := ECDHSharedSecret(ctx, &key, EncodeECDHPoint(other.Public().Point()))
+	require.NoError(t, err)
+	s2, err := ECDHSharedSecret(ctx, &other, EncodeECDHPoint(key.Public().Point()))
+	require.NoError(t, err)
+	assert.Equal(t, EncodeECDHPoint(s1), EncodeECDHPoint(s2))
+}
+
+func TestEncryptForAuditorBadOwner(t *testing.T) {
+	ctx := context.Background()
+	auditorKey := babyjub.NewRandPrivKey()
+	outputs := []*types.ZetoCoin{{Owner: pldtypes.MustParseHexBytes("0x1234"), Amount: pldtypes.Uint64ToUint256(1), Salt: pldtypes.Uint64ToUint256(1)}}
+	_, err := EncryptForAuditor(ctx, auditorKey.Public(), auditorKey.Public(), nil, outputs, 2)
+	assert.ErrorContains(t, err, "PD210037")
+}
+
+func TestTransactionDataWithAuditor(t *testing.T) {
+	ctx := context.Background()
+	ciphertext := &AuditorCiphertext{
+		EcdhPublicKey:   []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(1), pldtypes.Uint64ToUint256(2)},
+		EncryptionNonce: pldtypes.Uint64ToUint256(3),
+		EncryptedValues: []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(4), pldtypes.Uint64ToUint256(5)},
+	}
+	_, err := EncodeTransactionDataWithAuditor(ctx, &prototk.TransactionSpecification{TransactionId: "bad hex"}, ciphertext)
+	assert.ErrorContains(t, err, "PD210028")
+
+	txID := "0x1234567890123456789012345678901234567890123456789012345678901234"
+	data, err := EncodeTransactionDataWithAuditor(ctx, &prototk.TransactionSpecification{TransactionId: txID}, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "0x00020000"+txID[2:], data[0:36].String())
+
+	decoded, err := DecodeAuditorCiphertext(ctx, data)
+	require.NoError(t, err)
+	assert.JSONEq(t, pldtypes.JSONString(ciphertext).String(), pldtypes.JSONString(decoded).String())
+
+	// V0 data does not carry a ciphertext
+	data, err = EncodeTransactionData(ctx, &prototk.TransactionSpecification{TransactionId: txID})
+	require.NoError(t, err)
+	decoded, err = DecodeAuditorCiphertext(ctx, data)
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	data = append(pldtypes.MustParseHexBytes("0x00020000"+txID[2:]), 0x01)
+	_, err = DecodeAuditorCiphertext(ctx, data)
+	assert.ErrorContains(t, err, "PD210148")
+}
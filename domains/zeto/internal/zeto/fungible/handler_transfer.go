@@ -40,6 +40,7 @@ var _ types.DomainHandler = &transferHandler{}
 type transferHandler struct {
 	baseHandler
 	callbacks plugintk.DomainCallbacks
+	auditor   string
 }
 
 var transferABI = &abi.Entry{
@@ -79,7 +80,7 @@ var transferABI_withEncryption = &abi.Entry{
 	},
 }
 
-func NewTransferHandler(name string, callbacks plugintk.DomainCallbacks, coinSchema, merkleTreeRootSchema, merkleTreeNodeSchema *pb.StateSchema) *transferHandler {
+func NewTransferHandler(name string, callbacks plugintk.DomainCallbacks, coinSchema, merkleTreeRootSchema, merkleTreeNodeSchema *pb.StateSchema, auditor string) *transferHandler {
 	return &transferHandler{
 		baseHandler: baseHandler{
 			name: name,
@@ -90,6 +91,7 @@ func NewTransferHandler(name string, callbacks plugintk.DomainCallbacks, coinSch
 			},
 		},
 		callbacks: callbacks,
+		auditor:   auditor,
 	}
 }
 
@@ -125,6 +127,13 @@ func (h *transferHandler) Init(ctx context.Context, tx *types.ParsedTransaction,
 			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
 		})
 	}
+	if h.usesAuditor(tx) {
+		res.RequiredVerifiers = append(res.RequiredVerifiers, &pb.ResolveVerifierRequest{
+			Lookup:       common.AuditorViewingKey(h.auditor),
+			Algorithm:    h.getAlgoZetoSnarkBJJ(),
+			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+		})
+	}
 
 	return res, nil
 }
@@ -172,11 +181,21 @@ func (h *transferHandler) Assemble(ctx context.Context, tx *types.ParsedTransact
 		return nil, i18n.NewError(ctx, msgs.MsgErrorFormatProvingReq, err)
 	}
 
+	var domainData *string
+	if h.usesAuditor(tx) {
+		auditorData, err := h.encryptForAuditor(ctx, resolvedSender.Verifier, inputCoins, outputCoins, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+		domainData = &auditorData
+	}
+
 	return &pb.AssembleTransactionResponse{
 		AssemblyResult: pb.AssembleTransactionResponse_OK,
 		AssembledTransaction: &pb.AssembledTransaction{
 			InputStates:  inputStates,
 			OutputStates: outputStates,
+			DomainData:   domainData,
 		},
 		AttestationPlan: []*pb.AttestationRequest{
 			{
@@ -216,7 +235,7 @@ func (h *transferHandler) Prepare(ctx context.Context, tx *types.ParsedTransacti
 		return nil, err
 	}
 
-	data, err := common.EncodeTransactionData(ctx, req.Transaction)
+	data, err := h.encodeTransactionData(ctx, req)
 	if err != nil {
 		return nil, i18n.NewError(ctx, msgs.MsgErrorEncodeTxData, err)
 	}
@@ -261,6 +280,49 @@ func (h *transferHandler) Prepare(ctx context.Context, tx *types.ParsedTransacti
 	}, nil
 }
 
+// The auditor receives its own ciphertext of every transfer of a token that encrypts values
+func (h *transferHandler) usesAuditor(tx *types.ParsedTransaction) bool {
+	return h.auditor != "" && common.IsEncryptionToken(tx.DomainConfig.TokenName)
+}
+
+func (h *transferHandler) encryptForAuditor(ctx context.Context, senderVerifier string, inputCoins, outputCoins []*types.ZetoCoin, verifiers []*pb.ResolvedVerifier) (string, error) {
+	viewingKey := common.AuditorViewingKey(h.auditor)
+	resolvedAuditor := domain.FindVerifier(viewingKey, h.getAlgoZetoSnarkBJJ(), zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X, verifiers)
+	if resolvedAuditor == nil {
+		return "", i18n.NewError(ctx, msgs.MsgErrorResolveVerifier, viewingKey)
+	}
+	auditorKey, err := common.LoadBabyJubKey([]byte(resolvedAuditor.Verifier))
+	if err != nil {
+		return "", i18n.NewError(ctx, msgs.MsgErrorLoadOwnerPubKey, err)
+	}
+	senderKey, err := common.LoadBabyJubKey([]byte(senderVerifier))
+	if err != nil {
+		return "", i18n.NewError(ctx, msgs.MsgErrorLoadOwnerPubKey, err)
+	}
+	size := common.GetInputSize(len(inputCoins))
+	ciphertext, err := common.EncryptForAuditor(ctx, auditorKey, senderKey, inputCoins, outputCoins, size)
+	if err != nil {
+		return "", err
+	}
+	ciphertextJSON, err := json.Marshal(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(ciphertextJSON), nil
+}
+
+// The auditor ciphertext produced during assembly is carried to the base ledger in the transaction data
+func (h *transferHandler) encodeTransactionData(ctx context.Context, req *pb.PrepareTransactionRequest) (pldtypes.HexBytes, error) {
+	if req.DomainData == nil {
+		return common.EncodeTransactionData(ctx, req.Transaction)
+	}
+	var ciphertext common.AuditorCiphertext
+	if err := json.Unmarshal([]byte(*req.DomainData), &ciphertext); err != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgErrorDecodeAuditorData, err)
+	}
+	return common.EncodeTransactionDataWithAuditor(ctx, req.Transaction, &ciphertext)
+}
+
 func getTransferABI(tokenName string) *abi.Entry {
 	transferFunction := transferABI
 	if common.IsEncryptionToken(tokenName) {
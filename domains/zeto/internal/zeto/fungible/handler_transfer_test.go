@@ -32,6 +32,7 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -587,3 +588,129 @@ func (dc *testDomainCallbacks) LocalNodeName(context.Context, *pb.LocalNodeNameR
 func (dc *testDomainCallbacks) SendTransaction(ctx context.Context, tx *pb.SendTransactionRequest) (*pb.SendTransactionResponse, error) {
 	return nil, nil
 }
+
+func TestTransferWithAuditor(t *testing.T) {
+	h := NewTransferHandler("test1", nil, &pb.StateSchema{Id: "coin"}, &pb.StateSchema{Id: "merkle_tree_root"}, &pb.StateSchema{Id: "merkle_tree_node"}, "Auditor")
+	ctx := context.Background()
+	txSpec := &pb.TransactionSpecification{
+		From:          "Bob",
+		TransactionId: "0x1234567890123456789012345678901234567890123456789012345678901234",
+		ContractInfo: &pb.ContractInfo{
+			ContractAddress: "0x1234567890123456789012345678901234567890",
+		},
+	}
+	tx := &types.ParsedTransaction{
+		Params: []*types.FungibleTransferParamEntry{
+			{
+				To:     "Alice",
+				Amount: pldtypes.MustParseHexUint256("0x09"),
+			},
+		},
+		Transaction: txSpec,
+		DomainConfig: &types.DomainInstanceConfig{
+			TokenName: constants.TOKEN_ANON_ENC,
+			Circuits: &zetosignerapi.Circuits{
+				"transfer": &zetosignerapi.Circuit{Name: "anon_enc", Type: "transfer", UsesEncryption: true},
+			},
+		},
+	}
+
+	initRes, err := h.Init(ctx, tx, nil)
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 3)
+	assert.Equal(t, "Auditor.viewing", initRes.RequiredVerifiers[2].Lookup)
+
+	verifier := func(lookup, key string) *pb.ResolvedVerifier {
+		return &pb.ResolvedVerifier{
+			Lookup:       lookup,
+			Verifier:     key,
+			Algorithm:    h.getAlgoZetoSnarkBJJ(),
+			VerifierType: zetosignerapi.IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X,
+		}
+	}
+	req := &pb.AssembleTransactionRequest{
+		ResolvedVerifiers: []*pb.ResolvedVerifier{
+			verifier("Alice", "0x19d2ee6b9770a4f8d7c3b7906bc7595684509166fa42d718d1d880b62bcb7922"),
+			verifier("Bob", "0x7cdd539f3ed6c283494f47d8481f84308a6d7043087fb6711c9f1df04e2b8025"),
+		},
+		Transaction: txSpec,
+	}
+	h.callbacks = &domain.MockDomainCallbacks{
+		MockFindAvailableStates: func() (*pb.FindAvailableStatesResponse, error) {
+			return &pb.FindAvailableStatesResponse{
+				States: []*pb.StoredState{
+					{
+						DataJson: "{\"salt\":\"0x042fac32983b19d76425cc54dd80e8a198f5d477c6a327cb286eb81a0c2b95ec\",\"owner\":\"0x7cdd539f3ed6c283494f47d8481f84308a6d7043087fb6711c9f1df04e2b8025\",\"amount\":\"0x0f\"}",
+					},
+				},
+			}, nil
+		},
+	}
+	_, err = h.Assemble(ctx, tx, req)
+	assert.EqualError(t, err, "PD210036: Failed to resolve verifier: Auditor.viewing")
+
+	req.ResolvedVerifiers = append(req.ResolvedVerifiers, verifier("Auditor.viewing", "0x1234"))
+	_, err = h.Assemble(ctx, tx, req)
+	assert.ErrorContains(t, err, "PD210037")
+
+	req.ResolvedVerifiers[2].Verifier = "0x19d2ee6b9770a4f8d7c3b7906bc7595684509166fa42d718d1d880b62bcb7922"
+	res, err := h.Assemble(ctx, tx, req)
+	require.NoError(t, err)
+	require.NotNil(t, res.AssembledTransaction.DomainData)
+	var ciphertext common.AuditorCiphertext
+	err = json.Unmarshal([]byte(*res.AssembledTransaction.DomainData), &ciphertext)
+	require.NoError(t, err)
+	assert.Len(t, ciphertext.EcdhPublicKey, 2)
+
+	prepareReq := &pb.PrepareTransactionRequest{
+		InputStates: []*pb.EndorsableState{
+			{
+				SchemaId:      "coin",
+				StateDataJson: "{\"salt\":\"0x042fac32983b19d76425cc54dd80e8a198f5d477c6a327cb286eb81a0c2b95ec\",\"owner\":\"0x7cdd539f3ed6c283494f47d8481f84308a6d7043087fb6711c9f1df04e2b8025\",\"amount\":\"0x0f\"}",
+			},
+		},
+		OutputStates: []*pb.EndorsableState{
+			{
+				SchemaId:      "coin",
+				StateDataJson: res.AssembledTransaction.OutputStates[0].StateDataJson,
+			},
+		},
+		Transaction: txSpec,
+		DomainData:  res.AssembledTransaction.DomainData,
+	}
+	proofRes := corepb.ProvingResponse{
+		Proof: &corepb.SnarkProof{
+			A: []string{"0x1234567890", "0x1234567890"},
+			B: []*corepb.B_Item{
+				{Items: []string{"0x1234567890", "0x1234567890"}},
+				{Items: []string{"0x1234567890", "0x1234567890"}},
+			},
+			C: []string{"0x1234567890", "0x1234567890"},
+		},
+		PublicInputs: map[string]string{
+			"ecdhPublicKey":   "0x1234567890,0x1234567890",
+			"encryptionNonce": "0x1234567890",
+			"encryptedValues": "0x1234567890,0x1234567890",
+		},
+	}
+	payload, err := proto.Marshal(&proofRes)
+	require.NoError(t, err)
+	prepareReq.AttestationResult = []*pb.AttestationResult{
+		{
+			Name:            "sender",
+			AttestationType: pb.AttestationType_SIGN,
+			Payload:         payload,
+		},
+	}
+	prepareRes, err := h.Prepare(ctx, tx, prepareReq)
+	require.NoError(t, err)
+	var params map[string]any
+	err = json.Unmarshal([]byte(prepareRes.Transaction.ParamsJson), &params)
+	require.NoError(t, err)
+	assert.Regexp(t, "^0x000200001234567890123456789012345678901234567890123456789012345678901234", params["data"])
+
+	badData := "bad json"
+	prepareReq.DomainData = &badData
+	_, err = h.Prepare(ctx, tx, prepareReq)
+	assert.ErrorContains(t, err, "PD210049: Failed to encode transaction data. PD210148")
+}
@@ -88,6 +88,13 @@ func (z *Zeto) handleTransferWithEncryptionEvent(ctx context.Context, smtTree *m
 				return i18n.NewError(ctx, msgs.MsgErrorUpdateSMT, "UTXOTransferWithEncryptedValues", err)
 			}
 		}
+		auditRecord, err := z.newAuditRecordState(ctx, txID, &transfer)
+		if err != nil {
+			return err
+		}
+		if auditRecord != nil {
+			res.NewStates = append(res.NewStates, auditRecord)
+		}
 	} else {
 		log.L(ctx).Errorf("Failed to unmarshal transfer event: %s", err)
 	}
@@ -200,13 +207,56 @@ func formatErrors(errors []string) string {
 	}
 	return msg
 }
+
+// The auditor ciphertext of a transfer is recorded as a state alongside the UTXOs of the event,
+// so that the configured auditor can later decrypt the transfer history
+func (z *Zeto) newAuditRecordState(ctx context.Context, txID pldtypes.HexBytes, transfer *TransferWithEncryptedValuesEvent) (*prototk.NewConfirmedState, error) {
+	ciphertext, err := common.DecodeAuditorCiphertext(ctx, transfer.Data)
+	if err != nil || ciphertext == nil {
+		return nil, err
+	}
+	record := &types.AuditRecord{
+		TransactionID:   pldtypes.Bytes32(txID),
+		Inputs:          transfer.Inputs,
+		Outputs:         transfer.Outputs,
+		EncryptionNonce: *ciphertext.EncryptionNonce,
+	}
+	for _, v := range ciphertext.EcdhPublicKey {
+		record.EcdhPublicKey = append(record.EcdhPublicKey, *v)
+	}
+	for _, v := range ciphertext.EncryptedValues {
+		record.EncryptedValues = append(record.EncryptedValues, *v)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := record.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.NewConfirmedState{
+		Id:            &hash,
+		SchemaId:      z.auditRecordSchema.Id,
+		StateDataJson: string(data),
+		TransactionId: txID.String(),
+	}, nil
+}
+
 func decodeTransactionData(data pldtypes.HexBytes) (txID pldtypes.HexBytes) {
 	if len(data) < 4 {
 		return nil
 	}
 	dataPrefix := data[0:4]
-	if dataPrefix.String() != types.ZetoTransactionData_V0.String() {
-		return nil
+	switch dataPrefix.String() {
+	case types.ZetoTransactionData_V0.String():
+		return data[4:]
+	case types.ZetoTransactionData_V1.String():
+		// the transaction ID is followed by the auditor ciphertext
+		if len(data) < 36 {
+			return nil
+		}
+		return data[4:36]
 	}
-	return data[4:]
+	return nil
 }
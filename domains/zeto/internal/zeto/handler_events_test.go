@@ -17,9 +17,13 @@ package zeto
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/smt"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/constants"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
@@ -167,6 +171,52 @@ func TestHandleTransferWithEncryptionEvent(t *testing.T) {
 	assert.Equal(t, "0x30e43028afbb41d6887444f4c2b4ed6d00000000000000000000000000000000", res.TransactionsComplete[0].TransactionId)
 }
 
+func TestHandleTransferWithEncryptionEventAuditRecord(t *testing.T) {
+	z, _ := newTestZeto()
+	z.auditRecordSchema = &prototk.StateSchema{Id: "audit_record"}
+	ctx := context.Background()
+
+	txID := "0x30e43028afbb41d6887444f4c2b4ed6d00000000000000000000000000000000"
+	data, err := common.EncodeTransactionDataWithAuditor(ctx, &prototk.TransactionSpecification{TransactionId: txID}, &common.AuditorCiphertext{
+		EcdhPublicKey:   []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(1), pldtypes.Uint64ToUint256(2)},
+		EncryptionNonce: pldtypes.Uint64ToUint256(3),
+		EncryptedValues: []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(4), pldtypes.Uint64ToUint256(5)},
+	})
+	require.NoError(t, err)
+	ev := &prototk.OnChainEvent{
+		DataJson: pldtypes.JSONString(map[string]any{
+			"data":    data,
+			"inputs":  []string{"0x01", "0"},
+			"outputs": []string{"0x02", "0x03"},
+		}).String(),
+	}
+	res := &prototk.HandleEventBatchResponse{}
+	err = z.handleTransferWithEncryptionEvent(ctx, nil, ev, constants.TOKEN_ANON_ENC, res)
+	require.NoError(t, err)
+	require.Len(t, res.TransactionsComplete, 1)
+	assert.Equal(t, txID, res.TransactionsComplete[0].TransactionId)
+	require.Len(t, res.NewStates, 1)
+	assert.Equal(t, "audit_record", res.NewStates[0].SchemaId)
+	assert.Equal(t, txID, res.NewStates[0].TransactionId)
+	var record types.AuditRecord
+	err = json.Unmarshal([]byte(res.NewStates[0].StateDataJson), &record)
+	require.NoError(t, err)
+	assert.Equal(t, txID, record.TransactionID.String())
+	assert.Len(t, record.Outputs, 2)
+	assert.Len(t, record.EncryptedValues, 2)
+	hash, err := record.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, *res.NewStates[0].Id)
+
+	// a V1 prefix with a ciphertext that cannot be decoded fails the event
+	ev.DataJson = pldtypes.JSONString(map[string]any{
+		"data":    data[0:40],
+		"outputs": []string{"0x02"},
+	}).String()
+	err = z.handleTransferWithEncryptionEvent(ctx, nil, ev, constants.TOKEN_ANON_ENC, res)
+	assert.ErrorContains(t, err, "PD210148")
+}
+
 func TestHandleLockedEvent(t *testing.T) {
 	z, testCallbacks := newTestZeto()
 	storage1 := smt.NewStatesStorage(testCallbacks, "testToken1", "context1", "merkle_tree_root", "merkle_tree_node")
@@ -0,0 +1,219 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package zeto
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"slices"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/zeto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+func (z *Zeto) BuildReceipt(ctx context.Context, req *prototk.BuildReceiptRequest) (res *prototk.BuildReceiptResponse, err error) {
+	receipt := &types.ZetoDomainReceipt{}
+
+	receipt.States.Inputs, receipt.States.LockedInputs, err = z.receiptStates(ctx, req.InputStates)
+	if err == nil {
+		receipt.States.Outputs, receipt.States.LockedOutputs, err = z.receiptStates(ctx, req.OutputStates)
+	}
+	if err == nil {
+		// read states are not separated by whether they are locked
+		var readLocked []*types.ReceiptState
+		receipt.States.ReadInputs, readLocked, err = z.receiptStates(ctx, req.ReadStates)
+		receipt.States.ReadInputs = append(receipt.States.ReadInputs, readLocked...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nullifier := range req.InputNullifiers {
+		id, err := pldtypes.ParseHexBytes(ctx, nullifier)
+		if err != nil {
+			return nil, err
+		}
+		receipt.Nullifiers = append(receipt.Nullifiers, id)
+	}
+
+	inputs := slices.Concat(receipt.States.Inputs, receipt.States.LockedInputs)
+	outputs := slices.Concat(receipt.States.Outputs, receipt.States.LockedOutputs)
+	receipt.Transfers = append(receiptTransfers(inputs, outputs), receiptNFTTransfers(inputs, outputs)...)
+
+	// The value entering or leaving the private balances can only be known if we have all the states
+	if req.Complete {
+		receipt.Deposit, receipt.Withdraw = receiptSupplyChange(inputs, outputs)
+	}
+
+	receiptJSON, err := json.Marshal(receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prototk.BuildReceiptResponse{
+		ReceiptJson: string(receiptJSON),
+	}, nil
+}
+
+// receiptStates decodes the coins and tokens in a list of states, skipping the merkle tree
+// and audit record states, and returns the unlocked and locked UTXOs separately
+func (z *Zeto) receiptStates(ctx context.Context, states []*prototk.EndorsableState) (unlocked, locked []*types.ReceiptState, err error) {
+	for _, state := range states {
+		var receiptState *types.ReceiptState
+		var isLocked bool
+		switch state.SchemaId {
+		case z.coinSchema.Id:
+			var coin types.ZetoCoin
+			if err := json.Unmarshal([]byte(state.StateDataJson), &coin); err != nil {
+				return nil, nil, i18n.NewError(ctx, msgs.MsgErrorUnmarshalStateData, err)
+			}
+			receiptState = &types.ReceiptState{Owner: coin.Owner, Amount: coin.Amount}
+			isLocked = coin.Locked
+		case z.nftSchema.Id:
+			var token types.ZetoNFToken
+			if err := json.Unmarshal([]byte(state.StateDataJson), &token); err != nil {
+				return nil, nil, i18n.NewError(ctx, msgs.MsgErrorUnmarshalStateData, err)
+			}
+			receiptState = &types.ReceiptState{Owner: token.Owner, TokenID: token.TokenID}
+		default:
+			continue
+		}
+		receiptState.ID, err = pldtypes.ParseHexBytes(ctx, state.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		receiptState.Data = pldtypes.RawJSON(state.StateDataJson)
+		if isLocked {
+			locked = append(locked, receiptState)
+		} else {
+			unlocked = append(unlocked, receiptState)
+		}
+	}
+	return unlocked, locked, nil
+}
+
+// receiptTransfers summarizes the movement of value between owners of fungible coins.
+// All the inputs of a Zeto transaction belong to the sender, so any value returned
+// to the sender is change - and value not sent to anyone else is a burn or withdraw.
+func receiptTransfers(inputs, outputs []*types.ReceiptState) []*types.ReceiptTransfer {
+	var from pldtypes.HexBytes
+	fromAmount := big.NewInt(0)
+	to := make(map[string]*big.Int)
+	var recipients []pldtypes.HexBytes // preserves output order
+
+	for _, coin := range inputs {
+		if coin.Amount == nil {
+			continue
+		}
+		if from == nil {
+			from = coin.Owner
+		} else if !from.Equals(coin.Owner) {
+			return nil
+		}
+		fromAmount.Add(fromAmount, coin.Amount.Int())
+	}
+	for _, coin := range outputs {
+		if coin.Amount == nil {
+			continue
+		}
+		owner := coin.Owner.String()
+		if from.Equals(coin.Owner) {
+			fromAmount.Sub(fromAmount, coin.Amount.Int())
+		} else if toAmount, ok := to[owner]; ok {
+			toAmount.Add(toAmount, coin.Amount.Int())
+		} else {
+			to[owner] = new(big.Int).Set(coin.Amount.Int())
+			recipients = append(recipients, coin.Owner)
+		}
+	}
+
+	if len(to) == 0 && from != nil && fromAmount.Sign() > 0 {
+		// special case for burn and withdraw (no recipients)
+		return []*types.ReceiptTransfer{{
+			From:   from,
+			Amount: (*pldtypes.HexUint256)(fromAmount),
+		}}
+	}
+
+	transfers := make([]*types.ReceiptTransfer, 0, len(to))
+	for _, owner := range recipients {
+		if amount := to[owner.String()]; amount.Sign() > 0 {
+			transfers = append(transfers, &types.ReceiptTransfer{
+				From:   from,
+				To:     owner,
+				Amount: (*pldtypes.HexUint256)(amount),
+			})
+		}
+	}
+	return transfers
+}
+
+// receiptNFTTransfers matches the non-fungible tokens in the inputs and outputs by token ID,
+// ignoring tokens that stay with the same owner (such as when they are locked)
+func receiptNFTTransfers(inputs, outputs []*types.ReceiptState) []*types.ReceiptTransfer {
+	var transfers []*types.ReceiptTransfer
+	matched := make(map[string]bool)
+	for _, output := range outputs {
+		if output.TokenID == nil {
+			continue
+		}
+		transfer := &types.ReceiptTransfer{To: output.Owner, TokenID: output.TokenID}
+		for _, input := range inputs {
+			if input.TokenID != nil && input.TokenID.Int().Cmp(output.TokenID.Int()) == 0 {
+				transfer.From = input.Owner
+				matched[input.TokenID.String()] = true
+				break
+			}
+		}
+		if !transfer.From.Equals(transfer.To) {
+			transfers = append(transfers, transfer)
+		}
+	}
+	for _, input := range inputs {
+		if input.TokenID != nil && !matched[input.TokenID.String()] {
+			// special case for burn (no output for the token)
+			transfers = append(transfers, &types.ReceiptTransfer{From: input.Owner, TokenID: input.TokenID})
+		}
+	}
+	return transfers
+}
+
+// receiptSupplyChange compares the total value of the fungible inputs and outputs. Zeto transfers
+// preserve value, so a difference is value that was minted or deposited, or burnt or withdrawn.
+func receiptSupplyChange(inputs, outputs []*types.ReceiptState) (deposit, withdraw *pldtypes.HexUint256) {
+	change := big.NewInt(0)
+	for _, coin := range outputs {
+		if coin.Amount != nil {
+			change.Add(change, coin.Amount.Int())
+		}
+	}
+	for _, coin := range inputs {
+		if coin.Amount != nil {
+			change.Sub(change, coin.Amount.Int())
+		}
+	}
+	switch change.Sign() {
+	case 1:
+		return (*pldtypes.HexUint256)(change), nil
+	case -1:
+		return nil, (*pldtypes.HexUint256)(change.Neg(change))
+	}
+	return nil, nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package zeto
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kaleido-io/paladin/domains/zeto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	receiptAlice = "0x19d2ee6b9770a4f8d7c3b7906bc7595684509166fa42d718d1d880b62bcb7922"
+	receiptBob   = "0x7cdd539f3ed6c283494f47d8481f84308a6d7043087fb6711c9f1df04e2b8025"
+)
+
+func newReceiptTestZeto() *Zeto {
+	z, _ := newTestZeto()
+	z.nftSchema = &pb.StateSchema{Id: "nft"}
+	return z
+}
+
+func receiptCoin(t *testing.T, owner string, amount uint64, locked bool) *pb.EndorsableState {
+	data, err := json.Marshal(&types.ZetoCoin{
+		Salt:   pldtypes.Uint64ToUint256(1),
+		Owner:  pldtypes.MustParseHexBytes(owner),
+		Amount: pldtypes.Uint64ToUint256(amount),
+		Locked: locked,
+	})
+	require.NoError(t, err)
+	return &pb.EndorsableState{Id: pldtypes.RandHex(32), SchemaId: "coin", StateDataJson: string(data)}
+}
+
+func receiptNFT(t *testing.T, owner string, tokenID uint64) *pb.EndorsableState {
+	data, err := json.Marshal(&types.ZetoNFToken{
+		Salt:    pldtypes.Uint64ToUint256(1),
+		URI:     "https://example.com/token",
+		Owner:   pldtypes.MustParseHexBytes(owner),
+		TokenID: pldtypes.Uint64ToUint256(tokenID),
+	})
+	require.NoError(t, err)
+	return &pb.EndorsableState{Id: pldtypes.RandHex(32), SchemaId: "nft", StateDataJson: string(data)}
+}
+
+func buildTestReceipt(t *testing.T, z *Zeto, req *pb.BuildReceiptRequest) *types.ZetoDomainReceipt {
+	res, err := z.BuildReceipt(context.Background(), req)
+	require.NoError(t, err)
+	var receipt types.ZetoDomainReceipt
+	err = json.Unmarshal([]byte(res.ReceiptJson), &receipt)
+	require.NoError(t, err)
+	return &receipt
+}
+
+func TestBuildReceiptTransfer(t *testing.T) {
+	z := newReceiptTestZeto()
+	nullifier := pldtypes.RandHex(32)
+	receipt := buildTestReceipt(t, z, &pb.BuildReceiptRequest{
+		Complete:    true,
+		InputStates: []*pb.EndorsableState{receiptCoin(t, receiptAlice, 10, false), receiptCoin(t, receiptAlice, 5, false)},
+		OutputStates: []*pb.EndorsableState{
+			receiptCoin(t, receiptBob, 12, false),
+			receiptCoin(t, receiptAlice, 3, false),
+			{Id: pldtypes.RandHex(32), SchemaId: "merkle_tree_node", StateDataJson: "{}"},
+		},
+		InputNullifiers: []string{nullifier},
+	})
+
+	require.Len(t, receipt.States.Inputs, 2)
+	assert.Equal(t, receiptAlice, receipt.States.Inputs[0].Owner.String())
+	assert.Equal(t, "10", receipt.States.Inputs[0].Amount.Int().String())
+	require.Len(t, receipt.States.Outputs, 2)
+	assert.Empty(t, receipt.States.LockedOutputs)
+	assert.Equal(t, []pldtypes.HexBytes{pldtypes.MustParseHexBytes(nullifier)}, receipt.Nullifiers)
+	require.Len(t, receipt.Transfers, 1)
+	assert.Equal(t, receiptAlice, receipt.Transfers[0].From.String())
+	assert.Equal(t, receiptBob, receipt.Transfers[0].To.String())
+	assert.Equal(t, "12", receipt.Transfers[0].Amount.Int().String())
+	assert.Nil(t, receipt.Deposit)
+	assert.Nil(t, receipt.Withdraw)
+}
+
+func TestBuildReceiptMintAndDeposit(t *testing.T) {
+	z := newReceiptTestZeto()
+	receipt := buildTestReceipt(t, z, &pb.BuildReceiptRequest{
+		Complete:     true,
+		OutputStates: []*pb.EndorsableState{receiptCoin(t, receiptAlice, 10, false), receiptCoin(t, receiptBob, 20, false)},
+	})
+	require.Len(t, receipt.Transfers, 2)
+	assert.Nil(t, receipt.Transfers[0].From)
+	assert.Equal(t, receiptAlice, receipt.Transfers[0].To.String())
+	assert.Equal(t, receiptBob, receipt.Transfers[1].To.String())
+	require.NotNil(t, receipt.Deposit)
+	assert.Equal(t, "30", receipt.Deposit.Int().String())
+	assert.Nil(t, receipt.Withdraw)
+
+	// without all the states, the deposit amount is not known
+	receipt = buildTestReceipt(t, z, &pb.BuildReceiptRequest{
+		OutputStates: []*pb.EndorsableState{receiptCoin(t, receiptBob, 20, false)},
+	})
+	assert.Nil(t, receipt.Deposit)
+}
+
+func TestBuildReceiptWithdraw(t *testing.T) {
+	z := newReceiptTestZeto()
+	receipt := buildTestReceipt(t, z, &pb.BuildReceiptRequest{
+		Complete:     true,
+		InputStates:  []*pb.EndorsableState{receiptCoin(t, receiptAlice, 15, false)},
+		OutputStates: []*pb.EndorsableState{receiptCoin(t, receiptAlice, 5, false)},
+	})
+	require.Len(t, receipt.Transfers, 1)
+	assert.Equal(t, receiptAlice, receipt.Transfers[0].From.String())
+	assert.Nil(t, receipt.Transfers[0].To)
+	assert.Equal(t, "10", receipt.Transfers[0].Amount.Int().String())
+	assert.Nil(t, receipt.Deposit)
+	require.NotNil(t, receipt.Withdraw)
+	assert.Equal(t, "10", receipt.Withdraw.Int().String())
+}
+
+func TestBuildReceiptLock(t *testing.T) {
+	z := newReceiptTestZeto()
+	input := receiptCoin(t, receiptAlice, 15, false)
+	receipt := buildTestReceipt(t, z, &pb.BuildReceiptRequest{
+		Complete:     true,
+		InputStates:  []*pb.EndorsableState{input},
+		ReadStates:   []*pb.EndorsableState{input},
+		OutputStates: []*pb.EndorsableState{receiptCoin(t, receiptAlice, 10, true), receiptCoin(t, receiptAlice, 5, false)},
+	})
+	assert.Len(t, receipt.States.Inputs, 1)
+	assert.Len(t, receipt.States.ReadInputs, 1)
+	assert.Len(t, receipt.States.Outputs, 1)
+	require.Len(t, receipt.States.LockedOutputs, 1)
+	assert.Equal(t, "10", receipt.States.LockedOutputs[0].Amount.Int().String())
+	assert.Empty(t, receipt.Transfers)
+	assert.Nil(t, receipt.Deposit)
+	assert.Nil(t, receipt.Withdraw)
+}
+
+func TestBuildReceiptNFT(t *testing.T) {
+	z := newReceiptTestZeto()
+
+	receipt := buildTestReceipt(t, z, &pb.BuildReceiptRequest{
+		Complete:     true,
+		InputStates:  []*pb.EndorsableState{receiptNFT(t, receiptAlice, 1)},
+		OutputStates: []*pb.EndorsableState{receiptNFT(t, receiptBob, 1)},
+	})
+	require.Len(t, receipt.States.Outputs, 1)
+	assert.Equal(t, "1", receipt.States.Outputs[0].TokenID.Int().String())
+	assert.Nil(t, receipt.States.Outputs[0].Amount)
+	require.Len(t, receipt.Transfers, 1)
+	assert.Equal(t, receiptAlice, receipt.Transfers[0].From.String())
+	assert.Equal(t, receiptBob, receipt.Transfers[0].To.String())
+	assert.Equal(t, "1", receipt.Transfers[0].TokenID.Int().String())
+	assert.Nil(t, receipt.Transfers[0].Amount)
+	assert.Nil(t, receipt.Deposit)
+
+	// burn
+	receipt = buildTestReceipt(t, z, &pb.BuildReceiptRequest{
+		Complete:    true,
+		InputStates: []*pb.EndorsableState{receiptNFT(t, receiptAlice, 2)},
+	})
+	require.Len(t, receipt.Transfers, 1)
+	assert.Equal(t, receiptAlice, receipt.Transfers[0].From.String())
+	assert.Nil(t, receipt.Transfers[0].To)
+	assert.Equal(t, "2", receipt.Transfers[0].TokenID.Int().String())
+}
+
+func TestBuildReceiptErrors(t *testing.T) {
+	z := newReceiptTestZeto()
+	ctx := context.Background()
+
+	_, err := z.BuildReceipt(ctx, &pb.BuildReceiptRequest{
+		InputStates: []*pb.EndorsableState{{Id: pldtypes.RandHex(32), SchemaId: "coin", StateDataJson: "bad json"}},
+	})
+	assert.ErrorContains(t, err, "PD210087")
+
+	_, err = z.BuildReceipt(ctx, &pb.BuildReceiptRequest{
+		OutputStates: []*pb.EndorsableState{{Id: pldtypes.RandHex(32), SchemaId: "nft", StateDataJson: "bad json"}},
+	})
+	assert.ErrorContains(t, err, "PD210087")
+
+	state := receiptCoin(t, receiptAlice, 1, false)
+	state.Id = "bad id"
+	_, err = z.BuildReceipt(ctx, &pb.BuildReceiptRequest{ReadStates: []*pb.EndorsableState{state}})
+	assert.ErrorContains(t, err, "PD020007")
+
+	_, err = z.BuildReceipt(ctx, &pb.BuildReceiptRequest{InputNullifiers: []string{"bad nullifier"}})
+	assert.ErrorContains(t, err, "PD020007")
+}
@@ -56,6 +56,7 @@ type Zeto struct {
 	nftSchema                *prototk.StateSchema
 	merkleTreeRootSchema     *prototk.StateSchema
 	merkleTreeNodeSchema     *prototk.StateSchema
+	auditRecordSchema        *prototk.StateSchema
 	mintSignature            string
 	transferSignature        string
 	transferWithEncSignature string
@@ -193,6 +194,7 @@ func (z *Zeto) InitDomain(ctx context.Context, req *prototk.InitDomainRequest) (
 	z.nftSchema = req.AbiStateSchemas[1]
 	z.merkleTreeRootSchema = req.AbiStateSchemas[2]
 	z.merkleTreeNodeSchema = req.AbiStateSchemas[3]
+	z.auditRecordSchema = req.AbiStateSchemas[4]
 
 	return &prototk.InitDomainResponse{}, nil
 }
@@ -314,6 +316,13 @@ func (z *Zeto) PrepareTransaction(ctx context.Context, req *prototk.PrepareTrans
 	return handler.Prepare(ctx, tx, req)
 }
 
+func (z *Zeto) auditor() string {
+	if z.config == nil {
+		return ""
+	}
+	return z.config.Auditor
+}
+
 func (z *Zeto) GetHandler(method, tokenName string) types.DomainHandler {
 	if common.IsNonFungibleToken(tokenName) {
 		switch method {
@@ -329,7 +338,7 @@ func (z *Zeto) GetHandler(method, tokenName string) types.DomainHandler {
 	case types.METHOD_MINT:
 		return fungible.NewMintHandler(z.name, z.coinSchema)
 	case types.METHOD_TRANSFER:
-		return fungible.NewTransferHandler(z.name, z.Callbacks, z.coinSchema, z.merkleTreeRootSchema, z.merkleTreeNodeSchema)
+		return fungible.NewTransferHandler(z.name, z.Callbacks, z.coinSchema, z.merkleTreeRootSchema, z.merkleTreeNodeSchema, z.auditor())
 	case types.METHOD_TRANSFER_LOCKED:
 		return fungible.NewTransferLockedHandler(z.name, z.Callbacks, z.coinSchema, z.merkleTreeRootSchema, z.merkleTreeNodeSchema)
 	case types.METHOD_LOCK:
@@ -533,6 +542,18 @@ func (z *Zeto) Sign(ctx context.Context, req *prototk.SignRequest) (*prototk.Sig
 		return &prototk.SignResponse{
 			Payload: proof,
 		}, nil
+	case zetosignerapi.PAYLOAD_DOMAIN_ZETO_ECDH:
+		var sharedSecret *babyjub.Point
+		keyPair, err := signercommon.NewBabyJubJubPrivateKey(req.PrivateKey)
+		if err == nil {
+			sharedSecret, err = common.ECDHSharedSecret(ctx, keyPair, req.Payload)
+		}
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgErrorECDHSharedSecret)
+		}
+		return &prototk.SignResponse{
+			Payload: common.EncodeECDHPoint(sharedSecret),
+		}, nil
 	default:
 		return nil, i18n.NewError(ctx, msgs.MsgUnknownSignPayload, req.PayloadType)
 	}
@@ -570,19 +591,6 @@ func (z *Zeto) ValidateStateHashes(ctx context.Context, req *prototk.ValidateSta
 	return &res, nil
 }
 
-func (z *Zeto) InitCall(ctx context.Context, req *prototk.InitCallRequest) (*prototk.InitCallResponse, error) {
-	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
-}
-
-func (z *Zeto) ExecCall(ctx context.Context, req *prototk.ExecCallRequest) (*prototk.ExecCallResponse, error) {
-	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
-}
-
-func (z *Zeto) BuildReceipt(ctx context.Context, req *prototk.BuildReceiptRequest) (*prototk.BuildReceiptResponse, error) {
-	// TODO: Event logs for transfers would be great for Noto
-	return nil, i18n.NewError(ctx, msgs.MsgNoDomainReceipt)
-}
-
 func (z *Zeto) ConfigurePrivacyGroup(ctx context.Context, req *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error) {
 	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
 }
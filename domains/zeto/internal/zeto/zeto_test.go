@@ -25,7 +25,9 @@ import (
 	"testing"
 
 	"github.com/hyperledger-labs/zeto/go-sdk/pkg/crypto"
+	"github.com/iden3/go-iden3-crypto/babyjub"
 	"github.com/iden3/go-iden3-crypto/poseidon"
+	zetocommon "github.com/kaleido-io/paladin/domains/zeto/internal/zeto/common"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/signer"
 	"github.com/kaleido-io/paladin/domains/zeto/internal/zeto/signer/common"
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/constants"
@@ -152,6 +154,7 @@ func TestInitDomain(t *testing.T) {
 	assert.Equal(t, "schema2", z.nftSchema.Id)
 	assert.Equal(t, "schema3", z.merkleTreeRootSchema.Id)
 	assert.Equal(t, "schema4", z.merkleTreeNodeSchema.Id)
+	assert.Equal(t, "schema5", z.auditRecordSchema.Id)
 }
 
 func TestInitDeploy(t *testing.T) {
@@ -706,6 +709,27 @@ func TestSign(t *testing.T) {
 	res, err = z.Sign(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Len(t, res.Payload, 32)
+
+	// ECDH returns the same shared secret as the other party derives with the public key of the signer
+	ephemeralKey := babyjub.NewRandPrivKey()
+	req = &pb.SignRequest{
+		Algorithm:   z.getAlgoZetoSnarkBJJ(),
+		PayloadType: "domain:zeto:ecdh",
+		PrivateKey:  alice.PrivateKey[:],
+		Payload:     zetocommon.EncodeECDHPoint(ephemeralKey.Public().Point()),
+	}
+	res, err = z.Sign(context.Background(), req)
+	require.NoError(t, err)
+	sharedSecret := babyjub.NewPoint().Mul(babyjub.SkToBigInt(&ephemeralKey), alice.PublicKey.Point())
+	assert.Equal(t, zetocommon.EncodeECDHPoint(sharedSecret), res.Payload)
+
+	req.Payload = zetocommon.EncodeECDHPoint(&babyjub.Point{X: big.NewInt(1), Y: big.NewInt(2)})
+	_, err = z.Sign(context.Background(), req)
+	assert.Regexp(t, "PD210143.*PD210150", err)
+
+	req.PrivateKey = []byte{0x01}
+	_, err = z.Sign(context.Background(), req)
+	assert.ErrorContains(t, err, "PD210143")
 }
 
 func TestValidateStateHashes(t *testing.T) {
@@ -780,18 +804,12 @@ func TestGetHandler(t *testing.T) {
 
 func TestUnimplementedMethods(t *testing.T) {
 	z := &Zeto{}
-	_, err := z.InitCall(context.Background(), nil)
+	_, err := z.ConfigurePrivacyGroup(context.Background(), nil)
 	assert.ErrorContains(t, err, "PD210085: Not implemented")
-
-	_, err = z.ExecCall(context.Background(), nil)
-	assert.ErrorContains(t, err, "PD210085: Not implemented")
-
-	_, err = z.BuildReceipt(context.Background(), nil)
-	assert.ErrorContains(t, err, "PD210102: Not implemented")
 }
 
 func TestGetStateSchemas(t *testing.T) {
 	schemas, err := types.GetStateSchemas()
 	assert.NoError(t, err)
-	assert.Len(t, schemas, 4)
+	assert.Len(t, schemas, 5)
 }
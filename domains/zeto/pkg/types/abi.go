@@ -41,6 +41,15 @@ const (
 	METHOD_WITHDRAW        = "withdraw"
 )
 
+// Read-only methods, invoked with ptx_call
+const (
+	METHOD_BALANCE_OF        = "balanceOf"
+	METHOD_OWNER_OF          = "ownerOf"
+	METHOD_TOKENS_OF         = "tokensOf"
+	METHOD_GET_MERKLE_PROOF  = "getMerkleProof"
+	METHOD_DECRYPT_TRANSFERS = "decryptTransfers"
+)
+
 type InitializerParams struct {
 	TokenName string `json:"tokenName"`
 	// InitialOwner string `json:"initialOwner"` // TODO: allow the initial owner to be specified by the deploy request
@@ -98,3 +107,67 @@ type DepositParams struct {
 type WithdrawParams struct {
 	Amount *pldtypes.HexUint256 `json:"amount"`
 }
+
+type BalanceOfParams struct {
+	Account string `json:"account"`
+}
+
+type BalanceOfResult struct {
+	TotalStates   *pldtypes.HexUint256 `json:"totalStates"`
+	TotalBalance  *pldtypes.HexUint256 `json:"totalBalance"`
+	LockedStates  *pldtypes.HexUint256 `json:"lockedStates"`
+	LockedBalance *pldtypes.HexUint256 `json:"lockedBalance"`
+}
+
+type OwnerOfParams struct {
+	TokenID *pldtypes.HexUint256 `json:"tokenID"`
+}
+
+type OwnerOfResult struct {
+	Owner pldtypes.HexBytes `json:"owner"` // compressed Baby Jubjub public key of the owner (not an identity lookup string)
+}
+
+type TokensOfParams struct {
+	Account string `json:"account"`
+}
+
+type TokensOfResult struct {
+	Tokens []*TokensOfEntry `json:"tokens"`
+}
+
+type TokensOfEntry struct {
+	TokenID *pldtypes.HexUint256 `json:"tokenID"`
+	URI     string               `json:"uri"`
+}
+
+type GetMerkleProofParams struct {
+	UTXO *pldtypes.HexUint256 `json:"utxo"`
+}
+
+// GetMerkleProofResult is an inclusion proof for a UTXO against the current root of the
+// Sparse Merkle Tree, with the siblings in the form expected by the Zeto circuits
+type GetMerkleProofResult struct {
+	Root     *pldtypes.HexUint256   `json:"root"`
+	Siblings []*pldtypes.HexUint256 `json:"siblings"`
+}
+
+type DecryptTransfersParams struct{}
+
+// DecryptTransfersResult is the transfer history of a token, as reconstructed by the
+// configured auditor from the auditor ciphertexts attached to each encrypted transfer
+type DecryptTransfersResult struct {
+	Transfers []*AuditedTransfer `json:"transfers"`
+}
+
+type AuditedTransfer struct {
+	TransactionID pldtypes.Bytes32  `json:"transactionId"`
+	Sender        pldtypes.HexBytes `json:"sender"`
+	Inputs        []*AuditedUTXO    `json:"inputs"`
+	Outputs       []*AuditedUTXO    `json:"outputs"`
+}
+
+type AuditedUTXO struct {
+	ID     *pldtypes.HexUint256 `json:"id"`
+	Owner  pldtypes.HexBytes    `json:"owner"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
@@ -32,6 +32,9 @@ import (
 type DomainFactoryConfig struct {
 	DomainContracts DomainConfigContracts           `json:"domainContracts"`
 	SnarkProver     zetosignerapi.SnarkProverConfig `json:"snarkProver"`
+	// Auditor is the identity of a party that can decrypt the values of all transfers
+	// of tokens that use encryption. It must be configured identically on all nodes.
+	Auditor string `json:"auditor,omitempty"`
 }
 
 type DomainConfigContracts struct {
@@ -93,5 +96,8 @@ var DomainInstanceConfigABI = &abi.ParameterArray{
 // marks the version of the Zeto transaction data schema
 var ZetoTransactionData_V0 = ethtypes.MustNewHexBytes0xPrefix("0x00010000")
 
+// V1 additionally carries a ciphertext of the transfer values for the auditor, after the transaction ID
+var ZetoTransactionData_V1 = ethtypes.MustNewHexBytes0xPrefix("0x00020000")
+
 type DomainHandler = domain.DomainHandler[DomainInstanceConfig]
 type ParsedTransaction = domain.ParsedTransaction[DomainInstanceConfig]
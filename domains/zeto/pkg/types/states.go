@@ -46,6 +46,37 @@ var ZetoCoinABI = &abi.Parameter{
 	},
 }
 
+type ZetoDomainReceipt struct {
+	States     ReceiptStates        `json:"states"`
+	Nullifiers []pldtypes.HexBytes  `json:"nullifiers,omitempty"` // only available on the node that spent the inputs
+	Transfers  []*ReceiptTransfer   `json:"transfers,omitempty"`
+	Deposit    *pldtypes.HexUint256 `json:"deposit,omitempty"`  // value added to the private balances (mint or deposit)
+	Withdraw   *pldtypes.HexUint256 `json:"withdraw,omitempty"` // value removed from the private balances (burn or withdraw)
+}
+
+type ReceiptStates struct {
+	Inputs        []*ReceiptState `json:"inputs,omitempty"`
+	LockedInputs  []*ReceiptState `json:"lockedInputs,omitempty"`
+	Outputs       []*ReceiptState `json:"outputs,omitempty"`
+	LockedOutputs []*ReceiptState `json:"lockedOutputs,omitempty"`
+	ReadInputs    []*ReceiptState `json:"readInputs,omitempty"`
+}
+
+type ReceiptState struct {
+	ID      pldtypes.HexBytes    `json:"id"`
+	Owner   pldtypes.HexBytes    `json:"owner"`
+	Amount  *pldtypes.HexUint256 `json:"amount,omitempty"`  // fungible tokens only
+	TokenID *pldtypes.HexUint256 `json:"tokenId,omitempty"` // non-fungible tokens only
+	Data    pldtypes.RawJSON     `json:"data"`
+}
+
+type ReceiptTransfer struct {
+	From    pldtypes.HexBytes    `json:"from,omitempty"` // omitted for a mint, or if the inputs are not available on this node
+	To      pldtypes.HexBytes    `json:"to,omitempty"`   // omitted for a burn or withdraw
+	Amount  *pldtypes.HexUint256 `json:"amount,omitempty"`
+	TokenID *pldtypes.HexUint256 `json:"tokenId,omitempty"`
+}
+
 type ZetoCoinState struct {
 	ID              pldtypes.HexUint256 `json:"id"`
 	Created         pldtypes.Timestamp  `json:"created"`
@@ -232,11 +263,47 @@ func (m *MerkleTreeNode) Hash() (string, error) {
 	return pldtypes.Bytes32(h.Sum(nil)).HexString(), nil
 }
 
+// AuditRecordABI holds the auditor ciphertext of an encrypted transfer, along with the
+// UTXOs from the base ledger event that the decrypted values can be checked against
+var AuditRecordABI = &abi.Parameter{
+	Type:         "tuple",
+	InternalType: "struct AuditRecord",
+	Components: abi.ParameterArray{
+		{Name: "transactionId", Type: "bytes32", Indexed: true},
+		{Name: "inputs", Type: "uint256[]"},
+		{Name: "outputs", Type: "uint256[]"},
+		{Name: "ecdhPublicKey", Type: "uint256[2]"},
+		{Name: "encryptionNonce", Type: "uint256"},
+		{Name: "encryptedValues", Type: "uint256[]"},
+	},
+}
+
+type AuditRecord struct {
+	TransactionID   pldtypes.Bytes32      `json:"transactionId"`
+	Inputs          []pldtypes.HexUint256 `json:"inputs"`
+	Outputs         []pldtypes.HexUint256 `json:"outputs"`
+	EcdhPublicKey   []pldtypes.HexUint256 `json:"ecdhPublicKey"`
+	EncryptionNonce pldtypes.HexUint256   `json:"encryptionNonce"`
+	EncryptedValues []pldtypes.HexUint256 `json:"encryptedValues"`
+}
+
+func (a *AuditRecord) Hash() (string, error) {
+	h := sha256.New()
+	h.Write(a.TransactionID.Bytes())
+	for _, values := range [][]pldtypes.HexUint256{a.Inputs, a.Outputs, a.EcdhPublicKey, {a.EncryptionNonce}, a.EncryptedValues} {
+		for _, v := range values {
+			h.Write(v.Int().FillBytes(make([]byte, 32)))
+		}
+	}
+	return pldtypes.Bytes32(h.Sum(nil)).HexString(), nil
+}
+
 func GetStateSchemas() ([]string, error) {
 	coinJSON, _ := json.Marshal(ZetoCoinABI)
 	nftJSON, _ := json.Marshal(ZetoNFTokenABI)
 	smtRootJSON, _ := json.Marshal(MerkleTreeRootABI)
 	smtNodeJSON, _ := json.Marshal(MerkleTreeNodeABI)
+	auditRecordJSON, _ := json.Marshal(AuditRecordABI)
 
-	return []string{string(coinJSON), string(nftJSON), string(smtRootJSON), string(smtNodeJSON)}, nil
+	return []string{string(coinJSON), string(nftJSON), string(smtRootJSON), string(smtNodeJSON), string(auditRecordJSON)}, nil
 }
@@ -32,4 +32,9 @@ const PAYLOAD_DOMAIN_ZETO_SNARK = "domain:zeto:snark"
 
 const PAYLOAD_DOMAIN_ZETO_NULLIFIER = "domain:zeto:nullifier"
 
+// The payload is an ephemeral Baby Jub Jub public key, and the signature is the ECDH shared secret
+// for that key and the signing key. Used by the auditor to decrypt ciphertexts without the private
+// key ever leaving the signer.
+const PAYLOAD_DOMAIN_ZETO_ECDH = "domain:zeto:ecdh"
+
 const IDEN3_PUBKEY_BABYJUBJUB_COMPRESSED_0X = "iden3_pubkey_babyjubjub_compressed_0x"
@@ -37,7 +37,7 @@ type PaladinDomainSpec struct {
 }
 
 type PluginConfig struct {
-	// +kubebuilder:validation:Enum=c-shared;jar
+	// +kubebuilder:validation:Enum=c-shared;jar;process;remote
 	// The library type to load
 	Type string `json:"type"`
 	// The location of the library - do not include the "lib" prefix or the ".so" suffix for shared libraries
@@ -30,8 +30,9 @@ type BlockchainEventListener struct {
 }
 
 type BlockchainEventListenerOptions struct {
-	BatchSize    *int    `docstruct:"BlockchainEventListenerOptions" json:"batchSize,omitempty"`
-	BatchTimeout *string `docstruct:"BlockchainEventListenerOptions" json:"batchTimeout,omitempty"`
+	BatchSize    *int             `docstruct:"BlockchainEventListenerOptions" json:"batchSize,omitempty"`
+	BatchTimeout *string          `docstruct:"BlockchainEventListenerOptions" json:"batchTimeout,omitempty"`
+	Webhook      *ListenerWebhook `docstruct:"BlockchainEventListenerOptions" json:"webhook,omitempty"`
 }
 
 type BlockchainEventListenerSource struct {
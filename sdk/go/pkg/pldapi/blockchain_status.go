@@ -0,0 +1,35 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import "github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+
+type BlockchainStatus struct {
+	ChainID        int64                       `docstruct:"BlockchainStatus" json:"chainId"`
+	ActiveEndpoint int                         `docstruct:"BlockchainStatus" json:"activeEndpoint"`
+	Endpoints      []*BlockchainEndpointStatus `docstruct:"BlockchainStatus" json:"endpoints"`
+}
+
+type BlockchainEndpointStatus struct {
+	HTTP        string              `docstruct:"BlockchainEndpointStatus" json:"http"`
+	WS          string              `docstruct:"BlockchainEndpointStatus" json:"ws"`
+	Active      bool                `docstruct:"BlockchainEndpointStatus" json:"active"`
+	Healthy     bool                `docstruct:"BlockchainEndpointStatus" json:"healthy"`
+	BlockNumber pldtypes.HexUint64  `docstruct:"BlockchainEndpointStatus" json:"blockNumber"`
+	LastChecked *pldtypes.Timestamp `docstruct:"BlockchainEndpointStatus" json:"lastChecked,omitempty"`
+	Error       string              `docstruct:"BlockchainEndpointStatus" json:"error,omitempty"`
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// ListenerWebhook configures a listener to POST each batch to an HTTP endpoint, rather
+// than delivering to WebSocket subscribers. A 2xx response acknowledges the batch.
+type ListenerWebhook struct {
+	HTTP  pldconf.HTTPClientConfig   `docstruct:"ListenerWebhook" json:"http"`
+	Retry pldconf.RetryConfigWithMax `docstruct:"ListenerWebhook" json:"retry"`
+}
+
+// ListenerDeadLetter is a batch that could not be delivered to a webhook within the
+// configured maxAttempts. The listener moves on after recording it.
+type ListenerDeadLetter struct {
+	ID           uuid.UUID          `docstruct:"ListenerDeadLetter" json:"id"`
+	ListenerType string             `docstruct:"ListenerDeadLetter" json:"listenerType"`
+	Listener     string             `docstruct:"ListenerDeadLetter" json:"listener"`
+	Created      pldtypes.Timestamp `docstruct:"ListenerDeadLetter" json:"created"`
+	BatchID      string             `docstruct:"ListenerDeadLetter" json:"batchId"`
+	Attempts     int                `docstruct:"ListenerDeadLetter" json:"attempts"`
+	Error        string             `docstruct:"ListenerDeadLetter" json:"error"`
+	Batch        pldtypes.RawJSON   `docstruct:"ListenerDeadLetter" json:"batch"`
+}
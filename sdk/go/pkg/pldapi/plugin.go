@@ -0,0 +1,35 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type PluginStatus struct {
+	Name          string              `docstruct:"PluginStatus" json:"name"`
+	ID            uuid.UUID           `docstruct:"PluginStatus" json:"id"`
+	PluginType    string              `docstruct:"PluginStatus" json:"pluginType"`
+	LibraryType   string              `docstruct:"PluginStatus" json:"libraryType"`
+	Library       string              `docstruct:"PluginStatus" json:"library"`
+	Initialized   bool                `docstruct:"PluginStatus" json:"initialized"`
+	PID           int                 `docstruct:"PluginStatus" json:"pid,omitempty"`
+	Restarts      int                 `docstruct:"PluginStatus" json:"restarts"`
+	LastError     string              `docstruct:"PluginStatus" json:"lastError,omitempty"`
+	LastErrorTime *pldtypes.Timestamp `docstruct:"PluginStatus" json:"lastErrorTime,omitempty"`
+}
@@ -25,9 +25,10 @@ import (
 // If set these affect the submission of the public transaction.
 // All are optional
 type PublicTxOptions struct {
-	Gas                *pldtypes.HexUint64  `docstruct:"PublicTxOptions" json:"gas,omitempty"`
-	Value              *pldtypes.HexUint256 `docstruct:"PublicTxOptions" json:"value,omitempty"`
-	PublicTxGasPricing                      // fixed when any of these are supplied - disabling the gas pricing engine for this TX
+	Gas                  *pldtypes.HexUint64  `docstruct:"PublicTxOptions" json:"gas,omitempty"`
+	Value                *pldtypes.HexUint256 `docstruct:"PublicTxOptions" json:"value,omitempty"`
+	PublicTxGasPricing                        // fixed when any of these are supplied - disabling the gas pricing engine for this TX
+	PublicTxGasPriceCaps                      // limits on the EIP-1559 fees the gas pricing engine can set for this TX
 }
 
 type PublicCallOptions struct {
@@ -40,6 +41,11 @@ type PublicTxGasPricing struct {
 	GasPrice             *pldtypes.HexUint256 `docstruct:"PublicTxGasPricing" json:"gasPrice,omitempty"`
 }
 
+type PublicTxGasPriceCaps struct {
+	MaxFeePerGasCap         *pldtypes.HexUint256 `docstruct:"PublicTxGasPriceCaps" json:"maxFeePerGasCap,omitempty"`
+	MaxPriorityFeePerGasCap *pldtypes.HexUint256 `docstruct:"PublicTxGasPriceCaps" json:"maxPriorityFeePerGasCap,omitempty"`
+}
+
 type PublicTxInput struct {
 	From *pldtypes.EthAddress `docstruct:"PublicTxInput" json:"from"`           // resolved signing account
 	To   *pldtypes.EthAddress `docstruct:"PublicTxInput" json:"to,omitempty"`   // target contract address, or nil for deploy
@@ -72,6 +78,7 @@ type PublicTx struct {
 	RevertData      pldtypes.HexBytes           `docstruct:"PublicTx" json:"revertData,omitempty"`  // only once confirmed, if available
 	Submissions     []*PublicTxSubmissionData   `docstruct:"PublicTx" json:"submissions,omitempty"`
 	Activity        []TransactionActivityRecord `docstruct:"PublicTx" json:"activity,omitempty"`
+	Stuck           bool                        `docstruct:"PublicTx" json:"stuck,omitempty"` // pending after the configured number of submissions
 	PublicTxOptions
 }
 
@@ -83,3 +90,11 @@ type PublicTxWithBinding struct {
 	*PublicTx
 	PublicTxBinding
 }
+
+// A range of nonces for a signing address that are not held by any transaction, which blocks
+// all the transactions with higher nonces from being mined
+type PublicTxNonceGap struct {
+	From       pldtypes.EthAddress `docstruct:"PublicTxNonceGap" json:"from"`
+	FirstNonce pldtypes.HexUint64  `docstruct:"PublicTxNonceGap" json:"firstNonce"`
+	LastNonce  pldtypes.HexUint64  `docstruct:"PublicTxNonceGap" json:"lastNonce"`
+}
@@ -144,6 +144,7 @@ type TransactionStates struct {
 	Read        []*StateBase       `docstruct:"TransactionStates" json:"read,omitempty"`
 	Confirmed   []*StateBase       `docstruct:"TransactionStates" json:"confirmed,omitempty"`
 	Info        []*StateBase       `docstruct:"TransactionStates" json:"info,omitempty"`
+	Nullifiers  []*StateNullifier  `docstruct:"TransactionStates" json:"nullifiers,omitempty"`  // only for states in Spent where we hold the nullifier
 	Unavailable *UnavailableStates `docstruct:"TransactionStates" json:"unavailable,omitempty"` // nil if we have the data for all states
 }
 
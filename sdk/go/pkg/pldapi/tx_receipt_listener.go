@@ -57,4 +57,5 @@ func (tt IncompleteStateReceiptBehavior) Default() string {
 type TransactionReceiptListenerOptions struct {
 	DomainReceipts                 bool                                          `docstruct:"TransactionReceiptOptions" json:"domainReceipts"`
 	IncompleteStateReceiptBehavior pldtypes.Enum[IncompleteStateReceiptBehavior] `docstruct:"TransactionReceiptOptions" json:"incompleteStateReceiptBehavior,omitempty"`
+	Webhook                        *ListenerWebhook                              `docstruct:"TransactionReceiptOptions" json:"webhook,omitempty"`
 }
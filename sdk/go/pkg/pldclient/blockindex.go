@@ -68,6 +68,10 @@ var blockIndexInfo = &rpcModuleInfo{
 			Inputs: []string{},
 			Output: "blockHeight",
 		},
+		"bidx_getBlockchainStatus": {
+			Inputs: []string{},
+			Output: "status",
+		},
 		"bidx_decodeTransactionEvents": {
 			Inputs: []string{"transactionHash", "abi", "resultFormat"},
 			Output: "events",
@@ -129,6 +133,11 @@ func (r *blockIndex) GetConfirmedBlockHeight(ctx context.Context) (blockHeight p
 	return
 }
 
+func (r *blockIndex) GetBlockchainStatus(ctx context.Context) (status *pldapi.BlockchainStatus, err error) {
+	err = r.c.CallRPC(ctx, &status, "bidx_getBlockchainStatus")
+	return
+}
+
 func (r *blockIndex) DecodeTransactionEvents(ctx context.Context, transactionHash pldtypes.Bytes32, abi abi.ABI, resultFormat pldtypes.JSONFormatOptions) (events []*pldapi.EventWithData, err error) {
 	err = r.c.CallRPC(ctx, &events, "bidx_decodeTransactionEvents", transactionHash, abi, resultFormat)
 	return
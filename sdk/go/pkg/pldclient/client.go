@@ -62,6 +62,9 @@ type PaladinClient interface {
 
 	// Paladin pgroup RPC interface
 	PrivacyGroups() PrivacyGroups
+
+	// Paladin plugin RPC interface
+	Plugins() Plugins
 }
 
 type RPCModule interface {
@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pldclient
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+)
+
+type Plugins interface {
+	RPCModule
+
+	ListPlugins(ctx context.Context) (plugins []*pldapi.PluginStatus, err error)
+}
+
+// This is necessary because there's no way to introspect function parameter names via reflection
+var pluginsInfo = &rpcModuleInfo{
+	group: "plugin",
+	methodInfo: map[string]RPCMethodInfo{
+		"plugin_listPlugins": {
+			Inputs: []string{},
+			Output: "plugins",
+		},
+	},
+}
+
+var _ Plugins = &plugins{}
+
+type plugins struct {
+	*rpcModuleInfo
+	c *paladinClient
+}
+
+func (c *paladinClient) Plugins() Plugins {
+	return &plugins{rpcModuleInfo: pluginsInfo, c: c}
+}
+
+func (p *plugins) ListPlugins(ctx context.Context) (plugins []*pldapi.PluginStatus, err error) {
+	err = p.c.CallRPC(ctx, &plugins, "plugin_listPlugins")
+	return
+}
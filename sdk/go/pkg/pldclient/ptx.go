@@ -34,6 +34,7 @@ type PTX interface {
 	PrepareTransaction(ctx context.Context, tx *pldapi.TransactionInput) (txID *uuid.UUID, err error)
 	PrepareTransactions(ctx context.Context, txs []*pldapi.TransactionInput) (txIDs []uuid.UUID, err error)
 	UpdateTransaction(ctx context.Context, id uuid.UUID, tx *pldapi.TransactionInput) (txID *uuid.UUID, err error)
+	CancelTransaction(ctx context.Context, id uuid.UUID) (txID *uuid.UUID, err error)
 	Call(ctx context.Context, tx *pldapi.TransactionCall) (data pldtypes.RawJSON, err error)
 
 	GetTransaction(ctx context.Context, txID uuid.UUID) (receipt *pldapi.Transaction, err error)
@@ -49,6 +50,9 @@ type PTX interface {
 	QueryTransactionReceipts(ctx context.Context, jq *query.QueryJSON) (receipts []*pldapi.TransactionReceipt, err error)
 	GetPreparedTransaction(ctx context.Context, txID uuid.UUID) (preparedTransaction *pldapi.PreparedTransaction, err error)
 	QueryPreparedTransactions(ctx context.Context, jq *query.QueryJSON) (preparedTransactions []*pldapi.PreparedTransaction, err error)
+	QueryStuckPublicTransactions(ctx context.Context, jq *query.QueryJSON) (publicTransactions []*pldapi.PublicTxWithBinding, err error)
+	GetNonceGaps(ctx context.Context, from pldtypes.EthAddress) (nonceGaps []*pldapi.PublicTxNonceGap, err error)
+	FillNonceGap(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (publicTransaction *pldapi.PublicTx, err error)
 	DecodeError(ctx context.Context, revertData pldtypes.HexBytes, dataFormat pldtypes.JSONFormatOptions) (decodedError *pldapi.ABIDecodedData, err error)
 	DecodeCall(ctx context.Context, callData pldtypes.HexBytes, dataFormat pldtypes.JSONFormatOptions) (decodedCall *pldapi.ABIDecodedData, err error)
 	DecodeEvent(ctx context.Context, topics []pldtypes.Bytes32, eventData pldtypes.HexBytes, dataFormat pldtypes.JSONFormatOptions) (decodedEvent *pldapi.ABIDecodedData, err error)
@@ -65,6 +69,7 @@ type PTX interface {
 	StartReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
 	StopReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
 	DeleteReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
+	QueryReceiptListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error)
 
 	CreateBlockchainEventListener(ctx context.Context, listener *pldapi.BlockchainEventListener) (success bool, err error)
 	QueryBlockchainEventListeners(ctx context.Context, jq *query.QueryJSON) (listeners []*pldapi.BlockchainEventListener, err error)
@@ -72,6 +77,7 @@ type PTX interface {
 	StartBlockchainEventListener(ctx context.Context, listenerName string) (success bool, err error)
 	StopBlockchainEventListener(ctx context.Context, listenerName string) (success bool, err error)
 	DeleteBlockchainEventListener(ctx context.Context, listenerName string) (success bool, err error)
+	QueryBlockchainEventListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error)
 
 	SubscribeReceipts(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error)
 	SubscribeBlockchainEvents(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error)
@@ -109,6 +115,10 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"transactionId", "transaction"},
 			Output: "transactionId",
 		},
+		"ptx_cancelTransaction": {
+			Inputs: []string{"transactionId"},
+			Output: "transactionId",
+		},
 		"ptx_call": {
 			Inputs: []string{"transaction"},
 			Output: "result",
@@ -161,6 +171,18 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"query"},
 			Output: "preparedTransactions",
 		},
+		"ptx_queryStuckPublicTransactions": {
+			Inputs: []string{"query"},
+			Output: "publicTransactions",
+		},
+		"ptx_getNonceGaps": {
+			Inputs: []string{"from"},
+			Output: "nonceGaps",
+		},
+		"ptx_fillNonceGap": {
+			Inputs: []string{"from", "nonce"},
+			Output: "publicTransaction",
+		},
 		"ptx_storeABI": {
 			Inputs: []string{"abi"},
 			Output: "storedABI",
@@ -213,6 +235,10 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"listenerName"},
 			Output: "success",
 		},
+		"ptx_queryReceiptListenerDeadLetters": {
+			Inputs: []string{"listenerName", "query"},
+			Output: "deadLetters",
+		},
 		"ptx_createBlockchainEventListener": {
 			Inputs: []string{"listener"},
 			Output: "success",
@@ -237,6 +263,10 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"listenerName"},
 			Output: "success",
 		},
+		"ptx_queryBlockchainEventListenerDeadLetters": {
+			Inputs: []string{"listenerName", "query"},
+			Output: "deadLetters",
+		},
 	},
 	subscriptions: []RPCSubscriptionInfo{
 		{
@@ -286,6 +316,11 @@ func (p *ptx) UpdateTransaction(ctx context.Context, id uuid.UUID, tx *pldapi.Tr
 	return
 }
 
+func (p *ptx) CancelTransaction(ctx context.Context, id uuid.UUID) (txID *uuid.UUID, err error) {
+	err = p.c.CallRPC(ctx, &txID, "ptx_cancelTransaction", id)
+	return
+}
+
 func (p *ptx) Call(ctx context.Context, tx *pldapi.TransactionCall) (data pldtypes.RawJSON, err error) {
 	err = p.c.CallRPC(ctx, &data, "ptx_call", tx)
 	return
@@ -351,6 +386,21 @@ func (p *ptx) QueryPreparedTransactions(ctx context.Context, jq *query.QueryJSON
 	return
 }
 
+func (p *ptx) QueryStuckPublicTransactions(ctx context.Context, jq *query.QueryJSON) (publicTransactions []*pldapi.PublicTxWithBinding, err error) {
+	err = p.c.CallRPC(ctx, &publicTransactions, "ptx_queryStuckPublicTransactions", jq)
+	return
+}
+
+func (p *ptx) GetNonceGaps(ctx context.Context, from pldtypes.EthAddress) (nonceGaps []*pldapi.PublicTxNonceGap, err error) {
+	err = p.c.CallRPC(ctx, &nonceGaps, "ptx_getNonceGaps", from)
+	return
+}
+
+func (p *ptx) FillNonceGap(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (publicTransaction *pldapi.PublicTx, err error) {
+	err = p.c.CallRPC(ctx, &publicTransaction, "ptx_fillNonceGap", from, nonce)
+	return
+}
+
 func (p *ptx) StoreABI(ctx context.Context, abi abi.ABI) (storedABI *pldapi.StoredABI, err error) {
 	err = p.c.CallRPC(ctx, &storedABI, "ptx_storeABI", abi)
 	return
@@ -416,6 +466,11 @@ func (p *ptx) DeleteReceiptListener(ctx context.Context, listenerName string) (s
 	return
 }
 
+func (p *ptx) QueryReceiptListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error) {
+	err = p.c.CallRPC(ctx, &deadLetters, "ptx_queryReceiptListenerDeadLetters", listenerName, jq)
+	return
+}
+
 func (p *ptx) CreateBlockchainEventListener(ctx context.Context, listener *pldapi.BlockchainEventListener) (success bool, err error) {
 	err = p.c.CallRPC(ctx, &success, "ptx_createBlockchainEventListener", listener)
 	return
@@ -446,6 +501,11 @@ func (p *ptx) DeleteBlockchainEventListener(ctx context.Context, listenerName st
 	return
 }
 
+func (p *ptx) QueryBlockchainEventListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error) {
+	err = p.c.CallRPC(ctx, &deadLetters, "ptx_queryBlockchainEventListenerDeadLetters", listenerName, jq)
+	return
+}
+
 func (p *ptx) SubscribeReceipts(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error) {
 	ws, err := p.c.WSClient(ctx)
 	if err != nil {
@@ -19,6 +19,8 @@ type LibraryType string
 const (
 	LibraryTypeCShared LibraryType = "c-shared"
 	LibraryTypeJar     LibraryType = "jar"
+	LibraryTypeProcess LibraryType = "process" // an executable started and supervised by Paladin
+	LibraryTypeRemote  LibraryType = "remote"  // a plugin already running at a gRPC address
 )
 
 func (lt LibraryType) Enum() Enum[LibraryType] {
@@ -29,5 +31,7 @@ func (pl LibraryType) Options() []string {
 	return []string{
 		string(LibraryTypeCShared),
 		string(LibraryTypeJar),
+		string(LibraryTypeProcess),
+		string(LibraryTypeRemote),
 	}
 }
@@ -38,6 +38,7 @@ type RPCCode int64
 const (
 	RPCCodeParseError     RPCCode = -32700
 	RPCCodeInvalidRequest RPCCode = -32600
+	RPCCodeMethodNotFound RPCCode = -32601
 	RPCCodeInternalError  RPCCode = -32603
 )
 
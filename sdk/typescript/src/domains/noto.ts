@@ -98,6 +98,17 @@ export interface NotoApproveTransferParams {
 
 export interface NotoLockParams {
   amount: string | number;
+  expiry?: NotoLockExpiry;
+  data: string;
+}
+
+export interface NotoLockExpiry {
+  blockNumber: string | number;
+  timestamp: string | number;
+}
+
+export interface NotoReclaimLockParams {
+  lockId: string;
   data: string;
 }
 
@@ -273,7 +284,10 @@ export class NotoInstance {
       function: "lock",
       to: this.address,
       from: from.lookup,
-      data,
+      data: {
+        ...data,
+        expiry: data.expiry ?? { blockNumber: 0, timestamp: 0 },
+      },
     });
     return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
   }
@@ -328,6 +342,18 @@ export class NotoInstance {
     return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
   }
 
+  async reclaimLock(from: PaladinVerifier, data: NotoReclaimLockParams) {
+    const txID = await this.paladin.sendTransaction({
+      type: TransactionType.PRIVATE,
+      abi: notoPrivateJSON.abi,
+      function: "reclaimLock",
+      to: this.address,
+      from: from.lookup,
+      data,
+    });
+    return this.paladin.pollForReceipt(txID, this.options.pollTimeout);
+  }
+
   async delegateLock(from: PaladinVerifier, data: NotoDelegateLockParams) {
     const txID = await this.paladin.sendTransaction({
       type: TransactionType.PRIVATE,
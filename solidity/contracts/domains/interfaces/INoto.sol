@@ -6,6 +6,11 @@ pragma solidity ^0.8.20;
  * @dev All implementations of Noto must conform to this interface.
  */
 interface INoto {
+    struct LockExpiry {
+        uint256 blockNumber;
+        uint256 timestamp;
+    }
+
     event NotoTransfer(
         bytes32[] inputs,
         bytes32[] outputs,
@@ -51,6 +56,13 @@ interface INoto {
         bytes data
     );
 
+    event NotoNotaryChanged(
+        address notary,
+        bytes config,
+        bytes signature,
+        bytes data
+    );
+
     function initialize(
         address notaryAddress,
         bytes calldata data
@@ -91,6 +103,15 @@ interface INoto {
         bytes calldata data
     ) external;
 
+    function lockWithExpiry(
+        bytes32[] calldata inputs,
+        bytes32[] calldata outputs,
+        bytes32[] calldata lockedOutputs,
+        LockExpiry calldata expiry,
+        bytes calldata signature,
+        bytes calldata data
+    ) external;
+
     function unlock(
         bytes32[] calldata lockedInputs,
         bytes32[] calldata lockedOutputs,
@@ -112,4 +133,18 @@ interface INoto {
         bytes calldata signature,
         bytes calldata data
     ) external;
+
+    function reclaimLock(
+        bytes32[] calldata lockedInputs,
+        bytes32[] calldata outputs,
+        bytes calldata signature,
+        bytes calldata data
+    ) external;
+
+    function changeNotary(
+        address notary,
+        bytes calldata config,
+        bytes calldata signature,
+        bytes calldata data
+    ) external;
 }
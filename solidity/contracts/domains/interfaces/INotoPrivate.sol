@@ -19,8 +19,15 @@ interface INotoPrivate {
         bytes calldata data
     ) external;
 
+    function transferBatch(
+        TransferRecipient[] calldata transfers,
+        bytes calldata data
+    ) external;
+
     function burn(uint256 amount, bytes calldata data) external;
 
+    function consolidate(bytes calldata data) external;
+
     function approveTransfer(
         StateEncoded[] calldata inputs,
         StateEncoded[] calldata outputs,
@@ -28,7 +35,11 @@ interface INotoPrivate {
         address delegate
     ) external;
 
-    function lock(uint256 amount, bytes calldata data) external;
+    function lock(
+        uint256 amount,
+        LockExpiry calldata expiry,
+        bytes calldata data
+    ) external;
 
     function unlock(
         bytes32 lockId,
@@ -51,6 +62,31 @@ interface INotoPrivate {
         bytes calldata data
     ) external;
 
+    function reclaimLock(bytes32 lockId, bytes calldata data) external;
+
+    function changeNotary(string calldata notary, bytes calldata data) external;
+
+    function pause(bytes calldata data) external;
+
+    function unpause(bytes calldata data) external;
+
+    function freeze(string calldata account, bytes calldata data) external;
+
+    function unfreeze(string calldata account, bytes calldata data) external;
+
+    function balanceOf(
+        string calldata account
+    ) external view returns (uint256 totalStates, uint256 totalBalance);
+
+    function lockedBalanceOf(
+        string calldata account,
+        bytes32 lockId
+    ) external view returns (uint256 totalStates, uint256 totalBalance);
+
+    function getLock(
+        bytes32 lockId
+    ) external view returns (LockInfo memory lock, LockExpiry memory expiry);
+
     struct StateEncoded {
         bytes id;
         string domain;
@@ -59,11 +95,21 @@ interface INotoPrivate {
         bytes data;
     }
 
+    struct TransferRecipient {
+        string to;
+        uint256 amount;
+    }
+
     struct UnlockRecipient {
         string to;
         uint256 amount;
     }
 
+    struct LockExpiry {
+        uint256 blockNumber;
+        uint256 timestamp;
+    }
+
     struct UnlockPublicParams {
         bytes32[] lockedInputs;
         bytes32[] lockedOutputs;
@@ -71,4 +117,11 @@ interface INotoPrivate {
         bytes signature;
         bytes data;
     }
+
+    struct LockInfo {
+        bytes32 salt;
+        bytes32 lockId;
+        address owner;
+        address delegate;
+    }
 }
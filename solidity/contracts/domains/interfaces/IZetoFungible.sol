@@ -12,6 +12,19 @@ interface IZetoFungible {
         uint256 amount;
     }
 
+    struct AuditedUTXO {
+        uint256 id;
+        bytes32 owner;
+        uint256 amount;
+    }
+
+    struct AuditedTransfer {
+        bytes32 transactionId;
+        bytes32 sender;
+        AuditedUTXO[] inputs;
+        AuditedUTXO[] outputs;
+    }
+
     function mint(TransferParam[] memory mints) external;
     function transfer(TransferParam[] memory transfers) external;
     function transferLocked(
@@ -23,4 +36,23 @@ interface IZetoFungible {
     function deposit(uint256 amount) external;
     function withdraw(uint256 amount) external;
     function setERC20(address erc20) external;
+
+    function balanceOf(
+        string memory account
+    )
+        external
+        view
+        returns (
+            uint256 totalStates,
+            uint256 totalBalance,
+            uint256 lockedStates,
+            uint256 lockedBalance
+        );
+    function getMerkleProof(
+        uint256 utxo
+    ) external view returns (uint256 root, uint256[] memory siblings);
+    function decryptTransfers()
+        external
+        view
+        returns (AuditedTransfer[] memory transfers);
 }
\ No newline at end of file
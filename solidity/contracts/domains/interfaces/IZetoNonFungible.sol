@@ -18,6 +18,20 @@ interface IZetoNonFungible {
         uint256 tokenID;
     }
 
+    struct Token {
+        uint256 tokenID;
+        string uri;
+    }
+
     function mint(MintParam[] memory mints) external;
     function transfer(TransferParam[] memory transfers) external;
+
+    /// @return owner the compressed Baby Jubjub public key of the owner (not an identity)
+    function ownerOf(uint256 tokenID) external view returns (bytes32 owner);
+    function tokensOf(
+        string memory account
+    ) external view returns (Token[] memory tokens);
+    function getMerkleProof(
+        uint256 utxo
+    ) external view returns (uint256 root, uint256[] memory siblings);
 }
\ No newline at end of file
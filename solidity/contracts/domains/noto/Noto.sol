@@ -32,6 +32,7 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
     mapping(bytes32 => bool) private _locked;
     mapping(bytes32 => bytes32) private _unlockHashes; // state ID => unlock hash
     mapping(bytes32 => address) private _unlockDelegates; // unlock hash => delegate
+    mapping(bytes32 => LockExpiry) private _lockExpiries; // state ID => lock expiry
 
     // Config follows the convention of a 4 byte type selector, followed by ABI encoded bytes
     bytes4 public constant NotoConfigID_V0 = 0x00010000;
@@ -128,6 +129,17 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
         return _locked[id];
     }
 
+    /**
+     * @dev query the expiry of a locked TXO
+     * @param id the UTXO identifier
+     * @return expiry the expiry of the lock, or zero values if the lock does not expire
+     */
+    function getLockExpiry(
+        bytes32 id
+    ) public view returns (LockExpiry memory expiry) {
+        return _lockExpiries[id];
+    }
+
     /**
      * @dev query whether an approval exists for the given transaction
      * @param txhash the transaction hash
@@ -321,6 +333,33 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
         emit NotoLock(inputs, outputs, lockedOutputs, signature, data);
     }
 
+    /**
+     * @dev Lock some value until it is unlocked, or until the lock expires.
+     *      Once expired, the notary may reclaim the value for the lock owner, even if the lock
+     *      has been delegated.
+     *
+     * @param inputs array of zero or more outputs of a previous function call against this
+     *      contract that have not yet been spent, and the signer is authorized to spend
+     * @param outputs array of zero or more new outputs to generate, for future transactions to spend
+     * @param lockedOutputs array of zero or more locked outputs to generate, which will be tied to the lock ID
+     * @param expiry the block number and/or timestamp after which the lock may be reclaimed
+     * @param signature a signature over the original request to the notary (opaque to the blockchain)
+     * @param data any additional transaction data (opaque to the blockchain)
+     *
+     * Emits a {NotoLock} event.
+     */
+    function lockWithExpiry(
+        bytes32[] calldata inputs,
+        bytes32[] calldata outputs,
+        bytes32[] calldata lockedOutputs,
+        LockExpiry calldata expiry,
+        bytes calldata signature,
+        bytes calldata data
+    ) external virtual override {
+        lock(inputs, outputs, lockedOutputs, signature, data);
+        _setLockExpiry(lockedOutputs, expiry);
+    }
+
     /**
      * @dev Unlock some value from a set of locked states.
      *      May be triggered by the notary (if lock is undelegated) or by the current lock delegate.
@@ -342,15 +381,19 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
         bytes calldata data
     ) external virtual override {
         bytes32 expectedHash;
+        LockExpiry memory expiry;
         if (lockedInputs.length > 0) {
             expectedHash = _unlockHashes[lockedInputs[0]];
+            expiry = _lockExpiries[lockedInputs[0]];
             delete _unlockHashes[lockedInputs[0]];
+            delete _lockExpiries[lockedInputs[0]];
         }
         for (uint256 i = 1; i < lockedInputs.length; ++i) {
             if (_unlockHashes[lockedInputs[i]] != expectedHash) {
                 revert NotoInvalidInput(lockedInputs[i]);
             }
             delete _unlockHashes[lockedInputs[i]];
+            delete _lockExpiries[lockedInputs[i]];
         }
 
         address delegate = _unlockDelegates[expectedHash];
@@ -377,6 +420,9 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
         _processLockedOutputs(lockedOutputs);
         _processOutputs(outputs);
 
+        // Any value that remains locked keeps the original expiry
+        _setLockExpiry(lockedOutputs, expiry);
+
         emit NotoUnlock(
             msg.sender,
             lockedInputs,
@@ -441,6 +487,81 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
         emit NotoLockDelegated(unlockHash, delegate, signature, data);
     }
 
+    /**
+     * @dev Reclaim value from an expired lock, returning it to the lock owner.
+     *      May only be triggered by the notary, and only once every locked input has expired.
+     *      Any prepared unlock and delegation for the locked inputs is discarded.
+     *
+     * @param lockedInputs array of one or more expired locked outputs of a previous function call
+     * @param outputs array of zero or more new unlocked outputs to generate, for future transactions to spend
+     * @param signature a signature over the original request to the notary (opaque to the blockchain)
+     * @param data any additional transaction data (opaque to the blockchain)
+     *
+     * Emits a {NotoUnlock} event.
+     */
+    function reclaimLock(
+        bytes32[] calldata lockedInputs,
+        bytes32[] calldata outputs,
+        bytes calldata signature,
+        bytes calldata data
+    ) external virtual override onlyNotary {
+        for (uint256 i = 0; i < lockedInputs.length; ++i) {
+            if (!_isExpired(_lockExpiries[lockedInputs[i]])) {
+                revert NotoLockNotExpired(lockedInputs[i]);
+            }
+            delete _lockExpiries[lockedInputs[i]];
+            bytes32 unlockHash = _unlockHashes[lockedInputs[i]];
+            if (unlockHash != 0) {
+                delete _unlockDelegates[unlockHash];
+                delete _unlockHashes[lockedInputs[i]];
+            }
+        }
+
+        _processLockedInputs(lockedInputs);
+        _processOutputs(outputs);
+
+        emit NotoUnlock(
+            msg.sender,
+            lockedInputs,
+            new bytes32[](0),
+            outputs,
+            signature,
+            data
+        );
+    }
+
+    /**
+     * @dev Hand over control of the contract to a new notary.
+     *      May only be triggered by the current notary.
+     *
+     * @param notary the address of the new notary
+     * @param config the new notary configuration data (opaque to the blockchain)
+     * @param signature a signature over the original request to the notary (opaque to the blockchain)
+     * @param data any additional transaction data (opaque to the blockchain)
+     *
+     * Emits a {NotoNotaryChanged} event, with the full updated contract configuration.
+     */
+    function changeNotary(
+        address notary,
+        bytes calldata config,
+        bytes calldata signature,
+        bytes calldata data
+    ) external virtual onlyNotary {
+        _notary = notary;
+        emit NotoNotaryChanged(
+            notary,
+            _encodeConfig(
+                NotoConfig_V0({
+                    notaryAddress: notary,
+                    data: config,
+                    variant: NotoVariantDefault
+                })
+            ),
+            signature,
+            data
+        );
+    }
+
     /**
      * @dev Check the inputs are all locked
      */
@@ -475,4 +596,31 @@ contract Noto is EIP712Upgradeable, UUPSUpgradeable, INoto, INotoErrors {
             _locked[outputs[i]] = true;
         }
     }
+
+    /**
+     * @dev Record the expiry for a set of locked outputs
+     */
+    function _setLockExpiry(
+        bytes32[] calldata outputs,
+        LockExpiry memory expiry
+    ) internal {
+        if (expiry.blockNumber == 0 && expiry.timestamp == 0) {
+            return;
+        }
+        for (uint256 i = 0; i < outputs.length; ++i) {
+            _lockExpiries[outputs[i]] = expiry;
+        }
+    }
+
+    /**
+     * @dev Check if a lock has expired (a lock with no expiry never expires)
+     */
+    function _isExpired(LockExpiry memory expiry) internal view returns (bool) {
+        if (expiry.blockNumber == 0 && expiry.timestamp == 0) {
+            return false;
+        }
+        return
+            (expiry.blockNumber == 0 || block.number >= expiry.blockNumber) &&
+            (expiry.timestamp == 0 || block.timestamp >= expiry.timestamp);
+    }
 }
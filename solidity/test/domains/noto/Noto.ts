@@ -1,4 +1,7 @@
-import { loadFixture } from "@nomicfoundation/hardhat-toolbox/network-helpers";
+import {
+  loadFixture,
+  mine,
+} from "@nomicfoundation/hardhat-toolbox/network-helpers";
 import { expect } from "chai";
 import { ethers } from "hardhat";
 import { Noto } from "../../../typechain-types";
@@ -6,7 +9,9 @@ import {
   deployNotoInstance,
   doDelegateLock,
   doLock,
+  doLockWithExpiry,
   doPrepareUnlock,
+  doReclaimLock,
   doTransfer,
   doUnlock,
   fakeTXO,
@@ -142,4 +147,117 @@ describe("Noto", function () {
     // Perform the prepared unlock
     await doUnlock(delegate, noto, [locked2], [], [txo5], unlockData);
   });
+
+  it("lock with expiry and reclaim", async function () {
+    const { noto, notary } = await loadFixture(deployNotoFixture);
+    const [_, delegate] = await ethers.getSigners();
+
+    const txo1 = fakeTXO();
+    const txo2 = fakeTXO();
+    const txo3 = fakeTXO();
+    const locked1 = fakeTXO();
+    const locked2 = fakeTXO();
+
+    await doTransfer(notary, noto, [], [txo1], randomBytes32());
+
+    // Lock with an expiry 20 blocks in the future
+    const expiryBlock = (await ethers.provider.getBlockNumber()) + 20;
+    await doLockWithExpiry(
+      notary,
+      noto,
+      [txo1],
+      [],
+      [locked1],
+      { blockNumber: expiryBlock, timestamp: 0 },
+      randomBytes32()
+    );
+
+    // Partially unlock - the remaining locked value keeps the expiry
+    await doUnlock(notary, noto, [locked1], [locked2], [txo2], randomBytes32());
+    expect((await noto.getLockExpiry(locked1)).blockNumber).to.equal(0);
+    expect((await noto.getLockExpiry(locked2)).blockNumber).to.equal(
+      expiryBlock
+    );
+
+    // Prepare and delegate the unlock
+    const unlockData = randomBytes32();
+    const unlockHash = await newUnlockHash(
+      noto,
+      [locked2],
+      [],
+      [txo3],
+      unlockData
+    );
+    await doPrepareUnlock(notary, noto, [locked2], unlockHash, unlockData);
+    await doDelegateLock(
+      notary,
+      noto,
+      unlockHash,
+      delegate.address,
+      randomBytes32()
+    );
+
+    // Cannot reclaim before the expiry
+    await expect(
+      doReclaimLock(notary, noto, [locked2], [txo3], randomBytes32())
+    ).to.be.rejectedWith("NotoLockNotExpired");
+
+    // After the expiry the notary can reclaim, and the delegate can no longer unlock
+    await mine(20);
+    await expect(
+      doReclaimLock(delegate, noto, [locked2], [txo3], randomBytes32())
+    ).to.be.rejectedWith("NotoNotNotary");
+    await doReclaimLock(notary, noto, [locked2], [txo3], randomBytes32());
+    expect((await noto.getLockExpiry(locked2)).blockNumber).to.equal(0);
+    await expect(
+      doUnlock(delegate, noto, [locked2], [], [txo3], unlockData)
+    ).to.be.rejectedWith("NotoInvalidInput");
+  });
+
+  it("locks without expiry cannot be reclaimed", async function () {
+    const { noto, notary } = await loadFixture(deployNotoFixture);
+
+    const txo1 = fakeTXO();
+    const locked1 = fakeTXO();
+    await doTransfer(notary, noto, [], [txo1], randomBytes32());
+    await doLock(notary, noto, [txo1], [], [locked1], randomBytes32());
+
+    await expect(
+      doReclaimLock(notary, noto, [locked1], [fakeTXO()], randomBytes32())
+    ).to.be.rejectedWith("NotoLockNotExpired");
+  });
+
+  it("change notary", async function () {
+    const { noto, notary, other } = await loadFixture(deployNotoFixture);
+
+    const txo1 = fakeTXO();
+    const txo2 = fakeTXO();
+    await doTransfer(notary, noto, [], [txo1], randomBytes32());
+
+    // Only the current notary can hand over control
+    await expect(
+      noto.connect(other).changeNotary(other.address, "0x", "0x", "0x")
+    ).to.be.rejectedWith("NotoNotNotary");
+
+    const tx = await noto
+      .connect(notary)
+      .changeNotary(other.address, "0x1234", "0x", "0x");
+    const results = await tx.wait();
+    const event = noto.interface.parseLog(results!.logs[0]);
+    expect(event?.name).to.equal("NotoNotaryChanged");
+    expect(event?.args.notary).to.equal(other.address);
+    const [notaryAddress, , configData] =
+      ethers.AbiCoder.defaultAbiCoder().decode(
+        ["address", "uint64", "bytes"],
+        ethers.dataSlice(event?.args.config, 4)
+      );
+    expect(notaryAddress).to.equal(other.address);
+    expect(configData).to.equal("0x1234");
+
+    // The old notary can no longer submit transactions
+    await expect(
+      doTransfer(notary, noto, [txo1], [txo2], randomBytes32())
+    ).to.be.rejectedWith("NotoNotNotary");
+    await doTransfer(other, noto, [txo1], [txo2], randomBytes32());
+  });
 });
@@ -137,6 +137,35 @@ export async function doLock(
   }
 }
 
+export async function doLockWithExpiry(
+  notary: Signer,
+  noto: Noto,
+  inputs: string[],
+  outputs: string[],
+  lockedOutputs: string[],
+  expiry: { blockNumber: number; timestamp: number },
+  data: string
+) {
+  const tx = await noto
+    .connect(notary)
+    .lockWithExpiry(inputs, outputs, lockedOutputs, expiry, "0x", data);
+  const results = await tx.wait();
+  expect(results).to.exist;
+
+  for (const log of results?.logs || []) {
+    const event = noto.interface.parseLog(log);
+    expect(event).to.exist;
+    expect(event?.name).to.equal("NotoLock");
+    expect(event?.args.lockedOutputs).to.deep.equal(lockedOutputs);
+  }
+  for (const output of lockedOutputs) {
+    expect(await noto.isLocked(output)).to.equal(true);
+    const lockExpiry = await noto.getLockExpiry(output);
+    expect(lockExpiry.blockNumber).to.equal(expiry.blockNumber);
+    expect(lockExpiry.timestamp).to.equal(expiry.timestamp);
+  }
+}
+
 export async function doUnlock(
   sender: Signer,
   noto: Noto,
@@ -173,6 +202,35 @@ export async function doUnlock(
   }
 }
 
+export async function doReclaimLock(
+  notary: Signer,
+  noto: Noto,
+  lockedInputs: string[],
+  outputs: string[],
+  data: string
+) {
+  const tx = await noto
+    .connect(notary)
+    .reclaimLock(lockedInputs, outputs, "0x", data);
+  const results = await tx.wait();
+  expect(results).to.exist;
+
+  for (const log of results?.logs || []) {
+    const event = noto.interface.parseLog(log);
+    expect(event).to.exist;
+    expect(event?.name).to.equal("NotoUnlock");
+    expect(event?.args.lockedInputs).to.deep.equal(lockedInputs);
+    expect(event?.args.lockedOutputs).to.deep.equal([]);
+    expect(event?.args.outputs).to.deep.equal(outputs);
+  }
+  for (const input of lockedInputs) {
+    expect(await noto.isLocked(input)).to.equal(false);
+  }
+  for (const output of outputs) {
+    expect(await noto.isUnspent(output)).to.equal(true);
+  }
+}
+
 export async function doPrepareUnlock(
   notary: Signer,
   noto: Noto,
@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// SkipRemainingStates can be returned by the function passed to ForEachState, to stop paging
+// once it has found the states it needs. ForEachState then returns nil.
+var SkipRemainingStates = errors.New("skip remaining states")
+
+// ForEachState pages through all the states returned by find for the query, in the order they were created.
+// Each page starts after the creation time and ID of the last state, so that states created at the same time
+// are never skipped.
+func ForEachState(ctx context.Context, pageSize int, newQuery func() query.QueryBuilder, find func(query string) ([]*pb.StoredState, error), fn func(state *pb.StoredState) error) error {
+	var last *pb.StoredState
+	for {
+		queryBuilder := newQuery().
+			Limit(pageSize).
+			Sort(".created", ".id")
+
+		if last != nil {
+			queryBuilder.Or(
+				query.NewQueryBuilder().GreaterThan(".created", last.CreatedAt),
+				query.NewQueryBuilder().Equal(".created", last.CreatedAt).GreaterThan(".id", last.Id),
+			)
+		}
+
+		log.L(ctx).Debugf("State query: %s", queryBuilder.Query())
+		states, err := find(queryBuilder.Query().String())
+		if err != nil {
+			return err
+		}
+		if len(states) == 0 {
+			return nil
+		}
+		for _, state := range states {
+			last = state
+			if err := fn(state); err != nil {
+				if errors.Is(err, SkipRemainingStates) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachStatePaging(t *testing.T) {
+	ctx := context.Background()
+	pages := [][]*pb.StoredState{
+		{{Id: "0x01", CreatedAt: 1}, {Id: "0x02", CreatedAt: 2}},
+		{{Id: "0x03", CreatedAt: 2}},
+		{},
+	}
+	var queries []*query.QueryJSON
+	var ids []string
+	err := ForEachState(ctx, 2, query.NewQueryBuilder,
+		func(q string) ([]*pb.StoredState, error) {
+			var jq query.QueryJSON
+			require.NoError(t, json.Unmarshal([]byte(q), &jq))
+			queries = append(queries, &jq)
+			return pages[len(queries)-1], nil
+		},
+		func(state *pb.StoredState) error {
+			ids = append(ids, state.Id)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x01", "0x02", "0x03"}, ids)
+	require.Len(t, queries, 3)
+	assert.Equal(t, 2, *queries[0].Limit)
+	assert.Equal(t, []string{".created", ".id"}, queries[0].Sort)
+	assert.Empty(t, queries[0].Or)
+
+	// States created at the same time as the last state are only skipped if they sort before it by ID
+	require.Len(t, queries[1].Or, 2)
+	assert.JSONEq(t, `[
+		{"gt": [{"field": ".created", "value": 2}]},
+		{"eq": [{"field": ".created", "value": 2}], "gt": [{"field": ".id", "value": "0x02"}]}
+	]`, pldtypes.JSONString(queries[1].Or).String())
+	assert.JSONEq(t, `[
+		{"gt": [{"field": ".created", "value": 2}]},
+		{"eq": [{"field": ".created", "value": 2}], "gt": [{"field": ".id", "value": "0x03"}]}
+	]`, pldtypes.JSONString(queries[2].Or).String())
+}
+
+func TestForEachStateSkipRemaining(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	var ids []string
+	err := ForEachState(ctx, 2, query.NewQueryBuilder,
+		func(q string) ([]*pb.StoredState, error) {
+			calls++
+			return []*pb.StoredState{{Id: "0x01", CreatedAt: 1}, {Id: "0x02", CreatedAt: 2}}, nil
+		},
+		func(state *pb.StoredState) error {
+			ids = append(ids, state.Id)
+			return SkipRemainingStates
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x01"}, ids)
+	assert.Equal(t, 1, calls)
+}
+
+func TestForEachStateErrors(t *testing.T) {
+	ctx := context.Background()
+	err := ForEachState(ctx, 10, query.NewQueryBuilder,
+		func(q string) ([]*pb.StoredState, error) {
+			return nil, fmt.Errorf("pop")
+		},
+		func(state *pb.StoredState) error {
+			return nil
+		})
+	assert.EqualError(t, err, "pop")
+
+	err = ForEachState(ctx, 10, query.NewQueryBuilder,
+		func(q string) ([]*pb.StoredState, error) {
+			return []*pb.StoredState{{Id: "0x01"}}, nil
+		},
+		func(state *pb.StoredState) error {
+			return fmt.Errorf("pop")
+		})
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpserver
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const MetricsPath = "/metrics"
+
+// MetricsHandler serves the Prometheus text exposition format for the supplied registry
+func MetricsHandler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+func NewMetricsServer(ctx context.Context, metricsServerConf *pldconf.HTTPServerConfig, registry *prometheus.Registry) (_ Server, err error) {
+	r := mux.NewRouter()
+	r.Path(MetricsPath).Handler(MetricsHandler(registry))
+	server, err := NewServer(ctx, "metrics", metricsServerConf, r)
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Metrics server running on %s", server.Addr())
+	return server, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsServer(t *testing.T) {
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "test_metric_total",
+		Help: "Test metric",
+	})
+	registry.MustRegister(counter)
+	counter.Inc()
+
+	s, err := NewMetricsServer(context.Background(), &pldconf.HTTPServerConfig{
+		Address: confutil.P("127.0.0.1"),
+		Port:    confutil.P(0),
+	}, registry)
+	require.NoError(t, err)
+	err = s.Start()
+	require.NoError(t, err)
+	defer s.Stop()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/metrics", s.Addr()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Regexp(t, "test_metric_total 1", string(b))
+
+}
+
+func TestMetricsServerFail(t *testing.T) {
+
+	_, err := NewMetricsServer(context.Background(), &pldconf.HTTPServerConfig{}, prometheus.NewRegistry())
+	assert.Regexp(t, "PD020601", err)
+
+}
@@ -0,0 +1,307 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package plugintk
+
+import (
+	"context"
+	"crypto/subtle"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/tlsconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PluginMain is the main function for a plugin built as an executable, rather than as a c-shared library.
+// The same PluginBase factory that is passed to NewPluginLibraryEntrypoint can be passed here.
+//
+// The executable supports the two plugin library types that run outside of the Paladin process:
+//
+//   - process: started by Paladin with "-grpc-target <url> -plugin-id <uuid>", and runs until SIGTERM
+//   - remote: started independently with "-listen <host:port|unix:path>", and runs plugin instances
+//     whenever Paladin asks it to over the PluginRunner gRPC service. As anybody who can reach the
+//     listener could otherwise point the plugin at a controller of their choosing, Paladin must
+//     authenticate with a client certificate ("-tls-cert", "-tls-key" and "-tls-ca"), or with the
+//     shared token in "-auth-token-file" (or both).
+func PluginMain(factory func() PluginBase) {
+	ctx, cancelCtx := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	rc := runPluginExecutable(ctx, factory, os.Args[1:])
+	cancelCtx()
+	os.Exit(rc)
+}
+
+func runPluginExecutable(ctx context.Context, factory func() PluginBase, args []string) int {
+	fs := flag.NewFlagSet("plugin", flag.ContinueOnError)
+	grpcTarget := fs.String("grpc-target", "", "gRPC target URL of the Paladin plugin controller (process mode)")
+	pluginID := fs.String("plugin-id", "", "ID of the plugin allocated by Paladin (process mode)")
+	listen := fs.String("listen", "", "address to listen on for requests to start plugins (remote mode)")
+	var ls listenerSecurity
+	fs.StringVar(&ls.certFile, "tls-cert", "", "TLS certificate file for the listener (remote mode)")
+	fs.StringVar(&ls.keyFile, "tls-key", "", "TLS private key file for the listener (remote mode)")
+	fs.StringVar(&ls.caFile, "tls-ca", "", "CA file to require and verify client certificates on the listener (remote mode)")
+	fs.StringVar(&ls.tokenFile, "auth-token-file", "", "file containing a token that requests to the listener must present (remote mode)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ple := NewPluginLibraryEntrypoint(factory)
+	switch {
+	case *grpcTarget != "" && *pluginID != "" && *listen == "":
+		return runPluginProcess(ctx, ple, *grpcTarget, *pluginID)
+	case *listen != "" && *grpcTarget == "" && *pluginID == "":
+		if ls.caFile == "" && ls.tokenFile == "" {
+			fmt.Fprintln(fs.Output(), i18n.NewError(ctx, pldmsgs.MsgPluginRunnerAuthRequired))
+			fs.Usage()
+			return 2
+		}
+		return runPluginListener(ctx, ple, *listen, &ls)
+	default:
+		fmt.Fprintln(fs.Output(), "either -grpc-target and -plugin-id, or -listen, must be specified")
+		fs.Usage()
+		return 2
+	}
+}
+
+func runPluginProcess(ctx context.Context, ple *PluginLibraryEntrypoint, grpcTarget, pluginID string) int {
+	result := make(chan int, 1)
+	go func() {
+		result <- ple.Run(grpcTarget, pluginID)
+	}()
+
+	select {
+	case rc := <-result:
+		return rc
+	case <-ctx.Done():
+	}
+
+	// We might have been signalled before the plugin started, so keep trying until it stops
+	for {
+		ple.Stop(pluginID)
+		select {
+		case rc := <-result:
+			return rc
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+type pluginRunner struct {
+	prototk.UnimplementedPluginRunnerServer
+	ctx     context.Context
+	ple     *PluginLibraryEntrypoint
+	mux     sync.Mutex
+	running map[string]string // plugin name to the ID of the running instance
+	wg      sync.WaitGroup
+}
+
+type listenerSecurity struct {
+	certFile  string
+	keyFile   string
+	caFile    string
+	tokenFile string
+}
+
+func (ls *listenerSecurity) serverOptions(ctx context.Context) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	if ls.certFile != "" || ls.keyFile != "" || ls.caFile != "" {
+		if ls.certFile == "" || ls.keyFile == "" {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgPluginRunnerTLSKeyPair)
+		}
+		tlsConfig, err := tlsconf.BuildTLSConfig(ctx, &pldconf.TLSConfig{
+			Enabled:    true,
+			CertFile:   ls.certFile,
+			KeyFile:    ls.keyFile,
+			CAFile:     ls.caFile,
+			ClientAuth: ls.caFile != "",
+		}, tlsconf.ServerType)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	if ls.tokenFile != "" {
+		token, err := ReadAuthTokenFile(ctx, ls.tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.UnaryInterceptor(authTokenInterceptor(token)))
+	}
+	return opts, nil
+}
+
+// ReadAuthTokenFile reads the shared token used to authenticate Paladin to remote plugins
+func ReadAuthTokenFile(ctx context.Context, path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", i18n.WrapError(ctx, err, pldmsgs.MsgPluginAuthTokenFile, path)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", i18n.NewError(ctx, pldmsgs.MsgPluginAuthTokenEmpty, path)
+	}
+	return token, nil
+}
+
+// AuthTokenCredentials sends the shared token with every request to a remote plugin.
+// The token is sent in the clear unless the connection uses TLS, which should always
+// be the case for a plugin listening on a TCP address.
+func AuthTokenCredentials(token string) credentials.PerRPCCredentials {
+	return authTokenCredentials(token)
+}
+
+type authTokenCredentials string
+
+func (t authTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t authTokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func authTokenInterceptor(token string) grpc.UnaryServerInterceptor {
+	expected := []byte("Bearer " + token)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		supplied := md.Get("authorization")
+		if len(supplied) != 1 || subtle.ConstantTimeCompare([]byte(supplied[0]), expected) != 1 {
+			return nil, status.Error(codes.Unauthenticated, i18n.NewError(ctx, pldmsgs.MsgPluginRunnerUnauthorized).Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+func runPluginListener(ctx context.Context, ple *PluginLibraryEntrypoint, listen string, ls *listenerSecurity) int {
+	serverOptions, err := ls.serverOptions(ctx)
+	if err != nil {
+		log.L(ctx).Errorf("Invalid listener security configuration: %s", err)
+		return 1
+	}
+	if ls.tokenFile != "" && ls.certFile == "" && !strings.HasPrefix(listen, "unix:") {
+		log.L(ctx).Warnf("Listening on %s without TLS, so the auth token is sent in the clear", listen)
+	}
+
+	network, address := "tcp", listen
+	if udsPath, isUDS := strings.CutPrefix(listen, "unix:"); isUDS {
+		network, address = "unix", udsPath
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to listen on %s: %s", listen, err)
+		return 1
+	}
+
+	pr := &pluginRunner{
+		ctx:     ctx,
+		ple:     ple,
+		running: make(map[string]string),
+	}
+	server := grpc.NewServer(serverOptions...)
+	prototk.RegisterPluginRunnerServer(server, pr)
+
+	serverDone := make(chan error, 1)
+	go func() {
+		log.L(ctx).Infof("Plugin runner listening on %s", listener.Addr())
+		serverDone <- server.Serve(listener)
+	}()
+
+	rc := 0
+	select {
+	case err := <-serverDone:
+		log.L(ctx).Errorf("Plugin runner server failed: %s", err)
+		rc = 1
+	case <-ctx.Done():
+		server.Stop()
+	}
+	pr.stopAll()
+	return rc
+}
+
+// Paladin asks us to start a plugin each time it starts, and whenever the plugin disconnects.
+// As Paladin allocates new plugin IDs each time it starts, any instance we are running with
+// the same name but a different ID is left over from a previous run of Paladin, and is replaced.
+func (pr *pluginRunner) StartPlugin(ctx context.Context, req *prototk.StartPluginRequest) (*prototk.EmptyResponse, error) {
+	name, pluginID := req.GetPlugin().GetName(), req.GetPlugin().GetId()
+
+	pr.mux.Lock()
+	defer pr.mux.Unlock()
+	if previousID, running := pr.running[name]; running {
+		if previousID == pluginID {
+			// The instance retries its connection indefinitely, so we leave it to reconnect
+			log.L(ctx).Infof("Plugin %s [%s] already running", name, pluginID)
+			return &prototk.EmptyResponse{}, nil
+		}
+		log.L(ctx).Infof("Replacing plugin %s [%s] with [%s]", name, previousID, pluginID)
+		pr.ple.Stop(previousID)
+	}
+	pr.running[name] = pluginID
+
+	pr.wg.Add(1)
+	go func() {
+		defer pr.wg.Done()
+		rc := pr.ple.Run(req.GrpcTarget, pluginID)
+		log.L(pr.ctx).Infof("Plugin %s [%s] stopped rc=%d", name, pluginID, rc)
+		pr.mux.Lock()
+		if pr.running[name] == pluginID {
+			delete(pr.running, name)
+		}
+		pr.mux.Unlock()
+	}()
+	return &prototk.EmptyResponse{}, nil
+}
+
+func (pr *pluginRunner) stopAll() {
+	allStopped := make(chan struct{})
+	go func() {
+		pr.wg.Wait()
+		close(allStopped)
+	}()
+
+	// As with a process, an instance might not have registered with the entrypoint yet
+	for {
+		pr.mux.Lock()
+		running := make([]string, 0, len(pr.running))
+		for _, pluginID := range pr.running {
+			running = append(running, pluginID)
+		}
+		pr.mux.Unlock()
+
+		for _, pluginID := range running {
+			pr.ple.Stop(pluginID)
+		}
+		select {
+		case <-allStopped:
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package plugintk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func writeTokenFile(t *testing.T, token string) string {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(token), 0600))
+	return tokenFile
+}
+
+func testDomainFactory() PluginBase {
+	return NewDomain(func(callbacks DomainCallbacks) DomainAPI {
+		return &DomainAPIBase{}
+	})
+}
+
+func TestRunPluginExecutableBadArgs(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, 2, runPluginExecutable(ctx, testDomainFactory, []string{"-wrong"}))
+	assert.Equal(t, 2, runPluginExecutable(ctx, testDomainFactory, []string{}))
+	assert.Equal(t, 2, runPluginExecutable(ctx, testDomainFactory, []string{"-grpc-target", "unix:/tmp/x.sock"}))
+	assert.Equal(t, 2, runPluginExecutable(ctx, testDomainFactory, []string{
+		"-grpc-target", "unix:/tmp/x.sock", "-plugin-id", uuid.NewString(), "-listen", "127.0.0.1:0",
+	}))
+	// Listening requires either mTLS or a token
+	assert.Equal(t, 2, runPluginExecutable(ctx, testDomainFactory, []string{"-listen", "127.0.0.1:0"}))
+	assert.Equal(t, 2, runPluginExecutable(ctx, testDomainFactory, []string{
+		"-listen", "127.0.0.1:0", "-tls-cert", "cert.pem", "-tls-key", "key.pem",
+	}))
+}
+
+func TestRunPluginExecutableProcess(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	done := make(chan int)
+	go func() {
+		done <- runPluginExecutable(ctx, testDomainFactory, []string{
+			"-grpc-target", tempSocketFile(t),
+			"-plugin-id", uuid.NewString(),
+		})
+	}()
+
+	// Stops the plugin on a signal, even though it never connected
+	time.Sleep(10 * time.Millisecond)
+	cancelCtx()
+	assert.Equal(t, 0, <-done)
+}
+
+func TestRunPluginExecutableProcessPanic(t *testing.T) {
+	rc := runPluginExecutable(context.Background(), func() PluginBase { panic("pop") }, []string{
+		"-grpc-target", tempSocketFile(t),
+		"-plugin-id", uuid.NewString(),
+	})
+	assert.Equal(t, 1, rc)
+}
+
+func TestRunPluginExecutableListener(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	done := make(chan int)
+	go func() {
+		done <- runPluginExecutable(ctx, testDomainFactory, []string{
+			"-listen", "unix:" + tempSocketFile(t),
+			"-auth-token-file", writeTokenFile(t, "secret"),
+		})
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancelCtx()
+	assert.Equal(t, 0, <-done)
+}
+
+func TestRunPluginExecutableListenFail(t *testing.T) {
+	rc := runPluginExecutable(context.Background(), testDomainFactory, []string{
+		"-listen", "unix:" + t.TempDir() + "/missing/dir.sock",
+		"-auth-token-file", writeTokenFile(t, "secret"),
+	})
+	assert.Equal(t, 1, rc)
+}
+
+func TestRunPluginExecutableListenBadSecurity(t *testing.T) {
+	ctx := context.Background()
+	listen := "unix:" + tempSocketFile(t)
+	assert.Equal(t, 1, runPluginExecutable(ctx, testDomainFactory, []string{
+		"-listen", listen, "-auth-token-file", filepath.Join(t.TempDir(), "missing"),
+	}))
+	assert.Equal(t, 1, runPluginExecutable(ctx, testDomainFactory, []string{
+		"-listen", listen, "-auth-token-file", writeTokenFile(t, " \n"),
+	}))
+	assert.Equal(t, 1, runPluginExecutable(ctx, testDomainFactory, []string{
+		"-listen", listen, "-tls-ca", "ca.pem",
+	}))
+	assert.Equal(t, 1, runPluginExecutable(ctx, testDomainFactory, []string{
+		"-listen", listen, "-tls-cert", "missing.pem", "-tls-key", "missing.pem", "-tls-ca", "missing.pem",
+	}))
+}
+
+func TestRunPluginExecutableListenerAuthToken(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	socketFile := tempSocketFile(t)
+	done := make(chan int)
+	go func() {
+		done <- runPluginExecutable(ctx, testDomainFactory, []string{
+			"-listen", "unix:" + socketFile,
+			"-auth-token-file", writeTokenFile(t, "secret\n"),
+		})
+	}()
+
+	startPlugin := func(opts ...grpc.DialOption) error {
+		conn, err := grpc.NewClient("unix:"+socketFile, append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = prototk.NewPluginRunnerClient(conn).StartPlugin(ctx, &prototk.StartPluginRequest{
+			Plugin:     &prototk.PluginInfo{Id: uuid.NewString(), Name: "domain1"},
+			GrpcTarget: tempSocketFile(t),
+		}, grpc.WaitForReady(true))
+		return err
+	}
+
+	err := startPlugin()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Regexp(t, "PD020308", err)
+
+	err = startPlugin(grpc.WithPerRPCCredentials(AuthTokenCredentials("wrong")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	err = startPlugin(grpc.WithPerRPCCredentials(AuthTokenCredentials("secret")))
+	require.NoError(t, err)
+
+	cancelCtx()
+	assert.Equal(t, 0, <-done)
+}
+
+func TestPluginRunnerStartPlugin(t *testing.T) {
+	ctx := context.Background()
+	pr := &pluginRunner{
+		ctx:     ctx,
+		ple:     NewPluginLibraryEntrypoint(testDomainFactory),
+		running: make(map[string]string),
+	}
+	defer pr.stopAll()
+
+	startPlugin := func(pluginID string) {
+		_, err := pr.StartPlugin(ctx, &prototk.StartPluginRequest{
+			Plugin:     &prototk.PluginInfo{Id: pluginID, Name: "domain1"},
+			GrpcTarget: tempSocketFile(t),
+		})
+		require.NoError(t, err)
+	}
+	waitRunning := func(pluginID string) {
+		for {
+			pr.ple.l.Lock()
+			_, running := pr.ple.plugins[pluginID]
+			pr.ple.l.Unlock()
+			if running {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	firstID := uuid.NewString()
+	startPlugin(firstID)
+	waitRunning(firstID)
+
+	// A second request for the same instance leaves it running
+	startPlugin(firstID)
+	pr.mux.Lock()
+	assert.Equal(t, map[string]string{"domain1": firstID}, pr.running)
+	pr.mux.Unlock()
+
+	// A request with a new ID replaces the old instance
+	secondID := uuid.NewString()
+	startPlugin(secondID)
+	waitRunning(secondID)
+	pr.ple.l.Lock()
+	assert.Len(t, pr.ple.plugins, 1)
+	pr.ple.l.Unlock()
+	pr.mux.Lock()
+	assert.Equal(t, map[string]string{"domain1": secondID}, pr.running)
+	pr.mux.Unlock()
+
+	pr.stopAll()
+	pr.mux.Lock()
+	assert.Empty(t, pr.running)
+	pr.mux.Unlock()
+}
@@ -35,9 +35,10 @@ type testController struct {
 	server     *grpc.Server
 	socketFile string
 
-	fakeDomainController    func(grpc.BidiStreamingServer[prototk.DomainMessage, prototk.DomainMessage]) error
-	fakeTransportController func(grpc.BidiStreamingServer[prototk.TransportMessage, prototk.TransportMessage]) error
-	fakeRegistryController  func(grpc.BidiStreamingServer[prototk.RegistryMessage, prototk.RegistryMessage]) error
+	fakeDomainController        func(grpc.BidiStreamingServer[prototk.DomainMessage, prototk.DomainMessage]) error
+	fakeTransportController     func(grpc.BidiStreamingServer[prototk.TransportMessage, prototk.TransportMessage]) error
+	fakeRegistryController      func(grpc.BidiStreamingServer[prototk.RegistryMessage, prototk.RegistryMessage]) error
+	fakeSigningModuleController func(grpc.BidiStreamingServer[prototk.SigningModuleMessage, prototk.SigningModuleMessage]) error
 }
 
 func newTestController(t *testing.T) (context.Context, *testController, func()) {
@@ -77,6 +78,10 @@ func (tc *testController) ConnectRegistry(stream grpc.BidiStreamingServer[protot
 	return tc.fakeRegistryController(stream)
 }
 
+func (tc *testController) ConnectSigningModule(stream grpc.BidiStreamingServer[prototk.SigningModuleMessage, prototk.SigningModuleMessage]) error {
+	return tc.fakeSigningModuleController(stream)
+}
+
 func tempSocketFile(t *testing.T) string {
 	// note socket filenames need to be <108 chars
 	f, err := os.CreateTemp("", "ptk.*.sock")
@@ -27,6 +27,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/inflight"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -210,8 +211,13 @@ func (pr *pluginRun[M]) handleRequestToPlugin(msg PluginMessage[M]) {
 	replyID := uuid.NewString()
 	var replyHeader *prototk.Header
 
+	// Continue the trace of the Paladin runtime
+	ctx, span := tracing.StartSpan(tracing.ExtractMap(pr.ctx, header.TraceContext), PluginMessageSpanName(pr.pi.pluginType, msg.RequestToPlugin()),
+		tracing.AttrPluginID.String(pr.pi.id))
+
 	// Call the handler
-	reply, err := pr.handler.RequestToPlugin(pr.ctx, msg)
+	reply, err := pr.handler.RequestToPlugin(ctx, msg)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		// Build an new message containing only the error
 		reply = pr.pi.impl.Wrap(new(M))
@@ -244,6 +250,7 @@ func (pr *pluginRun[M]) RequestFromPlugin(ctx context.Context, req PluginMessage
 	header.MessageId = reqID.String()
 	header.MessageType = prototk.Header_REQUEST_FROM_PLUGIN
 	header.ErrorMessage = nil
+	header.TraceContext = tracing.InjectMap(ctx)
 
 	// Log under our context so we get the plugin ID
 	l := log.L(pr.ctx)
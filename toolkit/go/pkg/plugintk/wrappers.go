@@ -16,6 +16,8 @@ package plugintk
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
@@ -79,6 +81,14 @@ func PluginMessageToJSON[M any](msg PluginMessage[M]) (s string) {
 	return
 }
 
+// PluginMessageSpanName gives a readable trace span name for a request body, such as "DOMAIN InitDomain"
+func PluginMessageSpanName(pluginType string, body any) string {
+	name := fmt.Sprintf("%T", body)
+	name = name[strings.LastIndex(name, ".")+1:]
+	name = name[strings.LastIndex(name, "_")+1:]
+	return fmt.Sprintf("%s %s", pluginType, name)
+}
+
 // Maps the response payload to the requested type (avoids boilerplate in plugin types)
 func responseToPluginAs[M, ResType, T any](ctx context.Context, msg PluginMessage[M], err error, unwrap func(*ResType) *T) (*T, error) {
 	if err != nil {
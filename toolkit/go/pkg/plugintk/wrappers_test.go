@@ -66,3 +66,8 @@ func TestResponseToPluginAs(t *testing.T) {
 		})
 	assert.Regexp(t, "PD020301", err)
 }
+
+func TestPluginMessageSpanName(t *testing.T) {
+	assert.Equal(t, "DOMAIN ConfigureDomain", PluginMessageSpanName("DOMAIN", &prototk.DomainMessage_ConfigureDomain{}))
+	assert.Equal(t, "TRANSPORT SendMessage", PluginMessageSpanName("TRANSPORT", &prototk.TransportMessage_SendMessage{}))
+}
@@ -81,6 +81,8 @@ var allTypes = []interface{}{
 	pldapi.TransactionReceiptListener{},
 	pldapi.TransactionReceiptFilters{},
 	pldapi.TransactionReceiptListenerOptions{},
+	pldapi.ListenerWebhook{},
+	pldapi.ListenerDeadLetter{},
 	pldapi.TransactionStates{},
 	pldapi.TransactionInput{},
 	pldapi.TransactionFull{},
@@ -88,6 +90,7 @@ var allTypes = []interface{}{
 	pldapi.Transaction{},
 	pldapi.PreparedTransaction{},
 	pldapi.PublicTx{},
+	pldapi.PublicTxNonceGap{},
 	pldapi.StoredABI{
 		ABI: abi.ABI{
 			&abi.Entry{
@@ -114,9 +117,11 @@ var allTypes = []interface{}{
 	pldapi.IndexedBlock{},
 	pldapi.IndexedTransaction{},
 	pldapi.IndexedEvent{},
+	pldapi.BlockchainStatus{},
 	pldapi.EventWithData{},
 	pldapi.ABIDecodedData{},
 	pldapi.PeerInfo{},
+	pldapi.PluginStatus{},
 	pldapi.KeyMappingAndVerifier{},
 	pldapi.ReliableMessageAck{},
 	pldapi.ReliableMessage{},
@@ -238,6 +243,7 @@ var allAPITypes = []pldclient.RPCModule{
 	pldclient.New().StateStore(),
 	pldclient.New().BlockIndex(),
 	pldclient.New().PrivacyGroups(),
+	pldclient.New().Plugins(),
 }
 
 var allSimpleTypes = []interface{}{
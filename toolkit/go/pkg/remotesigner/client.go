@@ -0,0 +1,109 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package remotesigner
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signer"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+)
+
+// The JSON/RPC methods exposed by a remote signer, each of which takes a single
+// parameter that is the signerapi request object, and returns the signerapi response object.
+const (
+	MethodResolve = "signer_resolve"
+	MethodSign    = "signer_sign"
+	MethodList    = "signer_list"
+)
+
+type remoteSigningModule struct {
+	rpc             rpcclient.Client
+	wsClient        rpcclient.WSClient
+	inMemorySigners *signer.OutOfProcessInMemorySigners
+}
+
+// NewRemoteSigningModule returns a signer.SigningModule that forwards all requests to a signing
+// module running in a separate process (such as the one started with NewServer) over JSON/RPC.
+//
+// If a WebSocket URL is configured a single long-lived connection is established and
+// used for all requests, otherwise the HTTP client is used.
+//
+// The remote signer must host any in-memory signers it needs itself, and the prefixes of those
+// it does are passed in hostedInMemorySigners (see signer.OutOfProcessInMemorySigners).
+func NewRemoteSigningModule(ctx context.Context, conf *pldconf.RemoteSignerConfig, hostedInMemorySigners ...string) (_ signer.SigningModule, err error) {
+	rsm := &remoteSigningModule{
+		inMemorySigners: signer.NewOutOfProcessInMemorySigners(hostedInMemorySigners),
+	}
+	switch {
+	case conf.WS.URL != "" && conf.HTTP.URL == "":
+		if rsm.wsClient, err = rpcclient.NewWSClient(ctx, &conf.WS); err == nil {
+			err = rsm.wsClient.Connect(ctx)
+		}
+		rsm.rpc = rsm.wsClient
+	case conf.HTTP.URL != "" && conf.WS.URL == "":
+		rsm.rpc, err = rpcclient.NewHTTPClient(ctx, &conf.HTTP)
+	default:
+		err = i18n.NewError(ctx, pldmsgs.MsgSigningRemoteNoEndpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rsm, nil
+}
+
+// In-memory signers (such as those used by domains for ZKP proof generation) must be
+// registered with the signing module in the remote process, as they cannot be remoted.
+func (rsm *remoteSigningModule) AddInMemorySigner(prefix string, _ signerapi.InMemorySigner) error {
+	return rsm.inMemorySigners.Add(context.Background(), prefix)
+}
+
+func (rsm *remoteSigningModule) Resolve(ctx context.Context, req *signerapi.ResolveKeyRequest) (res *signerapi.ResolveKeyResponse, err error) {
+	if err := rsm.inMemorySigners.CheckResolve(ctx, req); err != nil {
+		return nil, err
+	}
+	if rpcErr := rsm.rpc.CallRPC(ctx, &res, MethodResolve, req); rpcErr != nil {
+		return nil, rpcErr
+	}
+	return res, nil
+}
+
+func (rsm *remoteSigningModule) Sign(ctx context.Context, req *signerapi.SignRequest) (res *signerapi.SignResponse, err error) {
+	if err := rsm.inMemorySigners.CheckAlgorithm(ctx, req.Algorithm); err != nil {
+		return nil, err
+	}
+	if rpcErr := rsm.rpc.CallRPC(ctx, &res, MethodSign, req); rpcErr != nil {
+		return nil, rpcErr
+	}
+	return res, nil
+}
+
+func (rsm *remoteSigningModule) List(ctx context.Context, req *signerapi.ListKeysRequest) (res *signerapi.ListKeysResponse, err error) {
+	if rpcErr := rsm.rpc.CallRPC(ctx, &res, MethodList, req); rpcErr != nil {
+		return nil, rpcErr
+	}
+	return res, nil
+}
+
+func (rsm *remoteSigningModule) Close() {
+	if rsm.wsClient != nil {
+		rsm.wsClient.Close()
+	}
+}
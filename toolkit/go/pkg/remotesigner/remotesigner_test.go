@@ -0,0 +1,235 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package remotesigner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "extra monster happy tone improve slight duck equal sponsor fruit sister rate very bulb reopen mammal venture pull just motion faculty grab tenant kind"
+
+func newTestServer(t *testing.T) (context.Context, Server) {
+	conf := newTestServerConfig()
+	conf.AllowUnauthenticated = true
+	return startTestServer(t, conf)
+}
+
+func startTestServer(t *testing.T, conf *ServerConfig) (context.Context, Server) {
+	ctx := context.Background()
+	s, err := NewServer(ctx, conf)
+	require.NoError(t, err)
+	require.NotNil(t, s.SigningModule())
+	err = s.Start()
+	require.NoError(t, err)
+	t.Cleanup(s.Stop)
+	return ctx, s
+}
+
+func newTestServerConfig() *ServerConfig {
+	return &ServerConfig{
+		RPCServer: pldconf.RPCServerConfig{
+			HTTP: pldconf.RPCServerConfigHTTP{
+				HTTPServerConfig: pldconf.HTTPServerConfig{
+					Address: confutil.P("127.0.0.1"),
+					Port:    confutil.P(0),
+				},
+			},
+			WS: pldconf.RPCServerConfigWS{
+				HTTPServerConfig: pldconf.HTTPServerConfig{
+					Address: confutil.P("127.0.0.1"),
+					Port:    confutil.P(0),
+				},
+			},
+		},
+		Signer: pldconf.SignerConfig{
+			KeyDerivation: pldconf.KeyDerivationConfig{
+				Type:                  pldconf.KeyDerivationTypeBIP32,
+				BIP44Prefix:           confutil.P("m/44'/60'/0'/0"),
+				BIP44HardenedSegments: confutil.P(0),
+			},
+			KeyStore: pldconf.KeyStoreConfig{
+				Type: pldconf.KeyStoreTypeStatic,
+				Static: pldconf.StaticKeyStoreConfig{
+					Keys: map[string]pldconf.StaticKeyEntryConfig{
+						"seed": {
+							Encoding: "none",
+							Inline:   testMnemonic,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testResolveSignList(t *testing.T, ctx context.Context, conf *pldconf.RemoteSignerConfig) {
+	rsm, err := NewRemoteSigningModule(ctx, conf)
+	require.NoError(t, err)
+	defer rsm.Close()
+
+	// The reference server does not host the domain signer
+	err = rsm.AddInMemorySigner("domain", nil)
+	assert.Regexp(t, "PD020831", err)
+
+	res, err := rsm.Resolve(ctx, &signerapi.ResolveKeyRequest{
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS}},
+		Name:                "key1",
+		Index:               0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/0'/0/0", res.KeyHandle)
+	assert.Equal(t, "0x6331ccb948aaf903a69d6054fd718062bd0d535c", res.Identifiers[0].Verifier)
+
+	resSign, err := rsm.Sign(ctx, &signerapi.SignRequest{
+		KeyHandle:   res.KeyHandle,
+		Algorithm:   algorithms.ECDSA_SECP256K1,
+		PayloadType: signpayloads.OPAQUE_TO_RSV,
+		Payload:     ([]byte)("some data"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, resSign.Payload, 65)
+
+	// HD wallets do not support listing
+	_, err = rsm.List(ctx, &signerapi.ListKeysRequest{Limit: 10})
+	assert.Regexp(t, "PD020815", err)
+}
+
+func TestRemoteSignerHTTP(t *testing.T) {
+	ctx, s := newTestServer(t)
+	testResolveSignList(t, ctx, &pldconf.RemoteSignerConfig{
+		HTTP: pldconf.HTTPClientConfig{
+			URL: fmt.Sprintf("http://%s", s.HTTPAddr()),
+		},
+	})
+}
+
+func TestRemoteSignerWebSocket(t *testing.T) {
+	ctx, s := newTestServer(t)
+	testResolveSignList(t, ctx, &pldconf.RemoteSignerConfig{
+		WS: pldconf.WSClientConfig{
+			HTTPClientConfig: pldconf.HTTPClientConfig{
+				URL: fmt.Sprintf("ws://%s", s.WSAddr()),
+			},
+		},
+	})
+}
+
+func TestRemoteSignerAuthToken(t *testing.T) {
+	conf := newTestServerConfig()
+	conf.RPCServer.WS.Disabled = true
+	conf.RPCServer.Auth = pldconf.RPCAuthConfig{
+		Enabled:      confutil.P(true),
+		StaticTokens: []pldconf.RPCAuthStaticToken{{Principal: "paladin", Token: "secret"}},
+	}
+	ctx, s := startTestServer(t, conf)
+
+	rsm, err := NewRemoteSigningModule(ctx, &pldconf.RemoteSignerConfig{
+		HTTP: pldconf.HTTPClientConfig{URL: fmt.Sprintf("http://%s", s.HTTPAddr())},
+	})
+	require.NoError(t, err)
+	_, err = rsm.Resolve(ctx, &signerapi.ResolveKeyRequest{Name: "key1"})
+	assert.Error(t, err)
+
+	testResolveSignList(t, ctx, &pldconf.RemoteSignerConfig{
+		HTTP: pldconf.HTTPClientConfig{
+			URL:         fmt.Sprintf("http://%s", s.HTTPAddr()),
+			HTTPHeaders: map[string]interface{}{"Authorization": "Bearer secret"},
+		},
+	})
+}
+
+func TestRemoteSignerConfigErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRemoteSigningModule(ctx, &pldconf.RemoteSignerConfig{})
+	assert.Regexp(t, "PD020828", err)
+
+	_, err = NewRemoteSigningModule(ctx, &pldconf.RemoteSignerConfig{
+		HTTP: pldconf.HTTPClientConfig{URL: "http://localhost:1234"},
+		WS:   pldconf.WSClientConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: "ws://localhost:1234"}},
+	})
+	assert.Regexp(t, "PD020828", err)
+
+	_, err = NewRemoteSigningModule(ctx, &pldconf.RemoteSignerConfig{
+		HTTP: pldconf.HTTPClientConfig{URL: "wrong://localhost:1234"},
+	})
+	assert.Regexp(t, "PD020501", err)
+
+	_, err = NewServer(ctx, &ServerConfig{})
+	assert.Regexp(t, "PD020833", err)
+
+	_, err = NewServer(ctx, &ServerConfig{
+		AllowUnauthenticated: true,
+		Signer: pldconf.SignerConfig{
+			KeyStore: pldconf.KeyStoreConfig{Type: "wrong"},
+		},
+	})
+	assert.Regexp(t, "PD020807", err)
+}
+
+func TestRemoteSignerServerDown(t *testing.T) {
+	ctx := context.Background()
+	rsm, err := NewRemoteSigningModule(ctx, &pldconf.RemoteSignerConfig{
+		HTTP: pldconf.HTTPClientConfig{URL: "http://127.0.0.1:1"},
+	})
+	require.NoError(t, err)
+
+	_, err = rsm.Resolve(ctx, &signerapi.ResolveKeyRequest{Name: "key1"})
+	assert.Regexp(t, "PD020502", err)
+	_, err = rsm.Sign(ctx, &signerapi.SignRequest{})
+	assert.Regexp(t, "PD020502", err)
+	_, err = rsm.List(ctx, &signerapi.ListKeysRequest{})
+	assert.Regexp(t, "PD020502", err)
+}
+
+func TestRemoteSignerInMemorySigners(t *testing.T) {
+	ctx := context.Background()
+	conf := &pldconf.RemoteSignerConfig{
+		HTTP: pldconf.HTTPClientConfig{URL: "http://127.0.0.1:1"},
+	}
+
+	// Requests for in-memory signers the remote signer does not host fail without being sent
+	rsm, err := NewRemoteSigningModule(ctx, conf)
+	require.NoError(t, err)
+	err = rsm.AddInMemorySigner("domain", nil)
+	assert.Regexp(t, "PD020831.*domain", err)
+	_, err = rsm.Resolve(ctx, &signerapi.ResolveKeyRequest{
+		Name:                "key1",
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{{Algorithm: "domain:zeto:snark"}},
+	})
+	assert.Regexp(t, "PD020832.*domain:zeto:snark", err)
+	_, err = rsm.Sign(ctx, &signerapi.SignRequest{Algorithm: "domain:zeto:snark"})
+	assert.Regexp(t, "PD020832.*domain:zeto:snark", err)
+
+	// ... but are sent to a remote signer that hosts them
+	rsm, err = NewRemoteSigningModule(ctx, conf, "domain")
+	require.NoError(t, err)
+	err = rsm.AddInMemorySigner("domain", nil)
+	require.NoError(t, err)
+	_, err = rsm.Sign(ctx, &signerapi.SignRequest{Algorithm: "domain:zeto:snark"})
+	assert.Regexp(t, "PD020502", err)
+}
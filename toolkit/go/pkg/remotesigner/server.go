@@ -0,0 +1,96 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package remotesigner
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signer"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+)
+
+type ServerConfig struct {
+	RPCServer            pldconf.RPCServerConfig `json:"rpcServer"`
+	Signer               pldconf.SignerConfig    `json:"signer"`
+	AllowUnauthenticated bool                    `json:"allowUnauthenticated"` // only for a server reachable solely by Paladin, such as on localhost
+}
+
+// Server is a reference implementation of a remote signer, that hosts a signing module
+// in a separate process to the Paladin runtime. Paladin connects to it by configuring
+// a wallet with signerType "remote".
+//
+// Anybody who can call the server can sign with any of its keys, so NewServer requires
+// authentication to be enabled in rpcServer.auth (such as a static token that Paladin sends
+// in its httpHeaders, or mTLS), and TLS should be enabled on any non-loopback address.
+type Server interface {
+	rpcserver.RPCServer
+	SigningModule() signer.SigningModule
+}
+
+type server struct {
+	rpcserver.RPCServer
+	signingModule signer.SigningModule
+}
+
+// NewServer builds a server around the default signer.NewSigningModule implementation, with
+// the supplied configuration.
+func NewServer(ctx context.Context, conf *ServerConfig) (Server, error) {
+	if !confutil.Bool(conf.RPCServer.Auth.Enabled, *pldconf.RPCAuthDefaults.Enabled) && !conf.AllowUnauthenticated {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningRemoteServerNoAuth)
+	}
+	sm, err := signer.NewSigningModule(ctx, (*signerapi.ConfigNoExt)(&conf.Signer))
+	if err != nil {
+		return nil, err
+	}
+	return NewServerForSigningModule(ctx, &conf.RPCServer, sm)
+}
+
+// NewServerForSigningModule allows a custom signing module (built using signer.NewSigningModule with
+// extensions, or implemented from scratch) to be hosted. The caller is responsible for
+// configuring authentication in the RPC server configuration.
+func NewServerForSigningModule(ctx context.Context, conf *pldconf.RPCServerConfig, sm signer.SigningModule) (Server, error) {
+	rpcServer, err := rpcserver.NewRPCServer(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	s := &server{
+		RPCServer:     rpcServer,
+		signingModule: sm,
+	}
+	s.Register(s.rpcModule())
+	return s, nil
+}
+
+func (s *server) SigningModule() signer.SigningModule {
+	return s.signingModule
+}
+
+func (s *server) Stop() {
+	s.RPCServer.Stop()
+	s.signingModule.Close()
+}
+
+func (s *server) rpcModule() *rpcserver.RPCModule {
+	return rpcserver.NewRPCModule("signer").
+		Add(MethodResolve, rpcserver.RPCMethod1(s.signingModule.Resolve)).
+		Add(MethodSign, rpcserver.RPCMethod1(s.signingModule.Sign)).
+		Add(MethodList, rpcserver.RPCMethod1(s.signingModule.List))
+}
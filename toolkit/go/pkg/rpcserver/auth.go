@@ -0,0 +1,263 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+var jwtSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+type principalContextKey struct{}
+
+// AuthenticatedPrincipal returns the principal that authenticated the JSON/RPC request being
+// processed, or the empty string if authentication is not enabled.
+func AuthenticatedPrincipal(ctx context.Context) string {
+	p, _ := ctx.Value(principalContextKey{}).(string)
+	return p
+}
+
+func withPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+type rpcAuth struct {
+	staticTokens   map[string]string // token -> principal
+	jwks           *jose.JSONWebKeySet
+	jwtExpected    jwt.Expected
+	jwtLeeway      time.Duration
+	principalClaim string
+	mtls           bool
+	policies       []*pldconf.RPCAuthorizationPolicy
+}
+
+func newRPCAuth(ctx context.Context, conf *pldconf.RPCAuthConfig) (*rpcAuth, error) {
+	if !confutil.Bool(conf.Enabled, *pldconf.RPCAuthDefaults.Enabled) {
+		return nil, nil
+	}
+
+	a := &rpcAuth{
+		staticTokens: make(map[string]string),
+		mtls:         conf.MTLS.Enabled,
+	}
+	for i, st := range conf.StaticTokens {
+		token := st.Token
+		if st.Principal == "" || (token == "") == (st.TokenFile == "") {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthInvalidToken, i)
+		}
+		if st.TokenFile != "" {
+			b, err := os.ReadFile(st.TokenFile)
+			if err != nil {
+				return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCAuthTokenFileFailed, st.TokenFile)
+			}
+			token = strings.TrimSpace(string(b))
+		}
+		a.staticTokens[token] = st.Principal
+	}
+
+	if conf.JWT.JWKSFile != "" {
+		a.jwks = &jose.JSONWebKeySet{}
+		b, err := os.ReadFile(conf.JWT.JWKSFile)
+		if err == nil {
+			err = json.Unmarshal(b, a.jwks)
+		}
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCAuthJWKSLoadFailed, conf.JWT.JWKSFile)
+		}
+		a.jwtExpected = jwt.Expected{Issuer: conf.JWT.Issuer}
+		if conf.JWT.Audience != "" {
+			a.jwtExpected.AnyAudience = jwt.Audience{conf.JWT.Audience}
+		}
+		a.jwtLeeway = confutil.DurationMin(conf.JWT.Leeway, 0, *pldconf.RPCAuthDefaults.JWT.Leeway)
+		a.principalClaim = confutil.StringNotEmpty(conf.JWT.PrincipalClaim, *pldconf.RPCAuthDefaults.JWT.PrincipalClaim)
+	}
+
+	if len(a.staticTokens) == 0 && a.jwks == nil && !a.mtls {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthNoMethods)
+	}
+
+	for i, p := range conf.Policies {
+		for _, patterns := range [][]string{p.Principals, p.Methods, p.Keys} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCAuthInvalidPattern, pattern, i)
+				}
+			}
+		}
+		a.policies = append(a.policies, &conf.Policies[i])
+	}
+
+	log.L(ctx).Infof("JSON/RPC authentication enabled staticTokens=%d jwt=%t mtls=%t policies=%d", len(a.staticTokens), a.jwks != nil, a.mtls, len(a.policies))
+	return a, nil
+}
+
+// authenticate resolves the principal for an HTTP request, or WebSocket upgrade request.
+//
+// A bearer token, if supplied, must be valid. Otherwise the identity of a verified client
+// certificate is used when mTLS authentication is enabled.
+func (a *rpcAuth) authenticate(req *http.Request) (string, error) {
+	ctx := req.Context()
+	authHeader := req.Header.Get("Authorization")
+	if len(authHeader) > 7 && strings.EqualFold(authHeader[0:7], "bearer ") {
+		token := strings.TrimSpace(authHeader[7:])
+		for staticToken, principal := range a.staticTokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(staticToken)) == 1 {
+				return principal, nil
+			}
+		}
+		if a.jwks != nil {
+			return a.authenticateJWT(ctx, token)
+		}
+	} else if a.mtls && req.TLS != nil && len(req.TLS.VerifiedChains) > 0 && len(req.TLS.VerifiedChains[0]) > 0 {
+		if cn := req.TLS.VerifiedChains[0][0].Subject.CommonName; cn != "" {
+			return cn, nil
+		}
+	}
+	return "", i18n.NewError(ctx, pldmsgs.MsgJSONRPCUnauthenticated)
+}
+
+func (a *rpcAuth) authenticateJWT(ctx context.Context, token string) (string, error) {
+	tok, err := jwt.ParseSigned(token, jwtSignatureAlgorithms)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to parse JWT: %s", err)
+		return "", i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthInvalidJWT)
+	}
+	keys := a.jwks.Keys
+	if kid := tok.Headers[0].KeyID; kid != "" {
+		keys = a.jwks.Key(kid)
+	}
+	for _, key := range keys {
+		var claims jwt.Claims
+		var allClaims map[string]any
+		if err = tok.Claims(key.Public(), &claims, &allClaims); err != nil {
+			continue
+		}
+		expected := a.jwtExpected
+		expected.Time = time.Now()
+		if err = claims.ValidateWithLeeway(expected, a.jwtLeeway); err != nil {
+			break
+		}
+		principal, _ := allClaims[a.principalClaim].(string)
+		if principal == "" {
+			return "", i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthJWTNoPrincipal, a.principalClaim)
+		}
+		return principal, nil
+	}
+	log.L(ctx).Errorf("JWT validation failed (kid=%s): %v", tok.Headers[0].KeyID, err)
+	return "", i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthInvalidJWT)
+}
+
+func (a *rpcAuth) unauthenticated(res http.ResponseWriter, err error) {
+	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(res).Encode(rpcclient.NewRPCErrorResponse(err, nil, rpcclient.RPCCodeInvalidRequest))
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if match, _ := path.Match(pattern, value); match {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize checks the authenticated principal has a policy that allows it to call the method,
+// and that every signing key the request uses is allowed by one of those policies. The keys are
+// those named in a "from" field of the parameters, plus any resolved for the method by its
+// signing keys function. The node qualifier of a key (the "@node" suffix) is not part of the match.
+func (a *rpcAuth) authorize(ctx context.Context, rpcReq *rpcclient.RPCRequest, signingKeys RPCSigningKeys) error {
+	if len(a.policies) == 0 {
+		return nil
+	}
+	principal := AuthenticatedPrincipal(ctx)
+	var keyPatterns []string
+	methodAllowed := false
+	for _, p := range a.policies {
+		if matchesAny(p.Principals, principal) && matchesAny(p.Methods, rpcReq.Method) {
+			methodAllowed = true
+			keyPatterns = append(keyPatterns, p.Keys...)
+		}
+	}
+	if !methodAllowed {
+		return i18n.NewError(ctx, pldmsgs.MsgJSONRPCMethodNotAuthorized, principal, rpcReq.Method)
+	}
+	keys := requestSigningKeys(rpcReq)
+	if signingKeys != nil {
+		methodKeys, err := signingKeys(ctx, rpcReq)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, methodKeys...)
+	}
+	for _, key := range keys {
+		if !matchesAny(keyPatterns, strings.SplitN(key, "@", 2)[0]) {
+			return i18n.NewError(ctx, pldmsgs.MsgJSONRPCKeyNotAuthorized, principal, key, rpcReq.Method)
+		}
+	}
+	return nil
+}
+
+// requestSigningKeys finds the "from" field of any object, or array of objects, in the params.
+// Field names are matched case-insensitively, consistent with how the handler will parse them.
+func requestSigningKeys(rpcReq *rpcclient.RPCRequest) []string {
+	var keys []string
+	addKey := func(v any) {
+		if obj, ok := v.(map[string]any); ok {
+			for k, v := range obj {
+				if from, ok := v.(string); ok && from != "" && strings.EqualFold(k, "from") {
+					keys = append(keys, from)
+				}
+			}
+		}
+	}
+	for _, param := range rpcReq.Params {
+		var v any
+		if err := json.Unmarshal(param, &v); err != nil {
+			continue // the handler will reject this
+		}
+		if arr, ok := v.([]any); ok {
+			for _, entry := range arr {
+				addKey(entry)
+			}
+		} else {
+			addKey(v)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,468 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testJWTIssuer struct {
+	key      *rsa.PrivateKey
+	jwksFile string
+}
+
+func newTestJWTIssuer(t *testing.T) *testJWTIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &key.PublicKey, KeyID: "key1", Algorithm: string(jose.RS256), Use: "sig"},
+	}}
+	b, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	jwksFile := path.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, b, 0644))
+	return &testJWTIssuer{key: key, jwksFile: jwksFile}
+}
+
+func (ji *testJWTIssuer) sign(t *testing.T, kid string, claims map[string]any) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: ji.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader(jose.HeaderKey("kid"), kid))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func testAuthRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func testRPCRequest(method string, params ...any) *rpcclient.RPCRequest {
+	req := &rpcclient.RPCRequest{Method: method}
+	for _, p := range params {
+		req.Params = append(req.Params, pldtypes.JSONString(p))
+	}
+	return req
+}
+
+func TestAuthDisabled(t *testing.T) {
+	a, err := newRPCAuth(context.Background(), &pldconf.RPCAuthConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, a)
+}
+
+func TestAuthStaticTokens(t *testing.T) {
+	tokenFile := path.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token2\n"), 0644))
+
+	a, err := newRPCAuth(context.Background(), &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		StaticTokens: []pldconf.RPCAuthStaticToken{
+			{Principal: "app1", Token: "token1"},
+			{Principal: "app2", TokenFile: tokenFile},
+		},
+	})
+	require.NoError(t, err)
+
+	principal, err := a.authenticate(testAuthRequest("token1"))
+	require.NoError(t, err)
+	assert.Equal(t, "app1", principal)
+
+	principal, err = a.authenticate(testAuthRequest("token2"))
+	require.NoError(t, err)
+	assert.Equal(t, "app2", principal)
+
+	_, err = a.authenticate(testAuthRequest("wrong"))
+	assert.Regexp(t, "PD020707", err)
+
+	_, err = a.authenticate(testAuthRequest(""))
+	assert.Regexp(t, "PD020707", err)
+}
+
+func TestAuthJWT(t *testing.T) {
+	ji := newTestJWTIssuer(t)
+
+	a, err := newRPCAuth(context.Background(), &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		JWT: pldconf.RPCAuthJWTConfig{
+			JWKSFile: ji.jwksFile,
+			Issuer:   "https://issuer.example.com",
+			Audience: "paladin",
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"sub": "app1",
+			"iss": "https://issuer.example.com",
+			"aud": "paladin",
+			"exp": now.Add(time.Hour).Unix(),
+		}
+	}
+
+	principal, err := a.authenticate(testAuthRequest(ji.sign(t, "key1", validClaims())))
+	require.NoError(t, err)
+	assert.Equal(t, "app1", principal)
+
+	// No kid - all keys are tried
+	principal, err = a.authenticate(testAuthRequest(ji.sign(t, "", validClaims())))
+	require.NoError(t, err)
+	assert.Equal(t, "app1", principal)
+
+	// Unknown kid
+	_, err = a.authenticate(testAuthRequest(ji.sign(t, "key2", validClaims())))
+	assert.Regexp(t, "PD020711", err)
+
+	// Expired
+	claims := validClaims()
+	claims["exp"] = now.Add(-time.Hour).Unix()
+	_, err = a.authenticate(testAuthRequest(ji.sign(t, "key1", claims)))
+	assert.Regexp(t, "PD020711", err)
+
+	// Wrong audience
+	claims = validClaims()
+	claims["aud"] = "other"
+	_, err = a.authenticate(testAuthRequest(ji.sign(t, "key1", claims)))
+	assert.Regexp(t, "PD020711", err)
+
+	// No principal
+	claims = validClaims()
+	delete(claims, "sub")
+	_, err = a.authenticate(testAuthRequest(ji.sign(t, "key1", claims)))
+	assert.Regexp(t, "PD020712", err)
+
+	// Signed by another key
+	other := newTestJWTIssuer(t)
+	_, err = a.authenticate(testAuthRequest(other.sign(t, "key1", validClaims())))
+	assert.Regexp(t, "PD020711", err)
+
+	// Garbage
+	_, err = a.authenticate(testAuthRequest("not.a.jwt"))
+	assert.Regexp(t, "PD020711", err)
+}
+
+func TestAuthMTLS(t *testing.T) {
+	a, err := newRPCAuth(context.Background(), &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		MTLS:    pldconf.RPCAuthMTLSConfig{Enabled: true},
+	})
+	require.NoError(t, err)
+
+	req := testAuthRequest("")
+	req.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "app1"}}}},
+	}
+	principal, err := a.authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "app1", principal)
+
+	req.TLS = &tls.ConnectionState{}
+	_, err = a.authenticate(req)
+	assert.Regexp(t, "PD020707", err)
+}
+
+func TestAuthConfigErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := newRPCAuth(ctx, &pldconf.RPCAuthConfig{Enabled: confutil.P(true)})
+	assert.Regexp(t, "PD020715", err)
+
+	_, err = newRPCAuth(ctx, &pldconf.RPCAuthConfig{
+		Enabled:      confutil.P(true),
+		StaticTokens: []pldconf.RPCAuthStaticToken{{Token: "token1"}},
+	})
+	assert.Regexp(t, "PD020713", err)
+
+	_, err = newRPCAuth(ctx, &pldconf.RPCAuthConfig{
+		Enabled:      confutil.P(true),
+		StaticTokens: []pldconf.RPCAuthStaticToken{{Principal: "app1", TokenFile: t.TempDir()}},
+	})
+	assert.Regexp(t, "PD020714", err)
+
+	_, err = newRPCAuth(ctx, &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		JWT:     pldconf.RPCAuthJWTConfig{JWKSFile: t.TempDir()},
+	})
+	assert.Regexp(t, "PD020710", err)
+
+	_, err = newRPCAuth(ctx, &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		MTLS:    pldconf.RPCAuthMTLSConfig{Enabled: true},
+		Policies: []pldconf.RPCAuthorizationPolicy{
+			{Principals: []string{"["}},
+		},
+	})
+	assert.Regexp(t, "PD020716", err)
+}
+
+func TestAuthorize(t *testing.T) {
+	a, err := newRPCAuth(context.Background(), &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		MTLS:    pldconf.RPCAuthMTLSConfig{Enabled: true},
+		Policies: []pldconf.RPCAuthorizationPolicy{
+			{Principals: []string{"*"}, Methods: []string{"ptx_getTransaction*", "ptx_query*"}},
+			{Principals: []string{"app1"}, Methods: []string{"ptx_*"}, Keys: []string{"app1.*"}},
+			{Principals: []string{"admin"}, Methods: []string{"*"}, Keys: []string{"*"}},
+		},
+	})
+	require.NoError(t, err)
+
+	app1 := withPrincipal(context.Background(), "app1")
+	app2 := withPrincipal(context.Background(), "app2")
+	admin := withPrincipal(context.Background(), "admin")
+
+	// Read-only access for everyone
+	assert.NoError(t, a.authorize(app2, testRPCRequest("ptx_getTransaction", "id1"), nil))
+	assert.Regexp(t, "PD020708", a.authorize(app2, testRPCRequest("ptx_sendTransaction", map[string]any{"from": "app2.key1"}), nil))
+	assert.Regexp(t, "PD020708", a.authorize(app1, testRPCRequest("keymgr_resolveKey", "app1.key1"), nil))
+
+	// Keys restricted per principal
+	assert.NoError(t, a.authorize(app1, testRPCRequest("ptx_sendTransaction", map[string]any{"from": "app1.key1"}), nil))
+	assert.NoError(t, a.authorize(app1, testRPCRequest("ptx_sendTransaction", map[string]any{"from": "app1.key1@node1"}), nil))
+	assert.Regexp(t, "PD020709.*admin.key1", a.authorize(app1, testRPCRequest("ptx_sendTransaction", map[string]any{"from": "admin.key1"}), nil))
+	assert.Regexp(t, "PD020709", a.authorize(app1, testRPCRequest("ptx_sendTransaction", map[string]any{"FROM": "admin.key1"}), nil))
+	assert.Regexp(t, "PD020709", a.authorize(app1, testRPCRequest("ptx_sendTransactions", []map[string]any{
+		{"from": "app1.key1"},
+		{"from": "admin.key1"},
+	}), nil))
+
+	// A principal with access to any key
+	assert.NoError(t, a.authorize(admin, testRPCRequest("keymgr_resolveKey", "anything"), nil))
+	assert.NoError(t, a.authorize(admin, testRPCRequest("ptx_sendTransaction", map[string]any{"from": "admin.key1"}), nil))
+
+	// Unparsable params are left for the handler to reject
+	req := testRPCRequest("ptx_sendTransaction")
+	req.Params = append(req.Params, pldtypes.RawJSON(`{!!!`))
+	assert.NoError(t, a.authorize(app1, req, nil))
+}
+
+func TestAuthorizeMethodSigningKeys(t *testing.T) {
+	a, err := newRPCAuth(context.Background(), &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		MTLS:    pldconf.RPCAuthMTLSConfig{Enabled: true},
+		Policies: []pldconf.RPCAuthorizationPolicy{
+			{Principals: []string{"app1"}, Methods: []string{"ptx_*"}, Keys: []string{"app1.*"}},
+		},
+	})
+	require.NoError(t, err)
+	app1 := withPrincipal(context.Background(), "app1")
+
+	// Keys resolved from an existing transaction by ID
+	txKeys := SigningKeysParam(0, func(ctx context.Context, id string) ([]string, error) {
+		switch id {
+		case "tx1":
+			return []string{"app1.key1@node1"}, nil
+		case "tx2":
+			return []string{"admin.key1"}, nil
+		}
+		return nil, fmt.Errorf("pop")
+	})
+	assert.NoError(t, a.authorize(app1, testRPCRequest("ptx_cancelTransaction", "tx1"), txKeys))
+	assert.Regexp(t, "PD020709.*admin.key1", a.authorize(app1, testRPCRequest("ptx_cancelTransaction", "tx2"), txKeys))
+	assert.Regexp(t, "pop", a.authorize(app1, testRPCRequest("ptx_cancelTransaction", "tx3"), txKeys))
+
+	// Keys resolved in addition to "from" fields
+	assert.Regexp(t, "PD020709.*admin.key2", a.authorize(app1, testRPCRequest("ptx_updateTransaction", "tx1", map[string]any{"from": "admin.key2"}), txKeys))
+
+	// A positional key, in a parameter that cannot be parsed or is missing
+	keyParam := SigningKeysParam(1, func(ctx context.Context, key string) ([]string, error) {
+		return []string{key}, nil
+	})
+	assert.NoError(t, a.authorize(app1, testRPCRequest("ptx_test", 1, "app1.key1"), keyParam))
+	assert.Regexp(t, "PD020709", a.authorize(app1, testRPCRequest("ptx_test", 1, "admin.key1"), keyParam))
+	assert.Regexp(t, "PD020704", a.authorize(app1, testRPCRequest("ptx_test", 1, 2), keyParam))
+	assert.Regexp(t, "PD020703", a.authorize(app1, testRPCRequest("ptx_test", 1), keyParam))
+}
+
+func TestAuthorizeNoPolicies(t *testing.T) {
+	a, err := newRPCAuth(context.Background(), &pldconf.RPCAuthConfig{
+		Enabled: confutil.P(true),
+		MTLS:    pldconf.RPCAuthMTLSConfig{Enabled: true},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, a.authorize(withPrincipal(context.Background(), "any"), testRPCRequest("keymgr_resolveKey", "anything"), nil))
+}
+
+func testAuthServerConfig() *pldconf.RPCServerConfig {
+	return &pldconf.RPCServerConfig{
+		Auth: pldconf.RPCAuthConfig{
+			Enabled: confutil.P(true),
+			StaticTokens: []pldconf.RPCAuthStaticToken{
+				{Principal: "app1", Token: "token1"},
+				{Principal: "app2", Token: "token2"},
+			},
+			Policies: []pldconf.RPCAuthorizationPolicy{
+				{Principals: []string{"app1"}, Methods: []string{"test_*"}, Keys: []string{"app1.*"}},
+			},
+		},
+	}
+}
+
+func TestAuthHTTP(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, testAuthServerConfig())
+	defer done()
+
+	regTestRPC(s, "test_whoami", RPCMethod1(func(ctx context.Context, tx map[string]any) (string, error) {
+		return AuthenticatedPrincipal(ctx), nil
+	}))
+
+	call := func(token string, from string) (*http.Response, *rpcclient.RPCResponse) {
+		body := pldtypes.JSONString(&rpcclient.RPCRequest{
+			JSONRpc: "2.0",
+			ID:      pldtypes.RawJSON(`1`),
+			Method:  "test_whoami",
+			Params:  []pldtypes.RawJSON{pldtypes.JSONString(map[string]any{"from": from})},
+		})
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		var rpcRes rpcclient.RPCResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&rpcRes))
+		return res, &rpcRes
+	}
+
+	res, rpcRes := call("token1", "app1.key1")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, `"app1"`, rpcRes.Result.String())
+
+	res, rpcRes = call("token1", "app2.key1")
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Regexp(t, "PD020709", rpcRes.Error.Message)
+
+	res, rpcRes = call("token2", "app2.key1")
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Regexp(t, "PD020708", rpcRes.Error.Message)
+
+	res, rpcRes = call("", "app1.key1")
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Regexp(t, "PD020707", rpcRes.Error.Message)
+}
+
+func TestAuthHTTPMethodSigningKeys(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, testAuthServerConfig())
+	defer done()
+
+	s.Register(NewRPCModule("test").
+		AddWithSigningKeys("test_sign", RPCMethod1(func(ctx context.Context, key string) (string, error) {
+			return key, nil
+		}), SigningKeysParam(0, func(ctx context.Context, key string) ([]string, error) {
+			return []string{key}, nil
+		})),
+	)
+
+	call := func(key string) *rpcclient.RPCResponse {
+		body := pldtypes.JSONString(&rpcclient.RPCRequest{
+			JSONRpc: "2.0",
+			ID:      pldtypes.RawJSON(`1`),
+			Method:  "test_sign",
+			Params:  []pldtypes.RawJSON{pldtypes.JSONString(key)},
+		})
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token1")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		var rpcRes rpcclient.RPCResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&rpcRes))
+		return &rpcRes
+	}
+
+	rpcRes := call("app1.key1")
+	require.Nil(t, rpcRes.Error)
+	assert.Equal(t, `"app1.key1"`, rpcRes.Result.String())
+
+	rpcRes = call("app2.key1")
+	assert.Regexp(t, "PD020709", rpcRes.Error.Message)
+}
+
+func TestAuthWebSocket(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelCtx()
+	url, s, done := newTestServerWebSockets(t, testAuthServerConfig())
+	defer done()
+
+	regTestRPC(s, "test_whoami", RPCMethod1(func(ctx context.Context, tx map[string]any) (string, error) {
+		return AuthenticatedPrincipal(ctx), nil
+	}))
+
+	client := rpcclient.WrapWSConfig(&wsclient.WSConfig{
+		WebSocketURL:     url,
+		DisableReconnect: true,
+		HTTPHeaders:      fftypes.JSONObject{"Authorization": "Bearer token1"},
+	})
+	defer client.Close()
+	err := client.Connect(ctx)
+	require.NoError(t, err)
+
+	var principal string
+	rpcErr := client.CallRPC(ctx, &principal, "test_whoami", map[string]any{"from": "app1.key1"})
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, "app1", principal)
+
+	rpcErr = client.CallRPC(ctx, &principal, "test_whoami", map[string]any{"from": "app2.key1"})
+	assert.Regexp(t, "PD020709", rpcErr)
+
+	// Connection refused without credentials
+	badClient := rpcclient.WrapWSConfig(&wsclient.WSConfig{WebSocketURL: url, DisableReconnect: true, InitialConnectAttempts: 1})
+	defer badClient.Close()
+	err = badClient.Connect(ctx)
+	assert.Error(t, err)
+}
+
+func TestNewRPCServerBadAuth(t *testing.T) {
+	_, err := NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+		Auth: pldconf.RPCAuthConfig{Enabled: confutil.P(true)},
+	})
+	assert.Regexp(t, "PD020715", err)
+}
@@ -49,6 +49,26 @@ type RPCAsyncHandler interface {
 	HandleLifecycle(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse
 }
 
+// RPCSigningKeys resolves the signing keys a request will use, so that they can be checked against the
+// key authorization policies of the caller before the method is invoked. It is only called when
+// authorization policies are configured.
+type RPCSigningKeys func(ctx context.Context, req *rpcclient.RPCRequest) ([]string, error)
+
+// SigningKeysParam resolves the signing keys of a request from one of its parameters, parsed in
+// the same way as the RPCMethod0 ... RPCMethod5 handlers parse it
+func SigningKeysParam[P any](index int, resolve func(ctx context.Context, param P) ([]string, error)) RPCSigningKeys {
+	return func(ctx context.Context, req *rpcclient.RPCRequest) ([]string, error) {
+		if index >= len(req.Params) {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCIncorrectParamCount, req.Method, index+1, len(req.Params))
+		}
+		param := new(P)
+		if err := json.Unmarshal(req.Params[index].Bytes(), param); err != nil {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCInvalidParam, req.Method, index, err)
+		}
+		return resolve(ctx, *param)
+	}
+}
+
 func HandlerFunc(fn func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse) RPCHandler {
 	return &rpcHandlerFunc{fn: fn}
 }
@@ -36,9 +36,10 @@ const (
 )
 
 type rpcMethodEntry struct {
-	methodType rpcMethodType
-	handler    RPCHandler
-	async      RPCAsyncHandler
+	methodType  rpcMethodType
+	handler     RPCHandler
+	async       RPCAsyncHandler
+	signingKeys RPCSigningKeys
 }
 
 func NewRPCModule(prefix string) *RPCModule {
@@ -70,6 +71,15 @@ func (m *RPCModule) Add(method string, handler RPCHandler) *RPCModule {
 	return m
 }
 
+// AddWithSigningKeys adds a method that uses signing keys which are not named in a "from" field
+// of its params - such as a key passed positionally, or the key of an existing transaction that
+// the method acts on. The keys returned are authorized in addition to any "from" fields.
+func (m *RPCModule) AddWithSigningKeys(method string, handler RPCHandler, signingKeys RPCSigningKeys) *RPCModule {
+	m.validateMethod(method)
+	m.methods[method] = &rpcMethodEntry{methodType: rpcMethodTypeMethod, handler: handler, signingKeys: signingKeys}
+	return m
+}
+
 func (m *RPCModule) AddAsync(handler RPCAsyncHandler) *RPCModule {
 	startMethod := handler.StartMethod()
 	m.validateMethod(startMethod)
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 )
@@ -45,6 +46,13 @@ func (s *rpcServer) processRPC(ctx context.Context, rpcReq *rpcclient.RPCRequest
 		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
 	}
 
+	if s.auth != nil {
+		if err := s.auth.authorize(ctx, rpcReq, mh.signingKeys); err != nil {
+			log.L(ctx).Errorf("JSON/RPC request %s not authorized: %s", rpcReq.Method, err)
+			return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
+		}
+	}
+
 	var rpcRes *rpcclient.RPCResponse
 	if mh.methodType == rpcMethodTypeMethod {
 		rpcRes = mh.handler.Handle(ctx, rpcReq)
@@ -51,6 +51,10 @@ func NewRPCServer(ctx context.Context, conf *pldconf.RPCServerConfig) (_ *rpcSer
 		rpcModules:    make(map[string]*RPCModule),
 	}
 
+	if s.auth, err = newRPCAuth(ctx, &conf.Auth); err != nil {
+		return nil, err
+	}
+
 	// Add the HTTP server
 	if !conf.HTTP.Disabled {
 		r, err := router.NewRouter(s.bgCtx, "JSON/RPC (HTTP)", &conf.HTTP.HTTPServerConfig)
@@ -99,6 +103,7 @@ type rpcServer struct {
 	wsUpgrader    *websocket.Upgrader
 	wsConnections map[string]*webSocketConnection
 	rpcModules    map[string]*RPCModule
+	auth          *rpcAuth
 }
 
 func (s *rpcServer) Register(module *RPCModule) {
@@ -128,12 +133,31 @@ func (s *rpcServer) HTTPHandler(w http.ResponseWriter, r *http.Request) {
 	s.httpHandler(w, r)
 }
 
+func (s *rpcServer) authenticate(res http.ResponseWriter, req *http.Request) (context.Context, bool) {
+	ctx := req.Context()
+	if s.auth != nil {
+		principal, err := s.auth.authenticate(req)
+		if err != nil {
+			log.L(ctx).Errorf("JSON/RPC authentication failed from %s: %s", req.RemoteAddr, err)
+			s.auth.unauthenticated(res, err)
+			return nil, false
+		}
+		ctx = withPrincipal(ctx, principal)
+	}
+	return ctx, true
+}
+
 func (s *rpcServer) httpHandler(res http.ResponseWriter, req *http.Request) {
 	if req.Method != http.MethodPost {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 	}
 
-	r := s.rpcHandler(req.Context(), req.Body, nil /* not websockets */)
+	ctx, ok := s.authenticate(res, req)
+	if !ok {
+		return
+	}
+
+	r := s.rpcHandler(ctx, req.Body, nil /* not websockets */)
 
 	res.Header().Set("Content-Type", "application/json; charset=utf-8")
 	status := http.StatusOK
@@ -145,12 +169,17 @@ func (s *rpcServer) httpHandler(res http.ResponseWriter, req *http.Request) {
 }
 
 func (s *rpcServer) wsHandler(res http.ResponseWriter, req *http.Request) {
+	// The connection is authenticated once on upgrade, then all requests on it are made as that principal
+	ctx, ok := s.authenticate(res, req)
+	if !ok {
+		return
+	}
 	conn, err := s.wsUpgrader.Upgrade(res, req, nil)
 	if err != nil {
 		log.L(req.Context()).Errorf("WebSocket upgrade failed: %s", err)
 		return
 	}
-	s.newWSConnection(conn)
+	s.newWSConnection(conn, AuthenticatedPrincipal(ctx))
 }
 
 func (s *rpcServer) Start() (err error) {
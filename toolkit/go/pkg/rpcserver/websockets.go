@@ -30,7 +30,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 )
 
-func (s *rpcServer) newWSConnection(conn *websocket.Conn) {
+func (s *rpcServer) newWSConnection(conn *websocket.Conn, principal string) {
 	s.wsMux.Lock()
 	defer s.wsMux.Unlock()
 
@@ -43,6 +43,9 @@ func (s *rpcServer) newWSConnection(conn *websocket.Conn) {
 		closing:        make(chan struct{}),
 	}
 	c.ctx, c.cancelCtx = context.WithCancel(log.WithLogField(s.bgCtx, "wsconn", c.id))
+	if s.auth != nil {
+		c.ctx = withPrincipal(c.ctx, principal)
+	}
 
 	s.wsConnections[c.id] = c
 	go c.listen()
@@ -0,0 +1,79 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+)
+
+// OutOfProcessInMemorySigners is used by signing modules that run outside of the Paladin process
+// (remote signers and signing module plugins) to handle the in-memory signers Paladin registers,
+// such as the "domain" signer. These cannot be used directly, as they need the private key to be
+// loaded into Paladin's memory.
+//
+// Instead the signing module can host the signer itself (for example with a DomainPrefixRouter
+// extension), in which case the prefix is configured as hosted and requests are passed through.
+// For any other prefix registration fails, and requests for its algorithms are rejected without
+// being sent to a signing module that cannot fulfil them.
+type OutOfProcessInMemorySigners struct {
+	hosted      map[string]bool
+	unsupported map[string]bool
+}
+
+func NewOutOfProcessInMemorySigners(hosted []string) *OutOfProcessInMemorySigners {
+	ims := &OutOfProcessInMemorySigners{
+		hosted:      make(map[string]bool),
+		unsupported: make(map[string]bool),
+	}
+	for _, prefix := range hosted {
+		ims.hosted[strings.ToLower(prefix)] = true
+	}
+	return ims
+}
+
+// Add is called (on initialization only) with each in-memory signer Paladin registers
+func (ims *OutOfProcessInMemorySigners) Add(ctx context.Context, prefix string) error {
+	prefix = strings.ToLower(prefix)
+	if ims.hosted[prefix] {
+		return nil
+	}
+	ims.unsupported[prefix] = true
+	return i18n.NewError(ctx, pldmsgs.MsgSigningInMemorySignerNotRemotable, prefix)
+}
+
+// CheckAlgorithm rejects an algorithm implemented by a registered in-memory signer that is not hosted
+func (ims *OutOfProcessInMemorySigners) CheckAlgorithm(ctx context.Context, algorithm string) error {
+	prefix := strings.ToLower(strings.SplitN(algorithm, ":", 2)[0])
+	if ims.unsupported[prefix] {
+		return i18n.NewError(ctx, pldmsgs.MsgSigningAlgorithmNotRemotable, algorithm, prefix)
+	}
+	return nil
+}
+
+// CheckResolve applies CheckAlgorithm to each of the identifiers required in a resolve request
+func (ims *OutOfProcessInMemorySigners) CheckResolve(ctx context.Context, req *signerapi.ResolveKeyRequest) error {
+	for _, required := range req.RequiredIdentifiers {
+		if err := ims.CheckAlgorithm(ctx, required.Algorithm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutOfProcessInMemorySigners(t *testing.T) {
+	ctx := context.Background()
+	ims := NewOutOfProcessInMemorySigners([]string{"Hosted"})
+
+	require.NoError(t, ims.Add(ctx, "hosted"))
+	assert.Regexp(t, "PD020831.*domain", ims.Add(ctx, "domain"))
+
+	require.NoError(t, ims.CheckAlgorithm(ctx, algorithms.ECDSA_SECP256K1))
+	require.NoError(t, ims.CheckAlgorithm(ctx, "hosted:anything"))
+	assert.Regexp(t, "PD020832.*domain:zeto:snark", ims.CheckAlgorithm(ctx, "domain:zeto:snark"))
+	assert.Regexp(t, "PD020832", ims.CheckAlgorithm(ctx, "DOMAIN:noto:anything"))
+
+	require.NoError(t, ims.CheckResolve(ctx, &signerapi.ResolveKeyRequest{
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{{Algorithm: algorithms.ECDSA_SECP256K1}},
+	}))
+	assert.Regexp(t, "PD020832", ims.CheckResolve(ctx, &signerapi.ResolveKeyRequest{
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{
+			{Algorithm: algorithms.ECDSA_SECP256K1},
+			{Algorithm: "domain:zeto:snark"},
+		},
+	}))
+}
@@ -0,0 +1,287 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+)
+
+// Hosts a SigningModule built with NewSigningModule (plus any extensions, such as a custom
+// HSM or KMS key store) as a signing module plugin.
+//
+// The configuration supplied by Paladin to ConfigureSigningModule is parsed into a fresh config object
+// allocated by newConfig, and the signing module is constructed at that point.
+func NewPluginSigningModuleAPI[C signerapi.ExtensibleConfig](newConfig func() C, extensions ...*signerapi.Extensions[C]) plugintk.SigningModuleAPI {
+	return &pluginAPIAdapter[C]{
+		newConfig:  newConfig,
+		extensions: extensions,
+	}
+}
+
+type pluginAPIAdapter[C signerapi.ExtensibleConfig] struct {
+	mux        sync.Mutex
+	newConfig  func() C
+	extensions []*signerapi.Extensions[C]
+	sm         SigningModule
+}
+
+func (pa *pluginAPIAdapter[C]) ConfigureSigningModule(ctx context.Context, req *prototk.ConfigureSigningModuleRequest) (*prototk.ConfigureSigningModuleResponse, error) {
+	conf := pa.newConfig()
+	if err := json.Unmarshal([]byte(req.ConfigJson), conf); err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningPluginInvalidConfig, req.Name)
+	}
+	sm, err := NewSigningModule(ctx, conf, pa.extensions...)
+	if err != nil {
+		return nil, err
+	}
+	pa.mux.Lock()
+	defer pa.mux.Unlock()
+	if pa.sm != nil {
+		// Paladin can re-configure us on reconnect
+		pa.sm.Close()
+	}
+	pa.sm = sm
+	return &prototk.ConfigureSigningModuleResponse{}, nil
+}
+
+func (pa *pluginAPIAdapter[C]) getSigningModule(ctx context.Context) (SigningModule, error) {
+	pa.mux.Lock()
+	defer pa.mux.Unlock()
+	if pa.sm == nil {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningPluginNotConfigured)
+	}
+	return pa.sm, nil
+}
+
+func (pa *pluginAPIAdapter[C]) ResolveKey(ctx context.Context, req *prototk.ResolveKeyRequest) (*prototk.ResolveKeyResponse, error) {
+	sm, err := pa.getSigningModule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := sm.Resolve(ctx, ResolveKeyRequestFromProto(req))
+	if err != nil {
+		return nil, err
+	}
+	return ResolveKeyResponseToProto(res), nil
+}
+
+func (pa *pluginAPIAdapter[C]) Sign(ctx context.Context, req *prototk.SignWithKeyRequest) (*prototk.SignWithKeyResponse, error) {
+	sm, err := pa.getSigningModule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := sm.Sign(ctx, &signerapi.SignRequest{
+		KeyHandle:   req.KeyHandle,
+		Algorithm:   req.Algorithm,
+		PayloadType: req.PayloadType,
+		Payload:     req.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &prototk.SignWithKeyResponse{Payload: res.Payload}, nil
+}
+
+func (pa *pluginAPIAdapter[C]) ListKeys(ctx context.Context, req *prototk.ListKeysRequest) (*prototk.ListKeysResponse, error) {
+	sm, err := pa.getSigningModule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := sm.List(ctx, &signerapi.ListKeysRequest{
+		Limit:    int(req.Limit),
+		Continue: req.Continue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ListKeysResponseToProto(res), nil
+}
+
+// WrapPluginAPI performs the reverse of NewPluginSigningModuleAPI, allowing the Paladin runtime to
+// use a loaded signing module plugin in exactly the same way as an embedded SigningModule.
+//
+// The plugin must host any in-memory signers it needs itself, and the prefixes of those it does
+// are passed in hostedInMemorySigners (see OutOfProcessInMemorySigners).
+func WrapPluginAPI(api plugintk.SigningModuleAPI, hostedInMemorySigners ...string) SigningModule {
+	return &pluginSigningModule{
+		api:             api,
+		inMemorySigners: NewOutOfProcessInMemorySigners(hostedInMemorySigners),
+	}
+}
+
+type pluginSigningModule struct {
+	api             plugintk.SigningModuleAPI
+	inMemorySigners *OutOfProcessInMemorySigners
+}
+
+func (psm *pluginSigningModule) AddInMemorySigner(prefix string, _ signerapi.InMemorySigner) error {
+	return psm.inMemorySigners.Add(context.Background(), prefix)
+}
+
+func (psm *pluginSigningModule) Resolve(ctx context.Context, req *signerapi.ResolveKeyRequest) (*signerapi.ResolveKeyResponse, error) {
+	if err := psm.inMemorySigners.CheckResolve(ctx, req); err != nil {
+		return nil, err
+	}
+	res, err := psm.api.ResolveKey(ctx, ResolveKeyRequestToProto(req))
+	if err != nil {
+		return nil, err
+	}
+	return ResolveKeyResponseFromProto(res), nil
+}
+
+func (psm *pluginSigningModule) Sign(ctx context.Context, req *signerapi.SignRequest) (*signerapi.SignResponse, error) {
+	if err := psm.inMemorySigners.CheckAlgorithm(ctx, req.Algorithm); err != nil {
+		return nil, err
+	}
+	res, err := psm.api.Sign(ctx, &prototk.SignWithKeyRequest{
+		KeyHandle:   req.KeyHandle,
+		Algorithm:   req.Algorithm,
+		PayloadType: req.PayloadType,
+		Payload:     req.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &signerapi.SignResponse{Payload: res.Payload}, nil
+}
+
+func (psm *pluginSigningModule) List(ctx context.Context, req *signerapi.ListKeysRequest) (*signerapi.ListKeysResponse, error) {
+	res, err := psm.api.ListKeys(ctx, &prototk.ListKeysRequest{
+		Limit:    int32(req.Limit),
+		Continue: req.Continue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ListKeysResponseFromProto(res), nil
+}
+
+// The lifecycle of the plugin is managed by the plugin manager
+func (psm *pluginSigningModule) Close() {}
+
+func ResolveKeyRequestToProto(req *signerapi.ResolveKeyRequest) *prototk.ResolveKeyRequest {
+	pReq := &prototk.ResolveKeyRequest{
+		Name:                req.Name,
+		Index:               req.Index,
+		Attributes:          req.Attributes,
+		Path:                make([]*prototk.ResolveKeyPathSegment, len(req.Path)),
+		RequiredIdentifiers: make([]*prototk.PublicKeyIdentifierType, len(req.RequiredIdentifiers)),
+	}
+	for i, p := range req.Path {
+		pReq.Path[i] = &prototk.ResolveKeyPathSegment{Name: p.Name, Index: p.Index}
+	}
+	for i, ri := range req.RequiredIdentifiers {
+		pReq.RequiredIdentifiers[i] = &prototk.PublicKeyIdentifierType{Algorithm: ri.Algorithm, VerifierType: ri.VerifierType}
+	}
+	return pReq
+}
+
+func ResolveKeyRequestFromProto(pReq *prototk.ResolveKeyRequest) *signerapi.ResolveKeyRequest {
+	req := &signerapi.ResolveKeyRequest{
+		Name:                pReq.Name,
+		Index:               pReq.Index,
+		Attributes:          pReq.Attributes,
+		Path:                make([]*signerapi.ResolveKeyPathSegment, len(pReq.Path)),
+		RequiredIdentifiers: make([]*signerapi.PublicKeyIdentifierType, len(pReq.RequiredIdentifiers)),
+	}
+	for i, p := range pReq.Path {
+		req.Path[i] = &signerapi.ResolveKeyPathSegment{Name: p.Name, Index: p.Index}
+	}
+	for i, ri := range pReq.RequiredIdentifiers {
+		req.RequiredIdentifiers[i] = &signerapi.PublicKeyIdentifierType{Algorithm: ri.Algorithm, VerifierType: ri.VerifierType}
+	}
+	return req
+}
+
+func identifiersToProto(identifiers []*signerapi.PublicKeyIdentifier) []*prototk.PublicKeyIdentifier {
+	pIdentifiers := make([]*prototk.PublicKeyIdentifier, len(identifiers))
+	for i, id := range identifiers {
+		pIdentifiers[i] = &prototk.PublicKeyIdentifier{Algorithm: id.Algorithm, VerifierType: id.VerifierType, Verifier: id.Verifier}
+	}
+	return pIdentifiers
+}
+
+func identifiersFromProto(pIdentifiers []*prototk.PublicKeyIdentifier) []*signerapi.PublicKeyIdentifier {
+	identifiers := make([]*signerapi.PublicKeyIdentifier, len(pIdentifiers))
+	for i, id := range pIdentifiers {
+		identifiers[i] = &signerapi.PublicKeyIdentifier{Algorithm: id.Algorithm, VerifierType: id.VerifierType, Verifier: id.Verifier}
+	}
+	return identifiers
+}
+
+func ResolveKeyResponseToProto(res *signerapi.ResolveKeyResponse) *prototk.ResolveKeyResponse {
+	return &prototk.ResolveKeyResponse{
+		KeyHandle:   res.KeyHandle,
+		Identifiers: identifiersToProto(res.Identifiers),
+	}
+}
+
+func ResolveKeyResponseFromProto(pRes *prototk.ResolveKeyResponse) *signerapi.ResolveKeyResponse {
+	return &signerapi.ResolveKeyResponse{
+		KeyHandle:   pRes.KeyHandle,
+		Identifiers: identifiersFromProto(pRes.Identifiers),
+	}
+}
+
+func ListKeysResponseToProto(res *signerapi.ListKeysResponse) *prototk.ListKeysResponse {
+	pRes := &prototk.ListKeysResponse{
+		Items: make([]*prototk.ListKeyEntry, len(res.Items)),
+		Next:  res.Next,
+	}
+	for i, item := range res.Items {
+		pItem := &prototk.ListKeyEntry{
+			Name:        item.Name,
+			KeyHandle:   item.KeyHandle,
+			Attributes:  item.Attributes,
+			Path:        make([]*prototk.ListKeyPathSegment, len(item.Path)),
+			Identifiers: identifiersToProto(item.Identifiers),
+		}
+		for j, p := range item.Path {
+			pItem.Path[j] = &prototk.ListKeyPathSegment{Name: p.Name}
+		}
+		pRes.Items[i] = pItem
+	}
+	return pRes
+}
+
+func ListKeysResponseFromProto(pRes *prototk.ListKeysResponse) *signerapi.ListKeysResponse {
+	res := &signerapi.ListKeysResponse{
+		Items: make([]*signerapi.ListKeyEntry, len(pRes.Items)),
+		Next:  pRes.Next,
+	}
+	for i, pItem := range pRes.Items {
+		item := &signerapi.ListKeyEntry{
+			Name:        pItem.Name,
+			KeyHandle:   pItem.KeyHandle,
+			Attributes:  pItem.Attributes,
+			Path:        make([]*signerapi.ListKeyPathSegment, len(pItem.Path)),
+			Identifiers: identifiersFromProto(pItem.Identifiers),
+		}
+		for j, p := range pItem.Path {
+			item.Path[j] = &signerapi.ListKeyPathSegment{Name: p.Name}
+		}
+		res.Items[i] = item
+	}
+	return res
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPluginAPI(t *testing.T) (context.Context, SigningModule) {
+	ctx := context.Background()
+	api := NewPluginSigningModuleAPI(func() *signerapi.ConfigNoExt { return &signerapi.ConfigNoExt{} })
+
+	_, err := api.ResolveKey(ctx, &prototk.ResolveKeyRequest{})
+	assert.Regexp(t, "PD020830", err)
+
+	_, err = api.ConfigureSigningModule(ctx, &prototk.ConfigureSigningModuleRequest{
+		Name: "sm1",
+		ConfigJson: `{
+			"keyDerivation": {
+				"type": "bip32",
+				"bip44Prefix": "m/44'/60'/0'/0",
+				"bip44HardenedSegments": 0
+			},
+			"keyStore": {
+				"type": "static",
+				"static": {
+					"keys": {
+						"seed": {
+							"encoding": "none",
+							"inline": "extra monster happy tone improve slight duck equal sponsor fruit sister rate very bulb reopen mammal venture pull just motion faculty grab tenant kind"
+						}
+					}
+				}
+			}
+		}`,
+	})
+	require.NoError(t, err)
+
+	return ctx, WrapPluginAPI(api)
+}
+
+func TestPluginAdapterRoundTrip(t *testing.T) {
+	ctx, sm := newTestPluginAPI(t)
+	defer sm.Close()
+
+	res, err := sm.Resolve(ctx, &signerapi.ResolveKeyRequest{
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS}},
+		Name:                "key1",
+		Path:                []*signerapi.ResolveKeyPathSegment{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/0'/0/0", res.KeyHandle)
+	assert.Equal(t, "0x6331ccb948aaf903a69d6054fd718062bd0d535c", res.Identifiers[0].Verifier)
+
+	resSign, err := sm.Sign(ctx, &signerapi.SignRequest{
+		KeyHandle:   res.KeyHandle,
+		Algorithm:   algorithms.ECDSA_SECP256K1,
+		PayloadType: signpayloads.OPAQUE_TO_RSV,
+		Payload:     ([]byte)("some data"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, resSign.Payload, 65)
+
+	_, err = sm.List(ctx, &signerapi.ListKeysRequest{Limit: 10})
+	assert.Regexp(t, "PD020815", err)
+
+	_, err = sm.Sign(ctx, &signerapi.SignRequest{KeyHandle: "wrong"})
+	assert.Error(t, err)
+}
+
+func TestPluginAdapterInMemorySigners(t *testing.T) {
+	ctx, sm := newTestPluginAPI(t)
+	defer sm.Close()
+
+	// The plugin does not host the domain signer, so it cannot be used
+	err := sm.AddInMemorySigner("domain", nil)
+	assert.Regexp(t, "PD020831.*domain", err)
+
+	_, err = sm.Resolve(ctx, &signerapi.ResolveKeyRequest{
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{
+			{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+			{Algorithm: "domain:zeto:snark", VerifierType: "iden3_pubkey_babyjubjub_compressed_0x"},
+		},
+		Name: "key1",
+	})
+	assert.Regexp(t, "PD020832.*domain:zeto:snark", err)
+
+	_, err = sm.Sign(ctx, &signerapi.SignRequest{
+		KeyHandle: "m/44'/60'/0'/0/0",
+		Algorithm: "Domain:zeto:snark",
+	})
+	assert.Regexp(t, "PD020832", err)
+
+	// Other algorithms are unaffected
+	_, err = sm.Resolve(ctx, &signerapi.ResolveKeyRequest{
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS}},
+		Name:                "key1",
+	})
+	require.NoError(t, err)
+}
+
+func TestPluginAdapterHostedInMemorySigners(t *testing.T) {
+	ctx, sm := newTestPluginAPI(t)
+	defer sm.Close()
+	sm = WrapPluginAPI(sm.(*pluginSigningModule).api, "domain")
+
+	// The plugin hosts the domain signer, so requests are passed through to it
+	err := sm.AddInMemorySigner("domain", nil)
+	require.NoError(t, err)
+
+	_, err = sm.Resolve(ctx, &signerapi.ResolveKeyRequest{
+		RequiredIdentifiers: []*signerapi.PublicKeyIdentifierType{{Algorithm: "domain:zeto:snark", VerifierType: "iden3_pubkey_babyjubjub_compressed_0x"}},
+		Name:                "key1",
+	})
+	// ... which fails here only as the test plugin does not actually have an in-memory signer for domains
+	require.Error(t, err)
+	assert.NotRegexp(t, "PD020832", err)
+}
+
+func TestPluginAdapterBadConfig(t *testing.T) {
+	ctx := context.Background()
+	api := NewPluginSigningModuleAPI(func() *signerapi.ConfigNoExt { return &signerapi.ConfigNoExt{} })
+
+	_, err := api.ConfigureSigningModule(ctx, &prototk.ConfigureSigningModuleRequest{ConfigJson: "!json"})
+	assert.Regexp(t, "PD020829", err)
+
+	_, err = api.ConfigureSigningModule(ctx, &prototk.ConfigureSigningModuleRequest{ConfigJson: `{"keyStore":{"type":"wrong"}}`})
+	assert.Regexp(t, "PD020807", err)
+
+	_, err = api.Sign(ctx, &prototk.SignWithKeyRequest{})
+	assert.Regexp(t, "PD020830", err)
+	_, err = api.ListKeys(ctx, &prototk.ListKeysRequest{})
+	assert.Regexp(t, "PD020830", err)
+}
+
+func TestListKeysProtoMapping(t *testing.T) {
+	res := &signerapi.ListKeysResponse{
+		Items: []*signerapi.ListKeyEntry{{
+			Name:        "key1",
+			KeyHandle:   "handle1",
+			Attributes:  map[string]string{"a": "b"},
+			Path:        []*signerapi.ListKeyPathSegment{{Name: "folder1"}},
+			Identifiers: []*signerapi.PublicKeyIdentifier{{Algorithm: "algo1", VerifierType: "type1", Verifier: "verifier1"}},
+		}},
+		Next: "next1",
+	}
+	assert.Equal(t, res, ListKeysResponseFromProto(ListKeysResponseToProto(res)))
+}
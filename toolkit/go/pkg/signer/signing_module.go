@@ -35,7 +35,7 @@ import (
 // This module can be wrapped and loaded into the core Paladin runtime as an embedded module called directly
 // within the runtime, or wrapped in a remote process connected over a transport like HTTP, WebSockets, gRPC etc.
 type SigningModule interface {
-	AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) // late bind support for signers only (keystores are construction only)
+	AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error // late bind support for signers only (keystores are construction only)
 	Resolve(ctx context.Context, req *signerapi.ResolveKeyRequest) (res *signerapi.ResolveKeyResponse, err error)
 	Sign(ctx context.Context, req *signerapi.SignRequest) (res *signerapi.SignResponse, err error)
 	List(ctx context.Context, req *signerapi.ListKeysRequest) (res *signerapi.ListKeysResponse, err error)
@@ -151,8 +151,9 @@ func NewSigningModule[C signerapi.ExtensibleConfig](ctx context.Context, conf C,
 	return sm, err
 }
 
-func (sm *signingModule[C]) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) {
+func (sm *signingModule[C]) AddInMemorySigner(prefix string, signer signerapi.InMemorySigner) error {
 	sm.signingImplementations[prefix] = signer
+	return nil
 }
 
 func (sm *signingModule[C]) getSignerForAlgorithm(ctx context.Context, algorithm string) (signerapi.InMemorySigner, error) {
@@ -506,7 +506,8 @@ func TestResolveLateBindMemSignerError(t *testing.T) {
 			return "", fmt.Errorf("pop")
 		},
 	}
-	sm.AddInMemorySigner("test1", testSigner)
+	err = sm.AddInMemorySigner("test1", testSigner)
+	require.NoError(t, err)
 	_, err = sm.Resolve(context.Background(), &signerapi.ResolveKeyRequest{
 		Name:                "test1",
 		Index:               0,
@@ -0,0 +1,127 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Shutdown flushes any buffered spans and stops the exporter
+type Shutdown func(ctx context.Context)
+
+// InitTracing installs a global tracer provider for the configured exporter. When tracing is
+// disabled nothing is installed, and the returned shutdown function is a no-op.
+func InitTracing(ctx context.Context, conf *pldconf.TracingConfig, attrs ...attribute.KeyValue) (Shutdown, error) {
+	if !confutil.Bool(conf.Enabled, *pldconf.TracingDefaults.Enabled) {
+		return func(context.Context) {}, nil
+	}
+
+	exporter, closeOutput, err := newExporter(ctx, &conf.Exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := confutil.StringNotEmpty(conf.ServiceName, *pldconf.TracingDefaults.ServiceName)
+	res := resource.NewSchemaless(append([]attribute.KeyValue{semconv.ServiceName(serviceName)}, attrs...)...)
+	ratio := confutil.Float64Min(conf.SampleRatio, 0, *pldconf.TracingDefaults.SampleRatio)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	log.L(ctx).Infof("Tracing enabled exporter=%s service=%s sampleRatio=%f", exporterType(&conf.Exporter), serviceName, ratio)
+
+	return func(ctx context.Context) {
+		if err := provider.Shutdown(ctx); err != nil {
+			log.L(ctx).Warnf("Failed to shut down tracing: %s", err)
+		}
+		if closeOutput != nil {
+			closeOutput()
+		}
+	}, nil
+}
+
+func exporterType(conf *pldconf.TracingExporterConfig) string {
+	return confutil.StringNotEmpty(conf.Type, *pldconf.TracingDefaults.Exporter.Type)
+}
+
+func newExporter(ctx context.Context, conf *pldconf.TracingExporterConfig) (exporter sdktrace.SpanExporter, closeOutput func(), err error) {
+	eType := exporterType(conf)
+	insecure := confutil.Bool(conf.Insecure, *pldconf.TracingDefaults.Exporter.Insecure)
+	switch eType {
+	case pldconf.TracingExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{}
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(conf.Endpoint))
+		}
+		if insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if len(conf.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(conf.Headers))
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case pldconf.TracingExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{}
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(conf.Endpoint))
+		}
+		if insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(conf.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(conf.Headers))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case pldconf.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case pldconf.TracingExporterFile:
+		fileName := confutil.StringNotEmpty(conf.File, *pldconf.TracingDefaults.Exporter.File)
+		f, fErr := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if fErr != nil {
+			return nil, nil, i18n.WrapError(ctx, fErr, pldmsgs.MsgTracingFileOpenFailed, fileName)
+		}
+		closeOutput = func() { _ = f.Close() }
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			closeOutput()
+		}
+	default:
+		return nil, nil, i18n.NewError(ctx, pldmsgs.MsgTracingUnsupportedExporter, eType)
+	}
+	if err != nil {
+		return nil, nil, i18n.WrapError(ctx, err, pldmsgs.MsgTracingExporterInitFailed, eType)
+	}
+	return exporter, closeOutput, nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	InstrumentationName = "github.com/kaleido-io/paladin"
+
+	AttrTransactionID = attribute.Key("paladin.transaction.id")
+	AttrNode          = attribute.Key("paladin.node")
+	AttrPluginType    = attribute.Key("paladin.plugin.type")
+	AttrPluginID      = attribute.Key("paladin.plugin.id")
+	AttrSigner        = attribute.Key("paladin.signer")
+)
+
+// We always use W3C trace context to propagate between nodes and plugins, regardless of
+// the global propagator, so that plugins loaded in a separate process (which might not
+// have initialized tracing at all) still continue the trace of the calling node.
+var propagator = propagation.TraceContext{}
+
+// Tracer returns the Paladin tracer from the global provider. Until InitTracing has been
+// called with tracing enabled, this is a no-op tracer.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// StartSpan starts a new span as a child of any span in the supplied context
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartTransactionSpan starts a span tagged with a transaction ID, so all the work done for a
+// transaction can be found across nodes and plugins
+func StartTransactionSpan(ctx context.Context, name string, txID uuid.UUID, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return StartSpan(ctx, name, append([]attribute.KeyValue{TransactionID(txID)}, attrs...)...)
+}
+
+func TransactionID(txID uuid.UUID) attribute.KeyValue {
+	return AttrTransactionID.String(txID.String())
+}
+
+// TransactionIDs is for spans covering a batch of transactions
+func TransactionIDs(txIDs []uuid.UUID) attribute.KeyValue {
+	strIDs := make([]string, len(txIDs))
+	for i, txID := range txIDs {
+		strIDs[i] = txID.String()
+	}
+	return AttrTransactionID.StringSlice(strIDs)
+}
+
+// EndSpan records the error (if any) on the span, then ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// WithSpanFrom returns a copy of ctx (keeping its cancellation and values) that has the active
+// span of another context, for when work started under a short-lived request context continues
+// asynchronously under a long-lived background context.
+func WithSpanFrom(ctx context.Context, spanCtx context.Context) context.Context {
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(spanCtx))
+}
+
+// InjectMap returns the trace context of the current span, for inclusion in the headers of
+// a message sent to another node or plugin. Returns nil if there is no active span.
+func InjectMap(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier
+}
+
+// ExtractMap returns a context with the remote span from the headers of a received message
+// as its parent, so that spans started under it join the trace of the sender.
+func ExtractMap(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(traceContext))
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestTracingToFile(t *testing.T) {
+	ctx := context.Background()
+	fileName := path.Join(t.TempDir(), "traces.json")
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	shutdown, err := InitTracing(ctx, &pldconf.TracingConfig{
+		Enabled: confutil.P(true),
+		Exporter: pldconf.TracingExporterConfig{
+			Type: confutil.P(pldconf.TracingExporterFile),
+			File: &fileName,
+		},
+	}, AttrNode.String("node1"))
+	require.NoError(t, err)
+
+	txID := uuid.New()
+	_, batchSpan := StartSpan(ctx, "batch", TransactionIDs([]uuid.UUID{txID}))
+	EndSpan(batchSpan, nil)
+	ctx1, span1 := StartTransactionSpan(ctx, "sender", txID)
+	traceContext := InjectMap(ctx1)
+	assert.NotEmpty(t, traceContext["traceparent"])
+
+	// Simulate receipt in another process
+	ctx2 := ExtractMap(context.Background(), traceContext)
+	_, span2 := StartSpan(ctx2, "receiver")
+	assert.Equal(t, span1.SpanContext().TraceID(), span2.SpanContext().TraceID())
+
+	// Continue under a background context
+	bgCtx, cancelBG := context.WithCancel(context.Background())
+	defer cancelBG()
+	_, span3 := StartSpan(WithSpanFrom(bgCtx, ctx2), "async")
+	assert.Equal(t, span1.SpanContext().TraceID(), span3.SpanContext().TraceID())
+	EndSpan(span3, nil)
+	EndSpan(span2, errors.New("pop"))
+	EndSpan(span1, nil)
+
+	shutdown(ctx)
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), txID.String())
+	assert.Contains(t, string(data), `"paladin.node"`)
+	assert.Contains(t, string(data), `"pop"`)
+	assert.Contains(t, string(data), `"receiver"`)
+}
+
+func TestTracingDisabled(t *testing.T) {
+	ctx := context.Background()
+	shutdown, err := InitTracing(ctx, &pldconf.TracingConfig{})
+	require.NoError(t, err)
+	defer shutdown(ctx)
+
+	ctx, span := StartSpan(ctx, "noop")
+	defer span.End()
+	assert.False(t, span.SpanContext().IsValid())
+	assert.Nil(t, InjectMap(ctx))
+	assert.Equal(t, ctx, ExtractMap(ctx, nil))
+}
+
+func TestTracingExporters(t *testing.T) {
+	ctx := context.Background()
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	for _, eType := range []string{pldconf.TracingExporterOTLPGRPC, pldconf.TracingExporterOTLPHTTP, pldconf.TracingExporterStdout} {
+		shutdown, err := InitTracing(ctx, &pldconf.TracingConfig{
+			Enabled: confutil.P(true),
+			Exporter: pldconf.TracingExporterConfig{
+				Type:     confutil.P(eType),
+				Endpoint: "localhost:4317",
+				Insecure: confutil.P(true),
+				Headers:  map[string]string{"authorization": "Bearer token"},
+			},
+		})
+		require.NoError(t, err)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		shutdown(cancelled)
+	}
+}
+
+func TestTracingExporterErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := InitTracing(ctx, &pldconf.TracingConfig{
+		Enabled:  confutil.P(true),
+		Exporter: pldconf.TracingExporterConfig{Type: confutil.P("wrong")},
+	})
+	assert.Regexp(t, "PD021100", err)
+
+	_, err = InitTracing(ctx, &pldconf.TracingConfig{
+		Enabled: confutil.P(true),
+		Exporter: pldconf.TracingExporterConfig{
+			Type: confutil.P(pldconf.TracingExporterFile),
+			File: confutil.P(t.TempDir()), // a directory
+		},
+	})
+	assert.Regexp(t, "PD021102", err)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2024 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/toolkit/pkg/remotesigner"
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	ctx := context.Background()
+	if len(args) != 1 {
+		log.L(ctx).Errorf("Usage: remotesigner <config.yaml>")
+		return 1
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		log.L(ctx).Errorf("Failed to read config file %s: %s", args[0], err)
+		return 1
+	}
+	var conf remotesigner.ServerConfig
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		log.L(ctx).Errorf("Failed to parse config file %s: %s", args[0], err)
+		return 1
+	}
+
+	s, err := remotesigner.NewServer(ctx, &conf)
+	if err == nil {
+		err = s.Start()
+	}
+	if err != nil {
+		log.L(ctx).Errorf("Failed to start remote signer: %s", err)
+		return 1
+	}
+	defer s.Stop()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigs
+	log.L(ctx).Infof("Stopping remote signer on signal %s", sig)
+	return 0
+}
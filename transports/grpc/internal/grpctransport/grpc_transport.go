@@ -197,6 +197,7 @@ func (t *grpcTransport) ConnectSendStream(stream grpc.ClientStreamingServer[prot
 				Component:     prototk.PaladinMsg_Component(msg.Component),
 				MessageType:   msg.MessageType,
 				Payload:       msg.Payload,
+				TraceContext:  msg.TraceContext,
 			},
 		})
 		if err != nil {
@@ -283,6 +284,7 @@ func (t *grpcTransport) SendMessage(ctx context.Context, req *prototk.SendMessag
 		Component:     int32(msg.Component),
 		MessageType:   msg.MessageType,
 		Payload:       msg.Payload,
+		TraceContext:  msg.TraceContext,
 	})
 	if err != nil {
 		return nil, err